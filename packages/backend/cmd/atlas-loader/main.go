@@ -11,12 +11,7 @@ import (
 
 func main() {
 	// Load all the models, generate SQL statements for them.
-	stmts, err := gormschema.New("postgres").Load(
-		&models.IssueScope{},
-		&models.Issue{},
-		&models.Link{},
-		&models.RelatedIssue{},
-	)
+	stmts, err := gormschema.New("postgres").Load(models.All()...)
 
 	if err != nil {
 		log.Fatalf("failed to load gorm schema: %v", err)
@@ -31,7 +31,7 @@ func main() {
 	logger.WithField("environment", env).Info("Starting database seeding")
 
 	// Initialize database
-	db, err := config.InitDatabase()
+	db, err := config.InitDatabase(logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize database")
 	}
@@ -7,12 +7,22 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/konflux-ci/kite/internal/config"
 	handler_http "github.com/konflux-ci/kite/internal/handlers/http"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/buildinfo"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/pkg/quiethours"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -41,11 +51,11 @@ func main() {
 
 	logger.WithFields(logrus.Fields{
 		"environment": cfg.Server.Environment,
-		"version":     getVersion(),
+		"version":     buildinfo.Get().Version,
 	})
 
 	// Initialize database
-	db, err := config.InitDatabase()
+	db, err := config.InitDatabase(logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to initialize database")
 	}
@@ -62,8 +72,27 @@ func main() {
 		}
 	}()
 
-	// Setup router
-	router, err := handler_http.SetupRouter(db, logger)
+	// Setup router, requests are rejected until the database schema is ready
+	state := readiness.NewStarting()
+	var kiteMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		kiteMetrics = metrics.New()
+	}
+	notifier, notificationRetries, err := newNotifier(cfg, logger, kiteMetrics)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to setup notifications")
+	}
+	// Webhooks are accepted right away and their issues persisted in the background
+	var ingestQueue *services.IngestQueue
+	if cfg.Webhooks.Async {
+		logger.WithFields(logrus.Fields{
+			"queueSize": cfg.Webhooks.AsyncQueueSize,
+			"workers":   cfg.Webhooks.AsyncWorkers,
+		}).Info("Processing webhooks asynchronously")
+		ingestQueue = services.NewIngestQueue(logger, cfg.Webhooks.AsyncQueueSize, cfg.Webhooks.AsyncWorkers,
+			services.WithIngestQueueMetrics(kiteMetrics))
+	}
+	router, err := handler_http.SetupRouter(db, cfg, logger, state, kiteMetrics, notifier, ingestQueue)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to setup router")
 	}
@@ -97,6 +126,73 @@ func main() {
 		}
 	}()
 
+	// Metrics are served on their own listener, so they can be scraped without a token
+	// while the namespaces they're labeled with aren't exposed along with the API
+	var metricsServer *http.Server
+	if kiteMetrics != nil {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", kiteMetrics.Handler())
+		metricsServer = &http.Server{
+			Addr:         cfg.Metrics.Address,
+			Handler:      metricsMux,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			IdleTimeout:  cfg.Server.IdleTimeout,
+		}
+		go func() {
+			logger.WithField("address", cfg.Metrics.Address).Info("Serving metrics")
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.WithError(err).Fatal("Failed to serve metrics")
+			}
+		}()
+	}
+
+	// Start serving requests once migrations are applied, then refresh the metrics
+	// and resolve the issues that stopped being reported in the background
+	sweepCtx, stopSweeping := context.WithCancel(context.Background())
+	defer stopSweeping()
+	// Normalized resource names are recomputed with the configured pattern, validated with the config
+	var resourceNameStrip *regexp.Regexp
+	if cfg.Dedup.ResourceNameStrip != "" {
+		resourceNameStrip = regexp.MustCompile(cfg.Dedup.ResourceNameStrip)
+	}
+	issueRepo := repository.NewIssueRepository(db, logger,
+		repository.WithDedupOptions(repository.DedupOptions{ResourceNameStrip: resourceNameStrip}))
+	autoResolver := services.NewAutoResolver(issueRepo, logger, cfg.Resolve.TTLs, cfg.Resolve.Interval,
+		services.WithDeescalation(cfg.Resolve.DeescalateAfter))
+	go func() {
+		if err := waitForSchema(sweepCtx, db, logger); err != nil {
+			return
+		}
+		// Duplicates are matched on the names normalized with the current pattern
+		if normalized, err := issueRepo.NormalizeResourceNames(sweepCtx); err != nil {
+			logger.WithError(err).Error("Failed to normalize resource names")
+		} else if normalized > 0 {
+			logger.WithField("scopes", normalized).Info("Normalized resource names with the new pattern")
+		}
+		state.Started()
+		logger.Info("Database schema ready, serving requests")
+
+		if notifier != nil && cfg.Notify.QuietHours != "" {
+			go notifier.Run(sweepCtx)
+		}
+		if notificationRetries != nil {
+			go notificationRetries.Run(sweepCtx)
+		}
+
+		if kiteMetrics != nil {
+			go services.NewMetricsRefresher(issueRepo, kiteMetrics, logger, cfg.Metrics.RefreshInterval).Run(sweepCtx)
+		}
+
+		if autoResolver.Enabled() {
+			logger.WithFields(logrus.Fields{
+				"ttls":            cfg.Resolve.TTLs,
+				"deescalateAfter": cfg.Resolve.DeescalateAfter,
+			}).Info("Auto-resolving stale issues")
+			autoResolver.Run(sweepCtx)
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown
 	// Create a channel that carries os.Signal values, buffer size 1
 	quit := make(chan os.Signal, 1)
@@ -106,18 +202,86 @@ func main() {
 	// Because the buffer size is one, once the signal is recieved we'll process the rest of the function.
 	<-quit
 
-	logger.Info("Shutting down server...")
-
-	// Create a context with timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
-	defer cancel()
+	logger.WithField("prestop_delay", cfg.Server.PrestopDelay).Info("Shutting down server...")
+	stopSweeping()
 
-	// Shut down server
-	if err := server.Shutdown(ctx); err != nil {
+	// Stop reporting ready and keep serving requests until load balancers
+	// deregister the server, then drain the requests in flight
+	err = state.GracefulShutdown(cfg.Server.PrestopDelay, cfg.Server.ShutdownTimeout, func(ctx context.Context) error {
+		logger.WithField("in_flight_requests", state.InFlight()).Info("Draining requests")
+		return server.Shutdown(ctx)
+	})
+	if err != nil {
 		logger.WithError(err).Error("Server forced to shutdown")
 	} else {
 		logger.Info("Server shutdown gracefully")
 	}
+	// Metrics are scraped until the requests are drained
+	if metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.WithError(err).Error("Failed to shutdown the metrics server")
+		}
+	}
+
+	// No more webhooks are received, persist the issues still queued before closing the database
+	if ingestQueue != nil {
+		logger.WithField("queued", ingestQueue.Len()).Info("Draining the ingest queue")
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := ingestQueue.Drain(ctx); err != nil {
+			logger.WithError(err).Error("Failed to drain the ingest queue")
+		}
+	}
+}
+
+// schemaCheckInterval is how often the database schema is checked while waiting for migrations
+const schemaCheckInterval = 2 * time.Second
+
+// waitForSchema waits until the migrations creating every model are applied,
+// or the context is done.
+func waitForSchema(ctx context.Context, db *gorm.DB, logger *logrus.Logger) error {
+	for {
+		err := config.CheckSchema(db, models.All()...)
+		if err == nil {
+			return nil
+		}
+		logger.WithError(err).Warn("Database schema not ready, waiting for migrations")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(schemaCheckInterval):
+		}
+	}
+}
+
+// newNotifier creates the notifier of new issues, along with the queue retrying the failed
+// notifications if retries are enabled. Returns nil when notifications are disabled.
+func newNotifier(cfg *config.Config, logger *logrus.Logger, m *metrics.Metrics) (*services.Notifier, *services.NotificationRetryQueue, error) {
+	if cfg.Notify.WebhookURL == "" {
+		return nil, nil, nil
+	}
+
+	var opts []services.NotifierOption
+	if cfg.Notify.QuietHours != "" {
+		window, err := quiethours.Parse(cfg.Notify.QuietHours, cfg.Notify.QuietHoursTimezone)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.WithField("quiet_hours", window.String()).Info("Holding back notifications during quiet hours")
+		opts = append(opts, services.WithQuietHours(window, cfg.Notify.QuietHoursMinSeverity))
+	}
+	var sender services.NotificationSender = services.NewWebhookSender(cfg.Notify.WebhookURL, cfg.Notify.Timeout)
+	var retries *services.NotificationRetryQueue
+	if cfg.Notify.MaxAttempts > 1 {
+		retries = services.NewNotificationRetryQueue(sender, logger, cfg.Notify.MaxAttempts, cfg.Notify.RetryQueueSize,
+			services.WithRetryBackoff(cfg.Notify.RetryBackoff, cfg.Notify.RetryMaxBackoff),
+			services.WithNotificationRetryMetrics(m))
+		sender = retries
+	}
+	return services.NewNotifier(sender, logger, opts...), retries, nil
 }
 
 func setupLogger() *logrus.Logger {
@@ -143,11 +307,3 @@ func setupLogger() *logrus.Logger {
 
 	return logger
 }
-
-func getVersion() string {
-	// This should be set during build time
-	if version := os.Getenv("VERSION"); version != "" {
-		return version
-	}
-	return "dev"
-}
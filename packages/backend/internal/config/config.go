@@ -2,21 +2,46 @@ package config
 
 import (
 	"fmt"
+	"mime"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/commitlink"
+	"github.com/konflux-ci/kite/internal/pkg/customfields"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
+	"github.com/konflux-ci/kite/internal/pkg/quiethours"
+	"github.com/konflux-ci/kite/internal/pkg/redact"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logging  LoggingConfig
-	Security SecurityConfig
-	Features FeatureFlags
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Logging   LoggingConfig
+	Security  SecurityConfig
+	Features  FeatureFlags
+	Dedup     DedupConfig
+	Redaction RedactionConfig
+	Webhooks  WebhookConfig
+	Relations RelationshipConfig
+	Paging    PaginationConfig
+	Resolve   AutoResolveConfig
+	Deadlines DeadlineConfig
+	Metrics   MetricsConfig
+	Limits    LimitsConfig
+	Templates TemplateConfig
+	Notify    NotificationConfig
+	Commits   CommitConfig
+	Hooks     HookConfig
+	Attach    AttachmentConfig
+	Severity  SeverityConfig
+	Fields    CustomFieldConfig
 }
 
 // ServerConfig holds all server-related configuration
@@ -28,12 +53,45 @@ type ServerConfig struct {
 	IdleTimeout     time.Duration
 	ShutdownTimeout time.Duration
 	Environment     string
+	// How long to keep serving requests after a shutdown signal, before shutting down
+	PrestopDelay time.Duration
+	// Compress responses of at least CompressionMinSize bytes with gzip
+	EnableCompression  bool
+	CompressionMinSize int
+	// What requests to the root path get: the service info, or a redirect to the version
+	RootResponse string
+	// Naming convention of the fields of JSON responses
+	JSONNaming string
+	// Reject issue create requests with unknown fields instead of ignoring them
+	StrictJSON bool
+	// Requests served at once across all routes but health checks, 0 for no limit
+	MaxInFlightRequests int
 }
 
+// Responses to requests to the root path
+const (
+	RootResponseInfo     = "info"
+	RootResponseRedirect = "redirect"
+)
+
+// Naming conventions of the fields of JSON responses
+const (
+	JSONNamingCamelCase = "camelCase"
+	JSONNamingSnakeCase = "snake_case"
+)
+
 // LoggingConfig holds all logging configuration
 type LoggingConfig struct {
 	Level  string
 	Format string //json or text
+	// Format of the HTTP access logs, written through the logger: json (as fields), combined (as messages) or off
+	AccessLogFormat string
+	// Log request and response bodies at debug level, for debugging only
+	LogBodies bool
+	// Number of bytes of each body logged, longer bodies are truncated
+	MaxLoggedBodySize int
+	// Log the key of every duplicate lookup at debug level, for debugging only
+	LogDedupKeys bool
 }
 
 // SecurityConfig holds all security-related configuration
@@ -41,47 +99,445 @@ type SecurityConfig struct {
 	EnableCORS     bool
 	AllowedOrigins []string
 	RateLimitRPS   int
+	// Let requests without a token read issues, writes still require a token
+	AnonymousRead bool
+	// Namespace governing access to issues: "issue", or "resource" to also require
+	// access to the namespace of the resource an issue is scoped to.
+	AuthorizationNamespace string
+	// Namespaces whose issue descriptions and links are hidden from requesters
+	// without elevated access to them
+	SensitiveNamespaces []string
+	// Verb on pods of a sensitive namespace granting elevated access to it
+	SensitiveAccessVerb string
+	// How sensitive fields are hidden: "redact" to replace them, or "omit" to leave them out
+	SensitiveFieldsMode string
+	// Verb on pods of a namespace required to resolve all its issues when it's decommissioned
+	DecommissionAccessVerb string
+	// Authentication level required by routes, keyed by method and path pattern,
+	// e.g. "GET /api/v1/issues/stats" => "public"
+	RoutePolicies map[string]string
+	// Domains the links of issues can point to, along with their subdomains, all are allowed if empty
+	AllowedLinkDomains []string
 }
 
+// Namespaces that can govern access to issues
+const (
+	AuthorizationNamespaceIssue    = "issue"
+	AuthorizationNamespaceResource = "resource"
+)
+
+// Ways the sensitive fields of issues can be hidden
+const (
+	SensitiveFieldsRedact = "redact"
+	SensitiveFieldsOmit   = "omit"
+)
+
 // FeatureFlags holds feature flag configuration
 type FeatureFlags struct {
 	EnableNamespaceChecking bool
 	EnableWebhooks          bool
 }
 
+// DedupConfig holds issue deduplication configuration
+type DedupConfig struct {
+	// Consider resolved issues as duplicates, re-using them when an issue recurs.
+	IncludeResolved bool
+	// Number of recent occurrences kept for each issue, 0 disables the timeline.
+	MaxOccurrences int
+	// Match duplicates on their resource only, whatever namespace they're tracked in.
+	AcrossNamespaces bool
+	// Duplicates reported within this interval of the last update of their issue are
+	// only counted, 0 updates the issue with every duplicate.
+	MinUpdateInterval time.Duration
+	// What duplicates are matched on besides their type and scope, one of the dedup strategies.
+	Strategy string
+	// Reject duplicate open issues in the database too, with a unique index on their dedup key.
+	EnforceUnique bool
+	// Regular expression matching the transient parts of resource names, e.g. random suffixes,
+	// stripped before matching duplicates on the name. Empty matches names as is.
+	ResourceNameStrip string
+}
+
+// Strategies of duplicate detection
+const (
+	// Duplicates are matched on their type and scope
+	DedupStrategyScope = "scope"
+	// Duplicates are also matched on their failure reason, i.e. normalized description
+	DedupStrategyReason = "reason"
+)
+
+// LimitsConfig holds the limits protecting the database from runaway producers
+type LimitsConfig struct {
+	// Maximum number of active issues per namespace, 0 disables the limit.
+	MaxActiveIssuesPerNamespace int
+	// Longest resource type and name of the scope of an issue, longer ones are rejected.
+	MaxScopeFieldLength int
+}
+
+// SeverityConfig holds the configuration of the severities issues are stored with
+type SeverityConfig struct {
+	// Minimum severity of the issues created in each namespace, less severe issues are
+	// escalated to it. Namespaces without a floor keep the reported severities.
+	Floors map[string]models.Severity
+	// Severities webhooks accept in place of the known ones, lowercase, e.g. high for major
+	Aliases map[string]models.Severity
+}
+
+// TemplateConfig holds the templates operators quickly create similar issues from
+type TemplateConfig struct {
+	// Issue templates keyed by name, in YAML or JSON
+	Issues string
+}
+
+// CustomFieldConfig holds the custom fields of issues
+type CustomFieldConfig struct {
+	// Custom fields of each issue type, in YAML or JSON, see customfields.Parse
+	Schemas string
+}
+
+// NotificationConfig holds the configuration of the notifications sent for new issues
+type NotificationConfig struct {
+	// URL notifications are posted to, notifications are disabled if empty
+	WebhookURL string
+	// How long to wait for the webhook to accept a notification
+	Timeout time.Duration
+	// Attempts to deliver a notification before giving up on it, 1 disables retries
+	MaxAttempts int
+	// Delay before retrying a failed notification, doubled for every next retry up to RetryMaxBackoff
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	// Number of failed notifications waiting to be retried at most
+	RetryQueueSize int
+	// Daily window, e.g. "22:00-06:00", during which issues less severe than
+	// QuietHoursMinSeverity are only notified in a summary once it ends.
+	QuietHours         string
+	QuietHoursTimezone string
+	// Issues at least this severe are notified during quiet hours too, critical issues always are
+	QuietHoursMinSeverity models.Severity
+}
+
+// CommitConfig holds the configuration of the links to the commits issues are reported for
+type CommitConfig struct {
+	// Go template of commit URLs, rendered with the RepoURL, CommitSHA and Branch of an
+	// issue. Links to commits aren't generated if empty.
+	LinkTemplate string
+}
+
+// HookConfig holds the configuration of the hooks called with the issues created
+type HookConfig struct {
+	// Fail the creation of issues when a create hook fails, rather than only logging
+	// the error. The issue is stored either way.
+	FailOnCreateHookError bool
+}
+
+// AttachmentConfig holds the configuration for the files attached to issues
+type AttachmentConfig struct {
+	// Media types of the files that can be attached, "type/*" allows all the subtypes of a type
+	ContentTypes []string
+	// Largest file that can be attached, in bytes
+	MaxSize int
+}
+
+// DefaultAttachmentContentTypes are the media types of the files that can be attached unless configured otherwise
+var DefaultAttachmentContentTypes = []string{
+	"text/*",
+	"image/*",
+	"application/json",
+	"application/gzip",
+	"application/zip",
+	"application/x-tar",
+}
+
+// PaginationConfig holds the configuration for paginated lists
+type PaginationConfig struct {
+	// Page size used when a request doesn't set a limit
+	DefaultPageSize int
+	// Largest page size a request can ask for, larger limits are clamped
+	MaxPageSize int
+	// Deepest offset a request can list issues from, 0 disables the limit
+	MaxOffset int
+	// Number of groups returned by the grouped issues view
+	MaxGroups int
+}
+
+// RelationshipConfig holds the configuration for relationships between issues
+type RelationshipConfig struct {
+	// Maximum number of relationships an issue can have, in either direction.
+	MaxPerIssue int
+	// Relate new issues to the active issues scoped to the same resource.
+	AutoRelateSameResource bool
+	// Maximum number of issues a new issue is automatically related to.
+	MaxAutoRelations int
+	// Maximum number of issues, and of relationships, of a relationship graph returned.
+	MaxGraphSize int
+}
+
+// AutoResolveConfig holds the configuration for resolving issues that stopped being reported
+type AutoResolveConfig struct {
+	// How long an active issue of each type can go unreported before it's resolved.
+	// Issues of types without a TTL are never resolved automatically.
+	TTLs map[models.IssueType]time.Duration
+	// How often issues to resolve are looked for
+	Interval time.Duration
+	// How long an active issue can go unreported before its severity is lowered by one
+	// level, down to the severity it was first reported with. 0 disables de-escalation.
+	DeescalateAfter time.Duration
+	// Window within which a failure wins over a concurrent resolution by a success webhook
+	// for the same resource, whichever came first. 0 lets the last one processed win.
+	ConflictWindow time.Duration
+}
+
+// DeadlineConfig holds the configuration of the deadlines issues are expected to be resolved by
+type DeadlineConfig struct {
+	// How long after detection an issue of each severity is expected to be resolved by,
+	// unless a deadline is set explicitly. Issues of severities without one have no deadline.
+	Resolution map[models.Severity]time.Duration
+}
+
+// MetricsConfig holds the configuration of the Prometheus metrics
+type MetricsConfig struct {
+	// Serve the metrics on /metrics
+	Enabled bool
+	// Address of the listener serving the metrics, separate from the API so they
+	// aren't exposed along with it
+	Address string
+	// How often the issue metrics are recomputed from the database
+	RefreshInterval time.Duration
+	// How long the results of MTTR queries are cached, 0 disables the cache.
+	AggregateCacheTTL time.Duration
+}
+
+// RedactionConfig holds the configuration for redacting sensitive data from issues
+type RedactionConfig struct {
+	// Regular expressions matching sensitive data, defaults to common secret shapes.
+	Patterns []string
+}
+
+// WebhookConfig holds the configuration for processing webhooks
+type WebhookConfig struct {
+	// Regular expressions matching failure reasons that shouldn't create issues, one per line.
+	IgnoreFailureReasons []string
+	// Go templates for the title and description of the issues created by webhooks,
+	// keyed by "<webhook>.<field>", e.g. "pipeline-failure.title".
+	IssueTemplates map[string]string
+	// Go template for the logs URL of release failures without a pipelineRunUrl, empty to link no logs.
+	ReleaseLogsURLTemplate string
+	// Bytes of the logs of mintmaker issues joined into their description, longer logs are truncated. 0 disables the limit.
+	MintmakerMaxLogBytes int
+	// What release failures are deduplicated on: their application, or the release itself.
+	ReleaseIssueScope string
+	// Events whose eventTime is older than this are ignored, 0 accepts events of any age.
+	MaxEventAge time.Duration
+	// Number of webhooks processed at once, 0 disables the limit.
+	MaxConcurrency int
+	// How long excess webhooks wait for a slot before being rejected with 429.
+	QueueTimeout time.Duration
+	// Accept the issues reported by webhooks right away and persist them in the background.
+	Async bool
+	// Number of issues waiting to be persisted before webhooks are rejected with 503.
+	AsyncQueueSize int
+	// Number of queued issues persisted at once.
+	AsyncWorkers int
+	// What webhooks creating issues respond with: the whole issue, or just its ID.
+	Response string
+}
+
+// Scopes of the issues created by release failures
+const (
+	// The failures of all the releases of an application update the same issue
+	ReleaseIssueScopeApplication = "application"
+	// The failures of each release create a separate issue
+	ReleaseIssueScopeRelease = "release"
+)
+
+// Responses of the webhooks creating issues
+const (
+	// Only the ID of the issue, and whether it was created or updated
+	WebhookResponseMinimal = "minimal"
+	// The whole issue, with its scope, links and related issues
+	WebhookResponseFull = "full"
+)
+
+// TemplatedWebhooks are the webhooks creating issues, whose title and description can be templated
+var TemplatedWebhooks = []string{"pipeline-failure", "build-failure", "mintmaker-custom", "release-failure"}
+
+// TemplatedIssueFields are the fields of webhook issues that can be templated
+var TemplatedIssueFields = []string{"title", "description"}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
+	dbUser, err := GetEnvOrFileOrDefault("KITE_DB_USER", "kite")
+	if err != nil {
+		return nil, err
+	}
+	dbPassword, err := GetEnvOrFileOrDefault("KITE_DB_PASSWORD", "postgres")
+	if err != nil {
+		return nil, err
+	}
+	issueTemplates, err := loadIssueTemplates()
+	if err != nil {
+		return nil, err
+	}
+	quickCreateTemplates, err := GetEnvOrFileOrDefault("KITE_ISSUE_TEMPLATES", "")
+	if err != nil {
+		return nil, err
+	}
+	customFieldSchemas, err := GetEnvOrFileOrDefault("KITE_CUSTOM_FIELDS", "")
+	if err != nil {
+		return nil, err
+	}
+	routePolicies, err := loadRoutePolicies()
+	if err != nil {
+		return nil, err
+	}
+	severityFloors, err := loadSeverityFloors()
+	if err != nil {
+		return nil, err
+	}
+	severityAliases, err := loadSeverityAliases()
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
-			Host:            GetEnvOrDefault("KITE_HOST", "0.0.0.0"),
-			Port:            getEnvOrDefault("KITE_PORT", "8080"),
-			ReadTimeout:     GetEnvDurationOrDefault("KITE_READ_TIMEOUT", 30*time.Second),
-			WriteTimeout:    GetEnvDurationOrDefault("KITE_WRITE_TIMEOUT", 30*time.Second),
-			IdleTimeout:     GetEnvDurationOrDefault("KITE_IDLE_TIMEOUT", 60*time.Second),
-			ShutdownTimeout: GetEnvDurationOrDefault("KITE_SHUTDOWN_TIMEOUT", 10*time.Second),
-			Environment:     getEnvOrDefault("KITE_PROJECT_ENV", "production"),
+			Host:               GetEnvOrDefault("KITE_HOST", "0.0.0.0"),
+			Port:               getEnvOrDefault("KITE_PORT", "8080"),
+			ReadTimeout:        GetEnvDurationOrDefault("KITE_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:       GetEnvDurationOrDefault("KITE_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:        GetEnvDurationOrDefault("KITE_IDLE_TIMEOUT", 60*time.Second),
+			ShutdownTimeout:    GetEnvDurationOrDefault("KITE_SHUTDOWN_TIMEOUT", 10*time.Second),
+			PrestopDelay:       GetEnvDurationOrDefault("KITE_PRESTOP_DELAY", 0),
+			Environment:        getEnvOrDefault("KITE_PROJECT_ENV", "production"),
+			EnableCompression:  GetEnvBoolOrDefault("KITE_ENABLE_COMPRESSION", false),
+			CompressionMinSize: GetEnvIntOrDefault("KITE_COMPRESSION_MIN_SIZE", 1024),
+			RootResponse:       GetEnvOrDefault("KITE_ROOT_RESPONSE", RootResponseInfo),
+			JSONNaming:         GetEnvOrDefault("KITE_JSON_NAMING", JSONNamingCamelCase),
+			StrictJSON:         GetEnvBoolOrDefault("KITE_STRICT_JSON", false),
+
+			MaxInFlightRequests: GetEnvIntOrDefault("KITE_MAX_INFLIGHT_REQUESTS", 0),
 		},
 		Database: DatabaseConfig{
 			Host:     GetEnvOrDefault("KITE_DB_HOST", "localhost"),
 			Port:     GetEnvOrDefault("KITE_DB_PORT", "5432"),
-			User:     GetEnvOrDefault("KITE_DB_USER", "kite"),
-			Password: GetEnvOrDefault("KITE_DB_PASSWORD", "postgres"),
+			User:     dbUser,
+			Password: dbPassword,
 			Name:     GetEnvOrDefault("KITE_DB_NAME", "issuesdb"),
 			SSLMode:  GetEnvOrDefault("KITE_DB_SSL_MODE", "disable"),
 		},
 		Logging: LoggingConfig{
-			Level:  GetEnvOrDefault("KITE_LOG_LEVEL", "info"),
-			Format: GetEnvOrDefault("KITE_LOG_FORMAT", "json"),
+			Level:             GetEnvOrDefault("KITE_LOG_LEVEL", "info"),
+			Format:            GetEnvOrDefault("KITE_LOG_FORMAT", "json"),
+			AccessLogFormat:   GetEnvOrDefault("KITE_ACCESS_LOG_FORMAT", "json"),
+			LogBodies:         GetEnvBoolOrDefault("KITE_DEBUG_LOG_BODIES", false),
+			MaxLoggedBodySize: GetEnvIntOrDefault("KITE_DEBUG_LOG_BODIES_MAX_SIZE", 4096),
+			LogDedupKeys:      GetEnvBoolOrDefault("KITE_DEBUG_LOG_DEDUP_KEYS", false),
 		},
 		Security: SecurityConfig{
-			EnableCORS:     GetEnvBoolOrDefault("KITE_ENABLE_CORS", true),
-			AllowedOrigins: GetEnvSliceOrDefault("KITE_ALLOWED_ORIGINS", []string{"*"}),
-			RateLimitRPS:   GetEnvIntOrDefault("KITE_RATE_LIMIT_RPS", 100),
+			EnableCORS:             GetEnvBoolOrDefault("KITE_ENABLE_CORS", true),
+			AllowedOrigins:         GetEnvSliceOrDefault("KITE_ALLOWED_ORIGINS", []string{"*"}),
+			RateLimitRPS:           GetEnvIntOrDefault("KITE_RATE_LIMIT_RPS", 100),
+			AnonymousRead:          GetEnvBoolOrDefault("KITE_ANONYMOUS_READ", false),
+			AuthorizationNamespace: GetEnvOrDefault("KITE_AUTHORIZATION_NAMESPACE", AuthorizationNamespaceIssue),
+			SensitiveNamespaces:    GetEnvSliceOrDefault("KITE_SENSITIVE_NAMESPACES", nil),
+			SensitiveAccessVerb:    GetEnvOrDefault("KITE_SENSITIVE_ACCESS_VERB", "update"),
+			SensitiveFieldsMode:    GetEnvOrDefault("KITE_SENSITIVE_FIELDS_MODE", SensitiveFieldsRedact),
+			DecommissionAccessVerb: GetEnvOrDefault("KITE_DECOMMISSION_ACCESS_VERB", "delete"),
+			RoutePolicies:          routePolicies,
+			AllowedLinkDomains:     GetEnvSliceOrDefault("KITE_ALLOWED_LINK_DOMAINS", nil),
 		},
 		Features: FeatureFlags{
 			EnableNamespaceChecking: GetEnvBoolOrDefault("KITE_FEATURE_NAMESPACE_CHECKING", true),
 			EnableWebhooks:          GetEnvBoolOrDefault("KITE_FEATURE_WEBHOOKS", true),
 		},
+		Dedup: DedupConfig{
+			IncludeResolved:   GetEnvBoolOrDefault("KITE_DEDUP_INCLUDE_RESOLVED", true),
+			MaxOccurrences:    GetEnvIntOrDefault("KITE_MAX_OCCURRENCES_PER_ISSUE", 20),
+			AcrossNamespaces:  GetEnvBoolOrDefault("KITE_DEDUP_ACROSS_NAMESPACES", false),
+			MinUpdateInterval: GetEnvDurationOrDefault("KITE_DUP_UPDATE_MIN_INTERVAL", 0),
+			Strategy:          GetEnvOrDefault("KITE_DEDUP_STRATEGY", DedupStrategyScope),
+			EnforceUnique:     GetEnvBoolOrDefault("KITE_DEDUP_ENFORCE_UNIQUE", false),
+			ResourceNameStrip: GetEnvOrDefault("KITE_RESOURCE_NAME_STRIP", ""),
+		},
+		Limits: LimitsConfig{
+			MaxActiveIssuesPerNamespace: GetEnvIntOrDefault("KITE_MAX_ACTIVE_ISSUES_PER_NAMESPACE", 0),
+			MaxScopeFieldLength:         GetEnvIntOrDefault("KITE_MAX_SCOPE_FIELD_LENGTH", 255),
+		},
+		Severity: SeverityConfig{
+			Floors:  severityFloors,
+			Aliases: severityAliases,
+		},
+		Templates: TemplateConfig{
+			Issues: quickCreateTemplates,
+		},
+		Fields: CustomFieldConfig{
+			Schemas: customFieldSchemas,
+		},
+		Notify: NotificationConfig{
+			WebhookURL:            GetEnvOrDefault("KITE_NOTIFY_WEBHOOK_URL", ""),
+			Timeout:               GetEnvDurationOrDefault("KITE_NOTIFY_TIMEOUT", 10*time.Second),
+			MaxAttempts:           GetEnvIntOrDefault("KITE_NOTIFY_MAX_ATTEMPTS", 5),
+			RetryBackoff:          GetEnvDurationOrDefault("KITE_NOTIFY_RETRY_BACKOFF", 5*time.Second),
+			RetryMaxBackoff:       GetEnvDurationOrDefault("KITE_NOTIFY_RETRY_MAX_BACKOFF", 5*time.Minute),
+			RetryQueueSize:        GetEnvIntOrDefault("KITE_NOTIFY_RETRY_QUEUE_SIZE", 100),
+			QuietHours:            GetEnvOrDefault("KITE_QUIET_HOURS", ""),
+			QuietHoursTimezone:    GetEnvOrDefault("KITE_QUIET_HOURS_TIMEZONE", "UTC"),
+			QuietHoursMinSeverity: models.Severity(GetEnvOrDefault("KITE_QUIET_HOURS_MIN_SEVERITY", string(models.SeverityCritical))),
+		},
+		Commits: CommitConfig{
+			LinkTemplate: GetEnvOrDefault("KITE_COMMIT_LINK_TEMPLATE", commitlink.DefaultTemplate),
+		},
+		Hooks: HookConfig{
+			FailOnCreateHookError: GetEnvBoolOrDefault("KITE_FAIL_ON_CREATE_HOOK_ERROR", false),
+		},
+		Attach: AttachmentConfig{
+			ContentTypes: GetEnvSliceOrDefault("KITE_ATTACHMENT_CONTENT_TYPES", DefaultAttachmentContentTypes),
+			MaxSize:      GetEnvIntOrDefault("KITE_ATTACHMENT_MAX_SIZE", 100<<20),
+		},
+		Paging: PaginationConfig{
+			DefaultPageSize: GetEnvIntOrDefault("KITE_DEFAULT_PAGE_SIZE", 50),
+			MaxPageSize:     GetEnvIntOrDefault("KITE_MAX_PAGE_SIZE", 200),
+			MaxOffset:       GetEnvIntOrDefault("KITE_MAX_OFFSET", 10000),
+			MaxGroups:       GetEnvIntOrDefault("KITE_MAX_ISSUE_GROUPS", 100),
+		},
+		Relations: RelationshipConfig{
+			MaxPerIssue:            GetEnvIntOrDefault("KITE_MAX_RELATIONSHIPS_PER_ISSUE", 50),
+			AutoRelateSameResource: GetEnvBoolOrDefault("KITE_AUTO_RELATE_SAME_RESOURCE", false),
+			MaxAutoRelations:       GetEnvIntOrDefault("KITE_MAX_AUTO_RELATIONS", 10),
+			MaxGraphSize:           GetEnvIntOrDefault("KITE_MAX_GRAPH_SIZE", 200),
+		},
+		Resolve: AutoResolveConfig{
+			TTLs:            loadAutoResolveTTLs(),
+			Interval:        GetEnvDurationOrDefault("KITE_AUTO_RESOLVE_INTERVAL", 5*time.Minute),
+			DeescalateAfter: GetEnvDurationOrDefault("KITE_DEESCALATE_AFTER", 0),
+			ConflictWindow:  GetEnvDurationOrDefault("KITE_RESOLVE_CONFLICT_WINDOW", 5*time.Second),
+		},
+		Deadlines: DeadlineConfig{
+			Resolution: loadResolutionDeadlines(),
+		},
+		Metrics: MetricsConfig{
+			Enabled:           GetEnvBoolOrDefault("KITE_METRICS_ENABLED", true),
+			Address:           GetEnvOrDefault("KITE_METRICS_ADDRESS", ":9090"),
+			RefreshInterval:   GetEnvDurationOrDefault("KITE_METRICS_REFRESH_INTERVAL", 30*time.Second),
+			AggregateCacheTTL: GetEnvDurationOrDefault("KITE_AGGREGATE_CACHE_TTL", 30*time.Second),
+		},
+		Redaction: RedactionConfig{
+			Patterns: GetEnvLinesOrDefault("KITE_REDACTION_PATTERNS", redact.DefaultPatterns),
+		},
+		Webhooks: WebhookConfig{
+			IgnoreFailureReasons:   GetEnvLinesOrDefault("KITE_IGNORE_FAILURE_REASONS", nil),
+			IssueTemplates:         issueTemplates,
+			ReleaseLogsURLTemplate: GetEnvOrDefault("KITE_RELEASE_LOGS_URL_TEMPLATE", ""),
+			ReleaseIssueScope:      GetEnvOrDefault("KITE_RELEASE_ISSUE_SCOPE", ReleaseIssueScopeApplication),
+			MintmakerMaxLogBytes:   GetEnvIntOrDefault("KITE_MINTMAKER_MAX_LOG_BYTES", 64*1024),
+			MaxEventAge:            GetEnvDurationOrDefault("KITE_MAX_EVENT_AGE", 0),
+			MaxConcurrency:         GetEnvIntOrDefault("KITE_WEBHOOK_MAX_CONCURRENCY", 20),
+			QueueTimeout:           GetEnvDurationOrDefault("KITE_WEBHOOK_QUEUE_TIMEOUT", 2*time.Second),
+			Async:                  GetEnvBoolOrDefault("KITE_WEBHOOK_ASYNC", false),
+			AsyncQueueSize:         GetEnvIntOrDefault("KITE_WEBHOOK_ASYNC_QUEUE_SIZE", 1000),
+			AsyncWorkers:           GetEnvIntOrDefault("KITE_WEBHOOK_ASYNC_WORKERS", 4),
+			Response:               GetEnvOrDefault("KITE_WEBHOOK_RESPONSE", WebhookResponseFull),
+		},
 	}
 
 	// Validate configuration
@@ -105,6 +561,27 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %s", c.Server.Port)
 	}
 
+	if c.Server.MaxInFlightRequests < 0 {
+		return fmt.Errorf("invalid maximum in-flight requests: %d", c.Server.MaxInFlightRequests)
+	}
+	if c.Server.PrestopDelay < 0 {
+		return fmt.Errorf("invalid prestop delay: %s", c.Server.PrestopDelay)
+	}
+	validRootResponses := []string{RootResponseInfo, RootResponseRedirect}
+	if !slices.Contains(validRootResponses, c.Server.RootResponse) {
+		return fmt.Errorf("invalid root response: %s (must be one of: %s)",
+			c.Server.RootResponse, strings.Join(validRootResponses, ", "))
+	}
+	validJSONNamings := []string{JSONNamingCamelCase, JSONNamingSnakeCase}
+	if !slices.Contains(validJSONNamings, c.Server.JSONNaming) {
+		return fmt.Errorf("invalid JSON naming: %s (must be one of: %s)",
+			c.Server.JSONNaming, strings.Join(validJSONNamings, ", "))
+	}
+
+	if c.Server.CompressionMinSize < 0 {
+		return fmt.Errorf("invalid compression minimum size: %d", c.Server.CompressionMinSize)
+	}
+
 	// Validate project environment
 	validEnvs := []string{"development", "staging", "production", "test"}
 	if !slices.Contains(validEnvs, c.Server.Environment) {
@@ -136,9 +613,333 @@ func (c *Config) Validate() error {
 			c.Logging.Format, strings.Join(validLogFormats, ", "))
 	}
 
+	validAccessLogFormats := []string{"json", "combined", "off"}
+	if !slices.Contains(validAccessLogFormats, c.Logging.AccessLogFormat) {
+		return fmt.Errorf("invalid access log format: %s (must be one of: %s)",
+			c.Logging.AccessLogFormat, strings.Join(validAccessLogFormats, ", "))
+	}
+	if c.Logging.LogBodies && c.Logging.MaxLoggedBodySize < 1 {
+		return fmt.Errorf("invalid maximum logged body size: %d", c.Logging.MaxLoggedBodySize)
+	}
+
+	// Validate security configuration
+	validAuthorizationNamespaces := []string{AuthorizationNamespaceIssue, AuthorizationNamespaceResource}
+	if !slices.Contains(validAuthorizationNamespaces, c.Security.AuthorizationNamespace) {
+		return fmt.Errorf("invalid authorization namespace: %s (must be one of: %s)",
+			c.Security.AuthorizationNamespace, strings.Join(validAuthorizationNamespaces, ", "))
+	}
+	validSensitiveFieldsModes := []string{SensitiveFieldsRedact, SensitiveFieldsOmit}
+	if !slices.Contains(validSensitiveFieldsModes, c.Security.SensitiveFieldsMode) {
+		return fmt.Errorf("invalid sensitive fields mode: %s (must be one of: %s)",
+			c.Security.SensitiveFieldsMode, strings.Join(validSensitiveFieldsModes, ", "))
+	}
+	if len(c.Security.SensitiveNamespaces) > 0 && c.Security.SensitiveAccessVerb == "" {
+		return fmt.Errorf("a verb granting access to sensitive namespaces is required")
+	}
+	if c.Security.DecommissionAccessVerb == "" {
+		return fmt.Errorf("a verb granting access to decommission namespaces is required")
+	}
+	if _, err := linkdomain.New(c.Security.AllowedLinkDomains); err != nil {
+		return err
+	}
+	if _, err := customfields.Parse(c.Fields.Schemas); err != nil {
+		return err
+	}
+
+	// Validate deduplication configuration
+	if c.Dedup.MaxOccurrences < 0 {
+		return fmt.Errorf("invalid maximum occurrences per issue: %d", c.Dedup.MaxOccurrences)
+	}
+	if c.Dedup.MinUpdateInterval < 0 {
+		return fmt.Errorf("invalid minimum interval between duplicate updates: %s", c.Dedup.MinUpdateInterval)
+	}
+	validDedupStrategies := []string{DedupStrategyScope, DedupStrategyReason}
+	if !slices.Contains(validDedupStrategies, c.Dedup.Strategy) {
+		return fmt.Errorf("invalid dedup strategy: %s (must be one of: %s)",
+			c.Dedup.Strategy, strings.Join(validDedupStrategies, ", "))
+	}
+	if _, err := regexp.Compile(c.Dedup.ResourceNameStrip); err != nil {
+		return fmt.Errorf("invalid resource name strip pattern %q: %w", c.Dedup.ResourceNameStrip, err)
+	}
+
+	// Validate limits configuration
+	if c.Limits.MaxActiveIssuesPerNamespace < 0 {
+		return fmt.Errorf("invalid maximum active issues per namespace: %d", c.Limits.MaxActiveIssuesPerNamespace)
+	}
+	if c.Limits.MaxScopeFieldLength < 1 {
+		return fmt.Errorf("invalid maximum scope field length: %d", c.Limits.MaxScopeFieldLength)
+	}
+
+	// Validate notification configuration
+	if c.Notify.WebhookURL != "" && c.Notify.Timeout <= 0 {
+		return fmt.Errorf("invalid notification timeout: %s", c.Notify.Timeout)
+	}
+	if c.Notify.WebhookURL != "" {
+		if c.Notify.MaxAttempts < 1 {
+			return fmt.Errorf("invalid notification maximum attempts: %d", c.Notify.MaxAttempts)
+		}
+		if c.Notify.MaxAttempts > 1 {
+			if c.Notify.RetryBackoff <= 0 || c.Notify.RetryMaxBackoff < c.Notify.RetryBackoff {
+				return fmt.Errorf("invalid notification retry backoff: %s up to %s", c.Notify.RetryBackoff, c.Notify.RetryMaxBackoff)
+			}
+			if c.Notify.RetryQueueSize < 1 {
+				return fmt.Errorf("invalid notification retry queue size: %d", c.Notify.RetryQueueSize)
+			}
+		}
+	}
+	if c.Notify.QuietHours != "" {
+		if _, err := quiethours.Parse(c.Notify.QuietHours, c.Notify.QuietHoursTimezone); err != nil {
+			return err
+		}
+		if c.Notify.QuietHoursMinSeverity.Rank() == 0 {
+			return fmt.Errorf("invalid quiet hours minimum severity: %s", c.Notify.QuietHoursMinSeverity)
+		}
+	}
+
+	// Validate pagination configuration
+	if c.Paging.DefaultPageSize < 1 {
+		return fmt.Errorf("invalid default page size: %d", c.Paging.DefaultPageSize)
+	}
+	if c.Paging.MaxPageSize < c.Paging.DefaultPageSize {
+		return fmt.Errorf("invalid maximum page size: %d (must be at least the default page size %d)",
+			c.Paging.MaxPageSize, c.Paging.DefaultPageSize)
+	}
+	if c.Paging.MaxOffset < 0 {
+		return fmt.Errorf("invalid maximum offset: %d", c.Paging.MaxOffset)
+	}
+	if c.Paging.MaxGroups < 1 {
+		return fmt.Errorf("invalid maximum number of issue groups: %d", c.Paging.MaxGroups)
+	}
+
+	// Validate relationship configuration
+	if c.Relations.MaxPerIssue < 1 {
+		return fmt.Errorf("invalid maximum relationships per issue: %d", c.Relations.MaxPerIssue)
+	}
+	if c.Relations.AutoRelateSameResource && c.Relations.MaxAutoRelations < 1 {
+		return fmt.Errorf("invalid maximum automatic relationships: %d", c.Relations.MaxAutoRelations)
+	}
+	if c.Relations.MaxGraphSize < 1 {
+		return fmt.Errorf("invalid maximum relationship graph size: %d", c.Relations.MaxGraphSize)
+	}
+
+	// Validate metrics configuration
+	if c.Metrics.Enabled && c.Metrics.RefreshInterval <= 0 {
+		return fmt.Errorf("invalid metrics refresh interval: %s", c.Metrics.RefreshInterval)
+	}
+	if c.Metrics.Enabled && c.Metrics.Address == "" {
+		return fmt.Errorf("metrics address is required when metrics are enabled")
+	}
+	if c.Metrics.AggregateCacheTTL < 0 {
+		return fmt.Errorf("invalid aggregate cache TTL: %s", c.Metrics.AggregateCacheTTL)
+	}
+
+	// Validate webhook configuration
+	if c.Webhooks.MaxConcurrency < 0 {
+		return fmt.Errorf("invalid maximum webhook concurrency: %d", c.Webhooks.MaxConcurrency)
+	}
+	if c.Webhooks.MaxEventAge < 0 {
+		return fmt.Errorf("invalid maximum webhook event age: %s", c.Webhooks.MaxEventAge)
+	}
+	if c.Webhooks.QueueTimeout < 0 {
+		return fmt.Errorf("invalid webhook queue timeout: %s", c.Webhooks.QueueTimeout)
+	}
+	if c.Webhooks.Async && c.Webhooks.AsyncQueueSize <= 0 {
+		return fmt.Errorf("invalid webhook async queue size: %d", c.Webhooks.AsyncQueueSize)
+	}
+	if c.Webhooks.Async && c.Webhooks.AsyncWorkers <= 0 {
+		return fmt.Errorf("invalid number of webhook async workers: %d", c.Webhooks.AsyncWorkers)
+	}
+	if c.Webhooks.MintmakerMaxLogBytes < 0 {
+		return fmt.Errorf("invalid maximum mintmaker log size: %d", c.Webhooks.MintmakerMaxLogBytes)
+	}
+	validReleaseIssueScopes := []string{ReleaseIssueScopeApplication, ReleaseIssueScopeRelease}
+	if !slices.Contains(validReleaseIssueScopes, c.Webhooks.ReleaseIssueScope) {
+		return fmt.Errorf("invalid release issue scope: %s (must be one of: %s)",
+			c.Webhooks.ReleaseIssueScope, strings.Join(validReleaseIssueScopes, ", "))
+	}
+	validWebhookResponses := []string{WebhookResponseMinimal, WebhookResponseFull}
+	if !slices.Contains(validWebhookResponses, c.Webhooks.Response) {
+		return fmt.Errorf("invalid webhook response: %s (must be one of: %s)",
+			c.Webhooks.Response, strings.Join(validWebhookResponses, ", "))
+	}
+
+	// Validate auto-resolve configuration
+	for issueType, ttl := range c.Resolve.TTLs {
+		if ttl < 0 {
+			return fmt.Errorf("invalid auto-resolve TTL for %s issues: %s", issueType, ttl)
+		}
+	}
+	if c.Resolve.DeescalateAfter < 0 {
+		return fmt.Errorf("invalid de-escalation window: %s", c.Resolve.DeescalateAfter)
+	}
+	if c.Resolve.ConflictWindow < 0 {
+		return fmt.Errorf("invalid resolve conflict window: %s", c.Resolve.ConflictWindow)
+	}
+	if (len(c.Resolve.TTLs) > 0 || c.Resolve.DeescalateAfter > 0) && c.Resolve.Interval <= 0 {
+		return fmt.Errorf("invalid auto-resolve interval: %s", c.Resolve.Interval)
+	}
+
+	// Validate deadline configuration
+	for severity, deadline := range c.Deadlines.Resolution {
+		if deadline < 0 {
+			return fmt.Errorf("invalid resolution deadline for %s issues: %s", severity, deadline)
+		}
+	}
+
+	// Validate severity configuration
+	for namespace, floor := range c.Severity.Floors {
+		if floor.Rank() == 0 {
+			return fmt.Errorf("invalid severity floor for namespace %s: %s", namespace, floor)
+		}
+	}
+	for alias, severity := range c.Severity.Aliases {
+		if models.Severity(alias).Rank() != 0 {
+			return fmt.Errorf("invalid severity alias %s: it's already a severity", alias)
+		}
+		if severity.Rank() == 0 {
+			return fmt.Errorf("invalid severity for alias %s: %s", alias, severity)
+		}
+	}
+
+	// Validate attachment configuration
+	if len(c.Attach.ContentTypes) == 0 {
+		return fmt.Errorf("at least one attachment content type must be allowed")
+	}
+	for _, contentType := range c.Attach.ContentTypes {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !strings.Contains(mediaType, "/") {
+			return fmt.Errorf("invalid attachment content type: %q", contentType)
+		}
+	}
+	if c.Attach.MaxSize < 1 {
+		return fmt.Errorf("invalid maximum attachment size: %d", c.Attach.MaxSize)
+	}
+
 	return nil
 }
 
+// defaultResolutionDeadlines are the resolution deadlines of the severities that have one by default
+var defaultResolutionDeadlines = map[models.Severity]time.Duration{
+	models.SeverityCritical: 4 * time.Hour,
+	models.SeverityMajor:    24 * time.Hour,
+	models.SeverityMinor:    72 * time.Hour,
+}
+
+// loadResolutionDeadlines reads the resolution deadline of each severity from
+// KITE_RESOLUTION_DEADLINE_<SEVERITY>, e.g. KITE_RESOLUTION_DEADLINE_CRITICAL=4h.
+// Severities with a deadline of 0 aren't in the returned map.
+func loadResolutionDeadlines() map[models.Severity]time.Duration {
+	deadlines := make(map[models.Severity]time.Duration)
+	for _, severity := range models.Severities {
+		key := "KITE_RESOLUTION_DEADLINE_" + strings.ToUpper(string(severity))
+		if deadline := GetEnvDurationOrDefault(key, defaultResolutionDeadlines[severity]); deadline != 0 {
+			deadlines[severity] = deadline
+		}
+	}
+	return deadlines
+}
+
+// loadAutoResolveTTLs reads the auto-resolve TTL of each issue type from
+// KITE_AUTO_RESOLVE_TTL_<TYPE>, e.g. KITE_AUTO_RESOLVE_TTL_PIPELINE=24h.
+// Types without a TTL, or with a TTL of 0, aren't in the returned map.
+func loadAutoResolveTTLs() map[models.IssueType]time.Duration {
+	ttls := make(map[models.IssueType]time.Duration)
+	for _, issueType := range models.IssueTypes {
+		key := "KITE_AUTO_RESOLVE_TTL_" + strings.ToUpper(string(issueType))
+		if ttl := GetEnvDurationOrDefault(key, 0); ttl != 0 {
+			ttls[issueType] = ttl
+		}
+	}
+	return ttls
+}
+
+// loadRoutePolicies reads the authentication level required by routes from KITE_ROUTE_POLICIES,
+// comma-separated routes and levels, e.g. "GET /api/v1/issues/stats=public,POST /api/v1/*=authenticated".
+// Routes and levels are validated when the router is set up.
+func loadRoutePolicies() (map[string]string, error) {
+	policies := make(map[string]string)
+	for _, entry := range GetEnvSliceOrDefault("KITE_ROUTE_POLICIES", nil) {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		route, level, ok := strings.Cut(entry, "=")
+		route = strings.TrimSpace(route)
+		if !ok || route == "" {
+			return nil, fmt.Errorf("invalid route policy %q: expected <method> <path>=<level>", entry)
+		}
+		if _, exists := policies[route]; exists {
+			return nil, fmt.Errorf("duplicate route policy for %q", route)
+		}
+		policies[route] = strings.TrimSpace(level)
+	}
+	return policies, nil
+}
+
+// loadSeverityFloors reads the severity floor of namespaces from KITE_SEVERITY_FLOORS,
+// comma-separated namespaces and severities, e.g. "prod-payments=major,prod-auth=critical".
+// Severities are validated with the rest of the configuration.
+func loadSeverityFloors() (map[string]models.Severity, error) {
+	floors := make(map[string]models.Severity)
+	for _, entry := range GetEnvSliceOrDefault("KITE_SEVERITY_FLOORS", nil) {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		namespace, severity, ok := strings.Cut(entry, "=")
+		namespace = strings.TrimSpace(namespace)
+		if !ok || namespace == "" {
+			return nil, fmt.Errorf("invalid severity floor %q: expected <namespace>=<severity>", entry)
+		}
+		if _, exists := floors[namespace]; exists {
+			return nil, fmt.Errorf("duplicate severity floor for namespace %q", namespace)
+		}
+		floors[namespace] = models.Severity(strings.TrimSpace(severity))
+	}
+	return floors, nil
+}
+
+// loadSeverityAliases reads the severities webhooks accept in place of the known ones
+// from KITE_SEVERITY_ALIASES, comma-separated <alias>=<severity> entries, e.g. high=major.
+// Aliases are matched regardless of case.
+func loadSeverityAliases() (map[string]models.Severity, error) {
+	aliases := make(map[string]models.Severity)
+	for _, entry := range GetEnvSliceOrDefault("KITE_SEVERITY_ALIASES", []string{"high=major", "low=minor"}) {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		alias, severity, ok := strings.Cut(entry, "=")
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if !ok || alias == "" {
+			return nil, fmt.Errorf("invalid severity alias %q: expected <alias>=<severity>", entry)
+		}
+		if _, exists := aliases[alias]; exists {
+			return nil, fmt.Errorf("duplicate severity alias %q", alias)
+		}
+		aliases[alias] = models.Severity(strings.TrimSpace(severity))
+	}
+	return aliases, nil
+}
+
+// loadIssueTemplates reads the templates of webhook issues.
+//
+// The template of a field is read from KITE_TEMPLATE_<WEBHOOK>_<FIELD>, or from
+// the file at KITE_TEMPLATE_<WEBHOOK>_<FIELD>_FILE, e.g. KITE_TEMPLATE_PIPELINE_FAILURE_TITLE.
+// Fields without a template aren't in the returned map.
+func loadIssueTemplates() (map[string]string, error) {
+	templates := make(map[string]string)
+	for _, webhook := range TemplatedWebhooks {
+		for _, field := range TemplatedIssueFields {
+			key := "KITE_TEMPLATE_" + strings.ToUpper(strings.ReplaceAll(webhook, "-", "_")+"_"+field)
+			tmpl, err := GetEnvOrFileOrDefault(key, "")
+			if err != nil {
+				return nil, err
+			}
+			if tmpl != "" {
+				templates[webhook+"."+field] = tmpl
+			}
+		}
+	}
+	return templates, nil
+}
+
 // Helper functions
 
 // IsDevelopment returns true if running in development mode
@@ -163,6 +964,25 @@ func GetEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// Helper function to get a value either from a file or an environment variable.
+//
+// If <key>_FILE is set, the value is read from that file path and takes precedence
+// over <key>. Trailing newlines are trimmed from the file contents. This supports
+// secrets that are mounted as files (e.g. Kubernetes secrets).
+//
+// Defaults to the value passed if neither is set.
+// Returns an error if <key>_FILE is set but the file can't be read.
+func GetEnvOrFileOrDefault(key, defaultValue string) (string, error) {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s_FILE: %w", key, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	return GetEnvOrDefault(key, defaultValue), nil
+}
+
 // Helper function to get an environment variable.
 //
 // If the value is found, it's converted into an int.
@@ -198,7 +1018,7 @@ func GetEnvBoolOrDefault(key string, defaultValue bool) bool {
 // Defaults to the value passed.
 func GetEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
-		if timeValue, err := time.ParseDuration(value); err != nil {
+		if timeValue, err := time.ParseDuration(value); err == nil {
 			return timeValue
 		}
 	}
@@ -217,6 +1037,25 @@ func GetEnvSliceOrDefault(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// Helper function to get an environment variable
+//
+// If the value is found, it's split into a slice of strings, one per non-empty line.
+// This is useful for values that may contain commas, like regular expressions.
+//
+// Defaults to the value passed.
+func GetEnvLinesOrDefault(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var lines []string
+		for _, line := range strings.Split(value, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+	return defaultValue
+}
+
 // GetEnvFileInCwd returns the full path to the given filename in project root directory
 func GetEnvFileInCwd(filename string) (string, error) {
 	cwd, err := os.Getwd()
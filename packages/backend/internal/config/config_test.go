@@ -0,0 +1,326 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// writeSecretFile writes the content to a file in a temporary directory and returns its path
+func writeSecretFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	return path
+}
+
+func TestGetEnvOrFileOrDefault_FileTakesPrecedence(t *testing.T) {
+	t.Setenv("KITE_DB_PASSWORD", "from-env")
+	t.Setenv("KITE_DB_PASSWORD_FILE", writeSecretFile(t, "password", "from-file\n\n"))
+
+	value, err := GetEnvOrFileOrDefault("KITE_DB_PASSWORD", "default")
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+
+	if value != "from-file" {
+		t.Errorf("expected value 'from-file', got '%s'", value)
+	}
+}
+
+func TestGetEnvOrFileOrDefault_FallsBackToEnv(t *testing.T) {
+	t.Setenv("KITE_DB_PASSWORD", "from-env")
+
+	value, err := GetEnvOrFileOrDefault("KITE_DB_PASSWORD", "default")
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+
+	if value != "from-env" {
+		t.Errorf("expected value 'from-env', got '%s'", value)
+	}
+}
+
+func TestGetEnvOrFileOrDefault_MissingFile(t *testing.T) {
+	t.Setenv("KITE_DB_PASSWORD", "from-env")
+	t.Setenv("KITE_DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "does-not-exist"))
+
+	_, err := GetEnvOrFileOrDefault("KITE_DB_PASSWORD", "default")
+	if err == nil {
+		t.Fatal("expected an error for a missing file, got nil")
+	}
+}
+
+func TestGetDatabaseConfig_CredentialsFromFiles(t *testing.T) {
+	t.Setenv("KITE_DB_USER", "env-user")
+	t.Setenv("KITE_DB_USER_FILE", writeSecretFile(t, "user", "file-user\n"))
+	t.Setenv("KITE_DB_PASSWORD_FILE", writeSecretFile(t, "password", "file-password"))
+
+	dbConfig, err := GetDatabaseConfig()
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+
+	if dbConfig.User != "file-user" {
+		t.Errorf("expected user 'file-user', got '%s'", dbConfig.User)
+	}
+	if dbConfig.Password != "file-password" {
+		t.Errorf("expected password 'file-password', got '%s'", dbConfig.Password)
+	}
+}
+
+func TestLoadConfig_MissingCredentialsFile(t *testing.T) {
+	t.Setenv("KITE_DB_USER_FILE", filepath.Join(t.TempDir(), "does-not-exist"))
+
+	if _, err := LoadConfig(); err == nil {
+		t.Fatal("expected an error for a missing credentials file, got nil")
+	}
+}
+
+func TestLoadConfig_QuietHours(t *testing.T) {
+	t.Setenv("KITE_QUIET_HOURS", "22:00-06:00")
+	t.Setenv("KITE_QUIET_HOURS_TIMEZONE", "Europe/Paris")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+	if cfg.Notify.QuietHoursMinSeverity != models.SeverityCritical {
+		t.Errorf("expected only critical issues to notify during quiet hours, got %s", cfg.Notify.QuietHoursMinSeverity)
+	}
+
+	t.Setenv("KITE_QUIET_HOURS", "22:00")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for invalid quiet hours, got nil")
+	}
+
+	t.Setenv("KITE_QUIET_HOURS", "22:00-06:00")
+	t.Setenv("KITE_QUIET_HOURS_MIN_SEVERITY", "urgent")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid minimum severity, got nil")
+	}
+}
+
+func TestLoadConfig_NotificationRetries(t *testing.T) {
+	t.Setenv("KITE_NOTIFY_WEBHOOK_URL", "https://chat.example.com/hooks/kite")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+	if cfg.Notify.MaxAttempts != 5 || cfg.Notify.RetryBackoff != 5*time.Second || cfg.Notify.RetryMaxBackoff != 5*time.Minute {
+		t.Errorf("expected 5 attempts backing off from 5s up to 5m, got %d from %s up to %s",
+			cfg.Notify.MaxAttempts, cfg.Notify.RetryBackoff, cfg.Notify.RetryMaxBackoff)
+	}
+
+	t.Setenv("KITE_NOTIFY_MAX_ATTEMPTS", "0")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for no attempts, got nil")
+	}
+
+	t.Setenv("KITE_NOTIFY_MAX_ATTEMPTS", "3")
+	t.Setenv("KITE_NOTIFY_RETRY_BACKOFF", "1m")
+	t.Setenv("KITE_NOTIFY_RETRY_MAX_BACKOFF", "30s")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected an error for a maximum backoff below the backoff, got nil")
+	}
+
+	// Retries aren't configured when disabled
+	t.Setenv("KITE_NOTIFY_MAX_ATTEMPTS", "1")
+	t.Setenv("KITE_NOTIFY_RETRY_QUEUE_SIZE", "0")
+	if _, err := LoadConfig(); err != nil {
+		t.Errorf("unexpected error, got %v", err)
+	}
+}
+
+func TestGetEnvDurationOrDefault(t *testing.T) {
+	t.Setenv("KITE_PRESTOP_DELAY", "15s")
+	if value := GetEnvDurationOrDefault("KITE_PRESTOP_DELAY", 0); value != 15*time.Second {
+		t.Errorf("expected 15s, got %s", value)
+	}
+
+	t.Setenv("KITE_PRESTOP_DELAY", "not-a-duration")
+	if value := GetEnvDurationOrDefault("KITE_PRESTOP_DELAY", 5*time.Second); value != 5*time.Second {
+		t.Errorf("expected the default for an invalid value, got %s", value)
+	}
+}
+
+func TestWithStatementTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		timeout  time.Duration
+		expected string
+	}{
+		{"disabled", "host=localhost dbname=issuesdb", 0, "host=localhost dbname=issuesdb"},
+		{"keyword/value", "host=localhost dbname=issuesdb", 30 * time.Second, "host=localhost dbname=issuesdb statement_timeout=30000"},
+		{"URL", "postgres://kite@localhost/issuesdb?sslmode=disable", 1500 * time.Millisecond, "postgres://kite@localhost/issuesdb?sslmode=disable&statement_timeout=1500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if dsn := WithStatementTimeout(tt.dsn, tt.timeout); dsn != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, dsn)
+			}
+		})
+	}
+}
+
+func TestLoadIssueTemplates(t *testing.T) {
+	t.Setenv("KITE_TEMPLATE_PIPELINE_FAILURE_TITLE", "CI failed: {{.PipelineName}}")
+	t.Setenv("KITE_TEMPLATE_RELEASE_FAILURE_DESCRIPTION_FILE", writeSecretFile(t, "description", "Phase {{.FailurePhase}}\n"))
+
+	templates, err := loadIssueTemplates()
+	if err != nil {
+		t.Fatalf("unexpected error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"pipeline-failure.title":      "CI failed: {{.PipelineName}}",
+		"release-failure.description": "Phase {{.FailurePhase}}",
+	}
+	if len(templates) != len(expected) {
+		t.Fatalf("expected %d templates, got %v", len(expected), templates)
+	}
+	for key, tmpl := range expected {
+		if templates[key] != tmpl {
+			t.Errorf("expected template %q for %s, got %q", tmpl, key, templates[key])
+		}
+	}
+}
+
+func TestLoadAutoResolveTTLs(t *testing.T) {
+	t.Setenv("KITE_AUTO_RESOLVE_TTL_PIPELINE", "24h")
+	t.Setenv("KITE_AUTO_RESOLVE_TTL_BUILD", "0")
+
+	ttls := loadAutoResolveTTLs()
+	if len(ttls) != 1 || ttls[models.IssueTypePipeline] != 24*time.Hour {
+		t.Errorf("expected only a 24h TTL for pipeline issues, got %v", ttls)
+	}
+}
+
+func TestLoadResolutionDeadlines(t *testing.T) {
+	t.Setenv("KITE_RESOLUTION_DEADLINE_CRITICAL", "2h")
+	t.Setenv("KITE_RESOLUTION_DEADLINE_MINOR", "0")
+
+	deadlines := loadResolutionDeadlines()
+	expected := map[models.Severity]time.Duration{
+		models.SeverityCritical: 2 * time.Hour,
+		models.SeverityMajor:    24 * time.Hour,
+	}
+	if len(deadlines) != len(expected) {
+		t.Fatalf("expected deadlines %v, got %v", expected, deadlines)
+	}
+	for severity, deadline := range expected {
+		if deadlines[severity] != deadline {
+			t.Errorf("expected a %s deadline for %s issues, got %s", deadline, severity, deadlines[severity])
+		}
+	}
+}
+
+func TestLoadRoutePolicies(t *testing.T) {
+	t.Setenv("KITE_ROUTE_POLICIES", "GET /api/v1/issues/stats=public, POST /api/v1/issues/* = authenticated")
+
+	policies, err := loadRoutePolicies()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]string{
+		"GET /api/v1/issues/stats": "public",
+		"POST /api/v1/issues/*":    "authenticated",
+	}
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("expected policies %v, got %v", expected, policies)
+	}
+
+	for _, invalid := range []string{"GET /api/v1/issues", "GET /api/v1/issues=public,GET /api/v1/issues=default"} {
+		t.Setenv("KITE_ROUTE_POLICIES", invalid)
+		if _, err := loadRoutePolicies(); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadSeverityFloors(t *testing.T) {
+	t.Setenv("KITE_SEVERITY_FLOORS", "prod-payments=major, prod-auth = critical")
+
+	floors, err := loadSeverityFloors()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]models.Severity{
+		"prod-payments": models.SeverityMajor,
+		"prod-auth":     models.SeverityCritical,
+	}
+	if !reflect.DeepEqual(floors, expected) {
+		t.Errorf("expected floors %v, got %v", expected, floors)
+	}
+
+	for _, invalid := range []string{"prod-payments", "=major", "prod-payments=major,prod-payments=minor"} {
+		t.Setenv("KITE_SEVERITY_FLOORS", invalid)
+		if _, err := loadSeverityFloors(); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadSeverityAliases(t *testing.T) {
+	aliases, err := loadSeverityAliases()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]models.Severity{"high": models.SeverityMajor, "low": models.SeverityMinor}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Errorf("expected default aliases %v, got %v", expected, aliases)
+	}
+
+	t.Setenv("KITE_SEVERITY_ALIASES", "Urgent=critical, warning = minor")
+	aliases, err = loadSeverityAliases()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected = map[string]models.Severity{"urgent": models.SeverityCritical, "warning": models.SeverityMinor}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Errorf("expected aliases %v, got %v", expected, aliases)
+	}
+
+	for _, invalid := range []string{"urgent", "=critical", "high=major,HIGH=critical"} {
+		t.Setenv("KITE_SEVERITY_ALIASES", invalid)
+		if _, err := loadSeverityAliases(); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	empty, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := CheckSchema(empty, models.All()...); err == nil {
+		t.Error("expected an error for a database without tables, got nil")
+	}
+
+	// A table from an older migration, missing the columns added since
+	if err := empty.Exec("CREATE TABLE issue_scopes (id TEXT PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	if err := CheckSchema(empty, &models.IssueScope{}); err == nil {
+		t.Error("expected an error for a table missing columns, got nil")
+	}
+
+	migrated := testhelpers.SetupTestDB(t)
+	if err := CheckSchema(migrated, models.All()...); err != nil {
+		t.Errorf("expected a migrated database to pass, got %v", err)
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/konflux-ci/kite/internal/pkg/dblog"
+	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 // Database configuration
@@ -20,33 +25,70 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// Host of a read replica of the database, reads are routed to it when set
+	ReplicaHost string
+	// Statements running longer than this are aborted by the database, 0 disables the timeout
+	StatementTimeout time.Duration
+	// Queries running longer than this are logged at warn level, 0 disables the logging
+	SlowQueryThreshold time.Duration
 }
 
 // Returns the database configuration using ENV variables. Uses defaults if ENV variables are not found.
-func GetDatabaseConfig() *DatabaseConfig {
+//
+// The user and password can also be read from files (e.g. mounted Kubernetes secrets)
+// using KITE_DB_USER_FILE and KITE_DB_PASSWORD_FILE, which take precedence over
+// KITE_DB_USER and KITE_DB_PASSWORD.
+func GetDatabaseConfig() (*DatabaseConfig, error) {
+	user, err := GetEnvOrFileOrDefault("KITE_DB_USER", "postgres")
+	if err != nil {
+		return nil, err
+	}
+	password, err := GetEnvOrFileOrDefault("KITE_DB_PASSWORD", "postgres")
+	if err != nil {
+		return nil, err
+	}
+
 	return &DatabaseConfig{
 		Host:     getEnvOrDefault("KITE_DB_HOST", "localhost"),
 		Port:     getEnvOrDefault("KITE_DB_PORT", "5432"),
-		User:     getEnvOrDefault("KITE_DB_USER", "postgres"),
-		Password: getEnvOrDefault("KITE_DB_PASSWORD", "postgres"),
+		User:     user,
+		Password: password,
 		Name:     getEnvOrDefault("KITE_DB_NAME", "issuesdb"),
 		SSLMode:  getEnvOrDefault("KITE_DB_SSL_MODE", "disable"),
-	}
-}
 
-// Initializes the database.
-func InitDatabase() (*gorm.DB, error) {
-	config := GetDatabaseConfig()
+		ReplicaHost: os.Getenv("KITE_DB_REPLICA_HOST"),
 
+		StatementTimeout:   GetEnvDurationOrDefault("KITE_DB_STATEMENT_TIMEOUT", 0),
+		SlowQueryThreshold: GetEnvDurationOrDefault("KITE_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+	}, nil
+}
+
+// connectionString returns the DSN of the database on the host passed, which is
+// either the primary or the replica host.
+func (c *DatabaseConfig) connectionString(host string) string {
 	connectionString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		config.Host, config.User, config.Password, config.Name, config.Port, config.SSLMode)
+		host, c.User, c.Password, c.Name, c.Port, c.SSLMode)
+	return WithStatementTimeout(connectionString, c.StatementTimeout)
+}
+
+// Initializes the database. Queries are logged with the logger passed.
+//
+// When KITE_DB_REPLICA_HOST is set, reads outside of transactions are routed to the replica,
+// see UseReadReplica. It shares the port, credentials and name of the primary database.
+func InitDatabase(appLogger *logrus.Logger) (*gorm.DB, error) {
+	config, err := GetDatabaseConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	connectionString := config.connectionString(config.Host)
 
-	var gormLogger logger.Interface
+	// Every query is logged in development, only failed and slow ones otherwise
+	logMode := logger.Warn
 	if os.Getenv("KITE_PROJECT_ENV") == "development" {
-		gormLogger = logger.Default.LogMode(logger.Info)
-	} else {
-		gormLogger = logger.Default.LogMode(logger.Error)
+		logMode = logger.Info
 	}
+	gormLogger := dblog.New(appLogger, logMode, config.SlowQueryThreshold)
 
 	// DB connection timeout settings
 	maxRetries := GetEnvIntOrDefault("KITE_DB_MAX_RETRIES", 10)
@@ -64,16 +106,97 @@ func InitDatabase() (*gorm.DB, error) {
 
 	// Set connection pool settings
 	// Keep x idle connections open
-	sqlDB.SetMaxIdleConns(GetEnvIntOrDefault("KITE_DB_MAX_IDLE_CONNS", 10))
+	maxIdleConns := GetEnvIntOrDefault("KITE_DB_MAX_IDLE_CONNS", 10)
+	sqlDB.SetMaxIdleConns(maxIdleConns)
 	// Max number of DB connections allowed to be open at the same time
-	sqlDB.SetMaxOpenConns(GetEnvIntOrDefault("KITE_DB_MAX_OPEN_CONNS", 100))
+	maxOpenConns := GetEnvIntOrDefault("KITE_DB_MAX_OPEN_CONNS", 100)
+	sqlDB.SetMaxOpenConns(maxOpenConns)
 	// Refresh the connection periodically
-	sqlDB.SetConnMaxLifetime(GetEnvDurationOrDefault("KITE_DB_CONN_MAX_LIFETIME", 1*time.Hour))
+	connMaxLifetime := GetEnvDurationOrDefault("KITE_DB_CONN_MAX_LIFETIME", 1*time.Hour)
+	sqlDB.SetConnMaxLifetime(connMaxLifetime)
 
 	log.Println("Database connection established successfully")
+
+	if config.ReplicaHost != "" {
+		// The replica gets the same pool settings as the primary
+		resolver, err := UseReadReplica(db, config.connectionString(config.ReplicaHost))
+		if err != nil {
+			return nil, err
+		}
+		resolver.SetMaxIdleConns(maxIdleConns).
+			SetMaxOpenConns(maxOpenConns).
+			SetConnMaxLifetime(connMaxLifetime)
+		log.Printf("Reads are routed to the replica at %s", config.ReplicaHost)
+	}
 	return db, nil
 }
 
+// UseReadReplica routes the reads of the database to the replica at the DSN passed.
+// Writes, transactions and locking reads stay on the primary, and reads can be forced
+// to it with the dbresolver.Write clause, e.g. right after a write the replica may
+// not have received yet.
+func UseReadReplica(db *gorm.DB, replicaDSN string) (*dbresolver.DBResolver, error) {
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{postgres.Open(replicaDSN)},
+	})
+	if err := db.Use(resolver); err != nil {
+		return nil, fmt.Errorf("failed to set up the read replica: %w", err)
+	}
+	return resolver, nil
+}
+
+// CheckSchema checks that the tables and columns of the models exist, i.e. that the
+// migrations creating them were applied.
+func CheckSchema(db *gorm.DB, models ...any) error {
+	migrator := db.Migrator()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model: %w", err)
+		}
+		if !migrator.HasTable(model) {
+			return fmt.Errorf("missing table %s", stmt.Schema.Table)
+		}
+		for _, column := range stmt.Schema.DBNames {
+			if !migrator.HasColumn(model, column) {
+				return fmt.Errorf("missing column %s.%s", stmt.Schema.Table, column)
+			}
+		}
+	}
+	return nil
+}
+
+// WithStatementTimeout sets the Postgres statement_timeout of every session opened with the DSN,
+// so a runaway query can't hold a connection forever. A timeout of 0 leaves the DSN unchanged.
+//
+// Both keyword/value ("host=... user=...") and URL ("postgres://...") DSNs are supported.
+// SQLite has no statement timeout, so this only applies to Postgres connections.
+func WithStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dsn
+	}
+	// Postgres reads the timeout in milliseconds
+	value := fmt.Sprintf("%d", timeout.Milliseconds())
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		parsed, err := url.Parse(dsn)
+		if err == nil {
+			query := parsed.Query()
+			query.Set("statement_timeout", value)
+			parsed.RawQuery = query.Encode()
+			return parsed.String()
+		}
+	}
+	return dsn + " statement_timeout=" + value
+}
+
+// IsStatementTimeout reports whether a database error was caused by the statement timeout
+func IsStatementTimeout(err error) bool {
+	// 57014 is query_canceled, reported when the statement timeout is reached
+	return err != nil && (strings.Contains(err.Error(), "SQLSTATE 57014") ||
+		strings.Contains(err.Error(), "canceling statement due to statement timeout"))
+}
+
 // Connects to the specified database a specific number of times (maxRetries) with a delay for each retry.
 //
 // The delay strategy uses a linear backoff (delay × attempt number).
@@ -84,6 +207,8 @@ func connectWithRetries(connectionString string, gormLogger logger.Interface, ma
 	for i := 0; i < maxRetries; i++ {
 		db, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
 			Logger: gormLogger,
+			// Timestamps set by GORM (createdAt, updatedAt) are in UTC like the others
+			NowFunc: func() time.Time { return time.Now().UTC() },
 		})
 		if err == nil {
 			sqlDB, err := db.DB()
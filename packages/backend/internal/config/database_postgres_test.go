@@ -0,0 +1,53 @@
+//go:build postgres
+
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStatementTimeout_AbortsSlowQueries(t *testing.T) {
+	dsn := os.Getenv("KITE_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("KITE_TEST_POSTGRES_DSN not set, skipping Postgres test")
+	}
+
+	db, err := gorm.Open(postgres.Open(WithStatementTimeout(dsn, 100*time.Millisecond)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to Postgres: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// Quick statements aren't affected
+	if err := db.Exec("SELECT pg_sleep(0.01)").Error; err != nil {
+		t.Fatalf("Expected a quick query to succeed, got %v", err)
+	}
+
+	start := time.Now()
+	err = db.Exec("SELECT pg_sleep(5)").Error
+	if err == nil {
+		t.Fatal("Expected the slow query to be aborted")
+	}
+	if !IsStatementTimeout(err) {
+		t.Errorf("Expected a statement timeout error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "statement timeout") {
+		t.Errorf("Expected the error to mention the statement timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the query to be aborted quickly, took %s", elapsed)
+	}
+}
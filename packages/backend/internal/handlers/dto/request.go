@@ -3,6 +3,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/konflux-ci/kite/internal/models"
@@ -63,12 +64,88 @@ type CreateIssueRequest struct {
 	Namespace   string              `json:"namespace" binding:"required"`
 	Scope       ScopeReqBody        `json:"scope" binding:"required"`
 	Links       []CreateLinkRequest `json:"links"`
+	// Expected resolution deadline, derived from the severity when not set
+	DueAt *time.Time `json:"dueAt"`
+	// Code change the issue is reported for, a link to the commit is generated from it
+	CommitContext
+	// Identity of the issue known to the producer, duplicates are matched on it instead of the scope
+	Fingerprint string `json:"fingerprint"`
+	// Environment the issue pertains to, e.g. dev, staging or prod
+	Environment string `json:"environment"`
+	// Structured fields defined for the issue type, see customfields.Schemas
+	CustomFields map[string]any `json:"customFields"`
+}
+
+// MaxFingerprintLength is the maximum length of an issue fingerprint
+const MaxFingerprintLength = 255
+
+// MaxEnvironmentLength is the maximum length of the environment of an issue
+const MaxEnvironmentLength = 63
+
+// CommitContext is the optional git context of the code change an issue is reported for.
+type CommitContext struct {
+	CommitSHA string `json:"commitSha"`
+	RepoURL   string `json:"repoUrl"`
+	Branch    string `json:"branch"`
+}
+
+// PatchOperation is a single operation of a JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op    string          `json:"op" binding:"required"`
+	Path  string          `json:"path" binding:"required"`
+	Value json.RawMessage `json:"value"`
 }
 
 // CreateLinkRequest represents a link associated with an issue.
 type CreateLinkRequest struct {
-	Title string `json:"title" binding:"required"`
-	URL   string `json:"url" binding:"required"`
+	Title   string `json:"title" binding:"required"`
+	URL     string `json:"url" binding:"required"`
+	Order   int    `json:"order"`
+	Primary bool   `json:"primary"`
+}
+
+// CreateAttachmentRequest registers a file attached to an issue, uploaded beforehand
+// to an external object storage.
+type CreateAttachmentRequest struct {
+	Name        string `json:"name" binding:"required"`
+	ContentType string `json:"contentType" binding:"required"`
+	Size        int64  `json:"size" binding:"required"`
+	StorageURL  string `json:"storageUrl" binding:"required"`
+}
+
+// IssueTemplate pre-fills the issues created from it, so similar issues are
+// filed quickly and with the same wording.
+type IssueTemplate struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Severity    models.Severity     `json:"severity"`
+	IssueType   models.IssueType    `json:"issueType"`
+	Links       []CreateLinkRequest `json:"links"`
+}
+
+// CreateIssueFromTemplateRequest is the payload for creating an issue from a template.
+// Only the namespace and scope are required, the other fields override the template's.
+type CreateIssueFromTemplateRequest struct {
+	Namespace   string              `json:"namespace" binding:"required"`
+	Scope       ScopeReqBody        `json:"scope" binding:"required"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Severity    models.Severity     `json:"severity"`
+	IssueType   models.IssueType    `json:"issueType"`
+	Links       []CreateLinkRequest `json:"links"`
+	// Custom fields of the issue, the template has none
+	CustomFields map[string]any `json:"customFields"`
+}
+
+// CountPrimaryLinks returns how many of the links are flagged as primary.
+func CountPrimaryLinks(links []CreateLinkRequest) int {
+	count := 0
+	for _, link := range links {
+		if link.Primary {
+			count++
+		}
+	}
+	return count
 }
 
 // UpdateIssueRequest is the payload for updating an existing issue.
@@ -84,6 +161,10 @@ type UpdateIssueRequest struct {
 	Scope       ScopeReqBodyOptional `json:"scope"`
 	Links       []CreateLinkRequest  `json:"links"`
 	ResolvedAt  time.Time            `json:"resolvedAt"`
+	DueAt       *time.Time           `json:"dueAt"`
+	Environment string               `json:"environment"`
+	// Replace the custom fields of the issue when set, they're left unchanged otherwise
+	CustomFields map[string]any `json:"customFields"`
 }
 
 // IssuePayload unifies CREATE and UPDATE payloads for issues so services can accept either.
@@ -95,29 +176,68 @@ type IssuePayload interface {
 	GetState() models.IssueState
 	GetLinks() []CreateLinkRequest
 	GetResolvedAt() time.Time
+	GetDueAt() *time.Time
+	GetCommit() CommitContext
+	GetFingerprint() string
 	GetNamespace() string
 	GetScope() ScopePayload
+	GetEnvironment() string
+	GetCustomFields() map[string]any
 }
 
-func (c CreateIssueRequest) GetTitle() string               { return c.Title }
-func (c CreateIssueRequest) GetDescription() string         { return c.Description }
-func (c CreateIssueRequest) GetSeverity() models.Severity   { return c.Severity }
-func (c CreateIssueRequest) GetIssueType() models.IssueType { return c.IssueType }
-func (c CreateIssueRequest) GetState() models.IssueState    { return c.State }
-func (c CreateIssueRequest) GetLinks() []CreateLinkRequest  { return c.Links }
-func (c CreateIssueRequest) GetScope() ScopePayload         { return c.Scope }
-func (c CreateIssueRequest) GetNamespace() string           { return c.Namespace }
+func (c CreateIssueRequest) GetTitle() string                { return c.Title }
+func (c CreateIssueRequest) GetDescription() string          { return c.Description }
+func (c CreateIssueRequest) GetSeverity() models.Severity    { return c.Severity }
+func (c CreateIssueRequest) GetIssueType() models.IssueType  { return c.IssueType }
+func (c CreateIssueRequest) GetState() models.IssueState     { return c.State }
+func (c CreateIssueRequest) GetLinks() []CreateLinkRequest   { return c.Links }
+func (c CreateIssueRequest) GetScope() ScopePayload          { return c.Scope }
+func (c CreateIssueRequest) GetNamespace() string            { return c.Namespace }
+func (c CreateIssueRequest) GetDueAt() *time.Time            { return c.DueAt }
+func (c CreateIssueRequest) GetCommit() CommitContext        { return c.CommitContext }
+func (c CreateIssueRequest) GetFingerprint() string          { return c.Fingerprint }
+func (c CreateIssueRequest) GetEnvironment() string          { return c.Environment }
+func (c CreateIssueRequest) GetCustomFields() map[string]any { return c.CustomFields }
 func (c CreateIssueRequest) GetResolvedAt() time.Time {
 	// CREATE requests do not set a resolved time. Return a zero time value.
 	return time.Time{}
 }
 
-func (u UpdateIssueRequest) GetTitle() string               { return u.Title }
-func (u UpdateIssueRequest) GetDescription() string         { return u.Description }
-func (u UpdateIssueRequest) GetSeverity() models.Severity   { return u.Severity }
-func (u UpdateIssueRequest) GetIssueType() models.IssueType { return u.IssueType }
-func (u UpdateIssueRequest) GetState() models.IssueState    { return u.State }
-func (u UpdateIssueRequest) GetLinks() []CreateLinkRequest  { return u.Links }
-func (u UpdateIssueRequest) GetScope() ScopePayload         { return u.Scope }
-func (u UpdateIssueRequest) GetNamespace() string           { return u.Namespace }
-func (u UpdateIssueRequest) GetResolvedAt() time.Time       { return u.ResolvedAt }
+func (u UpdateIssueRequest) GetTitle() string                { return u.Title }
+func (u UpdateIssueRequest) GetDescription() string          { return u.Description }
+func (u UpdateIssueRequest) GetSeverity() models.Severity    { return u.Severity }
+func (u UpdateIssueRequest) GetIssueType() models.IssueType  { return u.IssueType }
+func (u UpdateIssueRequest) GetState() models.IssueState     { return u.State }
+func (u UpdateIssueRequest) GetLinks() []CreateLinkRequest   { return u.Links }
+func (u UpdateIssueRequest) GetScope() ScopePayload          { return u.Scope }
+func (u UpdateIssueRequest) GetNamespace() string            { return u.Namespace }
+func (u UpdateIssueRequest) GetResolvedAt() time.Time        { return u.ResolvedAt }
+func (u UpdateIssueRequest) GetDueAt() *time.Time            { return u.DueAt }
+func (u UpdateIssueRequest) GetEnvironment() string          { return u.Environment }
+func (u UpdateIssueRequest) GetCustomFields() map[string]any { return u.CustomFields }
+func (u UpdateIssueRequest) GetCommit() CommitContext {
+	// UPDATE requests do not change the commit context. Return an empty one.
+	return CommitContext{}
+}
+func (u UpdateIssueRequest) GetFingerprint() string {
+	// UPDATE requests do not change the fingerprint. Return an empty one.
+	return ""
+}
+
+// RelationshipEdgeRequest is a single relationship to create between two issues.
+type RelationshipEdgeRequest struct {
+	SourceID string                  `json:"sourceId" binding:"required"`
+	TargetID string                  `json:"targetId" binding:"required"`
+	Kind     models.RelationshipKind `json:"kind"`
+}
+
+// BulkIssueRequest is the payload for operations on many issues at once.
+type BulkIssueRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// AcknowledgeRequest is the payload for acknowledging the issues of a resource.
+type AcknowledgeRequest struct {
+	// Optional, e.g. a link to the incident the issues are part of
+	Note string `json:"note"`
+}
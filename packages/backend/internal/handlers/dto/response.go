@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/konflux-ci/kite/internal/models"
+import (
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+)
 
 // DTOs (Data Transfer Objects)
 // These allow us to carry and format data between layers or services, without embedding any business logic.
@@ -10,4 +14,309 @@ type IssueResponse struct {
 	Total  int64          `json:"total"`
 	Limit  int            `json:"limit"`
 	Offset int            `json:"offset"`
+	// Issues deleted since the changedSince filter, if one is set
+	Deleted []models.DeletedIssue `json:"deleted,omitempty"`
+}
+
+// SearchResult is an issue matching a search, along with its relevance.
+type SearchResult struct {
+	Issue models.Issue `json:"issue"`
+	Score float64      `json:"score"`
+	// Snippets of the title and description with the matched terms wrapped in <mark> tags
+	Highlights []string `json:"highlights"`
+}
+
+// GroupedResource identifies the resource a group of issues is scoped to.
+type GroupedResource struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// IssueGroup is a set of issues scoped to the same resource.
+type IssueGroup struct {
+	Resource GroupedResource `json:"resource"`
+	Issues   []models.Issue  `json:"issues"`
+	// Number of issues of the group, including those beyond the issues listed
+	IssueCount  int             `json:"issueCount"`
+	ActiveCount int             `json:"activeCount"`
+	MaxSeverity models.Severity `json:"maxSeverity"`
+}
+
+// MTTRGroup is the time taken to resolve the issues of a group.
+type MTTRGroup struct {
+	// Value of the dimension grouped by, empty when not grouped
+	Group         string  `json:"group,omitempty" gorm:"column:grp"`
+	Count         int64   `json:"count"`
+	MeanSeconds   float64 `json:"meanSeconds"`
+	MedianSeconds float64 `json:"medianSeconds"`
+}
+
+// OccurrenceBucket is the number of occurrences of an issue in a bucket of time.
+type OccurrenceBucket struct {
+	// Start of the bucket, in UTC
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+}
+
+// NamespaceSummary describes a namespace that contains issues.
+type NamespaceSummary struct {
+	Namespace   string `json:"namespace"`
+	ActiveCount int64  `json:"activeCount"`
+}
+
+// NamespaceIssueCount is the number of issues of a namespace, in a ranking of namespaces.
+type NamespaceIssueCount struct {
+	Namespace  string `json:"namespace"`
+	IssueCount int64  `json:"issueCount"`
+}
+
+// ImportRowError describes a row of an import that wasn't imported.
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportSummary is the outcome of an issue import.
+type ImportSummary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	// Rows for namespaces the requester can't access
+	Skipped int              `json:"skipped"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// Outcomes of a relationship edge in a batch.
+const (
+	RelationshipEdgeCreated = "created"
+	RelationshipEdgeSkipped = "skipped"
+	RelationshipEdgeError   = "error"
+)
+
+// RelationshipEdgeResult is the outcome of creating a single relationship in a batch.
+type RelationshipEdgeResult struct {
+	SourceID string                  `json:"sourceId"`
+	TargetID string                  `json:"targetId"`
+	Kind     models.RelationshipKind `json:"kind"`
+	Status   string                  `json:"status"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+// GraphNode is an issue of a relationship graph.
+type GraphNode struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Severity  models.Severity   `json:"severity"`
+	IssueType models.IssueType  `json:"issueType"`
+	State     models.IssueState `json:"state"`
+	Namespace string            `json:"namespace"`
+}
+
+// GraphEdge is a relationship of a relationship graph.
+type GraphEdge struct {
+	ID       string                  `json:"id"`
+	SourceID string                  `json:"sourceId"`
+	TargetID string                  `json:"targetId"`
+	Kind     models.RelationshipKind `json:"kind"`
+}
+
+// IssueGraph is the set of issues connected to an issue through relationships.
+type IssueGraph struct {
+	RootID string      `json:"rootId"`
+	Nodes  []GraphNode `json:"nodes"`
+	Edges  []GraphEdge `json:"edges"`
+	// Set when the graph is larger than the maximum size, and only part of it is returned
+	Truncated bool `json:"truncated"`
+}
+
+// Outcomes of an issue in a bulk operation.
+const (
+	BulkIssueDeleted = "deleted"
+	BulkIssueError   = "error"
+)
+
+// BulkIssueResult is the outcome of a bulk operation for a single issue.
+type BulkIssueResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// DuplicateMerge is a group of duplicate issues merged, or to merge, into the oldest of them.
+type DuplicateMerge struct {
+	IssueID   string   `json:"issueId"`
+	MergedIDs []string `json:"mergedIds"`
+}
+
+// Codes of request validation errors.
+const (
+	ErrorCodeValidationFailed = "VALIDATION_FAILED"
+	ErrorCodeInvalidBody      = "INVALID_BODY"
+)
+
+// Codes of routing errors.
+const (
+	ErrorCodeNotFound = "NOT_FOUND"
+)
+
+// APIError is the envelope of error responses.
+type APIError struct {
+	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// FieldError describes a request field that failed validation.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// OpenIssueCount is the number of active issues sharing a namespace, type and severity.
+type OpenIssueCount struct {
+	Namespace string           `json:"namespace"`
+	IssueType models.IssueType `json:"issueType"`
+	Severity  models.Severity  `json:"severity"`
+	Count     int64            `json:"count"`
+}
+
+// OverdueIssueCount is the number of active issues past their deadline sharing a namespace and severity.
+type OverdueIssueCount struct {
+	Namespace string          `json:"namespace"`
+	Severity  models.Severity `json:"severity"`
+	Count     int64           `json:"count"`
+}
+
+// IssueStatus is the current state and severity of an issue, for pollers that don't need the whole issue.
+type IssueStatus struct {
+	ID         string            `json:"id"`
+	State      models.IssueState `json:"state"`
+	Severity   models.Severity   `json:"severity"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	ResolvedAt *time.Time        `json:"resolvedAt"`
+	// Namespaces the issue is tracked in and scoped to, to check the access to it
+	Namespace         string `json:"-"`
+	ResourceNamespace string `json:"-"`
+}
+
+// IssueResolution is when an issue was detected and resolved.
+type IssueResolution struct {
+	IssueType  models.IssueType `json:"issueType"`
+	DetectedAt time.Time        `json:"detectedAt"`
+	ResolvedAt time.Time        `json:"resolvedAt"`
+}
+
+// WhoAmIUser describes the user a request is authenticated as.
+type WhoAmIUser struct {
+	Name   string   `json:"name"`
+	UID    string   `json:"uid,omitempty"`
+	Groups []string `json:"groups"`
+}
+
+// NamespaceAccess is the decision of an access review for a namespace.
+type NamespaceAccess struct {
+	Namespace string `json:"namespace"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// WhoAmIResponse describes who a request is handled as, and optionally their access to a namespace.
+type WhoAmIResponse struct {
+	Authenticated bool             `json:"authenticated"`
+	Type          string           `json:"type"`
+	User          *WhoAmIUser      `json:"user,omitempty"`
+	Access        *NamespaceAccess `json:"access,omitempty"`
+}
+
+// BatchGetResponse holds the issues fetched by ID, in the order requested.
+type BatchGetResponse struct {
+	Data []models.Issue `json:"data"`
+	// IDs of the issues not found, or not accessible to the requester
+	Missing []string `json:"missing"`
+}
+
+// IngestQueueStats reports how full the queue of webhook issues is.
+type IngestQueueStats struct {
+	// Number of issues waiting to be persisted
+	Depth int `json:"depth"`
+	// Number of issues the queue holds before rejecting webhooks
+	Capacity int `json:"capacity"`
+	// Number of issues persisted at once
+	Workers int `json:"workers"`
+}
+
+// API version and kinds of issues shaped like Kubernetes objects.
+const (
+	IssueAPIVersion = "kite.konflux.dev/v1"
+	IssueKind       = "Issue"
+	IssueListKind   = "IssueList"
+)
+
+// IssueList is a page of issues shaped like a Kubernetes list, for clients
+// handling everything as Kubernetes objects.
+type IssueList struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   ListMeta      `json:"metadata"`
+	Items      []IssueObject `json:"items"`
+}
+
+// ListMeta is the metadata of a list, as in Kubernetes.
+type ListMeta struct {
+	// Token to pass back to fetch the next page, empty on the last page
+	Continue string `json:"continue,omitempty"`
+	// Number of issues after the next page, only set along with continue
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+}
+
+// IssueObject is an issue shaped like a Kubernetes object.
+type IssueObject struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Spec       IssueObjectSpec   `json:"spec"`
+	Status     IssueObjectStatus `json:"status"`
+}
+
+// ObjectMeta is the metadata of an object, as in Kubernetes.
+type ObjectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	UID               string            `json:"uid"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels,omitempty"`
+}
+
+// IssueObjectScope is the resource an issue object is scoped to.
+type IssueObjectScope struct {
+	ResourceType      string `json:"resourceType"`
+	ResourceName      string `json:"resourceName"`
+	ResourceNamespace string `json:"resourceNamespace"`
+}
+
+// IssueObjectSpec is what was reported about an issue object.
+type IssueObjectSpec struct {
+	Title        string           `json:"title"`
+	Description  string           `json:"description"`
+	Severity     models.Severity  `json:"severity"`
+	IssueType    models.IssueType `json:"issueType"`
+	Scope        IssueObjectScope `json:"scope"`
+	Links        []models.Link    `json:"links"`
+	DueAt        *time.Time       `json:"dueAt,omitempty"`
+	Fingerprint  string           `json:"fingerprint,omitempty"`
+	Environment  string           `json:"environment,omitempty"`
+	CustomFields map[string]any   `json:"customFields,omitempty"`
+	models.CommitContext
+}
+
+// IssueObjectStatus is the observed state of an issue object.
+type IssueObjectStatus struct {
+	State                  models.IssueState       `json:"state"`
+	DetectedAt             time.Time               `json:"detectedAt"`
+	LastSeenAt             time.Time               `json:"lastSeenAt"`
+	ResolvedAt             *time.Time              `json:"resolvedAt,omitempty"`
+	ResolvedBy             models.ResolutionSource `json:"resolvedBy,omitempty"`
+	OccurrenceCount        int                     `json:"occurrenceCount"`
+	SnoozedUntilRecurrence bool                    `json:"snoozedUntilRecurrence"`
+	UpdatedAt              time.Time               `json:"updatedAt"`
 }
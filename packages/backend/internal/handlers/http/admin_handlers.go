@@ -0,0 +1,161 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminHandler handles maintenance requests, e.g. cleaning up issues after the
+// configuration changed
+type AdminHandler struct {
+	issueService services.IssueServiceInterface
+	logger       *logrus.Logger
+
+	// Checks the elevated access required to decommission a namespace, nil to skip the check
+	decommissionChecker ElevatedAccessChecker
+	decommissionVerb    string
+
+	// Queue of the issues reported by webhooks, nil when they're persisted right away
+	ingestQueue *services.IngestQueue
+}
+
+// AdminHandlerOption configures an AdminHandler
+type AdminHandlerOption func(*AdminHandler)
+
+// WithDecommissionAccess requires the requesters decommissioning a namespace to be able
+// to perform the verb in it, on top of the access to the namespace itself
+func WithDecommissionAccess(checker ElevatedAccessChecker, verb string) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.decommissionChecker = checker
+		h.decommissionVerb = verb
+	}
+}
+
+// WithQueueStats reports the stats of the queue of the issues reported by webhooks
+func WithQueueStats(queue *services.IngestQueue) AdminHandlerOption {
+	return func(h *AdminHandler) {
+		h.ingestQueue = queue
+	}
+}
+
+// NewAdminHandler returns a new handler for the admin router
+func NewAdminHandler(issueService services.IssueServiceInterface, logger *logrus.Logger, opts ...AdminHandlerOption) *AdminHandler {
+	handler := &AdminHandler{
+		issueService: issueService,
+		logger:       logger,
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	return handler
+}
+
+// DedupScan handles POST /admin/dedup-scan
+//
+// Re-runs duplicate detection on the open issues of a namespace, and merges each
+// group of issues sharing the current dedup key into the oldest of them. This cleans
+// up the duplicates created before the dedup strategy was tuned. With dryRun=true,
+// the groups are only reported.
+//
+// Response:
+//   - 200 OK: The groups of duplicates merged, or to merge on a dry run
+//   - 400 Bad Request: Missing or invalid namespace, or invalid dryRun
+//   - 500 Internal Server Error: Database or processing error
+func (h *AdminHandler) DedupScan(c *gin.Context) {
+	namespace := middleware.RequestNamespace(c)
+	if namespace == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing namespace"})
+		return
+	}
+	if err := middleware.ValidateNamespace(namespace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+		return
+	}
+
+	dryRun := false
+	if value := c.Query("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun, expected true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+
+	merges, err := h.issueService.MergeDuplicateIssues(c.Request.Context(), namespace, dryRun)
+	if err != nil {
+		h.logger.WithError(err).WithField("namespace", namespace).Error("Failed to merge duplicate issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge duplicate issues"})
+		return
+	}
+
+	merged := 0
+	for _, merge := range merges {
+		merged += len(merge.MergedIDs)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"namespace": namespace,
+		"dryRun":    dryRun,
+		"merges":    merges,
+		"merged":    merged,
+	})
+}
+
+// ResolveNamespace handles POST /admin/namespaces/:namespace/resolve-all
+//
+// Resolves all the open issues of a namespace that's decommissioned, e.g. when a tenant
+// is offboarded, with the note "namespace decommissioned". Unlike resolving by scope,
+// every issue of the namespace is resolved, whatever resource it's scoped to.
+// Requires elevated access to the namespace.
+//
+// Response:
+//   - 200 OK: The number of issues resolved
+//   - 400 Bad Request: Invalid namespace
+//   - 403 Forbidden: No elevated access to the namespace
+//   - 500 Internal Server Error: Database or processing error
+func (h *AdminHandler) ResolveNamespace(c *gin.Context) {
+	namespace := c.Param("namespace")
+	if err := middleware.ValidateNamespace(namespace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+		return
+	}
+	if h.decommissionChecker != nil && !h.decommissionChecker.CanPerformInNamespace(c, namespace, h.decommissionVerb) {
+		h.logger.WithField("namespace", namespace).Warn("Access Denied, decommissioning requires elevated access")
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied, decommissioning a namespace requires elevated access"})
+		return
+	}
+
+	resolved, err := h.issueService.ResolveNamespaceIssues(c.Request.Context(), namespace)
+	if err != nil {
+		h.logger.WithError(err).WithField("namespace", namespace).Error("Failed to resolve namespace issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve namespace issues"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"namespace": namespace,
+		"resolved":  resolved,
+	})
+}
+
+// GetQueueStats handles GET /admin/queue/stats
+//
+// Reports how many webhook issues wait in the ingest queue, along with its capacity
+// and number of workers, so operators can tell when it's about to saturate.
+//
+// Response:
+//   - 200 OK: The stats of the queue
+//   - 404 Not Found: Webhooks are processed synchronously, there's no queue
+func (h *AdminHandler) GetQueueStats(c *gin.Context) {
+	if h.ingestQueue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhooks are processed synchronously, there's no ingest queue"})
+		return
+	}
+	c.JSON(http.StatusOK, h.ingestQueue.Stats())
+}
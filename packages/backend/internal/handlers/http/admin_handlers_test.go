@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// setupTestAdminRouter creates a test router where requests come from the user in the X-Test-User header
+func setupTestAdminRouter(mockService *MockIssueService, opts ...AdminHandlerOption) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewAdminHandler(mockService, logger, opts...)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if name := c.GetHeader("X-Test-User"); name != "" {
+			c.Set("user", &user.DefaultInfo{Name: name})
+		}
+		c.Next()
+	})
+	router.POST("/api/v1/admin/dedup-scan", handler.DedupScan)
+	router.POST("/api/v1/admin/namespaces/:namespace/resolve-all", handler.ResolveNamespace)
+	router.GET("/api/v1/admin/queue/stats", handler.GetQueueStats)
+	return router
+}
+
+func TestAdminHandler_DedupScan(t *testing.T) {
+	merges := []dto.DuplicateMerge{
+		{IssueID: "issue-a", MergedIDs: []string{"issue-b", "issue-c"}},
+		{IssueID: "issue-d", MergedIDs: []string{"issue-e"}},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		serviceError   error
+		expectedStatus int
+		expectedDryRun bool
+	}{
+		{"merge", "?namespace=team-alpha", nil, net_http.StatusOK, false},
+		{"dry run", "?namespace=team-alpha&dryRun=true", nil, net_http.StatusOK, true},
+		{"missing namespace", "", nil, net_http.StatusBadRequest, false},
+		{"invalid namespace", "?namespace=Team_Alpha", nil, net_http.StatusBadRequest, false},
+		{"invalid dry run", "?namespace=team-alpha&dryRun=maybe", nil, net_http.StatusBadRequest, false},
+		{"service error", "?namespace=team-alpha", errors.New("database down"), net_http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				mergeDuplicatesResult: merges,
+				mergeDuplicatesError:  tt.serviceError,
+			}
+			router := setupTestAdminRouter(mockService)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/admin/dedup-scan"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+			if mockService.mergeDuplicatesDryRun != tt.expectedDryRun {
+				t.Errorf("expected dry run %t, got %t", tt.expectedDryRun, mockService.mergeDuplicatesDryRun)
+			}
+
+			var response struct {
+				Namespace string               `json:"namespace"`
+				DryRun    bool                 `json:"dryRun"`
+				Merges    []dto.DuplicateMerge `json:"merges"`
+				Merged    int                  `json:"merged"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Namespace != "team-alpha" || response.DryRun != tt.expectedDryRun {
+				t.Errorf("expected the namespace and dry run flag in the report, got %+v", response)
+			}
+			if len(response.Merges) != 2 {
+				t.Errorf("expected 2 groups of duplicates, got %d", len(response.Merges))
+			}
+			if response.Merged != 3 {
+				t.Errorf("expected 3 duplicates merged, got %d", response.Merged)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ResolveNamespace(t *testing.T) {
+	checker := newFakeElevatedAccessChecker(logrus.New(), "admin")
+
+	tests := []struct {
+		name           string
+		namespace      string
+		user           string
+		serviceError   error
+		expectedStatus int
+	}{
+		{"privileged caller", "team-offboarded", "admin", nil, net_http.StatusOK},
+		{"ordinary caller", "team-offboarded", "viewer", nil, net_http.StatusForbidden},
+		{"requests without a user", "team-offboarded", "", nil, net_http.StatusForbidden},
+		{"invalid namespace", "Team_Offboarded", "admin", nil, net_http.StatusBadRequest},
+		{"service error", "team-offboarded", "admin", errors.New("database down"), net_http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				resolveNamespaceResult: 3,
+				resolveNamespaceError:  tt.serviceError,
+			}
+			router := setupTestAdminRouter(mockService, WithDecommissionAccess(checker, "update"))
+
+			req, err := net_http.NewRequest("POST", "/api/v1/admin/namespaces/"+tt.namespace+"/resolve-all", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tt.user != "" {
+				req.Header.Set("X-Test-User", tt.user)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Namespace string `json:"namespace"`
+				Resolved  int64  `json:"resolved"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Namespace != tt.namespace || response.Resolved != 3 {
+				t.Errorf("expected 3 issues resolved in %s, got %+v", tt.namespace, response)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetQueueStats(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	// Not started, so the queued issues stay queued
+	queue := services.NewIngestQueue(logger, 10, 4)
+	for _, name := range []string{"component-a", "component-b"} {
+		if err := queue.Enqueue(dto.CreateIssueRequest{Title: name, Namespace: "team-alpha"}, nil); err != nil {
+			t.Fatalf("Failed to queue issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		opts           []AdminHandlerOption
+		expectedStatus int
+	}{
+		{"queue", []AdminHandlerOption{WithQueueStats(queue)}, net_http.StatusOK},
+		{"synchronous webhooks", nil, net_http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestAdminRouter(&MockIssueService{}, tt.opts...)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/admin/queue/stats", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var stats dto.IngestQueueStats
+			if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			expected := dto.IngestQueueStats{Depth: 2, Capacity: 10, Workers: 4}
+			if stats != expected {
+				t.Errorf("expected stats %+v, got %+v", expected, stats)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -24,7 +25,9 @@ type ComponentHealth struct {
 	Details interface{} `json:"details,omitempty"`
 }
 
-func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) gin.HandlerFunc {
+// NewHealthHandler reports whether the service is ready to receive requests.
+// The service is ready once started, and stops being ready as soon as it starts shutting down.
+func NewHealthHandler(db *gorm.DB, logger *logrus.Logger, state *readiness.State) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
 
@@ -48,6 +51,24 @@ func NewHealthHandler(db *gorm.DB, logger *logrus.Logger) gin.HandlerFunc {
 		apiHealth := checkAPIHealth()
 		health.Components["api"] = apiHealth
 
+		// Don't receive requests until the database schema is ready, nor while shutting down
+		if state.Starting() {
+			overallHealthy = false
+			health.Components["api"] = ComponentHealth{
+				Status:  "DOWN",
+				Message: "API server is starting, waiting for the database schema",
+			}
+		} else if !state.Ready() {
+			overallHealthy = false
+			health.Components["api"] = ComponentHealth{
+				Status:  "DOWN",
+				Message: "API server is shutting down",
+				Details: map[string]interface{}{
+					"in_flight_requests": state.InFlight(),
+				},
+			}
+		}
+
 		// Add response time
 		responseTime := time.Since(startTime)
 		health.Components["response_time"] = ComponentHealth{
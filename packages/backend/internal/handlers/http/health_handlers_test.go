@@ -0,0 +1,78 @@
+package http
+
+import (
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+func TestHealthHandler_Shutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	state := readiness.New()
+
+	router := gin.New()
+	router.GET("/health", NewHealthHandler(db, logger, state))
+	router.GET("/health/live", middleware.HealthCheck(logger))
+
+	expectStatus := func(path string, expected int) {
+		t.Helper()
+		req, err := net_http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Errorf("expected status %d for %s, got %d", expected, path, w.Code)
+		}
+	}
+
+	expectStatus("/health", net_http.StatusOK)
+	expectStatus("/health/live", net_http.StatusOK)
+
+	// Once shutting down, the service is alive but not ready
+	state.Drain()
+	expectStatus("/health", net_http.StatusServiceUnavailable)
+	expectStatus("/health/live", net_http.StatusOK)
+}
+
+func TestHealthHandler_Starting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	state := readiness.NewStarting()
+
+	router := gin.New()
+	router.GET("/health", NewHealthHandler(db, logger, state))
+
+	expectStatus := func(expected int) {
+		t.Helper()
+		req, err := net_http.NewRequest("GET", "/health", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Errorf("expected status %d, got %d", expected, w.Code)
+		}
+	}
+
+	// Not ready until the database schema is
+	expectStatus(net_http.StatusServiceUnavailable)
+	state.Started()
+	expectStatus(net_http.StatusOK)
+}
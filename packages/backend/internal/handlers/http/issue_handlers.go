@@ -1,288 +1,1727 @@
 package http
 
 import (
+	"cmp"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	kiteConf "github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/commitlink"
+	"github.com/konflux-ci/kite/internal/pkg/customfields"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/konflux-ci/kite/internal/services"
 	"github.com/sirupsen/logrus"
 )
 
+// Page sizes used unless configured otherwise
+const (
+	DefaultPageSize    = 50
+	DefaultMaxPageSize = 200
+)
+
+// DefaultMaxOffset is the deepest offset issues can be listed from unless configured otherwise
+const DefaultMaxOffset = 10000
+
+// DefaultMaxIssueGroups is the number of groups returned by the grouped view unless configured otherwise
+const DefaultMaxIssueGroups = 100
+
+// DefaultMaxAttachmentSize is the largest file that can be attached to an issue unless configured otherwise
+const DefaultMaxAttachmentSize = 100 << 20
+
+// DefaultMaxScopeFieldLength is the longest resource type and name of an issue scope unless configured otherwise
+const DefaultMaxScopeFieldLength = 255
+
+// DefaultMaxGraphSize is the number of issues, and of relationships, of a relationship graph
+// returned unless configured otherwise
+const DefaultMaxGraphSize = 200
+
 type IssueHandler struct {
-	issueService services.IssueServiceInterface
-	logger       *logrus.Logger
+	issueService    services.IssueServiceInterface
+	logger          *logrus.Logger
+	defaultPageSize int
+	maxPageSize     int
+	maxOffset       int // Deepest offset issues can be listed from, 0 for no limit
+	maxGroups       int
+	maxGraphSize    int
+	accessChecker   NamespaceAccessChecker // Checks imported and ranked namespaces, all are allowed if nil
+	// Require access to the namespace of the resource an issue is scoped to, not just the issue namespace
+	authorizeResourceNamespace bool
+	templates                  *QuickCreateTemplates // Templates issues can be created from
+	// Hides the description and links of sensitive namespaces' issues, nothing is hidden if nil
+	sensitiveFields *SensitiveFieldsPolicy
+	// Media types of the files that can be attached, "type/*" allows all the subtypes of a type
+	attachmentContentTypes []string
+	maxAttachmentSize      int64
+	maxScopeFieldLength    int
+	// Domains links can point to, all are allowed if nil
+	linkDomains *linkdomain.Allowlist
+	// Custom fields of each issue type, issues can't have any if nil
+	customFields *customfields.Schemas
+	// Reject create requests with fields the API doesn't know, instead of ignoring them
+	strictJSON bool
+	// Tells which issues are overdue and when issues are resolved
+	clock clock.Clock
+}
+
+// IssueHandlerOption configures optional behavior of the issue handler
+type IssueHandlerOption func(*IssueHandler)
+
+// WithPageSize sets the page size used when listing issues without a limit,
+// and the largest page size that can be requested.
+func WithPageSize(defaultSize, maxSize int) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.defaultPageSize = defaultSize
+		h.maxPageSize = maxSize
+	}
+}
+
+// WithMaxOffset sets the deepest offset issues can be listed from, 0 disables the limit
+func WithMaxOffset(maxOffset int) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.maxOffset = maxOffset
+	}
+}
+
+// WithMaxGroups sets the number of groups returned by the grouped view
+func WithMaxGroups(maxGroups int) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.maxGroups = maxGroups
+	}
+}
+
+// WithMaxGraphSize sets the number of issues, and of relationships, of a relationship graph returned
+func WithMaxGraphSize(maxSize int) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.maxGraphSize = maxSize
+	}
+}
+
+// WithNamespaceAccessChecker checks that the requester can access the namespaces of imported
+// issues, and the namespaces ranked by their number of issues
+func WithNamespaceAccessChecker(accessChecker NamespaceAccessChecker) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.accessChecker = accessChecker
+	}
+}
+
+// WithResourceNamespaceAuthorization requires access to the namespace of the resource
+// an issue is scoped to, on top of the namespace the issue is tracked in.
+func WithResourceNamespaceAuthorization() IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.authorizeResourceNamespace = true
+	}
+}
+
+// WithQuickCreateTemplates sets the templates issues can be created from
+func WithQuickCreateTemplates(templates *QuickCreateTemplates) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.templates = templates
+	}
+}
+
+// WithSensitiveFieldsPolicy hides the description and links of the issues of sensitive
+// namespaces from requesters without elevated access to them
+func WithSensitiveFieldsPolicy(policy *SensitiveFieldsPolicy) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.sensitiveFields = policy
+	}
+}
+
+// WithAttachmentLimits sets the media types and the largest size of the files that can
+// be attached to issues. A media type of "type/*" allows all the subtypes of a type.
+func WithAttachmentLimits(contentTypes []string, maxSize int64) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.attachmentContentTypes = contentTypes
+		h.maxAttachmentSize = maxSize
+	}
+}
+
+// WithMaxScopeFieldLength sets the longest resource type and name of the scope of an issue
+func WithMaxScopeFieldLength(maxLength int) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.maxScopeFieldLength = maxLength
+	}
+}
+
+// WithAllowedLinkDomains rejects the links to domains missing from the allowlist
+func WithAllowedLinkDomains(allowlist *linkdomain.Allowlist) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.linkDomains = allowlist
+	}
+}
+
+// WithCustomFieldSchemas sets the custom fields issues of each type can, or must, have
+func WithCustomFieldSchemas(schemas *customfields.Schemas) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.customFields = schemas
+	}
+}
+
+// WithStrictJSON rejects issue create requests with unknown fields, so producers
+// find out about typos like "titel" instead of losing the data.
+func WithStrictJSON(strict bool) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.strictJSON = strict
+	}
+}
+
+// WithIssueClock sets the clock overdue issues and resolutions are timed with, e.g. a fake clock in tests
+func WithIssueClock(c clock.Clock) IssueHandlerOption {
+	return func(h *IssueHandler) {
+		h.clock = c
+	}
+}
+
+func NewIssueHandler(issueService services.IssueServiceInterface, logger *logrus.Logger, opts ...IssueHandlerOption) *IssueHandler {
+	h := &IssueHandler{
+		issueService:    issueService,
+		logger:          logger,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     DefaultMaxPageSize,
+		maxOffset:       DefaultMaxOffset,
+		maxGroups:       DefaultMaxIssueGroups,
+		maxGraphSize:    DefaultMaxGraphSize,
+
+		attachmentContentTypes: kiteConf.DefaultAttachmentContentTypes,
+		maxAttachmentSize:      DefaultMaxAttachmentSize,
+		maxScopeFieldLength:    DefaultMaxScopeFieldLength,
+		clock:                  clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// GetIssues handles GET /issues
+func (h *IssueHandler) GetIssues(c *gin.Context) {
+	// Esxtract query params
+	filters := repository.IssueQueryFilters{
+		ResourceType: c.Query("resourceType"),
+		ResourceName: c.Query("resourceName"),
+		Environment:  c.Query("environment"),
+		Search:       c.Query("search"),
+	}
+
+	if !h.applyNamespaceFilters(c, &filters) {
+		return
+	}
+
+	// Parse optional enum params
+	if severity := c.Query("severity"); severity != "" {
+		// Convert to custom type, then assign
+		sev := models.Severity(severity)
+		filters.Severity = &sev
+	}
+	if issueType := c.Query("issueType"); issueType != "" {
+		it := models.IssueType(issueType)
+		filters.IssueType = &it
+	}
+	if state := c.Query("state"); state != "" {
+		st := models.IssueState(state)
+		filters.State = &st
+	}
+	if stateGroup := c.Query("stateGroup"); stateGroup != "" {
+		states, ok := models.IssueStateGroup(stateGroup).States()
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stateGroup, expected open, closed or all"})
+			return
+		}
+		filters.States = states
+	}
+	if !applyResolvedByFilter(c, &filters) {
+		return
+	}
+
+	if lastSeenAfter := c.Query("lastSeenAfter"); lastSeenAfter != "" {
+		t, err := time.Parse(time.RFC3339, lastSeenAfter)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lastSeenAfter, expected an RFC 3339 timestamp"})
+			return
+		}
+		filters.LastSeenAfter = &t
+	}
+	if changedSince := c.Query("changedSince"); changedSince != "" {
+		t, err := time.Parse(time.RFC3339, changedSince)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid changedSince, expected an RFC 3339 timestamp"})
+			return
+		}
+		filters.ChangedSince = &t
+	}
+	if overdue := c.Query("overdue"); overdue != "" {
+		isOverdue, err := strconv.ParseBool(overdue)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overdue, expected true or false"})
+			return
+		}
+		if isOverdue {
+			now := h.clock.Now()
+			filters.OverdueAt = &now
+		}
+	}
+	// Snoozed issues are only listed when asked for
+	filters.ExcludeSnoozed = true
+	if includeSnoozed := c.Query("includeSnoozed"); includeSnoozed != "" {
+		include, err := strconv.ParseBool(includeSnoozed)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid includeSnoozed, expected true or false"})
+			return
+		}
+		filters.ExcludeSnoozed = !include
+	}
+	if sortBy := c.Query("sortBy"); sortBy != "" {
+		if !repository.IsValidSort(sortBy) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sortBy, expected detectedAt, lastSeenAt or updatedAt"})
+			return
+		}
+		filters.SortBy = sortBy
+	}
+
+	output := c.Query("output")
+	if output != "" && output != outputK8sList {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid output, expected k8slist"})
+		return
+	}
+
+	// Parse pagination parameters
+	filters.Limit = h.pageSize(c)
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+	// The continue token of a list takes over the offset
+	if token := c.Query("continue"); token != "" {
+		offset, err := decodeContinueToken(token)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid continue token", "details": err.Error()})
+			return
+		}
+		filters.Offset = offset
+	}
+	// Deep offsets make the database scan and discard every row before them
+	if h.maxOffset > 0 && filters.Offset > h.maxOffset {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   fmt.Sprintf("Invalid offset, expected at most %d", h.maxOffset),
+			"details": "Narrow the query down instead of paging deeper, e.g. with lastSeenAfter or changedSince",
+		})
+		return
+	}
+
+	result, err := h.issueService.FindIssues(c.Request.Context(), filters)
+	if err != nil {
+		h.respondQueryError(c, err, "Failed to fetch issues")
+		return
+	}
+
+	h.sensitiveFields.filter(c).issues(result.Data)
+	if output == outputK8sList {
+		c.JSON(http.StatusOK, newIssueList(result))
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// SearchIssues handles GET /issues/search
+func (h *IssueHandler) SearchIssues(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing search query q"})
+		return
+	}
+
+	// The repository applies the default and maximum number of results
+	filters := repository.IssueQueryFilters{Search: query}
+	if !h.applyNamespaceFilters(c, &filters) {
+		return
+	}
+	if state := c.Query("state"); state != "" {
+		st := models.IssueState(state)
+		filters.State = &st
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+
+	results, err := h.issueService.SearchIssues(c.Request.Context(), filters)
+	if err != nil {
+		h.respondQueryError(c, err, "Failed to search issues")
+		return
+	}
+	h.sensitiveFields.filter(c).searchResults(results)
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// Number of namespaces ranked by their number of issues returned by default, and at most
+const (
+	defaultNamespaceRankingLimit = 20
+	maxNamespaceRankingLimit     = 100
+)
+
+// Number of namespaces with the most issues whose access is reviewed per ranking request,
+// so a requester who can access few namespaces doesn't trigger a review of all of them
+const maxNamespaceAccessReviews = 200
+
+// GetIssuesByNamespace handles GET /issues/by-namespace
+//
+// Ranks the namespaces the requester can access by their number of open issues, or of
+// issues in the state requested, the namespaces with the most issues first. Namespaces
+// without such issues aren't ranked. Only the maxNamespaceAccessReviews namespaces with
+// the most issues are considered, the requester's access being reviewed for each of them
+// until enough are found.
+//
+// Response:
+//   - 200 OK: The namespaces ranked
+//   - 400 Bad Request: Invalid state
+//   - 500 Internal Server Error: Database or processing error
+func (h *IssueHandler) GetIssuesByNamespace(c *gin.Context) {
+	states := models.OpenStates
+	if state := c.Query("state"); state != "" {
+		st := models.IssueState(state)
+		if !st.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state, expected ACTIVE, ACKNOWLEDGED or RESOLVED"})
+			return
+		}
+		states = []models.IssueState{st}
+	}
+	limit := defaultNamespaceRankingLimit
+	if value := c.Query("limit"); value != "" {
+		if l, err := strconv.Atoi(value); err == nil && l > 0 {
+			limit = min(l, maxNamespaceRankingLimit)
+		}
+	}
+
+	candidates := limit
+	if h.accessChecker != nil {
+		candidates = maxNamespaceAccessReviews
+	}
+	ranking, err := h.issueService.RankNamespacesByIssues(c.Request.Context(), states, candidates)
+	if err != nil {
+		h.respondQueryError(c, err, "Failed to rank namespaces")
+		return
+	}
+
+	// Only keep the namespaces the requester can access, once the ranking is known
+	accessible := make([]dto.NamespaceIssueCount, 0, min(len(ranking), limit))
+	for _, namespace := range ranking {
+		if len(accessible) == limit {
+			break
+		}
+		if h.accessChecker != nil && !h.accessChecker.CanAccessNamespace(c, namespace.Namespace) {
+			continue
+		}
+		accessible = append(accessible, namespace)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": accessible})
+}
+
+// GetGroupedIssues handles GET /issues/grouped
+func (h *IssueHandler) GetGroupedIssues(c *gin.Context) {
+	// Resources are the only grouping supported for now
+	if groupBy := c.DefaultQuery("groupBy", "resource"); groupBy != "resource" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid groupBy, expected resource"})
+		return
+	}
+
+	filters := repository.IssueQueryFilters{
+		ResourceType: c.Query("resourceType"),
+	}
+	if !h.applyNamespaceFilters(c, &filters) {
+		return
+	}
+	if state := c.Query("state"); state != "" {
+		st := models.IssueState(state)
+		filters.State = &st
+	}
+
+	groups, err := h.issueService.GroupIssuesByResource(c.Request.Context(), filters, h.maxGroups)
+	if err != nil {
+		h.respondQueryError(c, err, "Failed to group issues")
+		return
+	}
+	filter := h.sensitiveFields.filter(c)
+	for idx := range groups {
+		filter.issues(groups[idx].Issues)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": groups})
 }
 
-func NewIssueHandler(issueService services.IssueServiceInterface, logger *logrus.Logger) *IssueHandler {
-	return &IssueHandler{
-		issueService: issueService,
-		logger:       logger,
+// GetMTTR handles GET /issues/metrics/mttr
+func (h *IssueHandler) GetMTTR(c *gin.Context) {
+	filters := repository.IssueQueryFilters{Environment: c.Query("environment")}
+	if !h.applyNamespaceFilters(c, &filters) {
+		return
+	}
+
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since, expected an RFC 3339 timestamp"})
+			return
+		}
+		since = t
+	}
+
+	if !applyResolvedByFilter(c, &filters) {
+		return
+	}
+
+	groupBy := c.Query("groupBy")
+	if groupBy != "" && !repository.IsValidMTTRGroup(groupBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid groupBy, expected issueType, severity, namespace, environment or resolvedBy"})
+		return
+	}
+
+	groups, err := h.issueService.FindMTTR(c.Request.Context(), filters, since, groupBy)
+	if err != nil {
+		h.respondQueryError(c, err, "Failed to compute MTTR")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": groups})
+}
+
+// respondQueryError responds to a failed query on issues, telling apart queries
+// aborted by the database statement timeout so clients can narrow them down.
+func (h *IssueHandler) respondQueryError(c *gin.Context, err error, message string) {
+	if kiteConf.IsStatementTimeout(err) {
+		h.logger.WithError(err).Warn(message + ": statement timeout reached")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": message, "details": "the query took too long, try narrowing it down"})
+		return
+	}
+	h.logger.WithError(err).Error(message)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+// pageSize returns the limit requested, clamped to the maximum page size.
+// The default page size is used when no valid limit is given.
+func (h *IssueHandler) pageSize(c *gin.Context) int {
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+			return min(l, h.maxPageSize)
+		}
+	}
+	return h.defaultPageSize
+}
+
+// applyResolvedByFilter restricts the filters to the issues resolved the way passed
+// in the resolvedBy query parameter, if any.
+//
+// Responds with 400 and returns false if the resolution source is unknown.
+func applyResolvedByFilter(c *gin.Context, filters *repository.IssueQueryFilters) bool {
+	resolvedBy := c.Query("resolvedBy")
+	if resolvedBy == "" {
+		return true
+	}
+	source := models.ResolutionSource(resolvedBy)
+	if !source.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid resolvedBy, expected manual, success-webhook, auto-resolve, orphan-reconcile or cascade",
+		})
+		return false
+	}
+	filters.ResolvedBy = &source
+	return true
+}
+
+// applyNamespaceFilters restricts the filters to the namespaces of the request.
+// When authorization is governed by the resource namespace, the issues must also
+// be scoped to resources in these namespaces.
+// The namespace can be repeated to query several namespaces at once.
+//
+// Responds with 400 and returns false if a namespace isn't a valid Kubernetes namespace.
+func (h *IssueHandler) applyNamespaceFilters(c *gin.Context, filters *repository.IssueQueryFilters) bool {
+	namespaces := c.QueryArray("namespace")
+	for _, namespace := range namespaces {
+		if err := middleware.ValidateNamespace(namespace); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+			return false
+		}
+	}
+	if verified := c.GetString(middleware.VerifiedNamespaceKey); verified != "" {
+		namespaces = []string{verified}
+	} else if allowed, ok := c.Get(middleware.AllowedNamespacesKey); ok {
+		// Only keep the namespaces the requester can access
+		namespaces = allowed.([]string)
+	}
+	if len(namespaces) == 1 {
+		filters.Namespace = namespaces[0]
+	} else if len(namespaces) > 1 {
+		filters.Namespaces = namespaces
+	}
+	if h.authorizeResourceNamespace && len(namespaces) > 0 {
+		filters.ResourceNamespaces = namespaces
+	}
+	return true
+}
+
+// GetIssue handles GET /issues/:id
+//
+// The issue is rendered as a markdown report with ?format=markdown, or when the ID is
+// suffixed with .md as in /issues/:id.md.
+func (h *IssueHandler) GetIssue(c *gin.Context) {
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	format := c.Query("format")
+	if trimmed, ok := strings.CutSuffix(id, markdownSuffix); ok {
+		id, format = trimmed, formatMarkdown
+	}
+	if format != "" && format != formatMarkdown {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected markdown"})
+		return
+	}
+
+	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch issue"})
+		return
+	}
+
+	if issue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+
+	if (namespace != "" && issue.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, issue.Namespace, issue.Scope.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	filter := h.sensitiveFields.filter(c)
+	filter.issue(issue)
+
+	if format == formatMarkdown {
+		occurrences, err := h.issueService.FindIssueOccurrences(c.Request.Context(), issue.ID)
+		if err != nil {
+			h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch occurrences")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch occurrences"})
+			return
+		}
+		filter.occurrences(issue.Namespace, occurrences)
+
+		c.Data(http.StatusOK, markdownContentType, []byte(renderIssueMarkdown(issue, occurrences)))
+		return
+	}
+
+	c.JSON(http.StatusOK, issue)
+}
+
+// GetIssueStatus handles GET /issues/:id/status
+//
+// Returns only the state and severity of an issue, read without loading its associations,
+// for pollers that don't need the whole issue.
+func (h *IssueHandler) GetIssueStatus(c *gin.Context) {
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	status, err := h.issueService.FindIssueStatus(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue status")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch issue status"})
+		return
+	}
+
+	if status == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+
+	if (namespace != "" && status.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, status.Namespace, status.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// CreateIssue handles POST /issues
+func (h *IssueHandler) CreateIssue(c *gin.Context) {
+	var req dto.CreateIssueRequest
+	if err := h.bindCreateJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	h.createIssue(c, req)
+}
+
+// CreateIssueFromTemplate handles POST /issues/from-template/:name
+//
+// The issue is pre-filled from the named template, the request only needs its
+// namespace and scope, and can override the other fields.
+func (h *IssueHandler) CreateIssueFromTemplate(c *gin.Context) {
+	name := c.Param("name")
+
+	var req dto.CreateIssueFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	issueReq, err := h.templates.build(name, req)
+	if errors.Is(err, errUnknownIssueTemplate) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Issue template %s not found", name)})
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).WithField("template", name).Error("Failed to render issue template")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue"})
+		return
+	}
+
+	h.createIssue(c, issueReq)
+}
+
+// bindCreateJSON binds the JSON body of a create request, rejecting unknown fields
+// and data following the request if the handler is strict
+func (h *IssueHandler) bindCreateJSON(c *gin.Context, obj any) error {
+	if !h.strictJSON {
+		return c.ShouldBindJSON(obj)
+	}
+	if c.Request.Body == nil {
+		return errors.New("invalid request")
+	}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return errors.New("unexpected data after the request body")
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// createIssue validates and creates the issue of a request
+func (h *IssueHandler) createIssue(c *gin.Context, req dto.CreateIssueRequest) {
+	req.Scope = trimScope(req.Scope)
+	if err := h.validateCreateIssueRequest(req); err != nil {
+		response := gin.H{"error": "Validation failed", "details": err.Error()}
+		var scopeErr *scopeFieldError
+		var customFieldErr *customfields.Error
+		if errors.As(err, &scopeErr) {
+			response["code"] = dto.ErrorCodeValidationFailed
+			response["fields"] = []dto.FieldError{{Field: "scope." + scopeErr.field, Reason: scopeErr.reason}}
+		} else if errors.As(err, &customFieldErr) {
+			response = customFieldErrorResponse(customFieldErr)
+		}
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+	if !h.canAccessIssueNamespaces(c, req.Namespace, req.Scope.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	issue, err := h.issueService.CreateIssue(authorizeDedupNamespaces(c.Request.Context(), c, h.accessChecker), req)
+	if respondIssueLimitExceeded(c, req.Namespace, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to create issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue"})
+		return
+	}
+
+	h.sensitiveFields.filter(c).issue(issue)
+	c.JSON(http.StatusCreated, issue)
+}
+
+// UpdateIssue handles PUT /issues/:id
+func (h *IssueHandler) UpdateIssue(c *gin.Context) {
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	var req dto.UpdateIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if dto.CountPrimaryLinks(req.Links) > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": "at most one link can be primary"})
+		return
+	}
+	if err := h.checkLinkDomains(req.Links); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+	if len(req.Environment) > dto.MaxEnvironmentLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": fmt.Sprintf("environment must be at most %d characters", dto.MaxEnvironmentLength)})
+		return
+	}
+
+	// Check if issue exists and verify namespace exists
+	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for update")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+		return
+	}
+	if existingIssue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+
+	// Verify namespace access, to the issue and to where it's moved
+	if (namespace != "" && existingIssue.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, existingIssue.Namespace, existingIssue.Scope.ResourceNamespace) ||
+		!h.canAccessIssueNamespaces(c, req.Namespace, req.Scope.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	// The custom fields must match the schema of the type once updated, both can change
+	if req.IssueType != "" || req.CustomFields != nil {
+		customFields := req.CustomFields
+		if customFields == nil {
+			customFields = existingIssue.CustomFields
+		}
+		var customFieldErr *customfields.Error
+		if errors.As(h.customFields.Validate(cmp.Or(req.IssueType, existingIssue.IssueType), customFields), &customFieldErr) {
+			c.JSON(http.StatusBadRequest, customFieldErrorResponse(customFieldErr))
+			return
+		}
+	}
+
+	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateOpenIssue) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to update issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+		return
+	}
+
+	h.sensitiveFields.filter(c).issue(updatedIssue)
+	c.JSON(http.StatusOK, updatedIssue)
+}
+
+// jsonPatchContentType is the media type of JSON Patch (RFC 6902) documents
+const jsonPatchContentType = "application/json-patch+json"
+
+// PatchIssue handles PATCH /issues/:id
+func (h *IssueHandler) PatchIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	if c.ContentType() != jsonPatchContentType {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Expected a " + jsonPatchContentType + " body"})
+		return
+	}
+
+	var ops []dto.PatchOperation
+	if err := c.ShouldBindJSON(&ops); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if !h.checkIssueAccess(c, id) {
+		return
+	}
+
+	issue, err := h.issueService.PatchIssue(c.Request.Context(), id, ops)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrIssueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		case errors.Is(err, services.ErrInvalidPatch):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid patch", "details": err.Error()})
+		case errors.Is(err, services.ErrPatchTestFailed):
+			c.JSON(http.StatusConflict, gin.H{"error": "Patch test failed", "details": err.Error()})
+		case errors.Is(err, repository.ErrDuplicateOpenIssue):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			h.logger.WithError(err).WithField("issue_id", id).Error("Failed to patch issue")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to patch issue"})
+		}
+		return
+	}
+
+	h.sensitiveFields.filter(c).issue(issue)
+	c.JSON(http.StatusOK, issue)
+}
+
+// SnoozeIssue handles POST /issues/:id/snooze, hiding an issue from the default
+// issue list until it's reported again
+func (h *IssueHandler) SnoozeIssue(c *gin.Context) {
+	h.setIssueSnoozed(c, true)
+}
+
+// UnsnoozeIssue handles DELETE /issues/:id/snooze, showing a snoozed issue again
+func (h *IssueHandler) UnsnoozeIssue(c *gin.Context) {
+	h.setIssueSnoozed(c, false)
+}
+
+// setIssueSnoozed snoozes or unsnoozes the issue of a request
+func (h *IssueHandler) setIssueSnoozed(c *gin.Context, snoozed bool) {
+	id := c.Param("id")
+
+	if !h.checkIssueAccess(c, id) {
+		return
+	}
+
+	issue, err := h.issueService.SnoozeIssue(c.Request.Context(), id, snoozed)
+	if err != nil {
+		if errors.Is(err, repository.ErrIssueNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+			return
+		}
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to snooze issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to snooze issue"})
+		return
+	}
+
+	h.sensitiveFields.filter(c).issue(issue)
+	c.JSON(http.StatusOK, issue)
+}
+
+// DeleteIssue handles DELETE /issues/:id
+func (h *IssueHandler) DeleteIssue(c *gin.Context) {
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for deletion")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+		return
+	}
+	if existingIssue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+
+	// Namespace access check
+	if (namespace != "" && existingIssue.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, existingIssue.Namespace, existingIssue.Scope.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	if err := h.issueService.DeleteIssue(c.Request.Context(), id); err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to delete issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// maxBulkDeleteSize is the maximum number of issues deleted in a single request
+const maxBulkDeleteSize = 100
+
+// BulkDeleteIssues handles POST /issues/bulk-delete
+func (h *IssueHandler) BulkDeleteIssues(c *gin.Context) {
+	namespace := middleware.RequestNamespace(c)
+
+	var req dto.BulkIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if len(req.IDs) == 0 || len(req.IDs) > maxBulkDeleteSize {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": fmt.Sprintf("ids must contain between 1 and %d issues", maxBulkDeleteSize),
+		})
+		return
+	}
+
+	results, err := h.issueService.BulkDeleteIssues(c.Request.Context(), namespace, req.IDs)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to bulk delete issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issues"})
+		return
+	}
+
+	deleted := 0
+	for _, result := range results {
+		if result.Status == dto.BulkIssueDeleted {
+			deleted++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"deleted": deleted,
+		"errors":  len(results) - deleted,
+	})
+}
+
+// maxBatchGetSize is the maximum number of issues fetched in a single request
+const maxBatchGetSize = 100
+
+// BatchGetIssues handles POST /issues/batch-get
+//
+// Returns the issues with the requested IDs in the requested order, in a single query.
+// The IDs of the issues not found, or outside the namespaces the requester can access,
+// are listed as missing.
+func (h *IssueHandler) BatchGetIssues(c *gin.Context) {
+	namespace := middleware.RequestNamespace(c)
+
+	var req dto.BulkIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	// Duplicate IDs are fetched once, at their first position
+	ids := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > maxBatchGetSize {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": fmt.Sprintf("ids must contain between 1 and %d issues", maxBatchGetSize),
+		})
+		return
+	}
+	for _, id := range ids {
+		if err := uuid.Validate(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": fmt.Sprintf("invalid issue ID %q", id)})
+			return
+		}
+	}
+
+	issues, err := h.issueService.FindIssuesByIDs(c.Request.Context(), ids)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to batch fetch issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch issues"})
+		return
+	}
+
+	found := make(map[string]models.Issue, len(issues))
+	for _, issue := range issues {
+		found[issue.ID] = issue
+	}
+	response := dto.BatchGetResponse{Data: []models.Issue{}, Missing: []string{}}
+	for _, id := range ids {
+		issue, ok := found[id]
+		if !ok || (namespace != "" && issue.Namespace != namespace) ||
+			!h.canAccessIssueNamespaces(c, issue.Namespace, issue.Scope.ResourceNamespace) {
+			response.Missing = append(response.Missing, id)
+			continue
+		}
+		response.Data = append(response.Data, issue)
+	}
+	h.sensitiveFields.filter(c).issues(response.Data)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResolveIssue handles POST /issues/:id/resolve
+func (h *IssueHandler) ResolveIssue(c *gin.Context) {
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("failed to find issue for resolution")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve issue"})
+		return
+	}
+
+	if existingIssue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return
+	}
+
+	// Namespace access check
+	if (namespace != "" && existingIssue.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, existingIssue.Namespace, existingIssue.Scope.ResourceNamespace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	// Resolve the issues caused by this one too, if asked to
+	if cascade, _ := strconv.ParseBool(c.Query("cascade")); cascade {
+		h.resolveIssueWithCascade(c, id)
+		return
+	}
+
+	now := h.clock.Now()
+	state := models.IssueStateResolved
+	req := dto.UpdateIssueRequest{
+		State:      state,
+		ResolvedAt: now,
+	}
+
+	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to mark issue resolved")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve issue"})
+		return
+	}
+
+	h.sensitiveFields.filter(c).issue(updatedIssue)
+	c.JSON(http.StatusOK, updatedIssue)
+}
+
+// resolveIssueWithCascade resolves an issue along with the issues it caused
+func (h *IssueHandler) resolveIssueWithCascade(c *gin.Context, id string) {
+	cascaded, err := h.issueService.ResolveIssueWithCascade(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to mark issue resolved with cascade")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve issue"})
+		return
+	}
+
+	updatedIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch resolved issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve issue"})
+		return
+	}
+
+	if cascaded == nil {
+		cascaded = []string{}
+	}
+	h.sensitiveFields.filter(c).issue(updatedIssue)
+	c.JSON(http.StatusOK, gin.H{
+		"issue":           updatedIssue,
+		"cascadeResolved": cascaded,
+	})
+}
+
+// AddRelatedIssue handles POST /issues/:id/related
+func (h *IssueHandler) AddRelatedIssue(c *gin.Context) {
+	id := c.Param("id")
+
+	var req struct {
+		RelatedID string `json:"relatedId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing relatdId field"})
+		return
+	}
+
+	if err := h.issueService.AddRelatedIssue(c.Request.Context(), id, req.RelatedID); err != nil {
+		if err.Error() == "one or both issues not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "relationship already exists" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrRelationshipLimitExceeded) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to add related issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue relationship"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Relationship created"})
+}
+
+// maxRelationshipBatchSize is the maximum number of relationships created in a single batch
+const maxRelationshipBatchSize = 500
+
+// BatchAddRelatedIssues handles POST /issues/relationships/batch
+func (h *IssueHandler) BatchAddRelatedIssues(c *gin.Context) {
+	var edges []dto.RelationshipEdgeRequest
+	if err := c.ShouldBindJSON(&edges); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if len(edges) == 0 || len(edges) > maxRelationshipBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"details": fmt.Sprintf("batch must contain between 1 and %d relationships", maxRelationshipBatchSize),
+		})
+		return
+	}
+
+	// Only the namespace of the request was checked by the middleware, the issues of
+	// every edge must be in it and accessible
+	accessible, err := h.accessibleIssues(c, edges)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to find issues of relationships")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue relationships"})
+		return
+	}
+
+	results := make([]dto.RelationshipEdgeResult, len(edges))
+	var allowed []dto.RelationshipEdgeRequest
+	var allowedIdx []int
+	for idx, edge := range edges {
+		if !accessible(edge.SourceID) || !accessible(edge.TargetID) {
+			results[idx] = dto.RelationshipEdgeResult{
+				SourceID: edge.SourceID,
+				TargetID: edge.TargetID,
+				Kind:     cmp.Or(edge.Kind, models.RelationshipRelatesTo),
+				Status:   dto.RelationshipEdgeError,
+				Error:    "access denied to the namespace of the issues",
+			}
+			continue
+		}
+		allowed = append(allowed, edge)
+		allowedIdx = append(allowedIdx, idx)
+	}
+
+	if len(allowed) > 0 {
+		created, err := h.issueService.BatchAddRelatedIssues(c.Request.Context(), allowed)
+		if err != nil {
+			if errors.Is(err, repository.ErrIssuesNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			h.logger.WithError(err).Error("Failed to add related issues in batch")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue relationships"})
+			return
+		}
+		for idx, result := range created {
+			results[allowedIdx[idx]] = result
+		}
+	}
+
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"created": counts[dto.RelationshipEdgeCreated],
+		"skipped": counts[dto.RelationshipEdgeSkipped],
+		"errors":  counts[dto.RelationshipEdgeError],
+	})
+}
+
+// accessibleIssues returns whether the requester can access each issue referenced by the
+// edges: issues in the namespace of the request, if any, whose namespaces they can access.
+// Issues that don't exist are reported accessible, for the batch to fail as not found.
+func (h *IssueHandler) accessibleIssues(c *gin.Context, edges []dto.RelationshipEdgeRequest) (func(id string) bool, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		for _, id := range []string{edge.SourceID, edge.TargetID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	issues, err := h.issueService.FindIssuesByIDs(c.Request.Context(), ids)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := middleware.RequestNamespace(c)
+	denied := make(map[string]bool)
+	// Access is checked once per pair of namespaces, batches reference few of them
+	access := make(map[[2]string]bool)
+	for _, issue := range issues {
+		key := [2]string{issue.Namespace, issue.Scope.ResourceNamespace}
+		allowed, ok := access[key]
+		if !ok {
+			allowed = h.canAccessIssueNamespaces(c, issue.Namespace, issue.Scope.ResourceNamespace)
+			access[key] = allowed
+		}
+		if (namespace != "" && issue.Namespace != namespace) || !allowed {
+			denied[issue.ID] = true
+		}
+	}
+	return func(id string) bool { return !denied[id] }, nil
+}
+
+// RemoveRelatedIssue handles DELETE /issues/:id/related/:relatedId
+func (h *IssueHandler) RemoveRelatedIssue(c *gin.Context) {
+	id := c.Param("id")
+	relatedID := c.Param("relatedId")
+
+	if err := h.issueService.RemoveRelatedIssue(c.Request.Context(), id, relatedID); err != nil {
+		if err.Error() == "relationship not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to remove related issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue relationship"})
+		return
 	}
+
+	c.Status(http.StatusNoContent)
 }
 
-// GetIssues handles GET /issues
-func (h *IssueHandler) GetIssues(c *gin.Context) {
-	// Esxtract query params
-	filters := repository.IssueQueryFilters{
-		Namespace:    c.Query("namespace"),
-		ResourceType: c.Query("resourceType"),
-		ResourceName: c.Query("resourceName"),
-		Search:       c.Query("search"),
-	}
+// RemoveAllRelatedIssues handles DELETE /issues/:id/related
+func (h *IssueHandler) RemoveAllRelatedIssues(c *gin.Context) {
+	id := c.Param("id")
 
-	// Parse optional enum params
-	if severity := c.Query("severity"); severity != "" {
-		// Convert to custom type, then assign
-		sev := models.Severity(severity)
-		filters.Severity = &sev
-	}
-	if issueType := c.Query("issueType"); issueType != "" {
-		it := models.IssueType(issueType)
-		filters.IssueType = &it
-	}
-	if state := c.Query("state"); state != "" {
-		st := models.IssueState(state)
-		filters.State = &st
+	if !h.checkIssueAccess(c, id) {
+		return
 	}
 
-	// Parse pagination parameters
-	if limit := c.Query("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			filters.Limit = l
+	removed, err := h.issueService.RemoveAllRelatedIssues(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrIssueNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+			return
 		}
+		h.logger.WithError(err).Error("Failed to remove related issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue relationships"})
+		return
 	}
-	if offset := c.Query("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			filters.Offset = o
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+// GetIssueGraph handles GET /issues/:id/graph
+func (h *IssueHandler) GetIssueGraph(c *gin.Context) {
+	id := c.Param("id")
+
+	if !h.checkIssueAccess(c, id) {
+		return
+	}
+
+	graph, err := h.issueService.FindIssueGraph(c.Request.Context(), id, h.maxGraphSize)
+	if err != nil {
+		if errors.Is(err, repository.ErrIssueNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+			return
 		}
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue graph")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch issue graph"})
+		return
 	}
 
-	// Default limit
-	if filters.Limit == 0 {
-		filters.Limit = 50
+	c.JSON(http.StatusOK, graph)
+}
+
+// GetIssueOccurrences handles GET /issues/:id/occurrences
+func (h *IssueHandler) GetIssueOccurrences(c *gin.Context) {
+	id := c.Param("id")
+
+	issue, ok := h.findAccessibleIssue(c, id)
+	if !ok {
+		return
 	}
 
-	result, err := h.issueService.FindIssues(c.Request.Context(), filters)
+	occurrences, err := h.issueService.FindIssueOccurrences(c.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to fetch issues")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch issues"})
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch occurrences")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch occurrences"})
 		return
 	}
+	h.sensitiveFields.filter(c).occurrences(issue.Namespace, occurrences)
 
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, gin.H{"data": occurrences})
 }
 
-// GetIssue handles GET /issues/:id
-func (h *IssueHandler) GetIssue(c *gin.Context) {
+// GetIssueOccurrenceHistogram handles GET /issues/:id/occurrences/histogram
+func (h *IssueHandler) GetIssueOccurrenceHistogram(c *gin.Context) {
 	id := c.Param("id")
-	namespace := c.Query("namespace")
 
-	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	bucket := c.DefaultQuery("bucket", "hour")
+	if !repository.IsValidOccurrenceBucket(bucket) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bucket, expected minute, hour, day or week"})
+		return
+	}
+
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since, expected an RFC 3339 timestamp"})
+			return
+		}
+		since = t
+	}
+
+	if _, ok := h.findAccessibleIssue(c, id); !ok {
+		return
+	}
+
+	buckets, err := h.issueService.FindOccurrenceHistogram(c.Request.Context(), id, bucket, since)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch issue"})
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to compute occurrence histogram")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute occurrence histogram"})
 		return
 	}
 
-	if issue == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+	c.JSON(http.StatusOK, gin.H{"data": buckets})
+}
+
+// GetIssueAttachments handles GET /issues/:id/attachments
+func (h *IssueHandler) GetIssueAttachments(c *gin.Context) {
+	id := c.Param("id")
+
+	issue, ok := h.findAccessibleIssue(c, id)
+	if !ok {
 		return
 	}
 
-	if namespace != "" && issue.Namespace != namespace {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+	attachments, err := h.issueService.FindIssueAttachments(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch attachments")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch attachments"})
 		return
 	}
+	h.sensitiveFields.filter(c).attachments(issue.Namespace, attachments)
 
-	c.JSON(http.StatusOK, issue)
+	c.JSON(http.StatusOK, gin.H{"data": attachments})
 }
 
-// CreateIssue handles POST /issues
-func (h *IssueHandler) CreateIssue(c *gin.Context) {
-	var req dto.CreateIssueRequest
+// AddIssueAttachment handles POST /issues/:id/attachments.
+// Only the metadata of the file is registered, it must be uploaded to the object storage beforehand.
+func (h *IssueHandler) AddIssueAttachment(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.CreateAttachmentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
 	}
 
-	if err := h.validateCreateIssueRequest(req); err != nil {
+	if err := h.validateAttachment(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
 		return
 	}
 
-	issue, err := h.issueService.CreateIssue(c.Request.Context(), req)
+	if !h.checkIssueAccess(c, id) {
+		return
+	}
+
+	attachment, err := h.issueService.AddIssueAttachment(c.Request.Context(), id, req)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to create issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue"})
+		if errors.Is(err, repository.ErrIssueNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+			return
+		}
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to add attachment")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add attachment"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, issue)
+	c.JSON(http.StatusCreated, attachment)
 }
 
-// UpdateIssue handles PUT /issues/:id
-func (h *IssueHandler) UpdateIssue(c *gin.Context) {
+// AddIssueLink handles POST /issues/:id/links
+func (h *IssueHandler) AddIssueLink(c *gin.Context) {
 	id := c.Param("id")
-	namespace := c.Query("namespace")
 
-	var req dto.UpdateIssueRequest
+	var req dto.CreateLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
 	}
 
-	// Check if issue exists and verify namespace exists
-	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
-	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for update")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+	if err := validateLinkURL(req.URL); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
 		return
 	}
-	if existingIssue == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+	if err := h.linkDomains.Check(req.URL); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
 		return
 	}
 
-	// Verify namespace access
-	if namespace != "" && existingIssue.Namespace != namespace {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+	if !h.checkIssueAccess(c, id) {
 		return
 	}
 
-	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
+	link, err := h.issueService.AddIssueLink(c.Request.Context(), id, req)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to update issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update issue"})
+		h.respondLinkError(c, err, id, "Failed to add link")
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedIssue)
+	c.JSON(http.StatusCreated, link)
 }
 
-// DeleteIssue handles DELETE /issues/:id
-func (h *IssueHandler) DeleteIssue(c *gin.Context) {
+// UpdateIssueLink handles PUT /issues/:id/links/:linkId
+func (h *IssueHandler) UpdateIssueLink(c *gin.Context) {
 	id := c.Param("id")
-	namespace := c.Query("namespace")
+	linkID := c.Param("linkId")
 
-	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
-	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to find issue for deletion")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+	var req dto.CreateLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
 	}
-	if existingIssue == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+
+	if err := validateLinkURL(req.URL); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+	if err := h.linkDomains.Check(req.URL); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
 		return
 	}
 
-	// Namespace access check
-	if namespace != "" && existingIssue.Namespace != namespace {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+	if !h.checkIssueAccess(c, id) {
 		return
 	}
 
-	if err := h.issueService.DeleteIssue(c.Request.Context(), id); err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to delete issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue"})
+	link, err := h.issueService.UpdateIssueLink(c.Request.Context(), id, linkID, req)
+	if err != nil {
+		h.respondLinkError(c, err, id, "Failed to update link")
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, link)
 }
 
-// ResolveIssue handles POST /issues/:id/resolve
-func (h *IssueHandler) ResolveIssue(c *gin.Context) {
+// DeleteIssueLink handles DELETE /issues/:id/links/:linkId
+func (h *IssueHandler) DeleteIssueLink(c *gin.Context) {
 	id := c.Param("id")
-	namespace := c.Query("namespace")
+	linkID := c.Param("linkId")
 
-	existingIssue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
-	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("failed to find issue for resolution")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve issue"})
+	if !h.checkIssueAccess(c, id) {
 		return
 	}
 
-	if existingIssue == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+	if err := h.issueService.DeleteIssueLink(c.Request.Context(), id, linkID); err != nil {
+		h.respondLinkError(c, err, id, "Failed to delete link")
 		return
 	}
 
-	// Namespace access check
-	if namespace != "" && existingIssue.Namespace != namespace {
+	c.Status(http.StatusNoContent)
+}
+
+// checkIssueAccess verifies that an issue exists and belongs to the requested
+// namespace, responding with an error otherwise.
+func (h *IssueHandler) checkIssueAccess(c *gin.Context, id string) bool {
+	_, ok := h.findAccessibleIssue(c, id)
+	return ok
+}
+
+// findAccessibleIssue works like checkIssueAccess, also returning the issue when it's accessible
+func (h *IssueHandler) findAccessibleIssue(c *gin.Context, id string) (*models.Issue, bool) {
+	namespace := middleware.RequestNamespace(c)
+
+	issue, err := h.issueService.FindIssueByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to fetch issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch issue"})
+		return nil, false
+	}
+	if issue == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+		return nil, false
+	}
+
+	if (namespace != "" && issue.Namespace != namespace) ||
+		!h.canAccessIssueNamespaces(c, issue.Namespace, issue.Scope.ResourceNamespace) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
-		return
+		return nil, false
+	}
+	return issue, true
+}
+
+// respondIssueLimitExceeded responds with 429 and returns true if an issue was rejected
+// because its namespace reached its maximum number of active issues.
+func respondIssueLimitExceeded(c *gin.Context, namespace string, err error) bool {
+	if !errors.Is(err, repository.ErrNamespaceIssueLimitExceeded) {
+		return false
 	}
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error": fmt.Sprintf("Namespace %s reached its maximum number of active issues, resolve some before reporting new ones", namespace),
+	})
+	return true
+}
 
-	now := time.Now()
-	state := models.IssueStateResolved
-	req := dto.UpdateIssueRequest{
-		State:      state,
-		ResolvedAt: now,
+// canAccessIssueNamespaces reports whether the requester can access an issue tracked in
+// namespace and scoped to a resource in resourceNamespace.
+//
+// Unless authorization is governed by the resource namespace, only the namespace of the
+// request, checked by the middleware, matters. Otherwise access to both namespaces is required,
+// the namespace verified by the middleware isn't checked again.
+func (h *IssueHandler) canAccessIssueNamespaces(c *gin.Context, namespace, resourceNamespace string) bool {
+	if !h.authorizeResourceNamespace || h.accessChecker == nil {
+		return true
+	}
+	verified := c.GetString(middleware.VerifiedNamespaceKey)
+	for _, ns := range []string{namespace, resourceNamespace} {
+		// Access to the namespace of the request was already checked by the middleware
+		if ns == "" || ns == verified {
+			continue
+		}
+		if !h.accessChecker.CanAccessNamespace(c, ns) {
+			return false
+		}
 	}
+	return true
+}
 
-	updatedIssue, err := h.issueService.UpdateIssue(c.Request.Context(), id, req)
+// respondLinkError maps errors from link operations to HTTP responses
+func (h *IssueHandler) respondLinkError(c *gin.Context, err error, issueID, message string) {
+	switch {
+	case errors.Is(err, repository.ErrIssueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Issue not found"})
+	case errors.Is(err, repository.ErrLinkNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Link not found"})
+	case errors.Is(err, repository.ErrMultiplePrimaryLinks):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).WithField("issue_id", issueID).Error(message)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// validateLinkURL checks that a link points to an absolute http(s) URL
+func validateLinkURL(raw string) error {
+	u, err := url.Parse(raw)
 	if err != nil {
-		h.logger.WithError(err).WithField("issue_id", id).Error("Failed to mark issue resolved")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve issue"})
-		return
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("url must use the http or https scheme")
 	}
+	if u.Host == "" {
+		return errors.New("url must include a host")
+	}
+	return nil
+}
 
-	c.JSON(http.StatusOK, updatedIssue)
+// checkLinkDomains checks that links point to allowed domains
+func (h *IssueHandler) checkLinkDomains(links []dto.CreateLinkRequest) error {
+	for _, link := range links {
+		if err := h.linkDomains.Check(link.URL); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// AddRelatedIssue handles POST /issues/:id/related
-func (h *IssueHandler) AddRelatedIssue(c *gin.Context) {
-	id := c.Param("id")
+// validateAttachment checks the size and media type of an attached file, and that it
+// references an object storage URL. The media type is normalized.
+func (h *IssueHandler) validateAttachment(req *dto.CreateAttachmentRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return errors.New("name is required")
+	}
+	if req.Size < 1 {
+		return errors.New("size must be positive")
+	}
+	if req.Size > h.maxAttachmentSize {
+		return fmt.Errorf("size exceeds the maximum of %d bytes", h.maxAttachmentSize)
+	}
 
-	var req struct {
-		RelatedID string `json:"relatedId" binding:"required"`
+	mediaType, _, err := mime.ParseMediaType(req.ContentType)
+	if err != nil {
+		return fmt.Errorf("invalid content type: %w", err)
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing relatdId field"})
-		return
+	if !attachmentContentTypeAllowed(h.attachmentContentTypes, mediaType) {
+		return fmt.Errorf("content type %s is not allowed", mediaType)
 	}
+	req.ContentType = mediaType
 
-	if err := h.issueService.AddRelatedIssue(c.Request.Context(), id, req.RelatedID); err != nil {
-		if err.Error() == "one or both issues not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		if err.Error() == "relationship already exists" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
+	u, err := url.Parse(req.StorageURL)
+	if err != nil {
+		return fmt.Errorf("invalid storage url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "s3" {
+		return errors.New("storage url must use the http, https or s3 scheme")
+	}
+	if u.Host == "" {
+		return errors.New("storage url must include a host")
+	}
+	return nil
+}
+
+// attachmentContentTypeAllowed reports whether a media type matches one of the allowed
+// media types, where "type/*" matches all the subtypes of a type
+func attachmentContentTypeAllowed(allowed []string, mediaType string) bool {
+	for _, contentType := range allowed {
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		if prefix, ok := strings.CutSuffix(contentType, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if contentType == mediaType {
+			return true
 		}
-		h.logger.WithError(err).Error("Failed to add related issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create issue relationship"})
-		return
 	}
+	return false
+}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Relationship created"})
+// customFieldErrorResponse is the validation error response of an invalid custom field
+func customFieldErrorResponse(err *customfields.Error) gin.H {
+	return gin.H{
+		"error":   "Validation failed",
+		"details": err.Error(),
+		"code":    dto.ErrorCodeValidationFailed,
+		"fields":  []dto.FieldError{{Field: "customFields." + err.Field, Reason: err.Reason}},
+	}
 }
 
-// RemoveRelatedIssue handles DELETE /issues/:id/related/:relatedId
-func (h *IssueHandler) RemoveRelatedIssue(c *gin.Context) {
-	id := c.Param("id")
-	relatedID := c.Param("relatedId")
+// scopeFieldError reports an invalid field of the scope of an issue
+type scopeFieldError struct {
+	field     string // JSON name of the field, e.g. "resourceName"
+	reason    string // "required" or "max", like the binding tags
+	maxLength int
+}
 
-	if err := h.issueService.RemoveRelatedIssue(c.Request.Context(), id, relatedID); err != nil {
-		if err.Error() == "relationship not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		h.logger.WithError(err).Error("Failed to remove related issue")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete issue relationship"})
-		return
+func (e *scopeFieldError) Error() string {
+	if e.reason == "max" {
+		return fmt.Sprintf("scope.%s must be at most %d characters", e.field, e.maxLength)
 	}
+	return fmt.Sprintf("scope.%s must not be empty", e.field)
+}
 
-	c.Status(http.StatusNoContent)
+// trimScope removes the whitespace around the fields of a scope, so resources
+// reported with stray spaces share the dedup key of the others
+func trimScope(scope dto.ScopeReqBody) dto.ScopeReqBody {
+	return dto.ScopeReqBody{
+		ResourceType:      strings.TrimSpace(scope.ResourceType),
+		ResourceName:      strings.TrimSpace(scope.ResourceName),
+		ResourceNamespace: strings.TrimSpace(scope.ResourceNamespace),
+	}
+}
+
+// validateScope returns an error if the resource type or name of a trimmed scope is
+// empty or longer than maxLength. Issues are deduplicated on them.
+func validateScope(scope dto.ScopeReqBody, maxLength int) *scopeFieldError {
+	fields := []struct{ name, value string }{
+		{"resourceType", scope.ResourceType},
+		{"resourceName", scope.ResourceName},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			return &scopeFieldError{field: field.name, reason: "required"}
+		}
+		if len(field.value) > maxLength {
+			return &scopeFieldError{field: field.name, reason: "max", maxLength: maxLength}
+		}
+	}
+	return nil
 }
 
 // Helper function for validation issue creation
@@ -308,10 +1747,39 @@ func (h *IssueHandler) validateCreateIssueRequest(req dto.CreateIssueRequest) er
 	}
 
 	// validate state if provided
-	if req.State != "" {
-		validStates := []models.IssueState{models.IssueStateActive, models.IssueStateResolved}
-		if !slices.Contains(validStates, req.State) {
-			return errors.New("invalid state value")
+	if req.State != "" && !req.State.IsValid() {
+		return errors.New("invalid state value")
+	}
+
+	if err := validateScope(req.Scope, h.maxScopeFieldLength); err != nil {
+		return err
+	}
+
+	if dto.CountPrimaryLinks(req.Links) > 1 {
+		return errors.New("at most one link can be primary")
+	}
+	if err := h.checkLinkDomains(req.Links); err != nil {
+		return err
+	}
+
+	if len(req.Fingerprint) > dto.MaxFingerprintLength {
+		return fmt.Errorf("fingerprint must be at most %d characters", dto.MaxFingerprintLength)
+	}
+	if len(req.Environment) > dto.MaxEnvironmentLength {
+		return fmt.Errorf("environment must be at most %d characters", dto.MaxEnvironmentLength)
+	}
+
+	if err := h.customFields.Validate(req.IssueType, req.CustomFields); err != nil {
+		return err
+	}
+
+	// validate the commit context if provided
+	if req.CommitSHA != "" && !commitlink.IsValidCommitSHA(req.CommitSHA) {
+		return errors.New("invalid commitSha value")
+	}
+	if req.RepoURL != "" {
+		if err := validateLinkURL(commitlink.NormalizeRepoURL(req.RepoURL)); err != nil {
+			return fmt.Errorf("invalid repoUrl value: %w", err)
 		}
 	}
 
@@ -3,14 +3,27 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	net_http "net/http"
 	net_httptest "net/http/httptest"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/customfields"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,10 +45,32 @@ func setupTestIssueRouter(handler *IssueHandler) *gin.Engine {
 	{
 		v1.GET("/issues", handler.GetIssues)
 		v1.POST("/issues", handler.CreateIssue)
+		v1.POST("/issues/bulk-delete", handler.BulkDeleteIssues)
+		v1.POST("/issues/batch-get", handler.BatchGetIssues)
+		v1.POST("/issues/from-template/:name", handler.CreateIssueFromTemplate)
+		v1.GET("/issues/search", handler.SearchIssues)
+		v1.GET("/issues/grouped", handler.GetGroupedIssues)
+		v1.GET("/issues/by-namespace", handler.GetIssuesByNamespace)
+		v1.GET("/issues/metrics/mttr", handler.GetMTTR)
+		v1.GET("/issues/:id/occurrences", handler.GetIssueOccurrences)
+		v1.GET("/issues/:id/occurrences/histogram", handler.GetIssueOccurrenceHistogram)
+		v1.POST("/issues/import", handler.ImportIssues)
 		v1.GET("/issues/:id", handler.GetIssue)
+		v1.GET("/issues/:id/status", handler.GetIssueStatus)
 		v1.PUT("/issues/:id", handler.UpdateIssue)
+		v1.PATCH("/issues/:id", handler.PatchIssue)
 		v1.DELETE("/issues/:id", handler.DeleteIssue)
 		v1.POST("/issues/:id/resolve", handler.ResolveIssue)
+		v1.POST("/issues/:id/snooze", handler.SnoozeIssue)
+		v1.DELETE("/issues/:id/snooze", handler.UnsnoozeIssue)
+		v1.POST("/issues/:id/links", handler.AddIssueLink)
+		v1.GET("/issues/:id/attachments", handler.GetIssueAttachments)
+		v1.POST("/issues/:id/attachments", handler.AddIssueAttachment)
+		v1.PUT("/issues/:id/links/:linkId", handler.UpdateIssueLink)
+		v1.DELETE("/issues/:id/links/:linkId", handler.DeleteIssueLink)
+		v1.POST("/issues/relationships/batch", handler.BatchAddRelatedIssues)
+		v1.DELETE("/issues/:id/related", handler.RemoveAllRelatedIssues)
+		v1.GET("/issues/:id/graph", handler.GetIssueGraph)
 	}
 
 	return router
@@ -146,6 +181,89 @@ func TestIssueHandler_GetIssue_Found(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_GetIssue_Markdown(t *testing.T) {
+	detectedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockIssue := &models.Issue{
+		ID:          "test-issue-abc",
+		Title:       "Build failed for frontend",
+		Description: "The build step exited with code 1",
+		Namespace:   "team-alpha",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		State:       models.IssueStateActive,
+		DetectedAt:  detectedAt,
+		LastSeenAt:  detectedAt.Add(time.Hour),
+		Scope:       models.IssueScope{ResourceType: "component", ResourceNamespace: "team-alpha", ResourceName: "front_end*`"},
+		CommitContext: models.CommitContext{
+			CommitSHA: "3f2a1c9",
+			RepoURL:   "https://github.com/org/frontend>\n# Heading",
+			Branch:    "fix_`build`",
+		},
+		Links: []models.Link{
+			{Title: "Pipeline run", URL: "https://ci.example.com/runs/1"},
+			{Title: "Build logs", URL: "https://ci.example.com/runs/1/logs"},
+			{Title: "Dashboard", URL: "https://ci.example.com/a b) [x](<javascript:alert(1)>\n# Heading"},
+		},
+		RelatedFrom: []models.RelatedIssue{
+			{Kind: models.RelationshipCausedBy, Target: models.Issue{ID: "cause-issue", Title: "Registry unavailable", State: models.IssueStateActive}},
+		},
+		Notes: []models.IssueNote{
+			{Content: "Retried the build", CreatedAt: detectedAt.Add(30 * time.Minute)},
+		},
+	}
+	mockService := &MockIssueService{
+		findIssueByIDResult: mockIssue,
+		findOccurrencesResult: []models.Occurrence{
+			{OccurredAt: detectedAt.Add(time.Hour), Detail: "exit code 1\nfull log"},
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	for _, path := range []string{"/api/v1/issues/test-issue-abc.md", "/api/v1/issues/test-issue-abc?format=markdown"} {
+		t.Run(path, func(t *testing.T) {
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, net_httptest.NewRequest("GET", path, nil))
+
+			if w.Code != net_http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/markdown") {
+				t.Errorf("expected markdown content type, got %q", contentType)
+			}
+
+			body := w.Body.String()
+			if !strings.HasPrefix(body, "# Build failed for frontend\n") {
+				t.Errorf("expected title heading, got %q", body)
+			}
+			for _, expected := range []string{
+				"- **Severity:** major",
+				"The build step exited with code 1",
+				"- **Resource:** component team-alpha/front\\_end\\*\\`\n",
+				"- **Commit:** `3f2a1c9` in <https://github.com/org/frontend%3E%0A# Heading> on fix\\_\\`build\\`\n",
+				"- [Pipeline run](<https://ci.example.com/runs/1>)",
+				"- [Build logs](<https://ci.example.com/runs/1/logs>)",
+				"- [Dashboard](<https://ci.example.com/a b) [x](%3Cjavascript:alert(1)%3E%0A# Heading>)\n",
+				"## Related issues",
+				"- This issue is caused by **Registry unavailable** (`cause-issue`, ACTIVE)",
+				"- 2026-10-01T12:30:00Z: Note: Retried the build\n- 2026-10-01T13:00:00Z: Occurred: exit code 1\n",
+			} {
+				if !strings.Contains(body, expected) {
+					t.Errorf("expected markdown to contain %q, got:\n%s", expected, body)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid format", func(t *testing.T) {
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues/test-issue-abc?format=pdf", nil))
+
+		if w.Code != net_http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
+
 func TestIssueHandler_GetIssue_NotFound(t *testing.T) {
 	mockService := &MockIssueService{
 		findIssueByIDResult: nil,
@@ -179,6 +297,68 @@ func TestIssueHandler_GetIssue_NotFound(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_GetIssueStatus(t *testing.T) {
+	resolvedAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	status := &dto.IssueStatus{
+		ID:                "123e4567-e89b-12d3-a456-426614174000",
+		State:             models.IssueStateResolved,
+		Severity:          models.SeverityMajor,
+		UpdatedAt:         resolvedAt,
+		ResolvedAt:        &resolvedAt,
+		Namespace:         "team-alpha",
+		ResourceNamespace: "team-alpha",
+	}
+
+	tests := []struct {
+		name           string
+		status         *dto.IssueStatus
+		query          string
+		expectedStatus int
+	}{
+		{name: "found", status: status, query: "?namespace=team-alpha", expectedStatus: net_http.StatusOK},
+		{name: "other namespace", status: status, query: "?namespace=team-beta", expectedStatus: net_http.StatusForbidden},
+		{name: "unknown issue", status: nil, expectedStatus: net_http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueStatusResult: tt.status}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues/123e4567-e89b-12d3-a456-426614174000/status"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			// Only the status fields are returned
+			var response map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			expected := map[string]any{
+				"id":         "123e4567-e89b-12d3-a456-426614174000",
+				"state":      "RESOLVED",
+				"severity":   "major",
+				"updatedAt":  "2026-10-17T09:30:00Z",
+				"resolvedAt": "2026-10-17T09:30:00Z",
+			}
+			if !reflect.DeepEqual(response, expected) {
+				t.Errorf("expected %v, got %v", expected, response)
+			}
+		})
+	}
+}
+
 func TestIssueHandler_CreateIssue_Success(t *testing.T) {
 	createRequest := dto.CreateIssueRequest{
 		Title:       "New Test Issue",
@@ -244,6 +424,245 @@ func TestIssueHandler_CreateIssue_Success(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_CreateIssue_NamespaceLimitExceeded(t *testing.T) {
+	mockService := &MockIssueService{
+		createIssueError: fmt.Errorf("%w: namespace team-gamma already has 2 active issues", repository.ErrNamespaceIssueLimitExceeded),
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	reqBody, err := json.Marshal(dto.CreateIssueRequest{
+		Title:       "One issue too many",
+		Description: "The namespace is full",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-gamma",
+		Scope: dto.ScopeReqBody{
+			ResourceType: "component",
+			ResourceName: "test-component",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "maximum number of active issues") {
+		t.Errorf("expected the limit to be explained, got %s", w.Body.String())
+	}
+}
+
+const testQuickCreateTemplates = `
+quota-exceeded:
+  title: "Quota exceeded for {{.Scope.ResourceName}}"
+  description: "The {{.Namespace}} namespace ran out of quota."
+  severity: major
+  issueType: build
+  links:
+    - title: Quota runbook
+      url: https://runbooks.example.com/quota
+      primary: true
+`
+
+func TestParseQuickCreateTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		expectErr bool
+	}{
+		{
+			name:   "no templates",
+			source: "",
+		},
+		{
+			name:   "valid YAML",
+			source: testQuickCreateTemplates,
+		},
+		{
+			name:   "valid JSON",
+			source: `{"flaky-test": {"title": "Flaky test", "description": "Retried", "severity": "minor", "issueType": "test"}}`,
+		},
+		{
+			name:      "missing title",
+			source:    `{"flaky-test": {"description": "Retried", "severity": "minor", "issueType": "test"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid severity",
+			source:    `{"flaky-test": {"title": "Flaky test", "description": "Retried", "severity": "urgent", "issueType": "test"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid issue type",
+			source:    `{"flaky-test": {"title": "Flaky test", "description": "Retried", "severity": "minor", "issueType": "flake"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "unknown field",
+			source:    `{"flaky-test": {"title": "Flaky test", "description": "Retried", "severity": "minor", "issueType": "test", "state": "RESOLVED"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "unknown request field",
+			source:    `{"flaky-test": {"title": "{{.PipelineName}}", "description": "Retried", "severity": "minor", "issueType": "test"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "name not usable in URLs",
+			source:    `{"flaky/test": {"title": "Flaky test", "description": "Retried", "severity": "minor", "issueType": "test"}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuickCreateTemplates(tt.source)
+			if tt.expectErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CreateIssueFromTemplate(t *testing.T) {
+	templates, err := ParseQuickCreateTemplates(testQuickCreateTemplates)
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		template       string
+		body           map[string]any
+		expectedStatus int
+		expected       dto.CreateIssueRequest
+	}{
+		{
+			name:     "template fields are applied",
+			template: "quota-exceeded",
+			body: map[string]any{
+				"namespace": "team-gamma",
+				"scope":     map[string]string{"resourceType": "component", "resourceName": "frontend"},
+			},
+			expectedStatus: net_http.StatusCreated,
+			expected: dto.CreateIssueRequest{
+				Title:       "Quota exceeded for frontend",
+				Description: "The team-gamma namespace ran out of quota.",
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Links: []dto.CreateLinkRequest{
+					{Title: "Quota runbook", URL: "https://runbooks.example.com/quota", Primary: true},
+				},
+			},
+		},
+		{
+			name:     "template fields are overridden",
+			template: "quota-exceeded",
+			body: map[string]any{
+				"namespace": "team-gamma",
+				"scope":     map[string]string{"resourceType": "component", "resourceName": "frontend"},
+				"title":     "Storage quota exceeded",
+				"severity":  "critical",
+				"links":     []map[string]string{{"title": "Dashboard", "url": "https://grafana.example.com/quota"}},
+			},
+			expectedStatus: net_http.StatusCreated,
+			expected: dto.CreateIssueRequest{
+				Title:       "Storage quota exceeded",
+				Description: "The team-gamma namespace ran out of quota.",
+				Severity:    models.SeverityCritical,
+				IssueType:   models.IssueTypeBuild,
+				Links: []dto.CreateLinkRequest{
+					{Title: "Dashboard", URL: "https://grafana.example.com/quota"},
+				},
+			},
+		},
+		{
+			name:     "overrides are validated",
+			template: "quota-exceeded",
+			body: map[string]any{
+				"namespace": "team-gamma",
+				"scope":     map[string]string{"resourceType": "component", "resourceName": "frontend"},
+				"severity":  "urgent",
+			},
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:     "missing scope",
+			template: "quota-exceeded",
+			body: map[string]any{
+				"namespace": "team-gamma",
+			},
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:     "unknown template",
+			template: "disk-full",
+			body: map[string]any{
+				"namespace": "team-gamma",
+				"scope":     map[string]string{"resourceType": "component", "resourceName": "frontend"},
+			},
+			expectedStatus: net_http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createIssueResult: &models.Issue{ID: "new-issue-abc"}}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logger, WithQuickCreateTemplates(templates)))
+
+			reqBody, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/from-template/"+tt.template, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusCreated {
+				return
+			}
+
+			got := mockService.createIssueRequest
+			if got.Title != tt.expected.Title || got.Description != tt.expected.Description {
+				t.Errorf("expected title %q and description %q, got %q and %q",
+					tt.expected.Title, tt.expected.Description, got.Title, got.Description)
+			}
+			if got.Severity != tt.expected.Severity || got.IssueType != tt.expected.IssueType {
+				t.Errorf("expected %s %s issue, got %s %s", tt.expected.Severity, tt.expected.IssueType, got.Severity, got.IssueType)
+			}
+			if got.Namespace != "team-gamma" || got.Scope.ResourceName != "frontend" {
+				t.Errorf("expected the namespace and scope of the request, got %s and %+v", got.Namespace, got.Scope)
+			}
+			if !slices.Equal(got.Links, tt.expected.Links) {
+				t.Errorf("expected links %+v, got %+v", tt.expected.Links, got.Links)
+			}
+		})
+	}
+}
+
 func TestIssueHandler_CreateIssue_InvalidRequest(t *testing.T) {
 	mockService := &MockIssueService{}
 	handler := setupTestIssueHandler(mockService)
@@ -284,6 +703,235 @@ func TestIssueHandler_CreateIssue_InvalidRequest(t *testing.T) {
 	}
 }
 
+func TestIssueHandler_CreateIssue_StrictJSON(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	// "titel" is a typo of "title", which is still set so the request is valid otherwise
+	body := `{
+		"title": "Build failed",
+		"titel": "Build failed",
+		"description": "The build step exited with code 1",
+		"severity": "major",
+		"issueType": "build",
+		"namespace": "team-alpha",
+		"scope": {"resourceType": "component", "resourceName": "frontend", "resourceNamespace": "team-alpha"}
+	}`
+
+	tests := []struct {
+		name           string
+		strict         bool
+		body           string
+		expectedStatus int
+	}{
+		{name: "lenient ignores unknown fields", strict: false, body: body, expectedStatus: net_http.StatusCreated},
+		{name: "strict rejects unknown fields", strict: true, body: body, expectedStatus: net_http.StatusBadRequest},
+		{
+			name:           "strict accepts known fields",
+			strict:         true,
+			body:           strings.Replace(body, `"titel": "Build failed",`, "", 1),
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "strict rejects trailing data",
+			strict:         true,
+			body:           strings.Replace(body, `"titel": "Build failed",`, "", 1) + `{"title": "Another"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "strict still validates fields",
+			strict:         true,
+			body:           `{"title": "Build failed"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createIssueResult: &models.Issue{ID: "new-issue-abc", Title: "Build failed"},
+			}
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logger, WithStrictJSON(tt.strict)))
+
+			req := net_httptest.NewRequest("POST", "/api/v1/issues", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if w.Code == net_http.StatusCreated && mockService.createIssueRequest.Title != "Build failed" {
+				t.Errorf("expected the issue to be created with its title, got %q", mockService.createIssueRequest.Title)
+			}
+			if tt.strict && tt.expectedStatus == net_http.StatusBadRequest && strings.Contains(tt.body, "titel") &&
+				!strings.Contains(w.Body.String(), "titel") {
+				t.Errorf("expected the unknown field in the error, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CreateIssue_Scope(t *testing.T) {
+	tests := []struct {
+		name           string
+		scope          dto.ScopeReqBody
+		expectedStatus int
+		expectedField  string
+		expectedScope  dto.ScopeReqBody
+	}{
+		{
+			name:           "trimmed",
+			scope:          dto.ScopeReqBody{ResourceType: " component ", ResourceName: "frontend\n", ResourceNamespace: " team-alpha"},
+			expectedStatus: net_http.StatusCreated,
+			expectedScope:  dto.ScopeReqBody{ResourceType: "component", ResourceName: "frontend", ResourceNamespace: "team-alpha"},
+		},
+		{
+			name:           "whitespace-only resource type",
+			scope:          dto.ScopeReqBody{ResourceType: "   ", ResourceName: "frontend"},
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "scope.resourceType",
+		},
+		{
+			name:           "whitespace-only resource name",
+			scope:          dto.ScopeReqBody{ResourceType: "component", ResourceName: "\t "},
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "scope.resourceName",
+		},
+		{
+			name:           "empty resource name",
+			scope:          dto.ScopeReqBody{ResourceType: "component"},
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "resource name too long",
+			scope:          dto.ScopeReqBody{ResourceType: "component", ResourceName: strings.Repeat("f", DefaultMaxScopeFieldLength+1)},
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "scope.resourceName",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createIssueResult: &models.Issue{ID: "created-abc"}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			reqBody, err := json.Marshal(dto.CreateIssueRequest{
+				Title:       "Build failed",
+				Description: "The build of the frontend failed",
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Namespace:   "team-alpha",
+				Scope:       tt.scope,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues?namespace=team-alpha", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusCreated {
+				if mockService.createIssueRequest.Scope != tt.expectedScope {
+					t.Errorf("expected scope %+v, got %+v", tt.expectedScope, mockService.createIssueRequest.Scope)
+				}
+				return
+			}
+			if mockService.createIssueRequest.Title != "" {
+				t.Error("expected no issue created")
+			}
+			if tt.expectedField == "" {
+				return
+			}
+
+			var response struct {
+				Code   string           `json:"code"`
+				Fields []dto.FieldError `json:"fields"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Code != dto.ErrorCodeValidationFailed || len(response.Fields) != 1 || response.Fields[0].Field != tt.expectedField {
+				t.Errorf("expected a validation error on %s, got %s", tt.expectedField, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CreateIssue_CommitContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		commit         dto.CommitContext
+		expectedStatus int
+	}{
+		{
+			name:           "commit on GitHub",
+			commit:         dto.CommitContext{CommitSHA: "3f2a1c9", RepoURL: "https://github.com/org/frontend", Branch: "main"},
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "SSH remote",
+			commit:         dto.CommitContext{CommitSHA: "3f2a1c9", RepoURL: "git@github.com:org/frontend.git"},
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "invalid commit SHA",
+			commit:         dto.CommitContext{CommitSHA: "HEAD~1", RepoURL: "https://github.com/org/frontend"},
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid repository URL",
+			commit:         dto.CommitContext{CommitSHA: "3f2a1c9", RepoURL: "ftp://example.com/frontend"},
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createIssueResult: &models.Issue{ID: "created-abc"}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			reqBody, err := json.Marshal(dto.CreateIssueRequest{
+				Title:         "Build failed",
+				Description:   "The build of the frontend failed",
+				Severity:      models.SeverityMajor,
+				IssueType:     models.IssueTypeBuild,
+				Namespace:     "team-alpha",
+				Scope:         dto.ScopeReqBody{ResourceType: "component", ResourceName: "frontend"},
+				CommitContext: tt.commit,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues?namespace=team-alpha", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusCreated && mockService.createIssueRequest.CommitContext != tt.commit {
+				t.Errorf("expected commit context %+v, got %+v", tt.commit, mockService.createIssueRequest.CommitContext)
+			}
+		})
+	}
+}
+
 func TestIssueHandler_DeleteIssue_Success(t *testing.T) {
 	mockIssue := &models.Issue{
 		ID:        "delete-test-abc",
@@ -338,7 +986,8 @@ func TestIssueHandler_ResolveIssue(t *testing.T) {
 		updateIssueResult:   resolvedIssue,
 	}
 
-	handler := setupTestIssueHandler(mockService)
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	handler := NewIssueHandler(mockService, logrus.New(), WithIssueClock(testhelpers.NewFakeClock(now)))
 	router := setupTestIssueRouter(handler)
 
 	req, err := net_http.NewRequest("POST", "/api/v1/issues/resolve-test-abc/resolve", nil)
@@ -363,4 +1012,2145 @@ func TestIssueHandler_ResolveIssue(t *testing.T) {
 	if response.State != models.IssueStateResolved {
 		t.Errorf("expeted state 'RESOLVED', got '%s'", response.State)
 	}
+	if resolvedAt := mockService.updateIssueRequest.ResolvedAt; !resolvedAt.Equal(now) {
+		t.Errorf("expected resolvedAt %v, got %v", now, resolvedAt)
+	}
+}
+
+func TestIssueHandler_BatchAddRelatedIssues(t *testing.T) {
+	mockService := &MockIssueService{
+		batchAddRelatedIssuesResult: []dto.RelationshipEdgeResult{
+			{SourceID: "abc-1", TargetID: "abc-2", Kind: models.RelationshipCausedBy, Status: dto.RelationshipEdgeCreated},
+			{SourceID: "abc-1", TargetID: "abc-3", Kind: models.RelationshipRelatesTo, Status: dto.RelationshipEdgeSkipped},
+			{SourceID: "abc-3", TargetID: "abc-3", Kind: models.RelationshipRelatesTo, Status: dto.RelationshipEdgeError, Error: "an issue cannot be related to itself"},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	edges := []dto.RelationshipEdgeRequest{
+		{SourceID: "abc-1", TargetID: "abc-2", Kind: models.RelationshipCausedBy},
+		{SourceID: "abc-1", TargetID: "abc-3"},
+		{SourceID: "abc-3", TargetID: "abc-3"},
+	}
+	reqBody, err := json.Marshal(edges)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/relationships/batch", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Results []dto.RelationshipEdgeResult `json:"results"`
+		Created int                          `json:"created"`
+		Skipped int                          `json:"skipped"`
+		Errors  int                          `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.Results) != 3 {
+		t.Errorf("expected 3 results, got %d", len(response.Results))
+	}
+	if response.Created != 1 || response.Skipped != 1 || response.Errors != 1 {
+		t.Errorf("expected 1 created, 1 skipped and 1 error, got %d, %d and %d", response.Created, response.Skipped, response.Errors)
+	}
+}
+
+func TestIssueHandler_BatchAddRelatedIssues_ForeignNamespace(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssuesByIDsResult: []models.Issue{
+			{ID: "abc-1", Namespace: "team-alpha"},
+			{ID: "abc-2", Namespace: "team-beta"},
+			{ID: "abc-3", Namespace: "team-alpha"},
+		},
+		batchAddRelatedIssuesResult: []dto.RelationshipEdgeResult{
+			{SourceID: "abc-1", TargetID: "abc-3", Kind: models.RelationshipRelatesTo, Status: dto.RelationshipEdgeCreated},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	reqBody := []byte(`[
+		{"sourceId": "abc-1", "targetId": "abc-2", "kind": "CAUSED_BY"},
+		{"sourceId": "abc-1", "targetId": "abc-3"}
+	]`)
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/relationships/batch?namespace=team-alpha", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Results []dto.RelationshipEdgeResult `json:"results"`
+		Created int                          `json:"created"`
+		Errors  int                          `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Results) != 2 || response.Created != 1 || response.Errors != 1 {
+		t.Fatalf("expected 1 created and 1 error, got %+v", response)
+	}
+	if result := response.Results[0]; result.TargetID != "abc-2" || result.Status != dto.RelationshipEdgeError {
+		t.Errorf("expected the edge to the foreign issue to be rejected, got %+v", result)
+	}
+	if result := response.Results[1]; result.TargetID != "abc-3" || result.Status != dto.RelationshipEdgeCreated {
+		t.Errorf("expected the edge within the namespace to be created, got %+v", result)
+	}
+	if len(mockService.batchAddRelatedIssuesRequest) != 1 || mockService.batchAddRelatedIssuesRequest[0].TargetID != "abc-3" {
+		t.Errorf("expected only the edge within the namespace to be created, got %+v", mockService.batchAddRelatedIssuesRequest)
+	}
+}
+
+func TestIssueHandler_BatchAddRelatedIssues_UnknownIssue(t *testing.T) {
+	mockService := &MockIssueService{
+		batchAddRelatedIssuesError: fmt.Errorf("%w: abc-9", repository.ErrIssuesNotFound),
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	reqBody := []byte(`[{"sourceId": "abc-1", "targetId": "abc-9"}]`)
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/relationships/batch", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_BatchAddRelatedIssues_EmptyBatch(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/relationships/batch", bytes.NewBufferString(`[]`))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_RemoveAllRelatedIssues(t *testing.T) {
+	tests := []struct {
+		name            string
+		mockService     *MockIssueService
+		expectedStatus  int
+		expectedRemoved int64
+	}{
+		{
+			name: "relationships removed",
+			mockService: &MockIssueService{
+				findIssueByIDResult:    &models.Issue{ID: "abc-1", Namespace: "team-alpha"},
+				removeAllRelatedResult: 3,
+			},
+			expectedStatus:  net_http.StatusOK,
+			expectedRemoved: 3,
+		},
+		{
+			name:           "issue not found",
+			mockService:    &MockIssueService{},
+			expectedStatus: net_http.StatusNotFound,
+		},
+		{
+			name: "issue in another namespace",
+			mockService: &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "abc-1", Namespace: "team-beta"},
+			},
+			expectedStatus: net_http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestIssueRouter(setupTestIssueHandler(tt.mockService))
+
+			req, err := net_http.NewRequest("DELETE", "/api/v1/issues/abc-1/related?namespace=team-alpha", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Removed int64 `json:"removed"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Removed != tt.expectedRemoved {
+				t.Errorf("expected %d relationships removed, got %d", tt.expectedRemoved, response.Removed)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssueGraph(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueByIDResult: &models.Issue{ID: "abc-1", Namespace: "team-alpha"},
+		findIssueGraphResult: &dto.IssueGraph{
+			RootID:    "abc-1",
+			Nodes:     []dto.GraphNode{{ID: "abc-1"}, {ID: "abc-2"}},
+			Edges:     []dto.GraphEdge{{ID: "edge-1", SourceID: "abc-1", TargetID: "abc-2"}},
+			Truncated: true,
+		},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	router := setupTestIssueRouter(NewIssueHandler(mockService, logger, WithMaxGraphSize(2)))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/abc-1/graph?namespace=team-alpha", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if mockService.findIssueGraphMaxSize != 2 {
+		t.Errorf("expected the configured graph size to be passed, got %d", mockService.findIssueGraphMaxSize)
+	}
+
+	var graph dto.IssueGraph
+	if err := json.Unmarshal(w.Body.Bytes(), &graph); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !graph.Truncated || len(graph.Nodes) != 2 || len(graph.Edges) != 1 {
+		t.Errorf("expected the truncated graph, got %+v", graph)
+	}
+}
+
+func TestIssueHandler_BatchGetIssues(t *testing.T) {
+	const (
+		firstID   = "11111111-1111-4111-8111-111111111111"
+		secondID  = "22222222-2222-4222-8222-222222222222"
+		otherID   = "33333333-3333-4333-8333-333333333333"
+		missingID = "44444444-4444-4444-8444-444444444444"
+	)
+	mockService := &MockIssueService{
+		findIssuesByIDsResult: []models.Issue{
+			{ID: secondID, Title: "Second", Namespace: "team-alpha"},
+			{ID: otherID, Title: "Other namespace", Namespace: "team-beta"},
+			{ID: firstID, Title: "First", Namespace: "team-alpha"},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	reqBody := fmt.Sprintf(`{"ids": [%q, %q, %q, %q, %q]}`, firstID, missingID, otherID, secondID, firstID)
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/batch-get?namespace=team-alpha", bytes.NewBufferString(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response dto.BatchGetResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	// Duplicate IDs are fetched once
+	expectedRequest := []string{firstID, missingID, otherID, secondID}
+	if !slices.Equal(mockService.findIssuesByIDsRequest, expectedRequest) {
+		t.Errorf("expected IDs %v to be fetched, got %v", expectedRequest, mockService.findIssuesByIDsRequest)
+	}
+	// Issues come in the requested order, issues of other namespaces are missing
+	var titles []string
+	for _, issue := range response.Data {
+		titles = append(titles, issue.Title)
+	}
+	if !slices.Equal(titles, []string{"First", "Second"}) {
+		t.Errorf("expected issues [First Second], got %v", titles)
+	}
+	if !slices.Equal(response.Missing, []string{missingID, otherID}) {
+		t.Errorf("expected missing IDs %v, got %v", []string{missingID, otherID}, response.Missing)
+	}
+}
+
+func TestIssueHandler_BatchGetIssues_Validation(t *testing.T) {
+	tooMany := make([]string, maxBatchGetSize+1)
+	for idx := range tooMany {
+		tooMany[idx] = fmt.Sprintf("%q", uuid.NewString())
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing ids", `{}`},
+		{"no ids", `{"ids": []}`},
+		{"invalid id", `{"ids": ["11111111-1111-4111-8111-111111111111", "abc-1"]}`},
+		{"too many ids", `{"ids": [` + strings.Join(tooMany, ",") + `]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/batch-get", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+			if mockService.findIssuesByIDsRequest != nil {
+				t.Errorf("expected no issues to be fetched, got %v", mockService.findIssuesByIDsRequest)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_BulkDeleteIssues(t *testing.T) {
+	mockService := &MockIssueService{
+		bulkDeleteIssuesResult: []dto.BulkIssueResult{
+			{ID: "abc-1", Status: dto.BulkIssueDeleted},
+			{ID: "abc-2", Status: dto.BulkIssueError, Error: "issue not found"},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	reqBody := []byte(`{"ids": ["abc-1", "abc-2"]}`)
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/bulk-delete?namespace=team-alpha", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Results []dto.BulkIssueResult `json:"results"`
+		Deleted int                   `json:"deleted"`
+		Errors  int                   `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.Results) != 2 || response.Deleted != 1 || response.Errors != 1 {
+		t.Errorf("expected 1 deleted and 1 error, got %+v", response)
+	}
+}
+
+func TestIssueHandler_BulkDeleteIssues_TooMany(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	ids := make([]string, maxBulkDeleteSize+1)
+	for idx := range ids {
+		ids[idx] = fmt.Sprintf("abc-%d", idx)
+	}
+	reqBody, err := json.Marshal(dto.BulkIssueRequest{IDs: ids})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/bulk-delete", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_ResolveIssue_Cascade(t *testing.T) {
+	resolvedIssue := &models.Issue{
+		ID:        "resolve-test-abc",
+		Title:     "Root cause",
+		State:     models.IssueStateResolved,
+		Namespace: "team-resolved",
+	}
+
+	mockService := &MockIssueService{
+		findIssueByIDResult:      resolvedIssue,
+		resolveWithCascadeResult: []string{"caused-abc"},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/resolve-test-abc/resolve?cascade=true", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Issue           models.Issue `json:"issue"`
+		CascadeResolved []string     `json:"cascadeResolved"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Issue.ID != resolvedIssue.ID {
+		t.Errorf("expected issue '%s', got '%s'", resolvedIssue.ID, response.Issue.ID)
+	}
+	if len(response.CascadeResolved) != 1 || response.CascadeResolved[0] != "caused-abc" {
+		t.Errorf("expected cascade to resolve 'caused-abc', got %v", response.CascadeResolved)
+	}
+}
+
+func TestIssueHandler_GetIssues_MultipleNamespaces(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	tests := []struct {
+		name               string
+		allowedNamespaces  []string
+		expectedStatus     int
+		expectedNamespaces []string
+	}{
+		{
+			name:               "forbidden namespaces are filtered out",
+			allowedNamespaces:  []string{"team-a"},
+			expectedStatus:     net_http.StatusOK,
+			expectedNamespaces: []string{"team-a"},
+		},
+		{
+			name:               "all namespaces allowed",
+			allowedNamespaces:  []string{"team-a", "team-b"},
+			expectedStatus:     net_http.StatusOK,
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name:           "no namespace allowed",
+			expectedStatus: net_http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueResults: &dto.IssueResponse{Data: []models.Issue{}},
+			}
+			handler := setupTestIssueHandler(mockService)
+
+			checker := newFakeAccessChecker(logger, tt.allowedNamespaces...)
+			router := setupTestAuthenticatedRouter()
+			router.GET("/api/v1/issues", checker.CheckNamespacessAccess("/api/v1/issues"), handler.GetIssues)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-a&namespace=team-b", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			filters := mockService.findIssuesFilters
+			got := filters.Namespaces
+			if filters.Namespace != "" {
+				got = []string{filters.Namespace}
+			}
+			if len(got) != len(tt.expectedNamespaces) {
+				t.Fatalf("expected namespaces %v, got %v", tt.expectedNamespaces, got)
+			}
+			for idx, namespace := range got {
+				if namespace != tt.expectedNamespaces[idx] {
+					t.Errorf("expected namespaces %v, got %v", tt.expectedNamespaces, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AddIssueLink(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueByIDResult: &models.Issue{ID: "link-test-abc", Namespace: "team-alpha"},
+		addIssueLinkResult: &models.Link{
+			ID:      "link-abc",
+			Title:   "Logs",
+			URL:     "https://konflux.test/logs",
+			IssueID: "link-test-abc",
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"valid link", `{"title": "Logs", "url": "https://konflux.test/logs"}`, net_http.StatusCreated},
+		{"missing scheme", `{"title": "Logs", "url": "konflux.test/logs"}`, net_http.StatusBadRequest},
+		{"unsupported scheme", `{"title": "Logs", "url": "ftp://konflux.test/logs"}`, net_http.StatusBadRequest},
+		{"missing url", `{"title": "Logs"}`, net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/link-test-abc/links", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AllowedLinkDomains(t *testing.T) {
+	allowlist, err := linkdomain.New([]string{"konflux.test"})
+	if err != nil {
+		t.Fatalf("Failed to parse allowlist: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "create with an allowed link",
+			method:         "POST",
+			path:           "/api/v1/issues",
+			body:           `{"title": "Build failed", "description": "Failed", "severity": "major", "issueType": "build", "namespace": "team-alpha", "scope": {"resourceType": "component", "resourceName": "frontend"}, "links": [{"title": "Logs", "url": "https://console.konflux.test/logs"}]}`,
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "create with a disallowed link",
+			method:         "POST",
+			path:           "/api/v1/issues",
+			body:           `{"title": "Build failed", "description": "Failed", "severity": "major", "issueType": "build", "namespace": "team-alpha", "scope": {"resourceType": "component", "resourceName": "frontend"}, "links": [{"title": "Logs", "url": "https://phishing.example/logs"}]}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "update with a disallowed link",
+			method:         "PUT",
+			path:           "/api/v1/issues/link-test-abc",
+			body:           `{"links": [{"title": "Logs", "url": "https://intranet.corp/logs"}]}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "add an allowed link",
+			method:         "POST",
+			path:           "/api/v1/issues/link-test-abc/links",
+			body:           `{"title": "Logs", "url": "https://konflux.test/logs"}`,
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "add a disallowed link",
+			method:         "POST",
+			path:           "/api/v1/issues/link-test-abc/links",
+			body:           `{"title": "Logs", "url": "https://konflux.test.phishing.example/logs"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "update to a disallowed link",
+			method:         "PUT",
+			path:           "/api/v1/issues/link-test-abc/links/link-abc",
+			body:           `{"title": "Logs", "url": "https://intranet.corp/logs"}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createIssueResult:   &models.Issue{ID: "created-abc"},
+				findIssueByIDResult: &models.Issue{ID: "link-test-abc", Namespace: "team-alpha"},
+				addIssueLinkResult:  &models.Link{ID: "link-abc", IssueID: "link-test-abc"},
+			}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logger, WithAllowedLinkDomains(allowlist)))
+
+			req, err := net_http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusBadRequest && !strings.Contains(w.Body.String(), "link domain not allowed") {
+				t.Errorf("expected the domain to be reported as not allowed, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIssueHandler_CustomFields(t *testing.T) {
+	schemas, err := customfields.Parse(`
+build:
+  buildNumber:
+    type: integer
+    required: true
+  pipeline:
+    type: string
+`)
+	if err != nil {
+		t.Fatalf("failed to parse custom fields: %v", err)
+	}
+
+	const createBody = `{"title": "Build failed", "description": "Failed", "severity": "major", "issueType": "%s", "namespace": "team-alpha", "scope": {"resourceType": "component", "resourceName": "frontend"}%s}`
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{
+			name:           "create with the required field",
+			method:         "POST",
+			body:           fmt.Sprintf(createBody, "build", `, "customFields": {"buildNumber": 42, "pipeline": "on-push"}`),
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "create without the required field",
+			method:         "POST",
+			body:           fmt.Sprintf(createBody, "build", `, "customFields": {"pipeline": "on-push"}`),
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "customFields.buildNumber",
+		},
+		{
+			name:           "create without custom fields",
+			method:         "POST",
+			body:           fmt.Sprintf(createBody, "build", ""),
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "customFields.buildNumber",
+		},
+		{
+			name:           "create with a field of the wrong type",
+			method:         "POST",
+			body:           fmt.Sprintf(createBody, "build", `, "customFields": {"buildNumber": "42"}`),
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "customFields.buildNumber",
+		},
+		{
+			name:           "create a type without custom fields",
+			method:         "POST",
+			body:           fmt.Sprintf(createBody, "test", ""),
+			expectedStatus: net_http.StatusCreated,
+		},
+		{
+			name:           "update the custom fields",
+			method:         "PUT",
+			body:           `{"customFields": {"buildNumber": 43}}`,
+			expectedStatus: net_http.StatusOK,
+		},
+		{
+			name:           "update without the required field",
+			method:         "PUT",
+			body:           `{"customFields": {"pipeline": "on-push"}}`,
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "customFields.buildNumber",
+		},
+		{
+			name:           "update to a type the custom fields aren't defined for",
+			method:         "PUT",
+			body:           `{"issueType": "test"}`,
+			expectedStatus: net_http.StatusBadRequest,
+			expectedField:  "customFields.buildNumber",
+		},
+		{
+			name:           "update other fields",
+			method:         "PUT",
+			body:           `{"title": "Build still failing"}`,
+			expectedStatus: net_http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &models.Issue{ID: "custom-abc", Namespace: "team-alpha", IssueType: models.IssueTypeBuild, CustomFields: map[string]any{"buildNumber": float64(42)}}
+			mockService := &MockIssueService{
+				createIssueResult:   &models.Issue{ID: "created-abc"},
+				findIssueByIDResult: existing,
+				updateIssueResult:   existing,
+			}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logger, WithCustomFieldSchemas(schemas)))
+
+			path := "/api/v1/issues"
+			if tt.method == "PUT" {
+				path += "/custom-abc"
+			}
+			req, err := net_http.NewRequest(tt.method, path, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedField == "" {
+				return
+			}
+			var response struct {
+				Code   string           `json:"code"`
+				Fields []dto.FieldError `json:"fields"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode the response: %v", err)
+			}
+			if response.Code != dto.ErrorCodeValidationFailed || len(response.Fields) != 1 || response.Fields[0].Field != tt.expectedField {
+				t.Errorf("expected %s to be reported invalid, got %s", tt.expectedField, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestIssueHandler_AddIssueAttachment(t *testing.T) {
+	tests := []struct {
+		name                string
+		body                string
+		addError            error
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{"valid attachment", `{"name": "build.log", "contentType": "text/plain; charset=utf-8", "size": 1024, "storageUrl": "s3://kite-attachments/build.log"}`, nil, net_http.StatusCreated, "text/plain"},
+		{"allowed subtype", `{"name": "screenshot.png", "contentType": "Image/PNG", "size": 1024, "storageUrl": "https://storage.konflux.test/screenshot.png"}`, nil, net_http.StatusCreated, "image/png"},
+		{"exact type", `{"name": "report.json", "contentType": "application/json", "size": 1024, "storageUrl": "https://storage.konflux.test/report.json"}`, nil, net_http.StatusCreated, "application/json"},
+		{"content type not allowed", `{"name": "tool.exe", "contentType": "application/x-msdownload", "size": 1024, "storageUrl": "s3://kite-attachments/tool.exe"}`, nil, net_http.StatusBadRequest, ""},
+		{"invalid content type", `{"name": "build.log", "contentType": "text", "size": 1024, "storageUrl": "s3://kite-attachments/build.log"}`, nil, net_http.StatusBadRequest, ""},
+		{"too large", `{"name": "build.log", "contentType": "text/plain", "size": 2048, "storageUrl": "s3://kite-attachments/build.log"}`, nil, net_http.StatusBadRequest, ""},
+		{"negative size", `{"name": "build.log", "contentType": "text/plain", "size": -1, "storageUrl": "s3://kite-attachments/build.log"}`, nil, net_http.StatusBadRequest, ""},
+		{"unsupported scheme", `{"name": "build.log", "contentType": "text/plain", "size": 1024, "storageUrl": "file:///tmp/build.log"}`, nil, net_http.StatusBadRequest, ""},
+		{"missing storage url", `{"name": "build.log", "contentType": "text/plain", "size": 1024}`, nil, net_http.StatusBadRequest, ""},
+		{"issue not found", `{"name": "build.log", "contentType": "text/plain", "size": 1024, "storageUrl": "s3://kite-attachments/build.log"}`, repository.ErrIssueNotFound, net_http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "attachment-test-abc", Namespace: "team-alpha"},
+				addAttachmentResult: &models.Attachment{ID: "attachment-abc", IssueID: "attachment-test-abc"},
+				addAttachmentError:  tt.addError,
+			}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewIssueHandler(mockService, logger, WithAttachmentLimits([]string{"text/*", "image/*", "application/json"}, 1024))
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("POST", "/api/v1/issues/attachment-test-abc/attachments", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedContentType != "" && mockService.addAttachmentReq.ContentType != tt.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tt.expectedContentType, mockService.addAttachmentReq.ContentType)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssueAttachments(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueByIDResult: &models.Issue{ID: "attachment-test-abc", Namespace: "team-alpha"},
+		findAttachmentsResult: []models.Attachment{
+			{ID: "attachment-abc", IssueID: "attachment-test-abc", Name: "build.log", StorageURL: "s3://kite-attachments/build.log"},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/attachment-test-abc/attachments", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var response struct {
+		Data []models.Attachment `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].StorageURL != "s3://kite-attachments/build.log" {
+		t.Errorf("expected the attachments of the issue, got %+v", response.Data)
+	}
+
+	// Attachments of issues in other namespaces are denied
+	req, err = net_http.NewRequest("GET", "/api/v1/issues/attachment-test-abc/attachments?namespace=team-beta", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_UpdateIssueLink_MultiplePrimary(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueByIDResult:  &models.Issue{ID: "link-test-abc", Namespace: "team-alpha"},
+		updateIssueLinkError: repository.ErrMultiplePrimaryLinks,
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	reqBody := []byte(`{"title": "Logs", "url": "https://konflux.test/logs", "primary": true}`)
+	req, err := net_http.NewRequest("PUT", "/api/v1/issues/link-test-abc/links/link-abc", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_DeleteIssueLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteError    error
+		namespace      string
+		expectedStatus int
+	}{
+		{"deleted", nil, "", net_http.StatusNoContent},
+		{"link not found", repository.ErrLinkNotFound, "", net_http.StatusNotFound},
+		{"other namespace", nil, "team-beta", net_http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult:  &models.Issue{ID: "link-test-abc", Namespace: "team-alpha"},
+				deleteIssueLinkError: tt.deleteError,
+			}
+
+			handler := setupTestIssueHandler(mockService)
+			router := setupTestIssueRouter(handler)
+
+			url := "/api/v1/issues/link-test-abc/links/link-abc"
+			if tt.namespace != "" {
+				url += "?namespace=" + tt.namespace
+			}
+			req, err := net_http.NewRequest("DELETE", url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_SearchIssues(t *testing.T) {
+	mockService := &MockIssueService{
+		searchIssuesResult: []dto.SearchResult{
+			{
+				Issue:      models.Issue{ID: "abc-1", Title: "Timeout in build", Namespace: "team-alpha"},
+				Score:      0.6,
+				Highlights: []string{"<mark>Timeout</mark> in build"},
+			},
+		},
+	}
+
+	handler := setupTestIssueHandler(mockService)
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/search?q=timeout&namespace=team-alpha&limit=500", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data []dto.SearchResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].Highlights[0] != "<mark>Timeout</mark> in build" {
+		t.Errorf("unexpected results %+v", response.Data)
+	}
+
+	// The limit is capped by the repository
+	filters := mockService.searchIssuesFilters
+	if filters.Search != "timeout" || filters.Namespace != "team-alpha" || filters.Limit != 500 {
+		t.Errorf("unexpected filters %+v", filters)
+	}
+}
+
+func TestIssueHandler_SearchIssues_MissingQuery(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/search?q=%20", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_PageSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedLimit int
+	}{
+		{"default applied", "", 10},
+		{"invalid limit", "&limit=abc", 10},
+		{"explicit limit", "&limit=15", 15},
+		{"over max limit", "&limit=1000", 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewIssueHandler(mockService, logger, WithPageSize(10, 25))
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+			if mockService.findIssuesFilters.Limit != tt.expectedLimit {
+				t.Errorf("expected limit %d, got %d", tt.expectedLimit, mockService.findIssuesFilters.Limit)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_MaxOffset(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxOffset      int
+		query          string
+		expectedStatus int
+		expectedOffset int
+	}{
+		{"within range", 100, "&offset=40", net_http.StatusOK, 40},
+		{"at the maximum", 100, "&offset=100", net_http.StatusOK, 100},
+		{"over the maximum", 100, "&offset=101", net_http.StatusBadRequest, 0},
+		{"limit disabled", 0, "&offset=5000000", net_http.StatusOK, 5000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewIssueHandler(mockService, logger, WithMaxOffset(tt.maxOffset))
+			router := setupTestIssueRouter(handler)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if mockService.findIssuesFilters.Offset != tt.expectedOffset {
+				t.Errorf("expected offset %d, got %d", tt.expectedOffset, mockService.findIssuesFilters.Offset)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetGroupedIssues(t *testing.T) {
+	mockService := &MockIssueService{
+		groupIssuesResult: []dto.IssueGroup{
+			{
+				Resource:    dto.GroupedResource{Type: "component", Name: "frontend", Namespace: "team-alpha"},
+				Issues:      []models.Issue{{ID: "abc-1", Namespace: "team-alpha", Severity: models.SeverityMajor}},
+				ActiveCount: 1,
+				MaxSeverity: models.SeverityMajor,
+			},
+		},
+	}
+
+	handler := NewIssueHandler(mockService, logrus.New(), WithMaxGroups(5))
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/grouped?namespace=team-alpha&groupBy=resource", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Data []dto.IssueGroup `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].Resource.Name != "frontend" || response.Data[0].MaxSeverity != models.SeverityMajor {
+		t.Errorf("unexpected groups: %+v", response.Data)
+	}
+	if mockService.groupIssuesFilters.Namespace != "team-alpha" {
+		t.Errorf("expected namespace team-alpha, got %q", mockService.groupIssuesFilters.Namespace)
+	}
+	if mockService.groupIssuesMaxGroups != 5 {
+		t.Errorf("expected a limit of 5 groups, got %d", mockService.groupIssuesMaxGroups)
+	}
+}
+
+func TestIssueHandler_GetGroupedIssues_InvalidGroupBy(t *testing.T) {
+	handler := setupTestIssueHandler(&MockIssueService{})
+	router := setupTestIssueRouter(handler)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/grouped?namespace=team-alpha&groupBy=severity", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_PatchIssue(t *testing.T) {
+	const patch = `[{"op":"replace","path":"/severity","value":"critical"}]`
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		patchError     error
+		expectedStatus int
+	}{
+		{"patched", "application/json-patch+json", patch, nil, net_http.StatusOK},
+		{"merge patch", "application/json", patch, nil, net_http.StatusUnsupportedMediaType},
+		{"malformed patch", "application/json-patch+json", `{"op":"replace"}`, nil, net_http.StatusBadRequest},
+		{"disallowed path", "application/json-patch+json", patch, fmt.Errorf("%w: path %q can't be patched", services.ErrInvalidPatch, "/namespace"), net_http.StatusUnprocessableEntity},
+		{"failed test", "application/json-patch+json", patch, services.ErrPatchTestFailed, net_http.StatusConflict},
+		{"open duplicate", "application/json-patch+json", patch, repository.ErrDuplicateOpenIssue, net_http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "abc-1", Namespace: "team-alpha"},
+				patchIssueResult:    &models.Issue{ID: "abc-1", Namespace: "team-alpha", Severity: models.SeverityCritical},
+				patchIssueError:     tt.patchError,
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("PATCH", "/api/v1/issues/abc-1?namespace=team-alpha", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusOK {
+				if len(mockService.patchIssueOps) != 1 || mockService.patchIssueOps[0].Path != "/severity" {
+					t.Errorf("unexpected operations received: %+v", mockService.patchIssueOps)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueHandler_SnoozeIssue(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		namespace       string
+		snoozeError     error
+		expectedStatus  int
+		expectedSnoozed bool
+	}{
+		{"snoozed", "POST", "team-alpha", nil, net_http.StatusOK, true},
+		{"unsnoozed", "DELETE", "team-alpha", nil, net_http.StatusOK, false},
+		{"other namespace", "POST", "team-beta", nil, net_http.StatusForbidden, false},
+		{"deleted meanwhile", "POST", "team-alpha", repository.ErrIssueNotFound, net_http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "abc-1", Namespace: "team-alpha"},
+				snoozeIssueError:    tt.snoozeError,
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest(tt.method, "/api/v1/issues/abc-1/snooze?namespace="+tt.namespace, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusForbidden {
+				if mockService.snoozeIssueSnoozed != nil {
+					t.Error("expected the issue of another namespace not to be snoozed")
+				}
+				return
+			}
+			if mockService.snoozeIssueID != "abc-1" || mockService.snoozeIssueSnoozed == nil || *mockService.snoozeIssueSnoozed != tt.expectedSnoozed {
+				t.Errorf("expected issue abc-1 to be snoozed: %v, got %s snoozed: %v",
+					tt.expectedSnoozed, mockService.snoozeIssueID, mockService.snoozeIssueSnoozed)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var issue models.Issue
+			if err := json.Unmarshal(w.Body.Bytes(), &issue); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if issue.SnoozedUntilRecurrence != tt.expectedSnoozed {
+				t.Errorf("expected snoozedUntilRecurrence %v, got %v", tt.expectedSnoozed, issue.SnoozedUntilRecurrence)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_IncludeSnoozed(t *testing.T) {
+	tests := []struct {
+		query           string
+		expectedStatus  int
+		expectedExclude bool
+	}{
+		{"", net_http.StatusOK, true},
+		{"&includeSnoozed=false", net_http.StatusOK, true},
+		{"&includeSnoozed=true", net_http.StatusOK, false},
+		{"&includeSnoozed=maybe", net_http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueResults: &dto.IssueResponse{Data: []models.Issue{}},
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusOK && mockService.findIssuesFilters.ExcludeSnoozed != tt.expectedExclude {
+				t.Errorf("expected ExcludeSnoozed %v, got %v", tt.expectedExclude, mockService.findIssuesFilters.ExcludeSnoozed)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_K8sList(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockService := &MockIssueService{
+		findIssueResults: &dto.IssueResponse{
+			Data: []models.Issue{
+				{
+					ID:          "issue-1",
+					Title:       "Frontend build failed",
+					Severity:    models.SeverityMajor,
+					IssueType:   models.IssueTypeBuild,
+					State:       models.IssueStateActive,
+					Namespace:   "team-alpha",
+					Scope:       models.IssueScope{ResourceType: "component", ResourceName: "frontend", ResourceNamespace: "team-alpha"},
+					CreatedAt:   createdAt,
+					Description: "Build failed",
+					Environment: "staging",
+					CustomFields: map[string]any{
+						"pipelineRun": "frontend-on-push-x7k2p",
+					},
+				},
+			},
+			Total: 1,
+			Limit: 10,
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&output=k8slist", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if body["apiVersion"] != "kite.konflux.dev/v1" || body["kind"] != "IssueList" {
+		t.Errorf("expected a kite.konflux.dev/v1 IssueList, got %v %v", body["apiVersion"], body["kind"])
+	}
+	metadata, ok := body["metadata"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected list metadata, got %v", body["metadata"])
+	}
+	if _, ok := metadata["continue"]; ok {
+		t.Errorf("expected no continue token on the last page, got %v", metadata["continue"])
+	}
+	if _, ok := body["data"]; ok {
+		t.Error("expected no data field in a list")
+	}
+
+	items, ok := body["items"].([]any)
+	if !ok || len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", body["items"])
+	}
+	item := items[0].(map[string]any)
+	if item["apiVersion"] != "kite.konflux.dev/v1" || item["kind"] != "Issue" {
+		t.Errorf("expected a kite.konflux.dev/v1 Issue, got %v %v", item["apiVersion"], item["kind"])
+	}
+	itemMetadata := item["metadata"].(map[string]any)
+	if itemMetadata["name"] != "issue-1" || itemMetadata["namespace"] != "team-alpha" {
+		t.Errorf("expected issue-1 in team-alpha, got %v in %v", itemMetadata["name"], itemMetadata["namespace"])
+	}
+	if itemMetadata["creationTimestamp"] != "2026-10-01T12:00:00Z" {
+		t.Errorf("expected the creation timestamp of the issue, got %v", itemMetadata["creationTimestamp"])
+	}
+	labels := itemMetadata["labels"].(map[string]any)
+	if labels["kite.konflux.dev/severity"] != "major" {
+		t.Errorf("expected the severity label major, got %v", labels["kite.konflux.dev/severity"])
+	}
+	spec := item["spec"].(map[string]any)
+	if spec["title"] != "Frontend build failed" {
+		t.Errorf("expected the title in the spec, got %v", spec["title"])
+	}
+	if scope := spec["scope"].(map[string]any); scope["resourceName"] != "frontend" {
+		t.Errorf("expected the scope in the spec, got %v", scope)
+	}
+	if spec["environment"] != "staging" {
+		t.Errorf("expected the environment in the spec, got %v", spec["environment"])
+	}
+	if customFields, _ := spec["customFields"].(map[string]any); customFields["pipelineRun"] != "frontend-on-push-x7k2p" {
+		t.Errorf("expected the custom fields in the spec, got %v", spec["customFields"])
+	}
+	if status := item["status"].(map[string]any); status["state"] != "ACTIVE" {
+		t.Errorf("expected the state in the status, got %v", status["state"])
+	}
+}
+
+func TestIssueHandler_GetIssues_K8sListContinue(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssueResults: &dto.IssueResponse{
+			Data:  []models.Issue{{ID: "issue-1", Namespace: "team-alpha"}, {ID: "issue-2", Namespace: "team-alpha"}},
+			Total: 5,
+			Limit: 2,
+		},
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&output=k8slist&limit=2", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list dto.IssueList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if list.Metadata.Continue == "" {
+		t.Fatal("expected a continue token")
+	}
+	if list.Metadata.RemainingItemCount == nil || *list.Metadata.RemainingItemCount != 3 {
+		t.Errorf("expected 3 remaining items, got %v", list.Metadata.RemainingItemCount)
+	}
+
+	// The token resumes the list after the first page
+	req, err = net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&output=k8slist&limit=2&continue="+list.Metadata.Continue, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	w = net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if mockService.findIssuesFilters.Offset != 2 {
+		t.Errorf("expected offset 2, got %d", mockService.findIssuesFilters.Offset)
+	}
+}
+
+func TestIssueHandler_GetIssues_K8sListInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown output", "&output=yaml"},
+		{"malformed continue token", "&output=k8slist&continue=not-a-token"},
+		{"negative continue offset", "&output=k8slist&continue=" + encodeContinueToken(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// fakeAccessChecker allows access to a fixed set of namespaces
+type fakeAccessChecker struct {
+	allowed []string
+}
+
+func (f fakeAccessChecker) CanAccessNamespace(c *gin.Context, namespace string) bool {
+	return slices.Contains(f.allowed, namespace)
+}
+
+// importIssues posts an NDJSON import and returns its summary
+func importIssues(t *testing.T, router *gin.Engine, body string) dto.ImportSummary {
+	t.Helper()
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/import", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var summary dto.ImportSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("Failed to unmarshal summary: %v", err)
+	}
+	return summary
+}
+
+func TestIssueHandler_ImportIssues(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	issueService := services.NewIssueService(repository.NewIssueRepository(db, logger), logger)
+	router := setupTestIssueRouter(NewIssueHandler(issueService, logger))
+
+	const importFile = `{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"team-import","scope":{"resourceType":"component","resourceName":"frontend"}}
+{"title":"Missing severity","description":"Imported from the previous tracker","issueType":"build","namespace":"team-import","scope":{"resourceType":"component","resourceName":"backend"}}
+
+{"title":"Tests failed","description":"Imported from the previous tracker","severity":"minor","issueType":"test","namespace":"team-import","scope":{"resourceType":"component","resourceName":"frontend"}}
+{"title":"Build failed again","description":"Imported from the previous tracker","severity":"critical","issueType":"build","namespace":"team-import","scope":{"resourceType":"component","resourceName":"frontend"}}
+`
+
+	summary := importIssues(t, router, importFile)
+
+	// The last row is a duplicate of the first one
+	if summary.Created != 2 || summary.Updated != 1 || summary.Skipped != 0 {
+		t.Errorf("expected 2 created and 1 updated, got %+v", summary)
+	}
+	if len(summary.Errors) != 1 || summary.Errors[0].Line != 2 {
+		t.Fatalf("expected an error on line 2, got %+v", summary.Errors)
+	}
+
+	var issues []models.Issue
+	if err := db.Where("namespace = ?", "team-import").Order("title").Find(&issues).Error; err != nil {
+		t.Fatalf("Failed to fetch issues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 imported issues, got %d", len(issues))
+	}
+	if issues[0].Title != "Build failed again" || issues[0].Severity != models.SeverityCritical {
+		t.Errorf("expected the duplicate to update the issue, got %q (%s)", issues[0].Title, issues[0].Severity)
+	}
+	if issues[1].Title != "Tests failed" {
+		t.Errorf("expected the tests failure to be imported, got %q", issues[1].Title)
+	}
+}
+
+func TestIssueHandler_ImportIssues_NamespaceAccess(t *testing.T) {
+	mockService := &MockIssueService{}
+	handler := NewIssueHandler(mockService, logrus.New(),
+		WithNamespaceAccessChecker(fakeAccessChecker{allowed: []string{"team-alpha"}}))
+	router := setupTestIssueRouter(handler)
+
+	summary := importIssues(t, router, `{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"team-alpha","scope":{"resourceType":"component","resourceName":"frontend"}}
+{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"team-beta","scope":{"resourceType":"component","resourceName":"frontend"}}
+`)
+
+	if summary.Created != 1 || summary.Skipped != 1 {
+		t.Errorf("expected 1 created and 1 skipped, got %+v", summary)
+	}
+	if len(summary.Errors) != 1 || summary.Errors[0].Line != 2 {
+		t.Errorf("expected line 2 to be denied, got %+v", summary.Errors)
+	}
+	if len(mockService.importedIssues) != 1 || mockService.importedIssues[0].Namespace != "team-alpha" {
+		t.Errorf("expected only the team-alpha issue to be imported, got %+v", mockService.importedIssues)
+	}
+}
+
+func TestIssueHandler_ImportIssues_InvalidNamespace(t *testing.T) {
+	mockService := &MockIssueService{}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	summary := importIssues(t, router, `{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"Team_Alpha","scope":{"resourceType":"component","resourceName":"frontend"}}
+{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"team-alpha","scope":{"resourceType":"component","resourceName":"frontend","resourceNamespace":"../team-beta"}}
+{"title":"Build failed","description":"Imported from the previous tracker","severity":"major","issueType":"build","namespace":"team-alpha","scope":{"resourceType":"component","resourceName":"frontend","resourceNamespace":"team-beta"}}
+`)
+
+	if summary.Created != 1 {
+		t.Errorf("expected 1 created, got %+v", summary)
+	}
+	if len(summary.Errors) != 2 || summary.Errors[0].Line != 1 || summary.Errors[1].Line != 2 {
+		t.Fatalf("expected errors on lines 1 and 2, got %+v", summary.Errors)
+	}
+	if !strings.Contains(summary.Errors[1].Error, "invalid resource namespace") {
+		t.Errorf("expected an invalid resource namespace error, got %q", summary.Errors[1].Error)
+	}
+	if len(mockService.importedIssues) != 1 || mockService.importedIssues[0].Scope.ResourceNamespace != "team-beta" {
+		t.Errorf("expected only the valid issue to be imported, got %+v", mockService.importedIssues)
+	}
+}
+
+func TestIssueHandler_GetIssues_ChangedSince(t *testing.T) {
+	tests := []struct {
+		name           string
+		changedSince   string
+		expectedStatus int
+		expectFilter   bool
+	}{
+		{"not set", "", net_http.StatusOK, false},
+		{"valid timestamp", "2026-10-16T12:00:00Z", net_http.StatusOK, true},
+		{"invalid timestamp", "yesterday", net_http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			url := "/api/v1/issues?namespace=team-alpha"
+			if tt.changedSince != "" {
+				url += "&changedSince=" + tt.changedSince
+			}
+			req, err := net_http.NewRequest("GET", url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			since := mockService.findIssuesFilters.ChangedSince
+			if !tt.expectFilter {
+				if since != nil {
+					t.Errorf("expected no changedSince filter, got %v", since)
+				}
+			} else if since == nil || since.Format(time.RFC3339) != tt.changedSince {
+				t.Errorf("expected changedSince %s, got %v", tt.changedSince, since)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_StateGroup(t *testing.T) {
+	tests := []struct {
+		name           string
+		stateGroup     string
+		expectedStatus int
+		expectedStates []models.IssueState
+	}{
+		{"not set", "", net_http.StatusOK, nil},
+		{"open", "open", net_http.StatusOK, []models.IssueState{models.IssueStateActive, models.IssueStateAcknowledged}},
+		{"closed", "closed", net_http.StatusOK, []models.IssueState{models.IssueStateResolved}},
+		{"all", "all", net_http.StatusOK, nil},
+		{"invalid group", "pending", net_http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			url := "/api/v1/issues?namespace=team-alpha"
+			if tt.stateGroup != "" {
+				url += "&stateGroup=" + tt.stateGroup
+			}
+			req, err := net_http.NewRequest("GET", url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if states := mockService.findIssuesFilters.States; !slices.Equal(states, tt.expectedStates) {
+				t.Errorf("expected states %v, got %v", tt.expectedStates, states)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_Environment(t *testing.T) {
+	mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&environment=prod", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status %d, got %d", net_http.StatusOK, w.Code)
+	}
+	if environment := mockService.findIssuesFilters.Environment; environment != "prod" {
+		t.Errorf("expected environment prod, got %q", environment)
+	}
+}
+
+func TestIssueHandler_GetIssues_ResolvedBy(t *testing.T) {
+	tests := []struct {
+		name           string
+		resolvedBy     string
+		expectedStatus int
+	}{
+		{"not set", "", net_http.StatusOK},
+		{"success webhook", "success-webhook", net_http.StatusOK},
+		{"manual", "manual", net_http.StatusOK},
+		{"invalid source", "magic", net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			url := "/api/v1/issues?namespace=team-alpha"
+			if tt.resolvedBy != "" {
+				url += "&resolvedBy=" + tt.resolvedBy
+			}
+			req, err := net_http.NewRequest("GET", url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+			resolvedBy := mockService.findIssuesFilters.ResolvedBy
+			if tt.resolvedBy == "" && resolvedBy != nil {
+				t.Errorf("expected no resolvedBy filter, got %s", *resolvedBy)
+			}
+			if tt.resolvedBy != "" && (resolvedBy == nil || string(*resolvedBy) != tt.resolvedBy) {
+				t.Errorf("expected resolvedBy filter %s, got %v", tt.resolvedBy, resolvedBy)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_Overdue(t *testing.T) {
+	tests := []struct {
+		name           string
+		overdue        string
+		expectedStatus int
+		expectFilter   bool
+	}{
+		{"not set", "", net_http.StatusOK, false},
+		{"overdue", "true", net_http.StatusOK, true},
+		{"not overdue", "false", net_http.StatusOK, false},
+		{"invalid value", "maybe", net_http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+			now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logrus.New(), WithIssueClock(testhelpers.NewFakeClock(now))))
+
+			url := "/api/v1/issues?namespace=team-alpha"
+			if tt.overdue != "" {
+				url += "&overdue=" + tt.overdue
+			}
+			req, err := net_http.NewRequest("GET", url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if overdueAt := mockService.findIssuesFilters.OverdueAt; (overdueAt != nil) != tt.expectFilter {
+				t.Errorf("expected overdue filter %v, got %v", tt.expectFilter, overdueAt)
+			} else if overdueAt != nil && !overdueAt.Equal(now) {
+				t.Errorf("expected issues overdue at %v, got %v", now, overdueAt)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssues_InvalidNamespace(t *testing.T) {
+	for _, namespace := range []string{"Team-Alpha", strings.Repeat("a", 64)} {
+		mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{}}
+		router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+		req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace="+namespace, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != net_http.StatusBadRequest {
+			t.Errorf("expected status 400 for namespace %q, got %d", namespace, w.Code)
+		}
+		if mockService.findIssuesFilters.Namespace != "" {
+			t.Errorf("expected no query for namespace %q", namespace)
+		}
+	}
+}
+
+func TestIssueHandler_GetIssues_StatementTimeout(t *testing.T) {
+	mockService := &MockIssueService{
+		findIssuesError: errors.New("failed to find issues: ERROR: canceling statement due to statement timeout (SQLSTATE 57014)"),
+	}
+	router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues?namespace=team-alpha", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+func TestIssueHandler_GetMTTR(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"grouped", "namespace=team-alpha&since=2025-06-01T00:00:00Z&groupBy=issueType", net_http.StatusOK},
+		{"invalid since", "namespace=team-alpha&since=yesterday", net_http.StatusBadRequest},
+		{"invalid groupBy", "namespace=team-alpha&groupBy=title", net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findMTTRResult: []dto.MTTRGroup{{Group: "build", Count: 2, MeanSeconds: 90, MedianSeconds: 90}},
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues/metrics/mttr?"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			if mockService.findMTTRGroupBy != "issueType" || mockService.findMTTRFilters.Namespace != "team-alpha" {
+				t.Errorf("unexpected arguments: groupBy %q, namespace %q", mockService.findMTTRGroupBy, mockService.findMTTRFilters.Namespace)
+			}
+			if !mockService.findMTTRSince.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected since: %s", mockService.findMTTRSince)
+			}
+
+			var response struct {
+				Data []dto.MTTRGroup `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(response.Data) != 1 || response.Data[0].MeanSeconds != 90 {
+				t.Errorf("unexpected response: %+v", response.Data)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_GetIssueOccurrenceHistogram(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedBucket string
+		expectedSince  time.Time
+	}{
+		{"default bucket", "", net_http.StatusOK, "hour", time.Time{}},
+		{"bucket and since", "?bucket=day&since=2025-06-01T00:00:00Z", net_http.StatusOK, "day", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"invalid bucket", "?bucket=second", net_http.StatusBadRequest, "", time.Time{}},
+		{"SQL in bucket", "?bucket=hour')--", net_http.StatusBadRequest, "", time.Time{}},
+		{"invalid since", "?since=yesterday", net_http.StatusBadRequest, "", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				findIssueByIDResult: &models.Issue{ID: "issue-1", Namespace: "team-alpha"},
+				histogramResult:     []dto.OccurrenceBucket{{Start: start, Count: 3}},
+			}
+			router := setupTestIssueRouter(setupTestIssueHandler(mockService))
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues/issue-1/occurrences/histogram"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			if mockService.histogramUnit != tt.expectedBucket {
+				t.Errorf("expected bucket %q, got %q", tt.expectedBucket, mockService.histogramUnit)
+			}
+			if !mockService.histogramSince.Equal(tt.expectedSince) {
+				t.Errorf("expected since %s, got %s", tt.expectedSince, mockService.histogramSince)
+			}
+
+			var response struct {
+				Data []dto.OccurrenceBucket `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(response.Data) != 1 || !response.Data[0].Start.Equal(start) || response.Data[0].Count != 3 {
+				t.Errorf("unexpected response: %+v", response.Data)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_ResourceNamespaceAuthorization(t *testing.T) {
+	// Tracked in the team namespace, scoped to shared infrastructure
+	issue := &models.Issue{
+		ID:        "issue-1",
+		Namespace: "team-alpha",
+		Scope: models.IssueScope{
+			ResourceType:      "registry",
+			ResourceName:      "quay-mirror",
+			ResourceNamespace: "shared-infra",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		resourceAuthz  bool
+		allowed        []string
+		expectedStatus int
+	}{
+		{"issue namespace governs", false, []string{"team-alpha"}, net_http.StatusOK},
+		{"resource namespace governs, access to both", true, []string{"team-alpha", "shared-infra"}, net_http.StatusOK},
+		{"resource namespace governs, no access to the resource namespace", true, []string{"team-alpha"}, net_http.StatusForbidden},
+		{"resource namespace governs, no access to the issue namespace", true, []string{"shared-infra"}, net_http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []IssueHandlerOption{WithNamespaceAccessChecker(fakeAccessChecker{allowed: tt.allowed})}
+			if tt.resourceAuthz {
+				opts = append(opts, WithResourceNamespaceAuthorization())
+			}
+			mockService := &MockIssueService{findIssueByIDResult: issue, createIssueResult: issue}
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logrus.New(), opts...))
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues/issue-1", nil))
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d getting the issue, got %d", tt.expectedStatus, w.Code)
+			}
+
+			reqBody, err := json.Marshal(dto.CreateIssueRequest{
+				Title:       "Shared registry unreachable",
+				Description: "Pulls from the mirror time out",
+				Severity:    models.SeverityMajor,
+				IssueType:   models.IssueTypeBuild,
+				Namespace:   issue.Namespace,
+				Scope: dto.ScopeReqBody{
+					ResourceType:      issue.Scope.ResourceType,
+					ResourceName:      issue.Scope.ResourceName,
+					ResourceNamespace: issue.Scope.ResourceNamespace,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req := net_httptest.NewRequest("POST", "/api/v1/issues", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w = net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			expectedCreateStatus := net_http.StatusCreated
+			if tt.expectedStatus != net_http.StatusOK {
+				expectedCreateStatus = tt.expectedStatus
+			}
+			if w.Code != expectedCreateStatus {
+				t.Errorf("expected status %d creating the issue, got %d", expectedCreateStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIssueHandler_ResourceNamespaceAuthorization_UnverifiedQuery(t *testing.T) {
+	issue := &models.Issue{
+		ID:        "issue-1",
+		Namespace: "team-alpha",
+		Scope:     models.IssueScope{ResourceType: "registry", ResourceName: "quay-mirror", ResourceNamespace: "shared-infra"},
+	}
+	mockService := &MockIssueService{findIssueByIDResult: issue}
+	handler := NewIssueHandler(mockService, logrus.New(),
+		WithNamespaceAccessChecker(fakeAccessChecker{allowed: []string{"team-alpha"}}),
+		WithResourceNamespaceAuthorization(),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Only team-alpha was checked by the middleware, whatever the query says
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.VerifiedNamespaceKey, "team-alpha")
+		c.Next()
+	})
+	router.GET("/api/v1/issues/:id", handler.GetIssue)
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues/issue-1?namespace=shared-infra", nil))
+	if w.Code != net_http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", net_http.StatusForbidden, w.Code)
+	}
+}
+
+func TestIssueHandler_GetIssues_ResourceNamespaceAuthorization(t *testing.T) {
+	mockService := &MockIssueService{findIssueResults: &dto.IssueResponse{Data: []models.Issue{}}}
+	handler := NewIssueHandler(mockService, logrus.New(), WithResourceNamespaceAuthorization())
+	router := setupTestIssueRouter(handler)
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues?namespace=team-alpha&namespace=shared-infra", nil))
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status %d, got %d", net_http.StatusOK, w.Code)
+	}
+
+	// Only issues scoped to resources in the namespaces checked by the middleware are listed
+	expected := []string{"team-alpha", "shared-infra"}
+	if !slices.Equal(mockService.findIssuesFilters.ResourceNamespaces, expected) {
+		t.Errorf("expected resource namespaces %v, got %v", expected, mockService.findIssuesFilters.ResourceNamespaces)
+	}
+}
+
+// countingAccessChecker denies every namespace, counting the reviews
+type countingAccessChecker struct {
+	reviews int
+}
+
+func (f *countingAccessChecker) CanAccessNamespace(c *gin.Context, namespace string) bool {
+	f.reviews++
+	return false
+}
+
+func TestIssueHandler_GetIssuesByNamespace_BoundedReviews(t *testing.T) {
+	ranking := make([]dto.NamespaceIssueCount, 0, 2*maxNamespaceAccessReviews)
+	for idx := range 2 * maxNamespaceAccessReviews {
+		ranking = append(ranking, dto.NamespaceIssueCount{Namespace: fmt.Sprintf("team-%d", idx), IssueCount: 1})
+	}
+	mockService := &MockIssueService{rankNamespacesResult: ranking}
+	checker := &countingAccessChecker{}
+	router := setupTestIssueRouter(NewIssueHandler(mockService, logrus.New(), WithNamespaceAccessChecker(checker)))
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues/by-namespace", nil))
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", net_http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// A requester who can't access any namespace only gets the top ones reviewed
+	if mockService.rankNamespacesLimit != maxNamespaceAccessReviews {
+		t.Errorf("expected ranking limit %d, got %d", maxNamespaceAccessReviews, mockService.rankNamespacesLimit)
+	}
+	if checker.reviews != maxNamespaceAccessReviews {
+		t.Errorf("expected %d reviews, got %d", maxNamespaceAccessReviews, checker.reviews)
+	}
+	if body := w.Body.String(); body != `{"data":[]}` {
+		t.Errorf("expected no namespaces, got %s", body)
+	}
+}
+
+func TestIssueHandler_GetIssuesByNamespace(t *testing.T) {
+	ranking := []dto.NamespaceIssueCount{
+		{Namespace: "team-gamma", IssueCount: 9},
+		{Namespace: "team-alpha", IssueCount: 7},
+		{Namespace: "team-beta", IssueCount: 4},
+		{Namespace: "team-delta", IssueCount: 1},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedStates []models.IssueState
+		expected       []string
+	}{
+		{
+			name:           "open issues by default",
+			expectedStatus: net_http.StatusOK,
+			expectedStates: models.OpenStates,
+			expected:       []string{"team-alpha", "team-beta", "team-delta"},
+		},
+		{
+			name:           "state and limit",
+			query:          "?state=ACTIVE&limit=2",
+			expectedStatus: net_http.StatusOK,
+			expectedStates: []models.IssueState{models.IssueStateActive},
+			expected:       []string{"team-alpha", "team-beta"},
+		},
+		{
+			name:           "invalid state",
+			query:          "?state=CLOSED",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{rankNamespacesResult: ranking}
+			checker := fakeAccessChecker{allowed: []string{"team-alpha", "team-beta", "team-delta"}}
+			router := setupTestIssueRouter(NewIssueHandler(mockService, logrus.New(), WithNamespaceAccessChecker(checker)))
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, net_httptest.NewRequest("GET", "/api/v1/issues/by-namespace"+tt.query, nil))
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+			if !slices.Equal(mockService.rankNamespacesStates, tt.expectedStates) {
+				t.Errorf("expected states %v, got %v", tt.expectedStates, mockService.rankNamespacesStates)
+			}
+
+			var response struct {
+				Data []dto.NamespaceIssueCount `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			var namespaces []string
+			for _, namespace := range response.Data {
+				namespaces = append(namespaces, namespace.Namespace)
+			}
+			// The namespace the requester can't access is left out, whatever its rank
+			if !slices.Equal(namespaces, tt.expected) {
+				t.Errorf("expected namespaces %v, got %v", tt.expected, namespaces)
+			}
+		})
+	}
 }
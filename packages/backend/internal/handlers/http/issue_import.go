@@ -0,0 +1,155 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+// maxImportLineSize is the largest issue accepted on a single line of an import
+const maxImportLineSize = 1024 * 1024
+
+// ImportIssues handles POST /issues/import
+//
+// Issues are read one per line (NDJSON), either from the request body or from
+// a "file" multipart upload, and processed as they're read so large files
+// aren't held in memory. Each issue is validated and deduplicated like issues
+// reported by webhooks, and rows that can't be imported are reported along
+// with their line number without failing the import.
+func (h *IssueHandler) ImportIssues(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing file to import", "details": err.Error()})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to open imported file")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read imported file"})
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	summary := dto.ImportSummary{Errors: []dto.ImportRowError{}}
+	// Access is only checked once for each namespace
+	namespaceAccess := make(map[string]bool)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		row := bytes.TrimSpace(scanner.Bytes())
+		if len(row) == 0 {
+			continue
+		}
+
+		req, err := h.parseImportedIssue(row)
+		if err != nil {
+			summary.Errors = append(summary.Errors, dto.ImportRowError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		namespaces := []string{req.Namespace}
+		if h.authorizeResourceNamespace && req.Scope.ResourceNamespace != "" {
+			namespaces = append(namespaces, req.Scope.ResourceNamespace)
+		}
+		if denied := h.deniedNamespace(c, namespaces, namespaceAccess); denied != "" {
+			summary.Skipped++
+			summary.Errors = append(summary.Errors, dto.ImportRowError{
+				Line:  line,
+				Error: fmt.Sprintf("access denied to namespace %s", denied),
+			})
+			continue
+		}
+
+		_, created, err := h.issueService.ImportIssue(authorizeDedupNamespaces(c.Request.Context(), c, h.accessChecker), req)
+		if errors.Is(err, repository.ErrNamespaceIssueLimitExceeded) {
+			summary.Errors = append(summary.Errors, dto.ImportRowError{
+				Line:  line,
+				Error: fmt.Sprintf("namespace %s reached its maximum number of active issues", req.Namespace),
+			})
+			continue
+		}
+		if err != nil {
+			h.logger.WithError(err).WithField("line", line).Error("Failed to import issue")
+			summary.Errors = append(summary.Errors, dto.ImportRowError{Line: line, Error: "failed to import issue"})
+			continue
+		}
+		if created {
+			summary.Created++
+		} else {
+			summary.Updated++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		h.logger.WithError(err).WithField("line", line+1).Warn("Failed to read import")
+		summary.Errors = append(summary.Errors, dto.ImportRowError{Line: line + 1, Error: err.Error()})
+	}
+
+	h.logger.WithFields(map[string]any{
+		"created": summary.Created,
+		"updated": summary.Updated,
+		"skipped": summary.Skipped,
+		"errors":  len(summary.Errors),
+	}).Info("Imported issues")
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// parseImportedIssue decodes and validates an issue from a line of an import
+func (h *IssueHandler) parseImportedIssue(row []byte) (dto.CreateIssueRequest, error) {
+	var req dto.CreateIssueRequest
+	if err := json.Unmarshal(row, &req); err != nil {
+		return req, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return req, fmt.Errorf("invalid issue: %w", err)
+	}
+	req.Scope = trimScope(req.Scope)
+	// Rows don't go through the namespace middleware, their namespaces are checked here
+	if err := middleware.ValidateNamespace(req.Namespace); err != nil {
+		return req, err
+	}
+	if req.Scope.ResourceNamespace != "" {
+		if err := middleware.ValidateNamespace(req.Scope.ResourceNamespace); err != nil {
+			return req, fmt.Errorf("invalid resource namespace: %w", err)
+		}
+	}
+	if err := h.validateCreateIssueRequest(req); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// deniedNamespace returns the first of the namespaces the requester can't access, if any.
+// Access is remembered in checked, so each namespace is only checked once per import.
+func (h *IssueHandler) deniedNamespace(c *gin.Context, namespaces []string, checked map[string]bool) string {
+	for _, namespace := range namespaces {
+		allowed, ok := checked[namespace]
+		if !ok {
+			allowed = h.accessChecker == nil || h.accessChecker.CanAccessNamespace(c, namespace)
+			checked[namespace] = allowed
+		}
+		if !allowed {
+			return namespace
+		}
+	}
+	return ""
+}
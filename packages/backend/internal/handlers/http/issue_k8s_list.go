@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// outputK8sList is the output of GET /issues listing issues as a Kubernetes list
+const outputK8sList = "k8slist"
+
+// Labels set on issue objects, so clients can select them like Kubernetes objects
+const (
+	issueLabelSeverity  = "kite.konflux.dev/severity"
+	issueLabelIssueType = "kite.konflux.dev/issue-type"
+	issueLabelState     = "kite.konflux.dev/state"
+)
+
+// continueToken is the position of the next page of a list, encoded in its
+// continue metadata. The filters aren't part of it, clients pass them again
+// along with the token, as with Kubernetes lists.
+type continueToken struct {
+	Offset int `json:"offset"`
+}
+
+// encodeContinueToken returns the opaque token of the page at the offset
+func encodeContinueToken(offset int) string {
+	data, _ := json.Marshal(continueToken{Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeContinueToken returns the offset of the page of a token
+func decodeContinueToken(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	var decoded continueToken
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return 0, err
+	}
+	if decoded.Offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	return decoded.Offset, nil
+}
+
+// newIssueList shapes a page of issues like a Kubernetes list, with the token
+// of the next page if there's one
+func newIssueList(result *dto.IssueResponse) dto.IssueList {
+	list := dto.IssueList{
+		APIVersion: dto.IssueAPIVersion,
+		Kind:       dto.IssueListKind,
+		Items:      make([]dto.IssueObject, 0, len(result.Data)),
+	}
+	for _, issue := range result.Data {
+		list.Items = append(list.Items, newIssueObject(issue))
+	}
+
+	next := result.Offset + len(result.Data)
+	if len(result.Data) > 0 && int64(next) < result.Total {
+		remaining := result.Total - int64(next)
+		list.Metadata.Continue = encodeContinueToken(next)
+		list.Metadata.RemainingItemCount = &remaining
+	}
+	return list
+}
+
+// newIssueObject shapes an issue like a Kubernetes object
+func newIssueObject(issue models.Issue) dto.IssueObject {
+	links := issue.Links
+	if links == nil {
+		links = []models.Link{}
+	}
+	return dto.IssueObject{
+		APIVersion: dto.IssueAPIVersion,
+		Kind:       dto.IssueKind,
+		Metadata: dto.ObjectMeta{
+			Name:              issue.ID,
+			Namespace:         issue.Namespace,
+			UID:               issue.ID,
+			CreationTimestamp: issue.CreatedAt,
+			Labels: map[string]string{
+				issueLabelSeverity:  string(issue.Severity),
+				issueLabelIssueType: string(issue.IssueType),
+				issueLabelState:     string(issue.State),
+			},
+		},
+		Spec: dto.IssueObjectSpec{
+			Title:       issue.Title,
+			Description: issue.Description,
+			Severity:    issue.Severity,
+			IssueType:   issue.IssueType,
+			Scope: dto.IssueObjectScope{
+				ResourceType:      issue.Scope.ResourceType,
+				ResourceName:      issue.Scope.ResourceName,
+				ResourceNamespace: issue.Scope.ResourceNamespace,
+			},
+			Links:         links,
+			DueAt:         issue.DueAt,
+			Fingerprint:   issue.Fingerprint,
+			Environment:   issue.Environment,
+			CustomFields:  issue.CustomFields,
+			CommitContext: issue.CommitContext,
+		},
+		Status: dto.IssueObjectStatus{
+			State:                  issue.State,
+			DetectedAt:             issue.DetectedAt,
+			LastSeenAt:             issue.LastSeenAt,
+			ResolvedAt:             issue.ResolvedAt,
+			ResolvedBy:             issue.ResolvedBy,
+			OccurrenceCount:        issue.OccurrenceCount,
+			SnoozedUntilRecurrence: issue.SnoozedUntilRecurrence,
+			UpdatedAt:              issue.UpdatedAt,
+		},
+	}
+}
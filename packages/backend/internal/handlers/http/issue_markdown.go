@@ -0,0 +1,165 @@
+package http
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// formatMarkdown is the format of GET /issues/:id rendering the issue as a markdown report
+const formatMarkdown = "markdown"
+
+// markdownSuffix requests the markdown report when appended to the issue ID, as in /issues/:id.md
+const markdownSuffix = ".md"
+
+// markdownContentType is the content type of markdown reports
+const markdownContentType = "text/markdown; charset=utf-8"
+
+// timelineEntry is an event of the timeline of a markdown report
+type timelineEntry struct {
+	at   time.Time
+	text string
+}
+
+// renderIssueMarkdown renders an issue as a markdown report meant to be pasted into
+// incident write-ups. The issue is expected with its scope, links, related issues
+// and notes loaded, its occurrences are passed separately.
+func renderIssueMarkdown(issue *models.Issue, occurrences []models.Occurrence) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", markdownInline(issue.Title))
+
+	fmt.Fprintf(&b, "- **ID:** `%s`\n", issue.ID)
+	fmt.Fprintf(&b, "- **State:** %s\n", issue.State)
+	fmt.Fprintf(&b, "- **Severity:** %s\n", issue.Severity)
+	fmt.Fprintf(&b, "- **Type:** %s\n", issue.IssueType)
+	fmt.Fprintf(&b, "- **Namespace:** %s\n", markdownInline(issue.Namespace))
+	if issue.Scope.ResourceName != "" {
+		fmt.Fprintf(&b, "- **Resource:** %s %s/%s\n", markdownInline(issue.Scope.ResourceType),
+			markdownInline(issue.Scope.ResourceNamespace), markdownInline(issue.Scope.ResourceName))
+	}
+	if issue.Environment != "" {
+		fmt.Fprintf(&b, "- **Environment:** %s\n", markdownInline(issue.Environment))
+	}
+	fmt.Fprintf(&b, "- **Detected:** %s\n", markdownTime(issue.DetectedAt))
+	fmt.Fprintf(&b, "- **Last seen:** %s\n", markdownTime(issue.LastSeenAt))
+	fmt.Fprintf(&b, "- **Occurrences:** %d\n", issue.OccurrenceCount)
+	if issue.ResolvedAt != nil {
+		fmt.Fprintf(&b, "- **Resolved:** %s", markdownTime(*issue.ResolvedAt))
+		if issue.ResolvedBy != "" {
+			fmt.Fprintf(&b, " (%s)", issue.ResolvedBy)
+		}
+		b.WriteString("\n")
+	}
+	if issue.CommitSHA != "" {
+		fmt.Fprintf(&b, "- **Commit:** `%s`", issue.CommitSHA)
+		if issue.RepoURL != "" {
+			fmt.Fprintf(&b, " in %s", markdownURL(issue.RepoURL))
+		}
+		if issue.Branch != "" {
+			fmt.Fprintf(&b, " on %s", markdownInline(issue.Branch))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## Description\n\n")
+	if description := strings.TrimSpace(issue.Description); description != "" {
+		b.WriteString(description)
+	} else {
+		b.WriteString("_No description._")
+	}
+	b.WriteString("\n")
+
+	if len(issue.Links) > 0 {
+		b.WriteString("\n## Links\n\n")
+		for _, link := range issue.Links {
+			fmt.Fprintf(&b, "- [%s](%s)", markdownInline(link.Title), markdownURL(link.URL))
+			if link.Primary {
+				b.WriteString(" (primary)")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(issue.RelatedFrom) > 0 || len(issue.RelatedTo) > 0 {
+		b.WriteString("\n## Related issues\n\n")
+		for _, related := range issue.RelatedFrom {
+			fmt.Fprintf(&b, "- This issue %s %s\n", markdownRelationship(related.Kind), markdownRelatedIssue(related.Target))
+		}
+		for _, related := range issue.RelatedTo {
+			fmt.Fprintf(&b, "- %s %s this issue\n", markdownRelatedIssue(related.Source), markdownRelationship(related.Kind))
+		}
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	for _, entry := range issueTimeline(issue, occurrences) {
+		fmt.Fprintf(&b, "- %s: %s\n", markdownTime(entry.at), entry.text)
+	}
+
+	return b.String()
+}
+
+// issueTimeline returns the events of an issue in chronological order
+func issueTimeline(issue *models.Issue, occurrences []models.Occurrence) []timelineEntry {
+	entries := []timelineEntry{{at: issue.DetectedAt, text: "Detected"}}
+	for _, occurrence := range occurrences {
+		text := "Occurred"
+		// Only the first line, details can be whole logs
+		if detail, _, _ := strings.Cut(strings.TrimSpace(occurrence.Detail), "\n"); detail != "" {
+			text += ": " + markdownInline(detail)
+		}
+		entries = append(entries, timelineEntry{at: occurrence.OccurredAt, text: text})
+	}
+	for _, note := range issue.Notes {
+		entries = append(entries, timelineEntry{at: note.CreatedAt, text: "Note: " + markdownInline(note.Content)})
+	}
+	if issue.ResolvedAt != nil {
+		entries = append(entries, timelineEntry{at: *issue.ResolvedAt, text: "Resolved"})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].at.Before(entries[j].at)
+	})
+	return entries
+}
+
+// markdownRelatedIssue renders a related issue on a single line
+func markdownRelatedIssue(issue models.Issue) string {
+	return fmt.Sprintf("**%s** (`%s`, %s)", markdownInline(issue.Title), issue.ID, issue.State)
+}
+
+// markdownRelationship renders the kind of a relationship as a verb
+func markdownRelationship(kind models.RelationshipKind) string {
+	if kind == models.RelationshipCausedBy {
+		return "is caused by"
+	}
+	return strings.ToLower(strings.ReplaceAll(string(kind), "_", " "))
+}
+
+// markdownTime renders a time in UTC, the same for every reader of the report
+func markdownTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// markdownInlineEscaper escapes the characters that would otherwise be read as markup
+var markdownInlineEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+	"\r\n", " ", "\n", " ",
+)
+
+// markdownInline escapes text rendered within a line of the report
+func markdownInline(text string) string {
+	return markdownInlineEscaper.Replace(text)
+}
+
+// markdownURLEscaper percent-encodes the characters that would end a link destination
+var markdownURLEscaper = strings.NewReplacer("<", "%3C", ">", "%3E", "\r", "%0D", "\n", "%0A")
+
+// markdownURL renders a URL as the destination of a link, within angle brackets so
+// spaces and parentheses don't end it
+func markdownURL(url string) string {
+	return "<" + markdownURLEscaper.Replace(url) + ">"
+}
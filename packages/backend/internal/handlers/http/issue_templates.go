@@ -0,0 +1,150 @@
+package http
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"sigs.k8s.io/yaml"
+)
+
+// errUnknownIssueTemplate is returned when creating an issue from a template that doesn't exist
+var errUnknownIssueTemplate = errors.New("unknown issue template")
+
+// issueTemplateName matches the names of templates, which are used in URLs
+var issueTemplateName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// sampleTemplateRequest is used to validate templates when they're parsed
+var sampleTemplateRequest = dto.CreateIssueFromTemplateRequest{
+	Namespace: "namespace",
+	Scope:     dto.ScopeReqBody{ResourceType: "component", ResourceName: "name", ResourceNamespace: "namespace"},
+}
+
+// quickCreateTemplate is a template issues can be created from, with its title and
+// description parsed as Go templates.
+type quickCreateTemplate struct {
+	dto.IssueTemplate
+	title       *template.Template
+	description *template.Template
+}
+
+// QuickCreateTemplates are the named templates operators create similar issues from
+type QuickCreateTemplates struct {
+	templates map[string]*quickCreateTemplate
+}
+
+// ParseQuickCreateTemplates parses and validates the templates issues can be created from.
+//
+// The source holds the templates keyed by name, in YAML or JSON. The title and description
+// of a template are Go templates, rendered with the request creating the issue so they can
+// mention e.g. its resource. An empty source has no templates.
+func ParseQuickCreateTemplates(source string) (*QuickCreateTemplates, error) {
+	var sources map[string]dto.IssueTemplate
+	if err := yaml.UnmarshalStrict([]byte(source), &sources); err != nil {
+		return nil, fmt.Errorf("invalid issue templates: %w", err)
+	}
+
+	templates := make(map[string]*quickCreateTemplate, len(sources))
+	for name, source := range sources {
+		if !issueTemplateName.MatchString(name) {
+			return nil, fmt.Errorf("invalid issue template name %q", name)
+		}
+		if source.Title == "" || source.Description == "" {
+			return nil, fmt.Errorf("issue template %q: title and description are required", name)
+		}
+		if source.Severity.Rank() == 0 {
+			return nil, fmt.Errorf("issue template %q: invalid severity %q", name, source.Severity)
+		}
+		if !slices.Contains(models.IssueTypes, source.IssueType) {
+			return nil, fmt.Errorf("issue template %q: invalid issueType %q", name, source.IssueType)
+		}
+		for _, link := range source.Links {
+			if link.Title == "" || link.URL == "" {
+				return nil, fmt.Errorf("issue template %q: links require a title and url", name)
+			}
+		}
+		if dto.CountPrimaryLinks(source.Links) > 1 {
+			return nil, fmt.Errorf("issue template %q: at most one link can be primary", name)
+		}
+
+		tmpl := &quickCreateTemplate{IssueTemplate: source}
+		var err error
+		if tmpl.title, err = parseQuickCreateField(name, "title", source.Title); err != nil {
+			return nil, err
+		}
+		if tmpl.description, err = parseQuickCreateField(name, "description", source.Description); err != nil {
+			return nil, err
+		}
+		templates[name] = tmpl
+	}
+	return &QuickCreateTemplates{templates: templates}, nil
+}
+
+// parseQuickCreateField parses a field of a template, and validates it by rendering it
+// with a sample request.
+func parseQuickCreateField(name, field, source string) (*template.Template, error) {
+	tmpl, err := template.New(name + "." + field).Funcs(issueTemplateFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue template %q: %w", name, err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, sampleTemplateRequest); err != nil {
+		return nil, fmt.Errorf("invalid issue template %q: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// build creates the issue for the request from the template with the name.
+// The fields set in the request take precedence over the template's.
+func (t *QuickCreateTemplates) build(name string, req dto.CreateIssueFromTemplateRequest) (dto.CreateIssueRequest, error) {
+	var tmpl *quickCreateTemplate
+	if t != nil {
+		tmpl = t.templates[name]
+	}
+	if tmpl == nil {
+		return dto.CreateIssueRequest{}, errUnknownIssueTemplate
+	}
+
+	issue := dto.CreateIssueRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		Severity:    cmp.Or(req.Severity, tmpl.Severity),
+		IssueType:   cmp.Or(req.IssueType, tmpl.IssueType),
+		Namespace:   req.Namespace,
+		Scope:       req.Scope,
+		Links:       req.Links,
+
+		CustomFields: req.CustomFields,
+	}
+	if issue.Links == nil {
+		issue.Links = slices.Clone(tmpl.Links)
+	}
+
+	var err error
+	if issue.Title == "" {
+		if issue.Title, err = renderQuickCreateField(tmpl.title, req); err != nil {
+			return issue, err
+		}
+	}
+	if issue.Description == "" {
+		if issue.Description, err = renderQuickCreateField(tmpl.description, req); err != nil {
+			return issue, err
+		}
+	}
+	return issue, nil
+}
+
+// renderQuickCreateField renders a field of a template with the request
+func renderQuickCreateField(tmpl *template.Template, req dto.CreateIssueFromTemplateRequest) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, req); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// NamespaceAccessChecker checks if the requester can access a namespace
+type NamespaceAccessChecker interface {
+	CanAccessNamespace(c *gin.Context, namespace string) bool
+}
+
+// authorizeDedupNamespaces returns a context whose reports only update the duplicates of
+// other namespaces the requester can access, when duplicates are matched across namespaces.
+// All namespaces are allowed without an access checker.
+func authorizeDedupNamespaces(ctx context.Context, c *gin.Context, accessChecker NamespaceAccessChecker) context.Context {
+	return repository.WithNamespaceAuthorizer(ctx, dedupNamespaceAuthorizer(c, accessChecker))
+}
+
+// dedupNamespaceAuthorizer returns the authorizer of authorizeDedupNamespaces, allowing the
+// namespaces the requester can access
+func dedupNamespaceAuthorizer(c *gin.Context, accessChecker NamespaceAccessChecker) repository.NamespaceAuthorizer {
+	return func(namespace string) bool {
+		return accessChecker == nil || accessChecker.CanAccessNamespace(c, namespace)
+	}
+}
+
+// NamespaceHandler handles requests about the namespaces containing issues
+type NamespaceHandler struct {
+	issueService  services.IssueServiceInterface
+	accessChecker NamespaceAccessChecker // Optional, no filtering is done if nil
+	logger        *logrus.Logger
+}
+
+// NewNamespaceHandler returns a new handler for the namespaces router
+func NewNamespaceHandler(issueService services.IssueServiceInterface, accessChecker NamespaceAccessChecker, logger *logrus.Logger) *NamespaceHandler {
+	return &NamespaceHandler{
+		issueService:  issueService,
+		accessChecker: accessChecker,
+		logger:        logger,
+	}
+}
+
+// GetNamespaces handles GET /namespaces
+//
+// Returns the namespaces containing issues that the requester can access,
+// along with the number of active issues in each namespace.
+//
+// Response:
+//   - 200 OK: The namespaces found
+//   - 500 Internal Server Error: Database or processing error
+func (h *NamespaceHandler) GetNamespaces(c *gin.Context) {
+	namespaces, err := h.issueService.FindNamespaces(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to fetch namespaces")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch namespaces"})
+		return
+	}
+
+	accessible := make([]dto.NamespaceSummary, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if h.accessChecker != nil && !h.accessChecker.CanAccessNamespace(c, namespace.Namespace) {
+			continue
+		}
+		accessible = append(accessible, namespace)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": accessible})
+}
+
+// AcknowledgeResourceIssues handles POST /namespaces/:namespace/resources/:type/:name/acknowledge
+//
+// Acknowledges every active issue of the namespace scoped to the resource, e.g. during
+// a known incident affecting it. The body is optional, and can hold a note added to
+// the acknowledged issues along with the name of the requester.
+//
+// Response:
+//   - 200 OK: The number of issues acknowledged
+//   - 400 Bad Request: Invalid namespace or body
+//   - 500 Internal Server Error: Database or processing error
+func (h *NamespaceHandler) AcknowledgeResourceIssues(c *gin.Context) {
+	namespace := c.Param("namespace")
+	if err := middleware.ValidateNamespace(namespace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+		return
+	}
+
+	var req dto.AcknowledgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	var acknowledgedBy string
+	if requester, ok := c.Get("user"); ok {
+		if info, ok := requester.(user.Info); ok {
+			acknowledgedBy = info.GetName()
+		}
+	}
+
+	resourceType, resourceName := c.Param("type"), c.Param("name")
+	acknowledged, err := h.issueService.AcknowledgeIssuesByScope(c.Request.Context(), resourceType, resourceName, namespace, acknowledgedBy, req.Note)
+	if err != nil {
+		h.logger.WithError(err).WithFields(logrus.Fields{
+			"namespace":     namespace,
+			"resource_type": resourceType,
+			"resource_name": resourceName,
+		}).Error("Failed to acknowledge issues")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge issues"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"acknowledged": acknowledged})
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeAccessChecker returns a namespace checker backed by a fake clientset
+// that only allows access to the namespaces passed.
+func newFakeAccessChecker(logger *logrus.Logger, allowedNamespaces ...string) *middleware.NamespaceChecker {
+	allowed := make(map[string]bool)
+	for _, namespace := range allowedNamespaces {
+		allowed[namespace] = true
+	}
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Namespace]
+		return true, review, nil
+	})
+
+	return middleware.NewNamespaceCheckerWithClient(client, logger)
+}
+
+// setupTestAuthenticatedRouter creates a test router where requests come from an authenticated user
+func setupTestAuthenticatedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &user.DefaultInfo{Name: "test-user"})
+		c.Next()
+	})
+
+	return router
+}
+
+// setupTestNamespaceRouter creates a test router with an authenticated user
+func setupTestNamespaceRouter(handler *NamespaceHandler) *gin.Engine {
+	router := setupTestAuthenticatedRouter()
+	router.GET("/api/v1/namespaces", handler.GetNamespaces)
+	router.POST("/api/v1/namespaces/:namespace/resources/:type/:name/acknowledge", handler.AcknowledgeResourceIssues)
+
+	return router
+}
+
+func TestNamespaceHandler_GetNamespaces(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	mockService := &MockIssueService{
+		findNamespacesResult: []dto.NamespaceSummary{
+			{Namespace: "team-alpha", ActiveCount: 3},
+			{Namespace: "team-beta", ActiveCount: 1},
+			{Namespace: "team-gamma", ActiveCount: 0},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		accessChecker NamespaceAccessChecker
+		expected      []string
+	}{
+		{
+			name:          "only accessible namespaces are returned",
+			accessChecker: newFakeAccessChecker(logger, "team-alpha", "team-gamma"),
+			expected:      []string{"team-alpha", "team-gamma"},
+		},
+		{
+			name:          "no accessible namespaces",
+			accessChecker: newFakeAccessChecker(logger),
+			expected:      []string{},
+		},
+		{
+			name:          "no access checker",
+			accessChecker: nil,
+			expected:      []string{"team-alpha", "team-beta", "team-gamma"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNamespaceHandler(mockService, tt.accessChecker, logger)
+			router := setupTestNamespaceRouter(handler)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/namespaces", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+
+			var response struct {
+				Data []dto.NamespaceSummary `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+
+			if len(response.Data) != len(tt.expected) {
+				t.Fatalf("expected %d namespaces, got %d", len(tt.expected), len(response.Data))
+			}
+			for i, namespace := range response.Data {
+				if namespace.Namespace != tt.expected[i] {
+					t.Errorf("expected namespace '%s', got '%s'", tt.expected[i], namespace.Namespace)
+				}
+			}
+		})
+	}
+}
+
+func TestNamespaceHandler_AcknowledgeResourceIssues(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		serviceError   error
+		expectedStatus int
+		expectedArgs   []string
+	}{
+		{
+			name:           "with a note",
+			path:           "/api/v1/namespaces/team-alpha/resources/component/registry/acknowledge",
+			body:           `{"note": "INC-42"}`,
+			expectedStatus: net_http.StatusOK,
+			expectedArgs:   []string{"component", "registry", "team-alpha", "test-user", "INC-42"},
+		},
+		{
+			name:           "without a body",
+			path:           "/api/v1/namespaces/team-alpha/resources/component/registry/acknowledge",
+			expectedStatus: net_http.StatusOK,
+			expectedArgs:   []string{"component", "registry", "team-alpha", "test-user", ""},
+		},
+		{
+			name:           "invalid body",
+			path:           "/api/v1/namespaces/team-alpha/resources/component/registry/acknowledge",
+			body:           `{"note": 42}`,
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "invalid namespace",
+			path:           "/api/v1/namespaces/Team_Alpha/resources/component/registry/acknowledge",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			path:           "/api/v1/namespaces/team-alpha/resources/component/registry/acknowledge",
+			serviceError:   errors.New("database unavailable"),
+			expectedStatus: net_http.StatusInternalServerError,
+			expectedArgs:   []string{"component", "registry", "team-alpha", "test-user", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{acknowledgeByScopeResult: 3, acknowledgeByScopeError: tt.serviceError}
+			router := setupTestNamespaceRouter(NewNamespaceHandler(mockService, nil, logger))
+
+			req, err := net_http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if !slices.Equal(mockService.acknowledgeByScopeArgs, tt.expectedArgs) {
+				t.Errorf("expected the service to be called with %v, got %v", tt.expectedArgs, mockService.acknowledgeByScopeArgs)
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Acknowledged int64 `json:"acknowledged"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Acknowledged != 3 {
+				t.Errorf("expected 3 issues acknowledged, got %d", response.Acknowledged)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/pkg/buildinfo"
+)
+
+// versionPath is the path of the version endpoint, the root path can redirect to it
+const versionPath = "/api/v1/version/"
+
+// serviceInfo returns the name, description and version of the service
+func serviceInfo() gin.H {
+	return gin.H{
+		"name":        "Konflux Issues Dashboard API",
+		"description": "The backend service that powers the Konflux Issues Dashboard",
+		"version":     kiteConf.GetEnvOrDefault("KITE_VERSION", "0.0.1"),
+	}
+}
+
+// GetVersion returns the service info, along with the metadata of the build
+// to correlate the deployed binary with its commit
+func GetVersion(c *gin.Context) {
+	info := serviceInfo()
+	info["build"] = buildinfo.Get()
+	c.JSON(http.StatusOK, info)
+}
+
+// NewRootHandler returns the handler of the root path. Depending on the response
+// configured, it returns the service info along with the paths of the API, or
+// redirects to the version endpoint.
+func NewRootHandler(response string) gin.HandlerFunc {
+	if response == kiteConf.RootResponseRedirect {
+		return func(c *gin.Context) {
+			c.Redirect(http.StatusFound, versionPath)
+		}
+	}
+
+	return func(c *gin.Context) {
+		info := serviceInfo()
+		info["api"] = "/api/v1"
+		info["health"] = "/api/v1/health"
+		c.JSON(http.StatusOK, info)
+	}
+}
+
+// NotFound responds to requests for unknown routes with a structured 404
+func NotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, dto.APIError{
+		Error:   "Route not found",
+		Code:    dto.ErrorCodeNotFound,
+		Details: fmt.Sprintf("No route for %s %s", c.Request.Method, c.Request.URL.Path),
+	})
+}
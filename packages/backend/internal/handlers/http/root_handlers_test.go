@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	kiteConf "github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/pkg/buildinfo"
+)
+
+func setupTestRootRouter(rootResponse string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/", NewRootHandler(rootResponse))
+	router.GET("/api/v1/version/", GetVersion)
+	router.NoRoute(NotFound)
+
+	return router
+}
+
+func TestNotFound(t *testing.T) {
+	router := setupTestRootRouter(kiteConf.RootResponseInfo)
+
+	for _, path := range []string{"/unknown", "/api/v1/unknown/route"} {
+		t.Run(path, func(t *testing.T) {
+			req, err := net_http.NewRequest("GET", path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusNotFound {
+				t.Fatalf("expected status %d, got %d", net_http.StatusNotFound, w.Code)
+			}
+
+			var response dto.APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != dto.ErrorCodeNotFound {
+				t.Errorf("expected code %s, got %s", dto.ErrorCodeNotFound, response.Code)
+			}
+			if response.Error == "" {
+				t.Error("expected an error message")
+			}
+			if expected := "No route for GET " + path; response.Details != expected {
+				t.Errorf("expected details '%s', got '%s'", expected, response.Details)
+			}
+		})
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	buildinfo.GitCommit = "0a1b2c3d"
+	t.Cleanup(func() { buildinfo.GitCommit = "" })
+	router := setupTestRootRouter(kiteConf.RootResponseInfo)
+
+	req, err := net_http.NewRequest("GET", "/api/v1/version/", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status %d, got %d", net_http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Name    string         `json:"name"`
+		Version string         `json:"version"`
+		Build   buildinfo.Info `json:"build"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Name != "Konflux Issues Dashboard API" {
+		t.Errorf("expected the service name, got '%s'", response.Name)
+	}
+	if response.Version == "" {
+		t.Error("expected the service version")
+	}
+	if response.Build.GitCommit != "0a1b2c3d" {
+		t.Errorf("expected the commit of the build, got '%s'", response.Build.GitCommit)
+	}
+	if response.Build.Version == "" || response.Build.BuildDate == "" {
+		t.Errorf("expected the version and date of the build, got %+v", response.Build)
+	}
+	if response.Build.GoVersion != runtime.Version() {
+		t.Errorf("expected Go version %s, got '%s'", runtime.Version(), response.Build.GoVersion)
+	}
+}
+
+func TestRootHandler(t *testing.T) {
+	t.Run("service info", func(t *testing.T) {
+		router := setupTestRootRouter(kiteConf.RootResponseInfo)
+
+		req, err := net_http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != net_http.StatusOK {
+			t.Fatalf("expected status %d, got %d", net_http.StatusOK, w.Code)
+		}
+
+		var response map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response["name"] != "Konflux Issues Dashboard API" {
+			t.Errorf("expected the service name, got '%s'", response["name"])
+		}
+		if response["version"] == "" {
+			t.Error("expected the service version")
+		}
+		if response["api"] != "/api/v1" {
+			t.Errorf("expected the API path /api/v1, got '%s'", response["api"])
+		}
+	})
+
+	t.Run("redirect to the version", func(t *testing.T) {
+		router := setupTestRootRouter(kiteConf.RootResponseRedirect)
+
+		req, err := net_http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != net_http.StatusFound {
+			t.Fatalf("expected status %d, got %d", net_http.StatusFound, w.Code)
+		}
+		if location := w.Header().Get("Location"); location != "/api/v1/version/" {
+			t.Errorf("expected a redirect to /api/v1/version/, got '%s'", location)
+		}
+	})
+}
@@ -1,19 +1,30 @@
 package http
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	kiteConf "github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/pkg/cache"
+	"github.com/konflux-ci/kite/internal/pkg/commitlink"
+	"github.com/konflux-ci/kite/internal/pkg/customfields"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+	"github.com/konflux-ci/kite/internal/pkg/redact"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/konflux-ci/kite/internal/services"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
+// SetupRouter creates the router serving the API. Metrics are recorded unless m is
+// nil, and new issues are notified unless notifier is nil. Unless
+// ingestQueue is nil, it's started and the issues reported by webhooks are queued.
+// The create hooks are called with the issues created.
+func SetupRouter(db *gorm.DB, cfg *kiteConf.Config, logger *logrus.Logger, state *readiness.State, m *metrics.Metrics, notifier *services.Notifier, ingestQueue *services.IngestQueue, createHooks ...services.CreateHook) (*gin.Engine, error) {
 	// Set Gin mode based on environment
 	if gin.Mode() == gin.DebugMode {
 		gin.SetMode(gin.DebugMode)
@@ -25,19 +36,128 @@ func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
 	router := gin.New()
 
 	// Setup middleware
-	router.Use(middleware.Logger(logger))
+	router.Use(middleware.InFlight(state))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger, middleware.AccessLogFormat(cfg.Logging.AccessLogFormat)))
+	// Shed load past the in-flight limit, health checks still report the server is up
+	router.Use(middleware.LoadShedding(cfg.Server.MaxInFlightRequests, "/api/v1/health"))
+	if cfg.Server.EnableCompression {
+		router.Use(middleware.Gzip(cfg.Server.CompressionMinSize, logger))
+	}
+	if cfg.Server.JSONNaming == kiteConf.JSONNamingSnakeCase {
+		router.Use(middleware.SnakeCaseJSON(logger))
+	}
+	// Redacts secrets from issues, and from bodies logged when debugging
+	redactor, err := redact.New(cfg.Redaction.Patterns)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Logging.LogBodies {
+		logger.Warn("Request and response bodies are logged at debug level, this is meant for debugging only")
+		router.Use(middleware.BodyLogger(logger, redactor, cfg.Logging.MaxLoggedBodySize))
+	}
 	router.Use(middleware.ErrorHandler(logger))
 	router.Use(middleware.CORS())
 	router.Use(gin.Recovery())
 
+	linkDomains, err := linkdomain.New(cfg.Security.AllowedLinkDomains)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transient parts of resource names, stripped before matching duplicates
+	var resourceNameStrip *regexp.Regexp
+	if cfg.Dedup.ResourceNameStrip != "" {
+		resourceNameStrip, err = regexp.Compile(cfg.Dedup.ResourceNameStrip)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize repository
-	issueRepo := repository.NewIssueRepository(db, logger)
+	repoOptions := []repository.Option{
+		repository.WithDedupOptions(repository.DedupOptions{
+			IncludeResolved:   cfg.Dedup.IncludeResolved,
+			MaxOccurrences:    cfg.Dedup.MaxOccurrences,
+			AcrossNamespaces:  cfg.Dedup.AcrossNamespaces,
+			MinUpdateInterval: cfg.Dedup.MinUpdateInterval,
+			Strategy:          repository.DedupStrategy(cfg.Dedup.Strategy),
+			EnforceUnique:     cfg.Dedup.EnforceUnique,
+			ResourceNameStrip: resourceNameStrip,
+		}),
+		repository.WithMaxRelationshipsPerIssue(cfg.Relations.MaxPerIssue),
+		repository.WithMaxActiveIssuesPerNamespace(cfg.Limits.MaxActiveIssuesPerNamespace),
+		repository.WithResolutionDeadlines(cfg.Deadlines.Resolution),
+		repository.WithResolveConflictWindow(cfg.Resolve.ConflictWindow),
+	}
+	if cfg.Relations.AutoRelateSameResource {
+		repoOptions = append(repoOptions, repository.WithAutoRelateSameResource(cfg.Relations.MaxAutoRelations))
+	}
+	if cfg.Logging.LogDedupKeys {
+		repoOptions = append(repoOptions, repository.WithDedupKeyLogging())
+	}
+	issueRepo := repository.NewIssueRepository(db, logger, repoOptions...)
 	// Initialize services
-	issueService := services.NewIssueService(issueRepo, logger)
+	serviceOptions := []services.Option{
+		services.WithRedactor(redactor),
+		services.WithMetrics(m),
+		services.WithNotifier(notifier),
+		services.WithCreateHooks(createHooks...),
+		services.WithCreateHookErrors(cfg.Hooks.FailOnCreateHookError),
+		services.WithSeverityFloors(cfg.Severity.Floors),
+		services.WithAllowedLinkDomains(linkDomains),
+	}
+	if cfg.Metrics.AggregateCacheTTL > 0 {
+		serviceOptions = append(serviceOptions, services.WithAggregateCache(cfg.Metrics.AggregateCacheTTL))
+	}
+	if cfg.Commits.LinkTemplate != "" {
+		linker, err := commitlink.New(cfg.Commits.LinkTemplate)
+		if err != nil {
+			return nil, err
+		}
+		serviceOptions = append(serviceOptions, services.WithCommitLinker(linker))
+	}
+	issueService := services.NewIssueService(issueRepo, logger, serviceOptions...)
 
 	// Initialize handlers
-	issueHandler := NewIssueHandler(issueService, logger)
-	webhookHandler := NewWebhookHandler(issueService, logger)
+	ignoredFailureReasons, err := compileFailureReasonPatterns(cfg.Webhooks.IgnoreFailureReasons)
+	if err != nil {
+		return nil, err
+	}
+	issueTemplates, err := ParseIssueTemplates(cfg.Webhooks.IssueTemplates)
+	if err != nil {
+		return nil, err
+	}
+	releaseLogsURLTemplate, err := ParseReleaseLogsURLTemplate(cfg.Webhooks.ReleaseLogsURLTemplate)
+	if err != nil {
+		return nil, err
+	}
+	quickCreateTemplates, err := ParseQuickCreateTemplates(cfg.Templates.Issues)
+	if err != nil {
+		return nil, err
+	}
+	customFieldSchemas, err := customfields.Parse(cfg.Fields.Schemas)
+	if err != nil {
+		return nil, err
+	}
+	deliveryService := services.NewWebhookDeliveryService(repository.NewWebhookDeliveryRepository(db, logger), logger)
+	webhookOptions := []WebhookOption{
+		WithIgnoredFailureReasons(ignoredFailureReasons),
+		WithDeliveryService(deliveryService),
+		WithIssueTemplates(issueTemplates),
+		WithReleaseLogsURLTemplate(releaseLogsURLTemplate),
+		WithReleaseIssueScope(cfg.Webhooks.ReleaseIssueScope),
+		WithMintmakerMaxLogBytes(cfg.Webhooks.MintmakerMaxLogBytes),
+		WithWebhookMaxScopeFieldLength(cfg.Limits.MaxScopeFieldLength),
+		WithWebhookAllowedLinkDomains(linkDomains),
+		WithMaxEventAge(cfg.Webhooks.MaxEventAge),
+		WithWebhookResponse(cfg.Webhooks.Response),
+		WithSeverityAliases(cfg.Severity.Aliases),
+	}
+	if ingestQueue != nil {
+		ingestQueue.Start(issueService)
+		webhookOptions = append(webhookOptions, WithIngestQueue(ingestQueue))
+	}
 
 	// Initialize namespace checker
 	namespaceChecker, err := middleware.NewNamespaceChecker(logger)
@@ -46,57 +166,168 @@ func SetupRouter(db *gorm.DB, logger *logrus.Logger) (*gin.Engine, error) {
 	}
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	// Don't serve requests until the database schema is ready, health checks report it
+	v1.Use(middleware.WaitForStartup(state, "/api/v1/health"))
+
+	// Authentication level required by each route, enforced by the authentication and namespace checks
+	routePolicies, err := middleware.NewRoutePolicies(cfg.Security.RoutePolicies)
+	if err != nil {
+		return nil, err
+	}
 
 	// Add middleware for authentication in non development environment
 	kiteEnv := kiteConf.GetEnvOrDefault("KITE_PROJECT_ENV", "development")
 	if kiteEnv != "development" {
-		v1.Use(namespaceChecker.Authentication(cache, 10 * time.Second, 10 * time.Second))
+		v1.Use(middleware.RoutePolicy(routePolicies))
+		v1.Use(namespaceChecker.Authentication(cache, 10 * time.Second, 10 * time.Second, cfg.Security.AnonymousRead))
 		v1.Use(namespaceChecker.Impersonation(cache, 10 * time.Second, 10 * time.Second))
 	}
 
-	// Issues routes with namespace checking
+	// Namespaces containing issues, filtered by what the requester can access
+	var accessChecker NamespaceAccessChecker
+	var accessReviewer NamespaceAccessReviewer
+	if namespaceChecker != nil && kiteEnv != "development" {
+		accessChecker = namespaceChecker
+		accessReviewer = namespaceChecker
+	}
+	webhookOptions = append(webhookOptions, WithWebhookAccessChecker(accessChecker))
+	webhookHandler := NewWebhookHandler(issueService, logger, webhookOptions...)
+	namespaceHandler := NewNamespaceHandler(issueService, accessChecker, logger)
+	v1.GET("/namespaces", namespaceHandler.GetNamespaces)
+
+	// Namespace routes with namespace checking
+	namespacesGroup := v1.Group("/namespaces/:namespace")
+	if namespaceChecker != nil && kiteEnv != "development" {
+		namespacesGroup.Use(namespaceChecker.CheckNamespacessAccess())
+	}
+	{
+		namespacesGroup.POST("/resources/:type/:name/acknowledge", namespaceHandler.AcknowledgeResourceIssues)
+	}
+
+	// Who the requester is authenticated as, to debug denied requests
+	whoAmIHandler := NewWhoAmIHandler(accessReviewer, logger)
+	v1.GET("/whoami", whoAmIHandler.WhoAmI)
+
+	issueHandlerOptions := []IssueHandlerOption{
+		WithPageSize(cfg.Paging.DefaultPageSize, cfg.Paging.MaxPageSize),
+		WithMaxOffset(cfg.Paging.MaxOffset),
+		WithMaxGroups(cfg.Paging.MaxGroups),
+		WithMaxGraphSize(cfg.Relations.MaxGraphSize),
+		WithAttachmentLimits(cfg.Attach.ContentTypes, int64(cfg.Attach.MaxSize)),
+		WithMaxScopeFieldLength(cfg.Limits.MaxScopeFieldLength),
+		WithAllowedLinkDomains(linkDomains),
+		WithNamespaceAccessChecker(accessChecker),
+		WithQuickCreateTemplates(quickCreateTemplates),
+		WithCustomFieldSchemas(customFieldSchemas),
+		WithStrictJSON(cfg.Server.StrictJSON),
+	}
+	if cfg.Security.AuthorizationNamespace == kiteConf.AuthorizationNamespaceResource {
+		issueHandlerOptions = append(issueHandlerOptions, WithResourceNamespaceAuthorization())
+	}
+	if namespaceChecker != nil && kiteEnv != "development" {
+		omit := cfg.Security.SensitiveFieldsMode == kiteConf.SensitiveFieldsOmit
+		sensitiveFields := NewSensitiveFieldsPolicy(cfg.Security.SensitiveNamespaces, cfg.Security.SensitiveAccessVerb, omit, namespaceChecker)
+		issueHandlerOptions = append(issueHandlerOptions, WithSensitiveFieldsPolicy(sensitiveFields))
+	}
+	issueHandler := NewIssueHandler(issueService, logger, issueHandlerOptions...)
+	// Imports span several namespaces, access is checked for each imported issue
+	v1.POST("/issues/import", issueHandler.ImportIssues)
+	// The ranking spans all namespaces, only the ones the requester can access are kept
+	v1.GET("/issues/by-namespace", issueHandler.GetIssuesByNamespace)
+
+	// Issues routes with namespace checking, only the listing routes can query several namespaces
 	issuesGroup := v1.Group("/issues")
 	if namespaceChecker != nil && kiteEnv != "development" {
-		issuesGroup.Use(namespaceChecker.CheckNamespacessAccess())
+		issuesGroup.Use(namespaceChecker.CheckNamespacessAccess(
+			"/api/v1/issues/", "/api/v1/issues/search", "/api/v1/issues/grouped", "/api/v1/issues/metrics/mttr",
+		))
 	}
 	{
 		issuesGroup.GET("/", issueHandler.GetIssues)
 		issuesGroup.POST("/", issueHandler.CreateIssue)
+		issuesGroup.POST("/bulk-delete", issueHandler.BulkDeleteIssues)
+		issuesGroup.POST("/batch-get", issueHandler.BatchGetIssues)
+		issuesGroup.POST("/from-template/:name", issueHandler.CreateIssueFromTemplate)
+		issuesGroup.GET("/search", issueHandler.SearchIssues)
+		issuesGroup.GET("/grouped", issueHandler.GetGroupedIssues)
+		issuesGroup.GET("/metrics/mttr", issueHandler.GetMTTR)
 		issuesGroup.GET("/:id", middleware.ValidateID(), issueHandler.GetIssue)
+		issuesGroup.GET("/:id/status", middleware.ValidateID(), issueHandler.GetIssueStatus)
 		issuesGroup.PUT("/:id", middleware.ValidateID(), issueHandler.UpdateIssue)
+		issuesGroup.PATCH("/:id", middleware.ValidateID(), issueHandler.PatchIssue)
 		issuesGroup.DELETE("/:id", middleware.ValidateID(), issueHandler.DeleteIssue)
 		issuesGroup.POST("/:id/resolve", middleware.ValidateID(), issueHandler.ResolveIssue)
+		issuesGroup.POST("/:id/snooze", middleware.ValidateID(), issueHandler.SnoozeIssue)
+		issuesGroup.DELETE("/:id/snooze", middleware.ValidateID(), issueHandler.UnsnoozeIssue)
+		issuesGroup.GET("/:id/occurrences", middleware.ValidateID(), issueHandler.GetIssueOccurrences)
+		issuesGroup.GET("/:id/occurrences/histogram", middleware.ValidateID(), issueHandler.GetIssueOccurrenceHistogram)
+		issuesGroup.GET("/:id/attachments", middleware.ValidateID(), issueHandler.GetIssueAttachments)
+		issuesGroup.POST("/:id/attachments", middleware.ValidateID(), issueHandler.AddIssueAttachment)
+		issuesGroup.GET("/:id/graph", middleware.ValidateID(), issueHandler.GetIssueGraph)
 		issuesGroup.POST("/:id/related", middleware.ValidateID(), issueHandler.AddRelatedIssue)
+		issuesGroup.DELETE("/:id/related", middleware.ValidateID(), issueHandler.RemoveAllRelatedIssues)
 		issuesGroup.DELETE("/:id/related/:relatedId", middleware.ValidateID(), issueHandler.RemoveRelatedIssue)
+		issuesGroup.POST("/:id/links", middleware.ValidateID(), issueHandler.AddIssueLink)
+		issuesGroup.PUT("/:id/links/:linkId", middleware.ValidateID(), issueHandler.UpdateIssueLink)
+		issuesGroup.DELETE("/:id/links/:linkId", middleware.ValidateID(), issueHandler.DeleteIssueLink)
+		issuesGroup.POST("/relationships/batch", issueHandler.BatchAddRelatedIssues)
+	}
+
+	// Admin routes with namespace checking
+	var adminHandlerOptions []AdminHandlerOption
+	if namespaceChecker != nil && kiteEnv != "development" {
+		adminHandlerOptions = append(adminHandlerOptions, WithDecommissionAccess(namespaceChecker, cfg.Security.DecommissionAccessVerb))
+	}
+	if ingestQueue != nil {
+		adminHandlerOptions = append(adminHandlerOptions, WithQueueStats(ingestQueue))
+	}
+	adminHandler := NewAdminHandler(issueService, logger, adminHandlerOptions...)
+	// The queue holds issues of every namespace, its stats don't reveal any of them
+	v1.GET("/admin/queue/stats", adminHandler.GetQueueStats)
+	adminGroup := v1.Group("/admin")
+	if namespaceChecker != nil && kiteEnv != "development" {
+		adminGroup.Use(namespaceChecker.CheckNamespacessAccess())
+	}
+	{
+		adminGroup.POST("/dedup-scan", adminHandler.DedupScan)
+		adminGroup.POST("/namespaces/:namespace/resolve-all", adminHandler.ResolveNamespace)
 	}
 
 	// Webhook routes with namespace checking
 	webhooksGroup := v1.Group("/webhooks")
+	// Smooth bursts of webhooks, before any of them reaches the database
+	webhooksGroup.Use(middleware.ConcurrencyLimit(cfg.Webhooks.MaxConcurrency, cfg.Webhooks.QueueTimeout))
 	if namespaceChecker != nil && kiteEnv != "development" {
 		webhooksGroup.Use(namespaceChecker.CheckNamespacessAccess())
 	}
+	webhooksGroup.Use(webhookHandler.TrackDelivery())
 	{
 		webhooksGroup.POST("/pipeline-failure", webhookHandler.PipelineFailure)
 		webhooksGroup.POST("/pipeline-success", webhookHandler.PipelineSuccess)
+		// custom webhooks for component builds
+		webhooksGroup.POST("/build-failure", webhookHandler.BuildFailure)
+		webhooksGroup.POST("/build-success", webhookHandler.BuildSuccess)
 		// custom webhook for mintmaker
 		webhooksGroup.POST("/mintmaker-custom", webhookHandler.MintmakerIssues)
+		webhooksGroup.POST("/mintmaker-resolve", webhookHandler.MintmakerResolve)
 		// custom webhooks for release-service
 		webhooksGroup.POST("/release-failure", webhookHandler.ReleaseFailure)
 		webhooksGroup.POST("/release-success", webhookHandler.ReleaseSuccess)
+		webhooksGroup.GET("/deliveries/:id", middleware.ValidateID(), webhookHandler.GetDelivery)
 	}
 
 	// Health and version endpoints
 	healthGroup := v1.Group("/health")
-	healthGroup.GET("/", NewHealthHandler(db, logger))
+	healthGroup.GET("/", NewHealthHandler(db, logger, state))
+	// Liveness stays up while shutting down, only readiness goes down
+	healthGroup.GET("/live", middleware.HealthCheck(logger))
 
 	versionGroup := v1.Group("/version")
-	versionGroup.GET("/", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"name":        "Konflux Issues Dashboard API",
-			"description": "The backend service that powers the Konflux Issues Dashboard",
-			"version":     kiteConf.GetEnvOrDefault("KITE_VERSION", "0.0.1"),
-		})
-	})
+	versionGroup.GET("/", GetVersion)
+
+	// Service info at the root, and a structured 404 for unknown routes
+	router.GET("/", NewRootHandler(cfg.Server.RootResponse))
+	router.NoRoute(NotFound)
 
 	return router, nil
 }
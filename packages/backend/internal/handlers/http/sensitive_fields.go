@@ -0,0 +1,146 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+// RedactedPlaceholder replaces the sensitive fields of issues hidden by redaction
+const RedactedPlaceholder = "[REDACTED]"
+
+// ElevatedAccessChecker checks if the requester can perform a verb in a namespace
+type ElevatedAccessChecker interface {
+	CanPerformInNamespace(c *gin.Context, namespace, verb string) bool
+}
+
+// SensitiveFieldsPolicy hides the description and links of the issues of sensitive
+// namespaces from requesters without elevated access to them. This is a coarse
+// data-level authorization, on top of the access to the namespace itself.
+type SensitiveFieldsPolicy struct {
+	namespaces map[string]bool
+	verb       string // Verb granting elevated access
+	omit       bool   // Leave the fields out instead of redacting them
+	checker    ElevatedAccessChecker
+}
+
+// NewSensitiveFieldsPolicy creates a policy for the sensitive namespaces, granting elevated
+// access to the requesters who can perform the verb in them. Fields are left out when
+// omit is set, and replaced by RedactedPlaceholder otherwise.
+//
+// Returns nil if there are no sensitive namespaces, or no checker to check access with.
+func NewSensitiveFieldsPolicy(namespaces []string, verb string, omit bool, checker ElevatedAccessChecker) *SensitiveFieldsPolicy {
+	sensitive := make(map[string]bool)
+	for _, namespace := range namespaces {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			sensitive[namespace] = true
+		}
+	}
+	if len(sensitive) == 0 || checker == nil {
+		return nil
+	}
+	return &SensitiveFieldsPolicy{namespaces: sensitive, verb: verb, omit: omit, checker: checker}
+}
+
+// sensitiveFieldsFilter applies a policy to the issues of a single request,
+// checking the access to each namespace once.
+type sensitiveFieldsFilter struct {
+	policy   *SensitiveFieldsPolicy
+	c        *gin.Context
+	elevated map[string]bool
+}
+
+// filter returns the filter of the policy for a request, a nil policy hides nothing
+func (p *SensitiveFieldsPolicy) filter(c *gin.Context) *sensitiveFieldsFilter {
+	return &sensitiveFieldsFilter{policy: p, c: c, elevated: make(map[string]bool)}
+}
+
+// hides reports whether the sensitive fields of the namespace's issues are hidden from the requester
+func (f *sensitiveFieldsFilter) hides(namespace string) bool {
+	if f.policy == nil || !f.policy.namespaces[namespace] {
+		return false
+	}
+	elevated, ok := f.elevated[namespace]
+	if !ok {
+		elevated = f.policy.checker.CanPerformInNamespace(f.c, namespace, f.policy.verb)
+		f.elevated[namespace] = elevated
+	}
+	return !elevated
+}
+
+// issue hides the sensitive fields of an issue, and of the issues it's related to.
+// Returns whether the issue's own fields were hidden.
+func (f *sensitiveFieldsFilter) issue(issue *models.Issue) bool {
+	if f.policy == nil || issue == nil {
+		return false
+	}
+	for idx := range issue.RelatedFrom {
+		f.issue(&issue.RelatedFrom[idx].Target)
+	}
+	for idx := range issue.RelatedTo {
+		f.issue(&issue.RelatedTo[idx].Source)
+	}
+
+	if !f.hides(issue.Namespace) {
+		return false
+	}
+	if f.policy.omit {
+		issue.Description = ""
+		issue.Links = []models.Link{}
+		return true
+	}
+	if issue.Description != "" {
+		issue.Description = RedactedPlaceholder
+	}
+	for idx := range issue.Links {
+		issue.Links[idx].URL = RedactedPlaceholder
+	}
+	return true
+}
+
+// issues hides the sensitive fields of a list of issues
+func (f *sensitiveFieldsFilter) issues(issues []models.Issue) {
+	for idx := range issues {
+		f.issue(&issues[idx])
+	}
+}
+
+// searchResults hides the sensitive fields of search results, highlights included
+func (f *sensitiveFieldsFilter) searchResults(results []dto.SearchResult) {
+	for idx := range results {
+		if f.issue(&results[idx].Issue) {
+			// Highlights can quote the description
+			results[idx].Highlights = []string{}
+		}
+	}
+}
+
+// occurrences hides the details of an issue's occurrences, copies of its description
+func (f *sensitiveFieldsFilter) occurrences(namespace string, occurrences []models.Occurrence) {
+	if !f.hides(namespace) {
+		return
+	}
+	for idx := range occurrences {
+		if f.policy.omit {
+			occurrences[idx].Detail = ""
+		} else if occurrences[idx].Detail != "" {
+			occurrences[idx].Detail = RedactedPlaceholder
+		}
+	}
+}
+
+// attachments hides where an issue's attachments are stored, their names and media types are kept
+func (f *sensitiveFieldsFilter) attachments(namespace string, attachments []models.Attachment) {
+	if !f.hides(namespace) {
+		return
+	}
+	for idx := range attachments {
+		if f.policy.omit {
+			attachments[idx].StorageURL = ""
+		} else {
+			attachments[idx].StorageURL = RedactedPlaceholder
+		}
+	}
+}
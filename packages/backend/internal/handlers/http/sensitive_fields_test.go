@@ -0,0 +1,246 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeElevatedAccessChecker returns a namespace checker backed by a fake clientset
+// that only lets the privileged user update pods, in any namespace.
+func newFakeElevatedAccessChecker(logger *logrus.Logger, privilegedUser string) *middleware.NamespaceChecker {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == privilegedUser && review.Spec.ResourceAttributes.Verb == "update"
+		return true, review, nil
+	})
+
+	return middleware.NewNamespaceCheckerWithClient(client, logger)
+}
+
+// setupTestSensitiveRouter creates a test router where requests come from the user in the X-Test-User header
+func setupTestSensitiveRouter(handler *IssueHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if name := c.GetHeader("X-Test-User"); name != "" {
+			c.Set("user", &user.DefaultInfo{Name: name})
+		}
+		c.Next()
+	})
+	router.GET("/api/v1/issues/search", handler.SearchIssues)
+	router.GET("/api/v1/issues/:id", handler.GetIssue)
+	router.POST("/api/v1/issues/:id/resolve", handler.ResolveIssue)
+
+	return router
+}
+
+func newSensitiveIssue(namespace string) *models.Issue {
+	return &models.Issue{
+		ID:          "123e4567-e89b-12d3-a456-426614174000",
+		Title:       "Payment service build failed",
+		Description: "Credentials of the payment provider rejected",
+		Namespace:   namespace,
+		Links:       []models.Link{{Title: "Logs", URL: "https://logs.example.com/payments"}},
+	}
+}
+
+func TestIssueHandler_SensitiveFields(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	checker := newFakeElevatedAccessChecker(logger, "admin")
+
+	tests := []struct {
+		name        string
+		namespace   string
+		user        string
+		omit        bool
+		description string
+		linkURLs    []string
+	}{
+		{
+			name:        "privileged caller sees the fields",
+			namespace:   "team-payments",
+			user:        "admin",
+			description: "Credentials of the payment provider rejected",
+			linkURLs:    []string{"https://logs.example.com/payments"},
+		},
+		{
+			name:        "ordinary caller gets them redacted",
+			namespace:   "team-payments",
+			user:        "viewer",
+			description: RedactedPlaceholder,
+			linkURLs:    []string{RedactedPlaceholder},
+		},
+		{
+			name:        "ordinary caller gets them omitted",
+			namespace:   "team-payments",
+			user:        "viewer",
+			omit:        true,
+			description: "",
+			linkURLs:    []string{},
+		},
+		{
+			name:        "requests without a user get them redacted",
+			namespace:   "team-payments",
+			description: RedactedPlaceholder,
+			linkURLs:    []string{RedactedPlaceholder},
+		},
+		{
+			name:        "namespaces that aren't sensitive are left untouched",
+			namespace:   "team-alpha",
+			user:        "viewer",
+			description: "Credentials of the payment provider rejected",
+			linkURLs:    []string{"https://logs.example.com/payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{findIssueByIDResult: newSensitiveIssue(tt.namespace)}
+			policy := NewSensitiveFieldsPolicy([]string{"team-payments", " team-billing "}, "update", tt.omit, checker)
+			handler := NewIssueHandler(mockService, logger, WithSensitiveFieldsPolicy(policy))
+			router := setupTestSensitiveRouter(handler)
+
+			req, err := net_http.NewRequest("GET", "/api/v1/issues/123e4567-e89b-12d3-a456-426614174000", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tt.user != "" {
+				req.Header.Set("X-Test-User", tt.user)
+			}
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+
+			var issue models.Issue
+			if err := json.Unmarshal(w.Body.Bytes(), &issue); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if issue.Title != "Payment service build failed" {
+				t.Errorf("expected the title to be left untouched, got '%s'", issue.Title)
+			}
+			if issue.Description != tt.description {
+				t.Errorf("expected description '%s', got '%s'", tt.description, issue.Description)
+			}
+			if len(issue.Links) != len(tt.linkURLs) {
+				t.Fatalf("expected %d links, got %d", len(tt.linkURLs), len(issue.Links))
+			}
+			for i, link := range issue.Links {
+				if link.URL != tt.linkURLs[i] {
+					t.Errorf("expected link URL '%s', got '%s'", tt.linkURLs[i], link.URL)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueHandler_SensitiveFields_SearchHighlights(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	mockService := &MockIssueService{
+		searchIssuesResult: []dto.SearchResult{
+			{Issue: *newSensitiveIssue("team-payments"), Highlights: []string{"<mark>Credentials</mark> of the payment provider"}},
+			{Issue: *newSensitiveIssue("team-alpha"), Highlights: []string{"<mark>Credentials</mark> of the payment provider"}},
+		},
+	}
+	policy := NewSensitiveFieldsPolicy([]string{"team-payments"}, "update", false, newFakeElevatedAccessChecker(logger, "admin"))
+	router := setupTestSensitiveRouter(NewIssueHandler(mockService, logger, WithSensitiveFieldsPolicy(policy)))
+
+	req, err := net_http.NewRequest("GET", "/api/v1/issues/search?q=credentials", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("X-Test-User", "viewer")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data []dto.SearchResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.Data) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(response.Data))
+	}
+	if len(response.Data[0].Highlights) != 0 || response.Data[0].Issue.Description != RedactedPlaceholder {
+		t.Errorf("expected the sensitive result to be redacted, got %+v", response.Data[0])
+	}
+	if len(response.Data[1].Highlights) != 1 {
+		t.Errorf("expected the other result to keep its highlights, got %v", response.Data[1].Highlights)
+	}
+}
+
+func TestIssueHandler_SensitiveFields_ResolveCascade(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	mockService := &MockIssueService{
+		findIssueByIDResult:      newSensitiveIssue("team-payments"),
+		resolveWithCascadeResult: []string{"caused-abc"},
+	}
+	policy := NewSensitiveFieldsPolicy([]string{"team-payments"}, "update", false, newFakeElevatedAccessChecker(logger, "admin"))
+	router := setupTestSensitiveRouter(NewIssueHandler(mockService, logger, WithSensitiveFieldsPolicy(policy)))
+
+	req, err := net_http.NewRequest("POST", "/api/v1/issues/123e4567-e89b-12d3-a456-426614174000/resolve?cascade=true", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("X-Test-User", "viewer")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Issue models.Issue `json:"issue"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Issue.Description != RedactedPlaceholder {
+		t.Errorf("expected the description to be redacted, got '%s'", response.Issue.Description)
+	}
+	if len(response.Issue.Links) != 1 || response.Issue.Links[0].URL != RedactedPlaceholder {
+		t.Errorf("expected the links to be redacted, got %+v", response.Issue.Links)
+	}
+}
+
+func TestNewSensitiveFieldsPolicy_Disabled(t *testing.T) {
+	checker := newFakeElevatedAccessChecker(logrus.New(), "admin")
+	if policy := NewSensitiveFieldsPolicy(nil, "update", false, checker); policy != nil {
+		t.Error("expected no policy without sensitive namespaces")
+	}
+	if policy := NewSensitiveFieldsPolicy([]string{"team-payments"}, "update", false, nil); policy != nil {
+		t.Error("expected no policy without an access checker")
+	}
+}
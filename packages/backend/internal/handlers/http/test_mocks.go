@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
@@ -10,24 +11,99 @@ import (
 
 // MockIssueService is a mock implementation for testing handlers
 type MockIssueService struct {
+	findIssuesFilters             repository.IssueQueryFilters // Filters received by FindIssues
 	findIssueResults              *dto.IssueResponse
 	findIssuesError               error
 	findIssueByIDResult           *models.Issue
 	findIssueByIDError            error
+	findIssueStatusResult         *dto.IssueStatus
+	findIssueStatusError          error
 	createIssueResult             *models.Issue
 	createIssueError              error
+	createIssueRequest            dto.CreateIssueRequest // Last request received by CreateIssue
 	deleteIssueError              error
+	updateIssueRequest            dto.UpdateIssueRequest // Request received by UpdateIssue
 	updateIssueResult             *models.Issue
 	updateIssueError              error
 	findDuplicateIssueResult      *models.Issue
 	findDuplicateIssueResultError error
 	resolveIssuesByScopeResult    int64
 	resolveIssuesByScopeError     error
+	resolveByScopePrefix          string   // Name prefix received by ResolveIssuesByScopePrefix
+	acknowledgeByScopeArgs        []string // Scope, acknowledger and note received by AcknowledgeIssuesByScope
+	acknowledgeByScopeResult      int64
+	acknowledgeByScopeError       error
 	createOrUpdateIssueResult     *models.Issue
+	createOrUpdateIssueCreated    bool
 	createOrUpdateIssueError      error
+	createOrUpdateIssueCalls      int                    // Number of times CreateOrUpdateIssue was called
+	createOrUpdateIssueRequest    dto.CreateIssueRequest // Last request received by CreateOrUpdateIssue
+	findNamespacesResult          []dto.NamespaceSummary
+	findNamespacesError           error
+	rankNamespacesStates          []models.IssueState // States received by RankNamespacesByIssues
+	rankNamespacesLimit           int                 // Limit received by RankNamespacesByIssues
+	rankNamespacesResult          []dto.NamespaceIssueCount
+	rankNamespacesError           error
+	batchAddRelatedIssuesRequest  []dto.RelationshipEdgeRequest // Edges received by BatchAddRelatedIssues
+	batchAddRelatedIssuesResult   []dto.RelationshipEdgeResult
+	batchAddRelatedIssuesError    error
+	removeAllRelatedResult        int64
+	removeAllRelatedError         error
+	findIssueGraphMaxSize         int // Size limit received by FindIssueGraph
+	findIssueGraphResult          *dto.IssueGraph
+	findIssueGraphError           error
+	bulkDeleteIssuesResult        []dto.BulkIssueResult
+	bulkDeleteIssuesError         error
+	mergeDuplicatesDryRun         bool // Dry run flag received by MergeDuplicateIssues
+	mergeDuplicatesResult         []dto.DuplicateMerge
+	mergeDuplicatesError          error
+	resolveNamespaceResult        int64
+	resolveNamespaceError         error
+	resolveWithCascadeResult      []string
+	resolveWithCascadeError       error
+	addIssueLinkResult            *models.Link
+	addIssueLinkError             error
+	updateIssueLinkResult         *models.Link
+	updateIssueLinkError          error
+	deleteIssueLinkError          error
+	findIssuesByIDsRequest        []string // IDs received by FindIssuesByIDs
+	findIssuesByIDsResult         []models.Issue
+	findIssuesByIDsError          error
+	findOccurrencesResult         []models.Occurrence
+	findOccurrencesError          error
+	histogramUnit                 string
+	histogramSince                time.Time
+	histogramResult               []dto.OccurrenceBucket
+	histogramError                error
+	addAttachmentReq              dto.CreateAttachmentRequest // Attachment received by AddIssueAttachment
+	addAttachmentResult           *models.Attachment
+	addAttachmentError            error
+	findAttachmentsResult         []models.Attachment
+	findAttachmentsError          error
+	searchIssuesFilters           repository.IssueQueryFilters // Filters received by SearchIssues
+	searchIssuesResult            []dto.SearchResult
+	searchIssuesError             error
+	groupIssuesFilters            repository.IssueQueryFilters // Filters received by GroupIssuesByResource
+	groupIssuesMaxGroups          int                          // Group limit received by GroupIssuesByResource
+	groupIssuesResult             []dto.IssueGroup
+	groupIssuesError              error
+	patchIssueOps                 []dto.PatchOperation // Operations received by PatchIssue
+	patchIssueResult              *models.Issue
+	patchIssueError               error
+	snoozeIssueID                 string // ID received by SnoozeIssue
+	snoozeIssueSnoozed            *bool  // Value received by SnoozeIssue
+	snoozeIssueError              error
+	importIssueError              error
+	findMTTRFilters               repository.IssueQueryFilters // Filters received by FindMTTR
+	findMTTRSince                 time.Time                    // Window start received by FindMTTR
+	findMTTRGroupBy               string                       // Grouping received by FindMTTR
+	findMTTRResult                []dto.MTTRGroup
+	findMTTRError                 error
+	importedIssues                []dto.CreateIssueRequest // Issues received by ImportIssue
 }
 
 func (m *MockIssueService) FindIssues(ctx context.Context, filters repository.IssueQueryFilters) (*dto.IssueResponse, error) {
+	m.findIssuesFilters = filters
 	return m.findIssueResults, m.findIssuesError
 }
 
@@ -35,11 +111,22 @@ func (m *MockIssueService) FindIssueByID(ctx context.Context, id string) (*model
 	return m.findIssueByIDResult, m.findIssueByIDError
 }
 
+func (m *MockIssueService) FindIssuesByIDs(ctx context.Context, ids []string) ([]models.Issue, error) {
+	m.findIssuesByIDsRequest = ids
+	return m.findIssuesByIDsResult, m.findIssuesByIDsError
+}
+
+func (m *MockIssueService) FindIssueStatus(ctx context.Context, id string) (*dto.IssueStatus, error) {
+	return m.findIssueStatusResult, m.findIssueStatusError
+}
+
 func (m *MockIssueService) CreateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
+	m.createIssueRequest = req
 	return m.createIssueResult, m.createIssueError
 }
 
 func (m *MockIssueService) UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error) {
+	m.updateIssueRequest = req
 	return m.updateIssueResult, m.updateIssueError
 }
 
@@ -51,14 +138,30 @@ func (m *MockIssueService) FindDuplicateIssue(ctx context.Context, req dto.Creat
 	return m.findDuplicateIssueResult, m.findDuplicateIssueResultError
 }
 
-func (m *MockIssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
-	return m.createOrUpdateIssueResult, m.findDuplicateIssueResultError
+func (m *MockIssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	m.createOrUpdateIssueCalls++
+	m.createOrUpdateIssueRequest = req
+	return m.createOrUpdateIssueResult, m.createOrUpdateIssueCreated, m.findDuplicateIssueResultError
 }
 
 func (m *MockIssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
 	return m.resolveIssuesByScopeResult, m.resolveIssuesByScopeError
 }
 
+func (m *MockIssueService) ResolveIssuesByScopes(ctx context.Context, resourceTypes []string, resourceName, namespace string) (int64, error) {
+	return m.resolveIssuesByScopeResult, m.resolveIssuesByScopeError
+}
+
+func (m *MockIssueService) ResolveIssuesByScopePrefix(ctx context.Context, resourceType, namePrefix, namespace string) (int64, error) {
+	m.resolveByScopePrefix = namePrefix
+	return m.resolveIssuesByScopeResult, m.resolveIssuesByScopeError
+}
+
+func (m *MockIssueService) AcknowledgeIssuesByScope(ctx context.Context, resourceType, resourceName, namespace, acknowledgedBy, note string) (int64, error) {
+	m.acknowledgeByScopeArgs = []string{resourceType, resourceName, namespace, acknowledgedBy, note}
+	return m.acknowledgeByScopeResult, m.acknowledgeByScopeError
+}
+
 func (m *MockIssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	return nil
 }
@@ -66,3 +169,115 @@ func (m *MockIssueService) AddRelatedIssue(ctx context.Context, sourceID, target
 func (m *MockIssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	return nil
 }
+
+func (m *MockIssueService) RemoveAllRelatedIssues(ctx context.Context, id string) (int64, error) {
+	return m.removeAllRelatedResult, m.removeAllRelatedError
+}
+
+func (m *MockIssueService) FindIssueGraph(ctx context.Context, id string, maxSize int) (*dto.IssueGraph, error) {
+	m.findIssueGraphMaxSize = maxSize
+	return m.findIssueGraphResult, m.findIssueGraphError
+}
+
+func (m *MockIssueService) FindNamespaces(ctx context.Context) ([]dto.NamespaceSummary, error) {
+	return m.findNamespacesResult, m.findNamespacesError
+}
+
+func (m *MockIssueService) RankNamespacesByIssues(ctx context.Context, states []models.IssueState, limit int) ([]dto.NamespaceIssueCount, error) {
+	m.rankNamespacesStates = states
+	m.rankNamespacesLimit = limit
+	return m.rankNamespacesResult[:min(limit, len(m.rankNamespacesResult))], m.rankNamespacesError
+}
+
+func (m *MockIssueService) BatchAddRelatedIssues(ctx context.Context, edges []dto.RelationshipEdgeRequest) ([]dto.RelationshipEdgeResult, error) {
+	m.batchAddRelatedIssuesRequest = edges
+	return m.batchAddRelatedIssuesResult, m.batchAddRelatedIssuesError
+}
+
+func (m *MockIssueService) BulkDeleteIssues(ctx context.Context, namespace string, ids []string) ([]dto.BulkIssueResult, error) {
+	return m.bulkDeleteIssuesResult, m.bulkDeleteIssuesError
+}
+
+func (m *MockIssueService) MergeDuplicateIssues(ctx context.Context, namespace string, dryRun bool) ([]dto.DuplicateMerge, error) {
+	m.mergeDuplicatesDryRun = dryRun
+	return m.mergeDuplicatesResult, m.mergeDuplicatesError
+}
+
+func (m *MockIssueService) ResolveNamespaceIssues(ctx context.Context, namespace string) (int64, error) {
+	return m.resolveNamespaceResult, m.resolveNamespaceError
+}
+
+func (m *MockIssueService) ResolveIssueWithCascade(ctx context.Context, id string) ([]string, error) {
+	return m.resolveWithCascadeResult, m.resolveWithCascadeError
+}
+
+func (m *MockIssueService) AddIssueLink(ctx context.Context, issueID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	return m.addIssueLinkResult, m.addIssueLinkError
+}
+
+func (m *MockIssueService) UpdateIssueLink(ctx context.Context, issueID, linkID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	return m.updateIssueLinkResult, m.updateIssueLinkError
+}
+
+func (m *MockIssueService) DeleteIssueLink(ctx context.Context, issueID, linkID string) error {
+	return m.deleteIssueLinkError
+}
+
+func (m *MockIssueService) FindIssueOccurrences(ctx context.Context, issueID string) ([]models.Occurrence, error) {
+	return m.findOccurrencesResult, m.findOccurrencesError
+}
+
+func (m *MockIssueService) FindOccurrenceHistogram(ctx context.Context, issueID, unit string, since time.Time) ([]dto.OccurrenceBucket, error) {
+	m.histogramUnit = unit
+	m.histogramSince = since
+	return m.histogramResult, m.histogramError
+}
+
+func (m *MockIssueService) AddIssueAttachment(ctx context.Context, issueID string, req dto.CreateAttachmentRequest) (*models.Attachment, error) {
+	m.addAttachmentReq = req
+	return m.addAttachmentResult, m.addAttachmentError
+}
+
+func (m *MockIssueService) FindIssueAttachments(ctx context.Context, issueID string) ([]models.Attachment, error) {
+	return m.findAttachmentsResult, m.findAttachmentsError
+}
+
+func (m *MockIssueService) SearchIssues(ctx context.Context, filters repository.IssueQueryFilters) ([]dto.SearchResult, error) {
+	m.searchIssuesFilters = filters
+	return m.searchIssuesResult, m.searchIssuesError
+}
+
+func (m *MockIssueService) GroupIssuesByResource(ctx context.Context, filters repository.IssueQueryFilters, maxGroups int) ([]dto.IssueGroup, error) {
+	m.groupIssuesFilters = filters
+	m.groupIssuesMaxGroups = maxGroups
+	return m.groupIssuesResult, m.groupIssuesError
+}
+
+func (m *MockIssueService) PatchIssue(ctx context.Context, id string, ops []dto.PatchOperation) (*models.Issue, error) {
+	m.patchIssueOps = ops
+	return m.patchIssueResult, m.patchIssueError
+}
+
+func (m *MockIssueService) SnoozeIssue(ctx context.Context, id string, snoozed bool) (*models.Issue, error) {
+	m.snoozeIssueID = id
+	m.snoozeIssueSnoozed = &snoozed
+	if m.snoozeIssueError != nil {
+		return nil, m.snoozeIssueError
+	}
+	return &models.Issue{ID: id, SnoozedUntilRecurrence: snoozed}, nil
+}
+
+func (m *MockIssueService) ImportIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	if m.importIssueError != nil {
+		return nil, false, m.importIssueError
+	}
+	m.importedIssues = append(m.importedIssues, req)
+	return &models.Issue{Title: req.Title, Namespace: req.Namespace}, true, nil
+}
+
+func (m *MockIssueService) FindMTTR(ctx context.Context, filters repository.IssueQueryFilters, since time.Time, groupBy string) ([]dto.MTTRGroup, error) {
+	m.findMTTRFilters = filters
+	m.findMTTRSince = since
+	m.findMTTRGroupBy = groupBy
+	return m.findMTTRResult, m.findMTTRError
+}
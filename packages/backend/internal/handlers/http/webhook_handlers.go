@@ -1,30 +1,556 @@
 package http
 
 import (
+	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/konflux-ci/kite/internal/config"
 	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
 	"github.com/konflux-ci/kite/internal/services"
 	"github.com/sirupsen/logrus"
 )
 
 // WebhookHandler handles incoming webhook requests for pipeline events.
 type WebhookHandler struct {
-	issueService services.IssueServiceInterface // Issue service for managing issues
-	logger       *logrus.Logger                 // Logger for structured logging
+	issueService          services.IssueServiceInterface // Issue service for managing issues
+	logger                *logrus.Logger                 // Logger for structured logging
+	ignoredFailureReasons []*regexp.Regexp               // Known-benign failures that don't create issues
+	// Records the webhooks received and their outcome, nil to disable
+	deliveryService services.WebhookDeliveryServiceInterface
+	// Custom title and description of the issues created, nil to use the default ones
+	issueTemplates *IssueTemplates
+	// Queues the issues to persist them in the background, nil to persist them right away
+	ingestQueue *services.IngestQueue
+	// Logs URL of release failures without a pipelineRunUrl, nil to link no logs
+	releaseLogsURLTemplate *template.Template
+	// Bytes of the logs joined into the description of mintmaker issues, 0 for no limit
+	mintmakerMaxLogBytes int
+	// Scope release failures to the release rather than to its application
+	scopeReleasesByName bool
+	// Events older than this are ignored, 0 accepts events of any age
+	maxEventAge time.Duration
+	// Clock the age of events is measured with
+	clock clock.Clock
+	// Longest resource type and name of the scope of the issues reported
+	maxScopeFieldLength int
+	// Domains the links of the issues reported can point to, all are allowed if nil
+	linkDomains *linkdomain.Allowlist
+	// Respond with the ID of the issue reported rather than the whole issue
+	minimalResponse bool
+	// Checks the namespaces of duplicates in other namespaces, all are allowed if nil
+	accessChecker NamespaceAccessChecker
+	// Severities accepted in place of the known ones, keyed by lowercase alias
+	severityAliases map[string]models.Severity
+}
+
+// DeliveryIDHeader is the response header carrying the ID of a webhook delivery
+const DeliveryIDHeader = "X-Kite-Delivery-ID"
+
+// deliveryKey is the context key holding the delivery of the webhook being processed
+const deliveryKey = "webhook_delivery"
+
+// WebhookOption configures optional behavior of the webhook handler
+type WebhookOption func(*WebhookHandler)
+
+// WithIgnoredFailureReasons sets the patterns of failure reasons that don't create issues
+func WithIgnoredFailureReasons(patterns []*regexp.Regexp) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.ignoredFailureReasons = patterns
+	}
+}
+
+// WithDeliveryService records the webhooks received and their outcome
+func WithDeliveryService(deliveryService services.WebhookDeliveryServiceInterface) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.deliveryService = deliveryService
+	}
+}
+
+// WithIssueTemplates customizes the title and description of the issues created by webhooks
+func WithIssueTemplates(templates *IssueTemplates) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.issueTemplates = templates
+	}
+}
+
+// WithIngestQueue accepts the issues reported by webhooks right away, queuing them to be persisted in the background
+func WithIngestQueue(queue *services.IngestQueue) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.ingestQueue = queue
+	}
+}
+
+// WithReleaseLogsURLTemplate links the logs at the URL rendered from the template to
+// release failures that don't provide their pipelineRunUrl
+func WithReleaseLogsURLTemplate(tmpl *template.Template) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.releaseLogsURLTemplate = tmpl
+	}
+}
+
+// WithMintmakerMaxLogBytes truncates the logs joined into the description of mintmaker
+// issues to maxBytes, so verbose runs don't store oversized descriptions
+func WithMintmakerMaxLogBytes(maxBytes int) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.mintmakerMaxLogBytes = maxBytes
+	}
+}
+
+// WithWebhookMaxScopeFieldLength sets the longest resource type and name of the scope of the issues reported
+func WithWebhookMaxScopeFieldLength(maxLength int) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.maxScopeFieldLength = maxLength
+	}
+}
+
+// WithWebhookAllowedLinkDomains leaves out the links to domains missing from the allowlist
+// from the issues reported
+func WithWebhookAllowedLinkDomains(allowlist *linkdomain.Allowlist) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.linkDomains = allowlist
+	}
+}
+
+// WithReleaseIssueScope sets what release failures are deduplicated on, config.ReleaseIssueScopeRelease
+// creating a separate issue for each release rather than one for all the releases of an application
+func WithReleaseIssueScope(scope string) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.scopeReleasesByName = scope == config.ReleaseIssueScopeRelease
+	}
+}
+
+// WithMaxEventAge ignores the events whose eventTime is older than maxAge, so replayed
+// or long-delayed events don't create or resolve issues
+func WithMaxEventAge(maxAge time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.maxEventAge = maxAge
+	}
+}
+
+// WithWebhookClock sets the clock the age of events is measured, and deliveries are timed, with,
+// e.g. a fake clock in tests
+func WithWebhookClock(c clock.Clock) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.clock = c
+	}
+}
+
+// WithWebhookResponse sets what the webhooks reporting issues respond with, see
+// config.WebhookResponseMinimal and config.WebhookResponseFull
+func WithWebhookResponse(response string) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.minimalResponse = response == config.WebhookResponseMinimal
+	}
+}
+
+// WithWebhookAccessChecker checks the requester can access the namespace of a duplicate
+// tracked in another namespace before updating it, when duplicates are matched across namespaces
+func WithWebhookAccessChecker(accessChecker NamespaceAccessChecker) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.accessChecker = accessChecker
+	}
+}
+
+// WithSeverityAliases accepts the aliases, e.g. high, in place of the severity they map to
+func WithSeverityAliases(aliases map[string]models.Severity) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.severityAliases = aliases
+	}
 }
 
 // NewWebhookHandler returns a new handler for the webhooks router
-func NewWebhookHandler(issueService services.IssueServiceInterface, logger *logrus.Logger) *WebhookHandler {
-	return &WebhookHandler{
+func NewWebhookHandler(issueService services.IssueServiceInterface, logger *logrus.Logger, opts ...WebhookOption) *WebhookHandler {
+	h := &WebhookHandler{
 		issueService: issueService,
 		logger:       logger,
+		clock:        clock.Real{},
+
+		maxScopeFieldLength: DefaultMaxScopeFieldLength,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// compileFailureReasonPatterns compiles the patterns of failure reasons to ignore
+func compileFailureReasonPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignored failure reason pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// isIgnoredFailure reports whether the failure reason matches one of the ignored patterns
+func (h *WebhookHandler) isIgnoredFailure(reason string) bool {
+	for _, pattern := range h.ignoredFailureReasons {
+		if pattern.MatchString(reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderIssueText renders the template of the key with the webhook request.
+// The fallback is used when there's no template for the key, or it fails to render.
+func (h *WebhookHandler) renderIssueText(key string, req any, fallback string) string {
+	text, err := h.issueTemplates.render(key, req, fallback)
+	if err != nil {
+		h.logger.WithError(err).WithField("template", key).Warn("Failed to render issue template, using the default text")
+	}
+	return text
+}
+
+// releaseLogsURL returns the URL of the logs of a release failure, rendered from the
+// fallback template when the request doesn't provide it. It's empty when there's neither.
+func (h *WebhookHandler) releaseLogsURL(req ReleaseFailureRequest) string {
+	if req.PipelineRunURL != "" || h.releaseLogsURLTemplate == nil {
+		return req.PipelineRunURL
+	}
+
+	var buf bytes.Buffer
+	if err := h.releaseLogsURLTemplate.Execute(&buf, req); err != nil {
+		h.logger.WithError(err).Warn("Failed to render release logs URL template, linking no logs")
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// TrackDelivery middleware records every webhook call as a delivery, so
+// producers can look up what their webhook resulted in.
+//
+// The ID of the delivery is returned in the X-Kite-Delivery-ID header, and the
+// outcome is recorded once the webhook has been processed.
+func (h *WebhookHandler) TrackDelivery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Delivery lookups aren't deliveries themselves
+		if h.deliveryService == nil || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		delivery := &models.WebhookDelivery{
+			ID:         uuid.New().String(),
+			Endpoint:   c.FullPath(),
+			ReceivedAt: h.clock.Now(),
+		}
+		c.Header(DeliveryIDHeader, delivery.ID)
+		c.Set(deliveryKey, delivery)
+
+		c.Next()
+
+		if delivery.Status == "" {
+			switch status := c.Writer.Status(); {
+			case status >= http.StatusInternalServerError:
+				delivery.Status = models.DeliveryFailed
+			case status >= http.StatusBadRequest:
+				delivery.Status = models.DeliveryRejected
+			default:
+				delivery.Status = models.DeliveryProcessed
+			}
+		}
+
+		if err := h.deliveryService.RecordDelivery(c.Request.Context(), delivery); err != nil {
+			h.logger.WithError(err).WithField("delivery_id", delivery.ID).Error("Failed to record webhook delivery")
+		}
+	}
+}
+
+// trackDelivery updates the delivery of the webhook being processed, if it's tracked
+func trackDelivery(c *gin.Context, update func(delivery *models.WebhookDelivery)) {
+	if value, ok := c.Get(deliveryKey); ok {
+		update(value.(*models.WebhookDelivery))
+	}
+}
+
+// trackDeliveryNamespace records the namespace a webhook was sent for
+func trackDeliveryNamespace(c *gin.Context, namespace string) {
+	trackDelivery(c, func(delivery *models.WebhookDelivery) {
+		delivery.Namespace = namespace
+	})
+}
+
+// bindWebhookRequest binds the JSON body of a webhook to req. It responds with 400 and
+// returns false if the body isn't valid JSON or misses required fields, listing every
+// invalid field by its JSON name.
+func bindWebhookRequest(c *gin.Context, req any) bool {
+	err := c.ShouldBindJSON(req)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"code":    dto.ErrorCodeInvalidBody,
+			"details": err.Error(),
+		})
+		return false
+	}
+
+	fields := make([]dto.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, dto.FieldError{
+			Field:  jsonFieldName(req, fieldErr.StructField()),
+			Reason: fieldErr.Tag(),
+		})
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":  "Missing required fields",
+		"code":   dto.ErrorCodeValidationFailed,
+		"fields": fields,
+	})
+	return false
+}
+
+// jsonFieldName returns the name a field of req is sent as in JSON, or the field name
+// if it has no json tag.
+func jsonFieldName(req any, fieldName string) string {
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if field, ok := t.FieldByName(fieldName); ok {
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return fieldName
+}
+
+// validateWebhookNamespace responds with 400 and returns false if the namespace
+// of a webhook isn't a valid Kubernetes namespace.
+func validateWebhookNamespace(c *gin.Context, namespace string) bool {
+	if err := middleware.ValidateNamespace(namespace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+		return false
+	}
+	return true
+}
+
+// trackDeliveryIssue records the issue created or updated by a webhook
+func trackDeliveryIssue(c *gin.Context, issueID string) {
+	trackDelivery(c, func(delivery *models.WebhookDelivery) {
+		delivery.IssueID = &issueID
+	})
+}
+
+// validateIssueScope trims the scope of the issue reported by a webhook, and responds with
+// 400 and returns false if it's invalid. The resource name of the scope is reported as
+// nameField, the field of the webhook it comes from.
+func (h *WebhookHandler) validateIssueScope(c *gin.Context, issueData *dto.CreateIssueRequest, nameField string) bool {
+	issueData.Scope = trimScope(issueData.Scope)
+	scopeErr := validateScope(issueData.Scope, h.maxScopeFieldLength)
+	if scopeErr == nil {
+		return true
+	}
+
+	field := nameField
+	if scopeErr.field != "resourceName" {
+		field = "scope." + scopeErr.field
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Invalid issue scope",
+		"code":    dto.ErrorCodeValidationFailed,
+		"details": scopeErr.Error(),
+		"fields":  []dto.FieldError{{Field: field, Reason: scopeErr.reason}},
+	})
+	return false
+}
+
+// parseSeverity returns the severity of the issue reported by a webhook, major when
+// it's omitted. Severities and their aliases are matched regardless of case, anything
+// else is rejected with 400, so issues can't be stored with unknown severities.
+func (h *WebhookHandler) parseSeverity(c *gin.Context, value string) (models.Severity, bool) {
+	if value == "" {
+		return models.SeverityMajor, true
 	}
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if severity := models.Severity(normalized); severity.Rank() != 0 {
+		return severity, true
+	}
+	if severity, ok := h.severityAliases[normalized]; ok {
+		return severity, true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Invalid severity",
+		"code":    dto.ErrorCodeValidationFailed,
+		"details": fmt.Sprintf("severity %q must be one of %v", value, models.Severities),
+		"fields":  []dto.FieldError{{Field: "severity", Reason: "oneof"}},
+	})
+	return "", false
+}
+
+// dropDisallowedLinks leaves out the links of the issue reported by a webhook that point to
+// domains not allowed. The failure matters more than its links, so the webhook isn't rejected.
+func (h *WebhookHandler) dropDisallowedLinks(issueData *dto.CreateIssueRequest) {
+	if h.linkDomains == nil {
+		return
+	}
+	issueData.Links = slices.DeleteFunc(slices.Clone(issueData.Links), func(link dto.CreateLinkRequest) bool {
+		if err := h.linkDomains.Check(link.URL); err != nil {
+			h.logger.WithError(err).WithField("namespace", issueData.Namespace).Warn("Left out webhook link to a domain not allowed")
+			return true
+		}
+		return false
+	})
+}
+
+// ingestRetryAfter is how long producers are asked to wait before retrying webhooks rejected by a full ingest queue
+const ingestRetryAfter = "5"
+
+// enqueueIssue queues the issue reported by a webhook when it's processed asynchronously,
+// and responds to the webhook. Returns false when the issue must be persisted right away.
+//
+// Webhooks are rejected with 503 when the queue is full or draining, so producers retry later.
+func (h *WebhookHandler) enqueueIssue(c *gin.Context, issueData dto.CreateIssueRequest) bool {
+	if h.ingestQueue == nil {
+		return false
+	}
+
+	// The issue is persisted once the request is over, and gin reuses its context by then,
+	// so the requester's access to the namespaces of duplicates is checked with a copy
+	if err := h.ingestQueue.Enqueue(issueData, dedupNamespaceAuthorizer(c.Copy(), h.accessChecker)); err != nil {
+		h.logger.WithError(err).WithField("namespace", issueData.Namespace).Warn("Rejected webhook, its issue couldn't be queued")
+		c.Header("Retry-After", ingestRetryAfter)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Too many webhooks waiting to be processed, retry later"})
+		return true
+	}
+
+	trackDelivery(c, func(delivery *models.WebhookDelivery) {
+		delivery.Status = models.DeliveryQueued
+	})
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "queued",
+		"message": "Issue queued, it will be created or updated shortly",
+	})
+	return true
+}
+
+// GetDelivery handles GET /webhooks/deliveries/:id
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	if h.deliveryService == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook deliveries are not recorded"})
+		return
+	}
+
+	id := c.Param("id")
+	namespace := middleware.RequestNamespace(c)
+
+	delivery, err := h.deliveryService.FindDelivery(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).WithField("delivery_id", id).Error("Failed to fetch webhook delivery")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch webhook delivery"})
+		return
+	}
+	if delivery == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook delivery not found"})
+		return
+	}
+
+	if namespace != "" && delivery.Namespace != namespace {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to this namespace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ignoreStaleEvent responds to a webhook for an event older than the maximum event age,
+// e.g. replayed or delivered from a backlog, and returns true. Events without a time
+// are never stale.
+func (h *WebhookHandler) ignoreStaleEvent(c *gin.Context, namespace string, event WebhookEvent) bool {
+	if h.maxEventAge <= 0 || event.EventTime == nil {
+		return false
+	}
+	age := h.clock.Now().Sub(*event.EventTime)
+	if age <= h.maxEventAge {
+		return false
+	}
+
+	trackDelivery(c, func(delivery *models.WebhookDelivery) {
+		delivery.Status = models.DeliveryIgnored
+	})
+
+	h.logger.WithFields(logrus.Fields{
+		"namespace":  namespace,
+		"event_time": event.EventTime.UTC(),
+		"age":        age.Round(time.Second).String(),
+	}).Info("Ignoring stale webhook event")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "ignored",
+		"message": fmt.Sprintf("Stale event ignored, it's older than %s", h.maxEventAge),
+	})
+	return true
+}
+
+// respondIssue responds to a webhook with the issue it created or updated, or with
+// just its ID and whether it was created when responses are minimal
+func (h *WebhookHandler) respondIssue(c *gin.Context, issue *models.Issue, created bool) {
+	if !h.minimalResponse {
+		c.JSON(http.StatusCreated, gin.H{
+			"status": "success",
+			"issue":  issue,
+		})
+		return
+	}
+
+	action := "updated"
+	if created {
+		action = "created"
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "success",
+		"issueId": issue.ID,
+		"action":  action,
+	})
+}
+
+// respondIgnored responds to a webhook for a failure that doesn't create issues
+func (h *WebhookHandler) respondIgnored(c *gin.Context, namespace, reason string) {
+	trackDelivery(c, func(delivery *models.WebhookDelivery) {
+		delivery.Status = models.DeliveryIgnored
+	})
+
+	h.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"reason":    reason,
+	}).Info("Ignoring failure matching an ignored pattern")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "ignored",
+		"message": fmt.Sprintf("Failure ignored, no issue created for reason: %s", reason),
+	})
+}
+
+// WebhookEvent holds the fields common to the payloads of all webhooks
+type WebhookEvent struct {
+	// When the event occurred, events older than the maximum event age are ignored
+	EventTime *time.Time `json:"eventTime"`
 }
 
 // PipelineFailureRequest represents the payload for a pipeline failure webhook.
@@ -36,6 +562,12 @@ func NewWebhookHandler(issueService services.IssueServiceInterface, logger *logr
 //   - severity:      (string. optional, - defaults to "major") Issue severity.
 //   - runId:         (string, optional) - Pipeline run identifier.
 //   - logsUrl:       (string, optional) - Direct URL to logs.
+//   - commitSha:     (string, optional) - Commit the pipeline ran for, linked from the issue.
+//   - repoUrl:       (string, optional) - Repository of the commit.
+//   - branch:        (string, optional) - Branch of the commit.
+//   - fingerprint:   (string, optional) - Identity of the issue, failures with the same one update the same issue.
+//   - environment:   (string, optional) - Environment the pipeline ran for, e.g. dev, staging or prod.
+//   - eventTime:     (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
 type PipelineFailureRequest struct {
 	PipelineName  string `json:"pipelineName" binding:"required"`
 	Namespace     string `json:"namespace" binding:"required"`
@@ -43,6 +575,10 @@ type PipelineFailureRequest struct {
 	FailureReason string `json:"failureReason" binding:"required"`
 	RunID         string `json:"runId"`
 	LogsURL       string `json:"logsUrl"`
+	Fingerprint   string `json:"fingerprint" binding:"max=255"`
+	Environment   string `json:"environment" binding:"max=63"`
+	dto.CommitContext
+	WebhookEvent
 }
 
 // PipelineSuccessRequest represents the payload for a pipeline success webhook.
@@ -50,9 +586,59 @@ type PipelineFailureRequest struct {
 // Fields:
 //   - pipelineName: (string, required) - Name of the successful pipeline.
 //   - namespace:    (string, required) - Kubernetes namespace where the pipeline ran.
+//   - matchMode:    (string, optional, default: "exact") - How the pipeline name is matched, "exact" or "prefix".
+//   - eventTime:    (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
 type PipelineSuccessRequest struct {
 	PipelineName string `json:"pipelineName" binding:"required"`
 	Namespace    string `json:"namespace" binding:"required"`
+	MatchMode    string `json:"matchMode" binding:"omitempty,oneof=exact prefix"`
+	WebhookEvent
+}
+
+// Modes of matching the resource name of success webhooks with the scope of issues
+const (
+	// Only the resource with the exact name
+	MatchModeExact = "exact"
+	// The resource with the name, and the ones named after it with a generated suffix
+	MatchModePrefix = "prefix"
+)
+
+// BuildFailureRequest represents the payload for a build failure webhook.
+//
+// Fields:
+//   - component:     (string, required) - Name of the Konflux Component that failed to build.
+//   - namespace:     (string, required) - Kubernetes namespace which owns the component.
+//   - failureReason: (string, required) - Description of why the build failed.
+//   - severity:      (string, optional) - Issue severity level, defaults to "major".
+//   - buildId:       (string, optional) - Identifier of the build pipeline run, for log URLs.
+//   - logsUrl:       (string, optional) - Direct URL to the build logs.
+//   - commitSha:     (string, optional) - Commit that was built, linked from the issue.
+//   - repoUrl:       (string, optional) - Repository of the commit.
+//   - branch:        (string, optional) - Branch of the commit.
+//   - fingerprint:   (string, optional) - Identity of the issue, failures with the same one update the same issue.
+//   - eventTime:     (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
+type BuildFailureRequest struct {
+	Component     string `json:"component" binding:"required"`
+	Namespace     string `json:"namespace" binding:"required"`
+	Severity      string `json:"severity"`
+	FailureReason string `json:"failureReason" binding:"required"`
+	BuildID       string `json:"buildId"`
+	LogsURL       string `json:"logsUrl"`
+	Fingerprint   string `json:"fingerprint" binding:"max=255"`
+	dto.CommitContext
+	WebhookEvent
+}
+
+// BuildSuccessRequest represents the payload for a build success webhook.
+//
+// Fields:
+//   - component: (string, required) - Name of the Konflux Component that was built.
+//   - namespace: (string, required) - Kubernetes namespace which owns the component.
+//   - eventTime: (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
+type BuildSuccessRequest struct {
+	Component string `json:"component" binding:"required"`
+	Namespace string `json:"namespace" binding:"required"`
+	WebhookEvent
 }
 
 // MintmakerRequest represents the payload for a custom mintmaker webhook.
@@ -64,13 +650,32 @@ type PipelineSuccessRequest struct {
 //   - namespace:    (string, required) - Kubernetes namespace which owns the component.
 //   - type: (string, required) - Type of the issue (error, warning, info).
 //   - logs: (array of strings, required) - Logs of the issue.
+//   - fingerprint: (string, optional) - Identity of the issue, reports with the same one update the same issue.
+//   - eventTime:   (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
 type MintmakerRequest struct {
-	PipelineId string   `json:"pipelineId" binding:"required"`
-	Namespace  string   `json:"namespace" binding:"required"`
-	Type       string   `json:"type" binding:"required"`
-	Logs       []string `json:"logs"`
+	PipelineId  string   `json:"pipelineId" binding:"required"`
+	Namespace   string   `json:"namespace" binding:"required"`
+	Type        string   `json:"type" binding:"required"`
+	Logs        []string `json:"logs"`
+	Fingerprint string   `json:"fingerprint" binding:"max=255"`
+	WebhookEvent
+}
+
+// MintmakerResolveRequest represents the payload for a mintmaker resolve webhook.
+//
+// Fields:
+//   - pipelineId: (string, required) - Identifier of the mintmaker run (repo/branch)
+//   - namespace:  (string, required) - Kubernetes namespace which owns the component.
+//   - eventTime:  (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
+type MintmakerResolveRequest struct {
+	PipelineId string `json:"pipelineId" binding:"required"`
+	Namespace  string `json:"namespace" binding:"required"`
+	WebhookEvent
 }
 
+// mintmakerResolveResourceTypes are the scope resource types resolved by the mintmaker resolve webhook.
+var mintmakerResolveResourceTypes = []string{"mintmaker-error", "mintmaker-warning"}
+
 // ReleaseFailureRequest represents the payload for a release failure webhook.
 //
 // Fields:
@@ -79,12 +684,22 @@ type MintmakerRequest struct {
 //   - failurePhase:   (string, required) - What phase the Release failed on (managed processing, validation, etc). (required)
 //   - release:        (string, required) - Release Custom Resource Name. (required)
 //   - pipelineRunUrl: (string, optional) - Direct URL to failing pipelineRun logs, if available.
+//   - commitSha:      (string, optional) - Commit that was released, linked from the issue.
+//   - repoUrl:        (string, optional) - Repository of the commit.
+//   - branch:         (string, optional) - Branch of the commit.
+//   - fingerprint:    (string, optional) - Identity of the issue, failures with the same one update the same issue.
+//   - environment:    (string, optional) - Environment released to, derived from the failure phase if omitted.
+//   - eventTime:      (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
 type ReleaseFailureRequest struct {
 	Application    string `json:"application" binding:"required"`
 	Namespace      string `json:"namespace" binding:"required"`
 	FailurePhase   string `json:"failurePhase" binding:"required"`
 	ReleaseName    string `json:"release" binding:"required"`
 	PipelineRunURL string `json:"pipelineRunUrl"`
+	Fingerprint    string `json:"fingerprint" binding:"max=255"`
+	Environment    string `json:"environment" binding:"max=63"`
+	dto.CommitContext
+	WebhookEvent
 }
 
 // ReleaseSuccessRequest represents the payload for a release success webhook.
@@ -92,9 +707,13 @@ type ReleaseFailureRequest struct {
 // Fields:
 //   - application:  (string, required) - Name of the Konflux Application that was released.
 //   - namespace:    (string, required) - Kubernetes namespace where the release ran.
+//   - release:      (string, optional) - Release Custom Resource Name, resolves the issues scoped to the release.
+//   - eventTime:    (string, optional) - When the event occurred, in RFC 3339. Stale events are ignored.
 type ReleaseSuccessRequest struct {
 	Application string `json:"application" binding:"required"`
 	Namespace   string `json:"namespace" binding:"required"`
+	ReleaseName string `json:"release"`
+	WebhookEvent
 }
 
 // PipelineFailure handles pipeline failure webhooks with idempotent behavior.
@@ -107,11 +726,16 @@ type ReleaseSuccessRequest struct {
 //   - severity:       (string, optional, default: "major") - Issue severity level.
 //   - runId:          (string, optional) - Pipeline run identifier for log URLs.
 //   - logsUrl:        (string, optional) - Direct URL to logs. Generated if omitted.
+//   - commitSha:      (string, optional) - Commit the pipeline ran for, linked from the issue.
+//   - repoUrl:        (string, optional) - Repository of the commit.
+//   - branch:         (string, optional) - Branch of the commit.
 //
 // Response:
 //   - 201 Created: Issue was created or updated successfully
+//   - 202 Accepted: Issue was queued, when webhooks are processed asynchronously
 //   - 400 Bad Request: Missing required fields
 //   - 500 Internal Server Error: Database or processing error
+//   - 503 Service Unavailable: Too many issues queued, retry later
 //
 // Example:
 //
@@ -125,8 +749,19 @@ type ReleaseSuccessRequest struct {
 func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 	var req PipelineFailureRequest
 	// Check if the request binds to proper JSON, in the format specified
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
+		return
+	}
+
+	if h.isIgnoredFailure(req.FailureReason) {
+		h.respondIgnored(c, req.Namespace, req.FailureReason)
 		return
 	}
 
@@ -138,14 +773,19 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 		logsURL = fmt.Sprintf("%s%s%s", baseURL, logsEndpoint, req.RunID)
 	}
 
-	severity := models.SeverityMajor
-	if req.Severity != "" {
-		severity = models.Severity(req.Severity)
+	severity, ok := h.parseSeverity(c, req.Severity)
+	if !ok {
+		return
 	}
 
+	title := h.renderIssueText("pipeline-failure.title", req,
+		fmt.Sprintf("Pipeline run failed: %s", req.PipelineName))
+	description := h.renderIssueText("pipeline-failure.description", req,
+		fmt.Sprintf("The pipeline run %s failed with reason: %s", req.PipelineName, req.FailureReason))
+
 	issueData := dto.CreateIssueRequest{
-		Title:       fmt.Sprintf("Pipeline run failed: %s", req.PipelineName),
-		Description: fmt.Sprintf("The pipeline run %s failed with reason: %s", req.PipelineName, req.FailureReason),
+		Title:       title,
+		Description: description,
 		Severity:    severity,
 		IssueType:   models.IssueTypePipeline,
 		Namespace:   req.Namespace,
@@ -156,26 +796,39 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 		},
 		Links: []dto.CreateLinkRequest{
 			{
-				Title: "Pipeline Run Logs",
-				URL:   logsURL,
+				Title:   "Pipeline Run Logs",
+				URL:     logsURL,
+				Primary: true,
 			},
 		},
+		CommitContext: req.CommitContext,
+		Fingerprint:   req.Fingerprint,
+		Environment:   req.Environment,
+	}
+
+	if !h.validateIssueScope(c, &issueData, "pipelineName") {
+		return
+	}
+	h.dropDisallowedLinks(&issueData)
+	if h.enqueueIssue(c, issueData) {
+		return
 	}
 
 	// Create or update the issue
-	issue, err := h.issueService.CreateOrUpdateIssue(c, issueData)
+	issue, created, err := h.issueService.CreateOrUpdateIssue(authorizeDedupNamespaces(c, c, h.accessChecker), issueData)
+	if respondIssueLimitExceeded(c, issueData.Namespace, err) {
+		return
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to create or update pipeline issue")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
 		return
 	}
 
+	trackDeliveryIssue(c, issue.ID)
 	h.logger.WithField("issue_id", issue.ID).Info("Processed pipeline failure webhook")
 
-	c.JSON(http.StatusCreated, gin.H{
-		"status": "success",
-		"issue":  issue,
-	})
+	h.respondIssue(c, issue, created)
 }
 
 // PipelineSuccess handles pipeline success webhooks.
@@ -183,6 +836,7 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 // Request Body:
 //   - pipelineName: (string, required) - Name of the successful pipeline
 //   - namespace:    (string, required) -  Namespace where the pipeline ran
+//   - matchMode:    (string, optional) - "exact" (default) or "prefix"
 //
 // Response:
 //   - 200 OK: Issues related to the pipeline are resolved
@@ -195,6 +849,9 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 //   - ResourceType: "pipelinerun"
 //   - ResourceNamespace: <pipeline namespace>
 //
+// With the "prefix" match mode, issues scoped to the runs of the pipeline named after it
+// with a generated suffix are resolved too, e.g. "frontend-build-abc123".
+//
 // Example:
 //
 //	    Content-Type: application/json
@@ -205,13 +862,25 @@ func (h *WebhookHandler) PipelineFailure(c *gin.Context) {
 //			 }
 func (h *WebhookHandler) PipelineSuccess(c *gin.Context) {
 	var req PipelineSuccessRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
 		return
 	}
 
 	// Resolve any active issues for this pipeline
-	resolved, err := h.issueService.ResolveIssuesByScope(c.Request.Context(), "pipelinerun", req.PipelineName, req.Namespace)
+	var resolved int64
+	var err error
+	if req.MatchMode == MatchModePrefix {
+		resolved, err = h.issueService.ResolveIssuesByScopePrefix(c.Request.Context(), "pipelinerun", req.PipelineName, req.Namespace)
+	} else {
+		resolved, err = h.issueService.ResolveIssuesByScope(c.Request.Context(), "pipelinerun", req.PipelineName, req.Namespace)
+	}
 	if err != nil {
 		h.logger.WithError(err).Errorf("failed to resolve issues for pipeline run %s : %v", req.PipelineName, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -221,9 +890,10 @@ func (h *WebhookHandler) PipelineSuccess(c *gin.Context) {
 	}
 
 	h.logger.WithFields(logrus.Fields{
-		"pipeline":  req.PipelineName,
-		"namespace": req.Namespace,
-		"resolved":  resolved,
+		"pipeline":   req.PipelineName,
+		"namespace":  req.Namespace,
+		"match_mode": cmp.Or(req.MatchMode, MatchModeExact),
+		"resolved":   resolved,
 	}).Info("Pipeline success webhook processed")
 
 	c.JSON(http.StatusOK, gin.H{
@@ -232,6 +902,213 @@ func (h *WebhookHandler) PipelineSuccess(c *gin.Context) {
 	})
 }
 
+// BuildFailure handles build failure webhooks with idempotent behavior.
+// Failures of the same component update the same issue.
+//
+// Request Body:
+//   - component:      (string, required) - Name of the component that failed to build.
+//   - namespace:      (string, required) - Namespace which owns the component.
+//   - failureReason:  (string, required) - Description of why the build failed.
+//   - severity:       (string, optional, default: "major") - Issue severity level.
+//   - buildId:        (string, optional) - Build pipeline run identifier for log URLs.
+//   - logsUrl:        (string, optional) - Direct URL to logs. Generated from the buildId if omitted.
+//   - commitSha:      (string, optional) - Commit that was built, linked from the issue.
+//   - repoUrl:        (string, optional) - Repository of the commit.
+//   - branch:         (string, optional) - Branch of the commit.
+//
+// Response:
+//   - 201 Created: Issue was created or updated successfully
+//   - 202 Accepted: Issue was queued, when webhooks are processed asynchronously
+//   - 400 Bad Request: Missing required fields
+//   - 500 Internal Server Error: Database or processing error
+//   - 503 Service Unavailable: Too many issues queued, retry later
+//
+// Example:
+//
+//	 POST /api/v1/webhooks/build-failure
+//	 Content-Type: application/json
+//		{
+//		  "component": "frontend",
+//		  "namespace": "team-alpha",
+//		  "failureReason": "Dockerfile not found",
+//		  "buildId": "frontend-on-push-x7k2p"
+//		}
+func (h *WebhookHandler) BuildFailure(c *gin.Context) {
+	var req BuildFailureRequest
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
+		return
+	}
+
+	if h.isIgnoredFailure(req.FailureReason) {
+		h.respondIgnored(c, req.Namespace, req.FailureReason)
+		return
+	}
+
+	// Builds are pipeline runs, their logs are found the same way
+	logsURL := req.LogsURL
+	if logsURL == "" && req.BuildID != "" {
+		baseURL := config.GetEnvOrDefault("KITE_CLUSTER_URL", "https://konflux.dev")
+		logsEndpoint := config.GetEnvOrDefault("KITE_LOGS_ENDPOINT", "/logs/pipelineruns/")
+		logsURL = fmt.Sprintf("%s%s%s", baseURL, logsEndpoint, req.BuildID)
+	}
+
+	severity, ok := h.parseSeverity(c, req.Severity)
+	if !ok {
+		return
+	}
+
+	title := h.renderIssueText("build-failure.title", req,
+		fmt.Sprintf("Build failed: %s", req.Component))
+	description := h.renderIssueText("build-failure.description", req,
+		fmt.Sprintf("The build of component %s failed with reason: %s", req.Component, req.FailureReason))
+
+	issueData := dto.CreateIssueRequest{
+		Title:       title,
+		Description: description,
+		Severity:    severity,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   req.Namespace,
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      req.Component,
+			ResourceNamespace: req.Namespace,
+		},
+		CommitContext: req.CommitContext,
+		Fingerprint:   req.Fingerprint,
+	}
+	if logsURL != "" {
+		issueData.Links = []dto.CreateLinkRequest{
+			{
+				Title:   "Build Logs",
+				URL:     logsURL,
+				Primary: true,
+			},
+		}
+	}
+
+	if !h.validateIssueScope(c, &issueData, "component") {
+		return
+	}
+	h.dropDisallowedLinks(&issueData)
+	if h.enqueueIssue(c, issueData) {
+		return
+	}
+
+	// Create or update the issue
+	issue, created, err := h.issueService.CreateOrUpdateIssue(authorizeDedupNamespaces(c, c, h.accessChecker), issueData)
+	if respondIssueLimitExceeded(c, issueData.Namespace, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to create or update build issue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
+		return
+	}
+
+	trackDeliveryIssue(c, issue.ID)
+	h.logger.WithField("issue_id", issue.ID).Info("Processed build failure webhook")
+
+	h.respondIssue(c, issue, created)
+}
+
+// BuildSuccess handles build success webhooks.
+//
+// Request Body:
+//   - component: (string, required) - Name of the component that was built
+//   - namespace: (string, required) - Namespace which owns the component
+//
+// Response:
+//   - 200 OK: Issues related to the component are resolved
+//   - 400 Bad Request: Missing required fields
+//   - 500 Internal Server Error: Database or processing error
+//
+// Issues that match the component name and namespace will be marked as resolved using
+// the scope:
+//   - ResourceName: <component name>
+//   - ResourceType: "component"
+//   - ResourceNamespace: <component namespace>
+//
+// Example:
+//
+//	    Content-Type: application/json
+//		  POST /api/v1/webhooks/build-success
+//			 {
+//			   "component": "frontend",
+//			   "namespace": "team-alpha"
+//			 }
+func (h *WebhookHandler) BuildSuccess(c *gin.Context) {
+	var req BuildSuccessRequest
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
+		return
+	}
+
+	// Resolve any active issues for this component
+	resolved, err := h.issueService.ResolveIssuesByScope(c.Request.Context(), "component", req.Component, req.Namespace)
+	if err != nil {
+		h.logger.WithError(err).Errorf("failed to resolve issues for component %s : %v", req.Component, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve build issues",
+		})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"component": req.Component,
+		"namespace": req.Namespace,
+		"resolved":  resolved,
+	}).Info("Build success webhook processed")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": fmt.Sprintf("Resolved %d issue(s) for component %s", resolved, req.Component),
+	})
+}
+
+// mintmakerLogSeparator separates the log lines of mintmaker issues in their description
+const mintmakerLogSeparator = "\n--------------------------------\n"
+
+// joinMintmakerLogs joins the log lines of a mintmaker issue into its description. The
+// lines going over maxBytes are left out, and a note says how many, 0 keeps all the lines.
+// A first line longer than maxBytes is cut, so the description is never empty.
+func joinMintmakerLogs(logs []string, maxBytes int) string {
+	joined := strings.Join(logs, mintmakerLogSeparator)
+	if maxBytes <= 0 || len(joined) <= maxBytes {
+		return joined
+	}
+
+	var description strings.Builder
+	kept := 0
+	for _, line := range logs {
+		if kept > 0 {
+			line = mintmakerLogSeparator + line
+		}
+		if description.Len()+len(line) > maxBytes {
+			break
+		}
+		description.WriteString(line)
+		kept++
+	}
+	if kept == 0 {
+		description.WriteString(strings.ToValidUTF8(logs[0][:maxBytes], ""))
+	}
+	fmt.Fprintf(&description, "\n... truncated %d of %d lines", len(logs)-kept, len(logs))
+	return description.String()
+}
+
 // MintmakerIssues handles custom mintmaker webhooks.
 //
 // Request Body:
@@ -240,14 +1117,25 @@ func (h *WebhookHandler) PipelineSuccess(c *gin.Context) {
 //   - type: (string, required) - Type of the issue (error, warning, info).
 //   - logs: (array of strings, required) - Logs of the issue.
 //
+// The logs are joined into the description of the issue, truncated to the maximum size
+// set by WithMintmakerMaxLogBytes. The title counts all the lines.
+//
 // Response:
 //   - 200 OK: Issue was created or updated successfully
+//   - 202 Accepted: Issue was queued, when webhooks are processed asynchronously
 //   - 400 Bad Request: Missing required fields
 //   - 500 Internal Server Error: Database or processing error
+//   - 503 Service Unavailable: Too many issues queued, retry later
 func (h *WebhookHandler) MintmakerIssues(c *gin.Context) {
 	var req MintmakerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
 		return
 	}
 
@@ -267,9 +1155,14 @@ func (h *WebhookHandler) MintmakerIssues(c *gin.Context) {
 		severity = models.SeverityInfo
 	}
 
+	title := h.renderIssueText("mintmaker-custom.title", req,
+		fmt.Sprintf("Mintmaker %s(%d): %s", req.Type, len(req.Logs), req.PipelineId))
+	description := h.renderIssueText("mintmaker-custom.description", req,
+		joinMintmakerLogs(req.Logs, h.mintmakerMaxLogBytes))
+
 	issueData := dto.CreateIssueRequest{
-		Title:       fmt.Sprintf("Mintmaker %s(%d): %s", req.Type, len(req.Logs), req.PipelineId),
-		Description: strings.Join(req.Logs, "\n--------------------------------\n"),
+		Title:       title,
+		Description: description,
 		Severity:    severity,
 		IssueType:   models.IssueTypeDependency,
 		Namespace:   req.Namespace,
@@ -288,22 +1181,92 @@ func (h *WebhookHandler) MintmakerIssues(c *gin.Context) {
 				URL:   "https://docs.renovatebot.com/configuration-options/",
 			},
 		},
+		Fingerprint: req.Fingerprint,
 		// in future ideally -> AutoResolveAt: time.Now().Add(48 * time.Hour),
 	}
 
+	if !h.validateIssueScope(c, &issueData, "pipelineId") {
+		return
+	}
+	h.dropDisallowedLinks(&issueData)
+	if h.enqueueIssue(c, issueData) {
+		return
+	}
+
 	// Create or update the issue
-	issue, err := h.issueService.CreateOrUpdateIssue(c, issueData)
+	issue, created, err := h.issueService.CreateOrUpdateIssue(authorizeDedupNamespaces(c, c, h.accessChecker), issueData)
+	if respondIssueLimitExceeded(c, issueData.Namespace, err) {
+		return
+	}
 	if err != nil {
 		h.logger.WithError(err).Error(fmt.Sprintf("Failed to create or update dependency (%s) issue", req.Type))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
 		return
 	}
 
+	trackDeliveryIssue(c, issue.ID)
 	h.logger.WithField("issue_id", issue.ID).Info(fmt.Sprintf("Processed dependency (%s) issue", req.Type))
 
-	c.JSON(http.StatusCreated, gin.H{
-		"status": "success",
-		"issue":  issue,
+	h.respondIssue(c, issue, created)
+}
+
+// MintmakerResolve handles mintmaker resolve webhooks.
+//
+// Request Body:
+//   - pipelineId: (string, required) - Identifier of the mintmaker run (repo/branch)
+//   - namespace:  (string, required) - Kubernetes namespace which owns the component.
+//
+// Response:
+//   - 200 OK: Issues related to the mintmaker run are resolved
+//   - 400 Bad Request: Missing required fields
+//   - 500 Internal Server Error: Database or processing error
+//
+// Issues that match the pipelineId and namespace will be marked as resolved using
+// the scope:
+//   - ResourceName: <pipelineId>
+//   - ResourceType: "mintmaker-error" or "mintmaker-warning"
+//   - ResourceNamespace: <namespace>
+//
+// Example:
+//
+//	    Content-Type: application/json
+//		  POST /api/v1/webhooks/mintmaker-resolve
+//			 {
+//			   "pipelineId": "org/repo/main",
+//			   "namespace": "team-alpha"
+//			 }
+func (h *WebhookHandler) MintmakerResolve(c *gin.Context) {
+	var req MintmakerResolveRequest
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
+		return
+	}
+
+	// Resolve any active error and warning issues for this mintmaker run
+	resolved, err := h.issueService.ResolveIssuesByScopes(c.Request.Context(), mintmakerResolveResourceTypes, req.PipelineId, req.Namespace)
+	if err != nil {
+		h.logger.WithError(err).Errorf("failed to resolve issues for mintmaker run %s : %v", req.PipelineId, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to resolve mintmaker issues",
+		})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"pipeline_id": req.PipelineId,
+		"namespace":   req.Namespace,
+		"resolved":    resolved,
+	}).Info("Mintmaker resolve webhook processed")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": fmt.Sprintf("Resolved %d issue(s) for mintmaker run %s", resolved, req.PipelineId),
 	})
 }
 
@@ -314,12 +1277,17 @@ func (h *WebhookHandler) MintmakerIssues(c *gin.Context) {
 //   - namespace:      (string, required) - Kubernetes namespace where the release ran. (required)
 //   - failurePhase:   (string, required) - What phase the Release failed on (managed processing, validation, etc). (required)
 //   - release:        (string, required) - Release Custom Resource Name. (required)
-//   - pipelineRunUrl: (string, optional) - Direct URL to failing pipelineRun logs, if available.
+//   - pipelineRunUrl: (string, optional) - Direct URL to failing pipelineRun logs, linked from the issue. Generated if omitted and a template is configured.
+//   - commitSha:      (string, optional) - Commit that was released, linked from the issue.
+//   - repoUrl:        (string, optional) - Repository of the commit.
+//   - branch:         (string, optional) - Branch of the commit.
 //
 // Response:
 //   - 201 Created: Issue was created or updated successfully
+//   - 202 Accepted: Issue was queued, when webhooks are processed asynchronously
 //   - 400 Bad Request: Missing required fields
 //   - 500 Internal Server Error: Database or processing error
+//   - 503 Service Unavailable: Too many issues queued, retry later
 //
 // Example:
 //
@@ -334,43 +1302,118 @@ func (h *WebhookHandler) MintmakerIssues(c *gin.Context) {
 func (h *WebhookHandler) ReleaseFailure(c *gin.Context) {
 	var req ReleaseFailureRequest
 	// Check if the request binds to proper JSON, in the format specified
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
 		return
 	}
 
-	description := fmt.Sprintf("The release failed in phase: %s", req.FailurePhase)
-	if req.PipelineRunURL != "" {
-		description = fmt.Sprintf("The release failed in phase: %s. Link to logs: %s", req.FailurePhase, req.PipelineRunURL)
+	if h.isIgnoredFailure(req.FailurePhase) {
+		h.respondIgnored(c, req.Namespace, req.FailurePhase)
+		return
 	}
 
+	description := h.renderIssueText("release-failure.description", req,
+		fmt.Sprintf("The release failed in phase: %s", req.FailurePhase))
+	title := h.renderIssueText("release-failure.title", req,
+		fmt.Sprintf("Release %s failed for application %s", req.ReleaseName, req.Application))
+
 	issueData := dto.CreateIssueRequest{
-		Title:       fmt.Sprintf("Release %s failed for application %s", req.ReleaseName, req.Application),
-		Description: description,
-		Severity:    models.SeverityMajor,
-		IssueType:   models.IssueTypeRelease,
-		Namespace:   req.Namespace,
-		Scope: dto.ScopeReqBody{
-			ResourceType:      "application",
-			ResourceName:      req.Application,
-			ResourceNamespace: req.Namespace,
-		},
+		Title:         title,
+		Description:   description,
+		Severity:      models.SeverityMajor,
+		IssueType:     models.IssueTypeRelease,
+		Namespace:     req.Namespace,
+		Scope:         h.releaseScope(req),
+		CommitContext: req.CommitContext,
+		Fingerprint:   req.Fingerprint,
+		Environment:   cmp.Or(req.Environment, environmentFromPhase(req.FailurePhase)),
+	}
+
+	if logsURL := h.releaseLogsURL(req); logsURL != "" {
+		issueData.Links = []dto.CreateLinkRequest{
+			{
+				Title:   "Release Pipeline Logs",
+				URL:     logsURL,
+				Primary: true,
+			},
+		}
+	}
+
+	nameField := "application"
+	if h.scopeReleasesByName {
+		nameField = "release"
+	}
+	if !h.validateIssueScope(c, &issueData, nameField) {
+		return
+	}
+	h.dropDisallowedLinks(&issueData)
+	if h.enqueueIssue(c, issueData) {
+		return
 	}
 
 	// Create or update the issue
-	issue, err := h.issueService.CreateOrUpdateIssue(c, issueData)
+	issue, created, err := h.issueService.CreateOrUpdateIssue(authorizeDedupNamespaces(c, c, h.accessChecker), issueData)
+	if respondIssueLimitExceeded(c, issueData.Namespace, err) {
+		return
+	}
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to create or update release issue")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process webhook"})
 		return
 	}
 
+	trackDeliveryIssue(c, issue.ID)
 	h.logger.WithField("issue_id", issue.ID).Info("Processed release failure webhook")
 
-	c.JSON(http.StatusCreated, gin.H{
-		"status": "success",
-		"issue":  issue,
-	})
+	h.respondIssue(c, issue, created)
+}
+
+// releaseScope returns the scope of the issue of a release failure: the application
+// released, or the release itself when releases are scoped by name
+func (h *WebhookHandler) releaseScope(req ReleaseFailureRequest) dto.ScopeReqBody {
+	if h.scopeReleasesByName {
+		return dto.ScopeReqBody{
+			ResourceType:      "release",
+			ResourceName:      req.ReleaseName,
+			ResourceNamespace: req.Namespace,
+		}
+	}
+	return dto.ScopeReqBody{
+		ResourceType:      "application",
+		ResourceName:      req.Application,
+		ResourceNamespace: req.Namespace,
+	}
+}
+
+// environmentAliases maps the words naming an environment in release phases to the environment
+var environmentAliases = map[string]string{
+	"dev":         "dev",
+	"development": "dev",
+	"stage":       "staging",
+	"staging":     "staging",
+	"prod":        "prod",
+	"production":  "prod",
+}
+
+// phaseWord matches the words of a release phase, e.g. "Managed", "Processing" and "Prod"
+// of "ManagedProcessing to Prod"
+var phaseWord = regexp.MustCompile(`[A-Z]?[a-z]+|[A-Z]+`)
+
+// environmentFromPhase returns the environment named in the phase a release failed in,
+// e.g. prod for "managed processing to prod", empty if it names none
+func environmentFromPhase(phase string) string {
+	for _, word := range phaseWord.FindAllString(phase, -1) {
+		if environment, ok := environmentAliases[strings.ToLower(word)]; ok {
+			return environment
+		}
+	}
+	return ""
 }
 
 // ReleaseSuccess handles release success webhooks.
@@ -378,7 +1421,8 @@ func (h *WebhookHandler) ReleaseFailure(c *gin.Context) {
 // Request Body:
 //   - application:  (string, required) - Name of the Konflux Application that was released
 //   - namespace:    (string, required) - Namespace where the release ran
-
+//   - release:      (string, optional) - Name of the release, to resolve the issues scoped to it
+//
 // Response:
 //   - 200 OK: Issues related to the application are resolved
 //   - 400 Bad Request: Missing required fields
@@ -390,6 +1434,8 @@ func (h *WebhookHandler) ReleaseFailure(c *gin.Context) {
 //   - ResourceType: "application"
 //   - ResourceNamespace: <application namespace>
 //
+// When the release is set, the issues scoped to it are resolved too, see WithReleaseIssueScope.
+//
 // Example:
 //
 //	    Content-Type: application/json
@@ -400,13 +1446,24 @@ func (h *WebhookHandler) ReleaseFailure(c *gin.Context) {
 //			 }
 func (h *WebhookHandler) ReleaseSuccess(c *gin.Context) {
 	var req ReleaseSuccessRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields", "details": err.Error()})
+	if !bindWebhookRequest(c, &req) {
+		return
+	}
+	if !validateWebhookNamespace(c, req.Namespace) {
+		return
+	}
+	trackDeliveryNamespace(c, req.Namespace)
+	if h.ignoreStaleEvent(c, req.Namespace, req.WebhookEvent) {
 		return
 	}
 
-	// Resolve any active issues for this application
+	// Resolve any active issues for this application, and for this release
 	resolved, err := h.issueService.ResolveIssuesByScope(c.Request.Context(), "application", req.Application, req.Namespace)
+	if err == nil && req.ReleaseName != "" {
+		var releaseResolved int64
+		releaseResolved, err = h.issueService.ResolveIssuesByScope(c.Request.Context(), "release", req.ReleaseName, req.Namespace)
+		resolved += releaseResolved
+	}
 	if err != nil {
 		h.logger.WithError(err).Errorf("failed to resolve issues for application %s : %v", req.Application, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -417,6 +1474,7 @@ func (h *WebhookHandler) ReleaseSuccess(c *gin.Context) {
 
 	h.logger.WithFields(logrus.Fields{
 		"application": req.Application,
+		"release":     req.ReleaseName,
 		"namespace":   req.Namespace,
 		"resolved":    resolved,
 	}).Info("Release success webhook processed")
@@ -2,14 +2,25 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	net_http "net/http"
 	net_httptest "net/http/httptest"
 
 	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/services"
 	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
 )
@@ -29,6 +40,10 @@ func setupTestWebhookRouter(handler *WebhookHandler) *gin.Engine {
 	{
 		v1.POST("/pipeline-failure", handler.PipelineFailure)
 		v1.POST("/pipeline-success", handler.PipelineSuccess)
+		v1.POST("/build-failure", handler.BuildFailure)
+		v1.POST("/build-success", handler.BuildSuccess)
+		v1.POST("/mintmaker-custom", handler.MintmakerIssues)
+		v1.POST("/mintmaker-resolve", handler.MintmakerResolve)
 		v1.POST("/release-failure", handler.ReleaseFailure)
 		v1.POST("/release-success", handler.ReleaseSuccess)
 	}
@@ -186,6 +201,271 @@ func TestWebhookHandler_PipelineSuccess(t *testing.T) {
 	}
 }
 
+func TestWebhookHandler_PipelineSuccess_MatchMode(t *testing.T) {
+	tests := []struct {
+		name           string
+		matchMode      string
+		expectedStatus int
+		expectedPrefix string
+	}{
+		{name: "exact by default", expectedStatus: net_http.StatusOK},
+		{name: "exact", matchMode: "exact", expectedStatus: net_http.StatusOK},
+		{name: "prefix", matchMode: "prefix", expectedStatus: net_http.StatusOK, expectedPrefix: "frontend-build"},
+		{name: "unknown mode", matchMode: "suffix", expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{resolveIssuesByScopeResult: 1}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			reqBody, err := json.Marshal(PipelineSuccessRequest{
+				PipelineName: "frontend-build",
+				Namespace:    "team-alpha",
+				MatchMode:    tt.matchMode,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req, err := net_http.NewRequest("POST", "/webhooks/pipeline-success", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if mockService.resolveByScopePrefix != tt.expectedPrefix {
+				t.Errorf("expected the prefix '%s' to be resolved, got '%s'", tt.expectedPrefix, mockService.resolveByScopePrefix)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_BuildFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       BuildFailureRequest
+		expectedLinks []dto.CreateLinkRequest
+	}{
+		{
+			name: "logs URL provided",
+			request: BuildFailureRequest{
+				Component:     "frontend",
+				Namespace:     "team-alpha",
+				FailureReason: "Dockerfile not found",
+				LogsURL:       "https://ci.example.com/builds/42",
+			},
+			expectedLinks: []dto.CreateLinkRequest{
+				{Title: "Build Logs", URL: "https://ci.example.com/builds/42", Primary: true},
+			},
+		},
+		{
+			name: "logs URL generated from the build",
+			request: BuildFailureRequest{
+				Component:     "frontend",
+				Namespace:     "team-alpha",
+				FailureReason: "Dockerfile not found",
+				BuildID:       "frontend-on-push-x7k2p",
+			},
+			expectedLinks: []dto.CreateLinkRequest{
+				{Title: "Build Logs", URL: "https://konflux.dev/logs/pipelineruns/frontend-on-push-x7k2p", Primary: true},
+			},
+		},
+		{
+			name: "no logs",
+			request: BuildFailureRequest{
+				Component:     "frontend",
+				Namespace:     "team-alpha",
+				FailureReason: "Dockerfile not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KITE_CLUSTER_URL", "https://konflux.dev")
+			t.Setenv("KITE_LOGS_ENDPOINT", "/logs/pipelineruns/")
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			reqBody, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/webhooks/build-failure", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d", net_http.StatusCreated, w.Code)
+			}
+
+			issue := mockService.createOrUpdateIssueRequest
+			if issue.IssueType != models.IssueTypeBuild {
+				t.Errorf("expected issue type %s, got %s", models.IssueTypeBuild, issue.IssueType)
+			}
+			expectedScope := dto.ScopeReqBody{ResourceType: "component", ResourceName: "frontend", ResourceNamespace: "team-alpha"}
+			if issue.Scope != expectedScope {
+				t.Errorf("expected scope %+v, got %+v", expectedScope, issue.Scope)
+			}
+			if issue.Title != "Build failed: frontend" {
+				t.Errorf("expected title 'Build failed: frontend', got '%s'", issue.Title)
+			}
+			if issue.Severity != models.SeverityMajor {
+				t.Errorf("expected severity %s, got %s", models.SeverityMajor, issue.Severity)
+			}
+			if !slices.Equal(issue.Links, tt.expectedLinks) {
+				t.Errorf("expected links %+v, got %+v", tt.expectedLinks, issue.Links)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_BuildSuccess(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	repo := repository.NewIssueRepository(db, logger)
+	router := setupTestWebhookRouter(NewWebhookHandler(services.NewIssueService(repo, logger), logger))
+
+	post := func(path string, request any) *net_httptest.ResponseRecorder {
+		reqBody, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req, err := net_http.NewRequest("POST", path, bytes.NewBuffer(reqBody))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Two components fail to build
+	for _, component := range []string{"frontend", "backend"} {
+		w := post("/webhooks/build-failure", BuildFailureRequest{
+			Component:     component,
+			Namespace:     "team-builds",
+			FailureReason: "Dockerfile not found",
+		})
+		if w.Code != net_http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// The build of one of them succeeds
+	w := post("/webhooks/build-success", BuildSuccessRequest{Component: "frontend", Namespace: "team-builds"})
+	if w.Code != net_http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if expected := "Resolved 1 issue(s) for component frontend"; response["message"] != expected {
+		t.Errorf("expected message '%s', got '%s'", expected, response["message"])
+	}
+
+	issues, _, err := repo.FindAll(context.Background(), repository.IssueQueryFilters{Namespace: "team-builds"})
+	if err != nil {
+		t.Fatalf("Failed to find issues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	for _, issue := range issues {
+		if issue.IssueType != models.IssueTypeBuild {
+			t.Errorf("expected issue type %s, got %s", models.IssueTypeBuild, issue.IssueType)
+		}
+		expectedState := models.IssueStateActive
+		if issue.Scope.ResourceName == "frontend" {
+			expectedState = models.IssueStateResolved
+		}
+		if issue.State != expectedState {
+			t.Errorf("expected the issue of %s to be %s, got %s", issue.Scope.ResourceName, expectedState, issue.State)
+		}
+	}
+}
+
+func TestWebhookHandler_Severity(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		body             map[string]any
+		expectedStatus   int
+		expectedSeverity models.Severity
+	}{
+		{name: "omitted", path: "/webhooks/pipeline-failure", body: map[string]any{}, expectedStatus: net_http.StatusCreated, expectedSeverity: models.SeverityMajor},
+		{name: "valid", path: "/webhooks/pipeline-failure", body: map[string]any{"severity": "critical"}, expectedStatus: net_http.StatusCreated, expectedSeverity: models.SeverityCritical},
+		{name: "other case", path: "/webhooks/pipeline-failure", body: map[string]any{"severity": "Minor"}, expectedStatus: net_http.StatusCreated, expectedSeverity: models.SeverityMinor},
+		{name: "alias", path: "/webhooks/pipeline-failure", body: map[string]any{"severity": "HIGH"}, expectedStatus: net_http.StatusCreated, expectedSeverity: models.SeverityMajor},
+		{name: "invalid", path: "/webhooks/pipeline-failure", body: map[string]any{"severity": "hihg"}, expectedStatus: net_http.StatusBadRequest},
+		{name: "invalid build severity", path: "/webhooks/build-failure", body: map[string]any{"severity": "urgent"}, expectedStatus: net_http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "severity-abc"}}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			aliases := map[string]models.Severity{"high": models.SeverityMajor, "low": models.SeverityMinor}
+			router := setupTestWebhookRouter(NewWebhookHandler(mockService, logger, WithSeverityAliases(aliases)))
+
+			body := map[string]any{
+				"pipelineName":  "fancy-pipeline",
+				"component":     "fancy-component",
+				"namespace":     "team-severity",
+				"failureReason": "Step build failed",
+			}
+			maps.Copy(body, tt.body)
+			reqBody, err := json.Marshal(body)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == net_http.StatusBadRequest {
+				if !strings.Contains(w.Body.String(), `"field":"severity"`) {
+					t.Errorf("expected the severity to be reported invalid, got %s", w.Body.String())
+				}
+				if mockService.createOrUpdateIssueRequest.Title != "" {
+					t.Error("expected no issue to be created")
+				}
+				return
+			}
+			if severity := mockService.createOrUpdateIssueRequest.Severity; severity != tt.expectedSeverity {
+				t.Errorf("expected severity %s, got %s", tt.expectedSeverity, severity)
+			}
+		})
+	}
+}
+
 func TestWebhookHandler_ReleaseFailure(t *testing.T) {
 	// What gets sent to the webhook endpoint
 	releaseFailureRequest := ReleaseFailureRequest{
@@ -199,7 +479,7 @@ func TestWebhookHandler_ReleaseFailure(t *testing.T) {
 	// Expected issue created
 	expectedIssue := &models.Issue{
 		Title:       "Release release-to-prod-123 failed for application fancy-app",
-		Description: "The release failed in phase: ManagedProcessing. Link to logs: logs.com/managed-123",
+		Description: "The release failed in phase: ManagedProcessing",
 		Severity:    models.SeverityMajor,
 		Namespace:   "team-failed-release",
 		Scope: models.IssueScope{
@@ -273,6 +553,146 @@ func TestWebhookHandler_ReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestWebhookHandler_ReleaseFailure_LogsLink(t *testing.T) {
+	logsURLTemplate, err := ParseReleaseLogsURLTemplate("https://konflux.dev/ns/{{.Namespace}}/releases/{{.ReleaseName}}")
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		pipelineRunURL string
+		opts           []WebhookOption
+		expectedLinks  []dto.CreateLinkRequest
+	}{
+		{
+			name:           "provided URL",
+			pipelineRunURL: "https://konflux.dev/logs/managed-123",
+			opts:           []WebhookOption{WithReleaseLogsURLTemplate(logsURLTemplate)},
+			expectedLinks: []dto.CreateLinkRequest{
+				{Title: "Release Pipeline Logs", URL: "https://konflux.dev/logs/managed-123", Primary: true},
+			},
+		},
+		{
+			name: "URL rendered from the template",
+			opts: []WebhookOption{WithReleaseLogsURLTemplate(logsURLTemplate)},
+			expectedLinks: []dto.CreateLinkRequest{
+				{Title: "Release Pipeline Logs", URL: "https://konflux.dev/ns/team-alpha/releases/release-1", Primary: true},
+			},
+		},
+		{
+			name:          "no URL and no template",
+			expectedLinks: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			router := setupTestWebhookRouter(NewWebhookHandler(mockService, logger, tt.opts...))
+
+			reqBody, err := json.Marshal(ReleaseFailureRequest{
+				Application:    "fancy-app",
+				Namespace:      "team-alpha",
+				FailurePhase:   "ManagedProcessing",
+				ReleaseName:    "release-1",
+				PipelineRunURL: tt.pipelineRunURL,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/webhooks/release-failure", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d", net_http.StatusCreated, w.Code)
+			}
+
+			// The logs are linked rather than mentioned in the description
+			expectedDescription := "The release failed in phase: ManagedProcessing"
+			if description := mockService.createOrUpdateIssueRequest.Description; description != expectedDescription {
+				t.Errorf("expected description %q, got %q", expectedDescription, description)
+			}
+			if links := mockService.createOrUpdateIssueRequest.Links; !slices.Equal(links, tt.expectedLinks) {
+				t.Errorf("expected links %+v, got %+v", tt.expectedLinks, links)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_ReleaseFailure_Environment(t *testing.T) {
+	tests := []struct {
+		name                string
+		phase               string
+		environment         string
+		expectedEnvironment string
+	}{
+		{name: "derived from the phase", phase: "managed processing to prod", expectedEnvironment: "prod"},
+		{name: "derived from a camel case phase", phase: "ManagedProcessingToStaging", expectedEnvironment: "staging"},
+		{name: "alias", phase: "Deploy to production", expectedEnvironment: "prod"},
+		{name: "not named in the phase", phase: "Validation", expectedEnvironment: ""},
+		{name: "word containing an environment", phase: "Reproduction", expectedEnvironment: ""},
+		{name: "set in the request", phase: "managed processing to prod", environment: "prod-eu", expectedEnvironment: "prod-eu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "release-abc"}}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			reqBody, err := json.Marshal(ReleaseFailureRequest{
+				Application:  "fancy-app",
+				Namespace:    "team-release",
+				FailurePhase: tt.phase,
+				ReleaseName:  "fancy-app-release-1",
+				Environment:  tt.environment,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+			req, err := net_http.NewRequest("POST", "/webhooks/release-failure", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			if environment := mockService.createOrUpdateIssueRequest.Environment; environment != tt.expectedEnvironment {
+				t.Errorf("expected environment %q, got %q", tt.expectedEnvironment, environment)
+			}
+		})
+	}
+}
+
+func TestParseReleaseLogsURLTemplate(t *testing.T) {
+	if tmpl, err := ParseReleaseLogsURLTemplate(""); tmpl != nil || err != nil {
+		t.Errorf("expected no template and no error, got %v, %v", tmpl, err)
+	}
+	if _, err := ParseReleaseLogsURLTemplate("https://konflux.dev/{{.PipelineName}}"); err == nil {
+		t.Error("expected an error for an unknown request field, got nil")
+	}
+	if _, err := ParseReleaseLogsURLTemplate("https://konflux.dev/{{.Namespace"); err == nil {
+		t.Error("expected an error for a syntax error, got nil")
+	}
+}
+
 func TestWebhookHandler_ReleaseSuccess(t *testing.T) {
 	// What gets sent to the webhook endpoint
 	releaseSuccessRequest := ReleaseSuccessRequest{
@@ -330,3 +750,1215 @@ func TestWebhookHandler_ReleaseSuccess(t *testing.T) {
 		t.Errorf("expected response with message '%s', got '%s'", expectedMessage, response["message"])
 	}
 }
+
+func TestWebhookHandler_Response(t *testing.T) {
+	failure := PipelineFailureRequest{
+		PipelineName:  "fancy-pipeline",
+		Namespace:     "team-response",
+		FailureReason: "Step build failed",
+	}
+
+	tests := []struct {
+		response string
+		minimal  bool
+	}{
+		{config.WebhookResponseFull, false},
+		{config.WebhookResponseMinimal, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.response, func(t *testing.T) {
+			db := testhelpers.SetupTestDB(t)
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			repo := repository.NewIssueRepository(db, logger)
+			handler := NewWebhookHandler(services.NewIssueService(repo, logger), logger, WithWebhookResponse(tt.response))
+			router := setupTestWebhookRouter(handler)
+
+			post := func() map[string]any {
+				t.Helper()
+				reqBody, err := json.Marshal(failure)
+				if err != nil {
+					t.Fatalf("Failed to marshal request: %v", err)
+				}
+				req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+				if err != nil {
+					t.Fatalf("Failed to create request: %v", err)
+				}
+				req.Header.Set("Content-Type", "application/json")
+				w := net_httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				if w.Code != net_http.StatusCreated {
+					t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+				}
+				var response map[string]any
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to parse response: %v", err)
+				}
+				return response
+			}
+
+			for _, expectedAction := range []string{"created", "updated"} {
+				response := post()
+				if response["status"] != "success" {
+					t.Errorf("expected status success, got %v", response["status"])
+				}
+				if !tt.minimal {
+					issue, ok := response["issue"].(map[string]any)
+					if !ok || issue["id"] == "" || issue["scope"] == nil {
+						t.Errorf("expected the whole issue, got %v", response)
+					}
+					continue
+				}
+				if _, ok := response["issue"]; ok {
+					t.Errorf("expected no issue in a minimal response, got %v", response)
+				}
+				if id, _ := response["issueId"].(string); id == "" {
+					t.Errorf("expected the ID of the issue, got %v", response)
+				}
+				if response["action"] != expectedAction {
+					t.Errorf("expected action %s, got %v", expectedAction, response["action"])
+				}
+			}
+		})
+	}
+
+	// Duplicates aren't always counted as new occurrences, e.g. when reported too often
+	t.Run("updated without a new occurrence", func(t *testing.T) {
+		logger := logrus.New()
+		logger.SetLevel(logrus.ErrorLevel)
+		mockService := &MockIssueService{
+			createOrUpdateIssueResult:  &models.Issue{ID: "issue-1", OccurrenceCount: 1},
+			createOrUpdateIssueCreated: false,
+		}
+		handler := NewWebhookHandler(mockService, logger, WithWebhookResponse(config.WebhookResponseMinimal))
+		router := setupTestWebhookRouter(handler)
+
+		reqBody, err := json.Marshal(failure)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != net_http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response["action"] != "updated" {
+			t.Errorf("expected action updated, got %v", response["action"])
+		}
+	})
+}
+
+func TestWebhookHandler_ReleaseIssueScope(t *testing.T) {
+	tests := []struct {
+		scope          string
+		expectedIssues int64
+	}{
+		// Different releases of the same application are different problems
+		{config.ReleaseIssueScopeRelease, 2},
+		{config.ReleaseIssueScopeApplication, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			db := testhelpers.SetupTestDB(t)
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			repo := repository.NewIssueRepository(db, logger)
+			handler := NewWebhookHandler(services.NewIssueService(repo, logger), logger, WithReleaseIssueScope(tt.scope))
+			router := setupTestWebhookRouter(handler)
+
+			post := func(path string, body any) {
+				t.Helper()
+				reqBody, err := json.Marshal(body)
+				if err != nil {
+					t.Fatalf("Failed to marshal request: %v", err)
+				}
+				req, err := net_http.NewRequest("POST", path, bytes.NewBuffer(reqBody))
+				if err != nil {
+					t.Fatalf("Failed to create request: %v", err)
+				}
+				req.Header.Set("Content-Type", "application/json")
+				w := net_httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				if w.Code != net_http.StatusCreated && w.Code != net_http.StatusOK {
+					t.Fatalf("expected the webhook to succeed, got %d: %s", w.Code, w.Body.String())
+				}
+			}
+
+			for _, release := range []string{"fancy-app-release-1", "fancy-app-release-2"} {
+				post("/webhooks/release-failure", ReleaseFailureRequest{
+					Application:  "fancy-app",
+					Namespace:    "team-release",
+					FailurePhase: "Validation",
+					ReleaseName:  release,
+				})
+			}
+
+			var count int64
+			if err := db.Model(&models.Issue{}).Where("namespace = ?", "team-release").Count(&count).Error; err != nil {
+				t.Fatalf("Failed to count issues: %v", err)
+			}
+			if count != tt.expectedIssues {
+				t.Fatalf("expected %d issues, got %d", tt.expectedIssues, count)
+			}
+
+			// The success of a release resolves its own issue, and the application's
+			post("/webhooks/release-success", ReleaseSuccessRequest{
+				Application: "fancy-app",
+				Namespace:   "team-release",
+				ReleaseName: "fancy-app-release-2",
+			})
+
+			var active int64
+			if err := db.Model(&models.Issue{}).Where("state = ?", models.IssueStateActive).Count(&active).Error; err != nil {
+				t.Fatalf("Failed to count issues: %v", err)
+			}
+			if active != tt.expectedIssues-1 {
+				t.Errorf("expected %d active issues, got %d", tt.expectedIssues-1, active)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_MintmakerIssues_MaxLogBytes(t *testing.T) {
+	const maxLogBytes = 4096
+
+	logs := make([]string, 500)
+	for idx := range logs {
+		logs[idx] = fmt.Sprintf("WARN: renovate could not look up dependency %d: %s", idx, strings.Repeat("x", 200))
+	}
+
+	mockService := &MockIssueService{
+		createOrUpdateIssueResult: &models.Issue{ID: "mintmaker-abc"},
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	handler := NewWebhookHandler(mockService, logger, WithMintmakerMaxLogBytes(maxLogBytes))
+	router := setupTestWebhookRouter(handler)
+
+	reqBody, err := json.Marshal(MintmakerRequest{
+		PipelineId: "org/repo/main",
+		Namespace:  "team-alpha",
+		Type:       "warning",
+		Logs:       logs,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	req, err := net_http.NewRequest("POST", "/webhooks/mintmaker-custom", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	issue := mockService.createOrUpdateIssueRequest
+	if expected := "Mintmaker warning(500): org/repo/main"; issue.Title != expected {
+		t.Errorf("expected title '%s', got '%s'", expected, issue.Title)
+	}
+	joined, note, found := strings.Cut(issue.Description, "\n... truncated ")
+	if !found {
+		t.Fatalf("expected a truncation note, got description of %d bytes", len(issue.Description))
+	}
+	if len(joined) > maxLogBytes {
+		t.Errorf("expected at most %d bytes of logs, got %d", maxLogBytes, len(joined))
+	}
+	kept := strings.Count(joined, "WARN: ")
+	if expected := fmt.Sprintf("%d of 500 lines", 500-kept); note != expected {
+		t.Errorf("expected note '%s', got '%s'", expected, note)
+	}
+	if kept == 0 || !strings.HasSuffix(joined, strings.Repeat("x", 200)) {
+		t.Errorf("expected whole log lines to be kept, got %d lines", kept)
+	}
+}
+
+func TestJoinMintmakerLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		logs     []string
+		maxBytes int
+		expected string
+	}{
+		{"no limit", []string{"first", "second"}, 0, "first" + mintmakerLogSeparator + "second"},
+		{"under the limit", []string{"first", "second"}, 100, "first" + mintmakerLogSeparator + "second"},
+		{"over the limit", []string{"first", "second", "third"}, 50, "first" + mintmakerLogSeparator + "second\n... truncated 1 of 3 lines"},
+		{"long first line", []string{"0123456789", "second"}, 4, "0123\n... truncated 2 of 2 lines"},
+		{"multibyte first line", []string{"ééé", "second"}, 3, "é\n... truncated 2 of 2 lines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinMintmakerLogs(tt.logs, tt.maxBytes); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_MintmakerResolve(t *testing.T) {
+	// What gets sent to the webhook endpoint
+	mintmakerResolveRequest := MintmakerResolveRequest{
+		PipelineId: "org/repo/main",
+		Namespace:  "team-mintmaker",
+	}
+
+	// Mock service results
+	mockService := &MockIssueService{
+		resolveIssuesByScopeResult: 2,
+		resolveIssuesByScopeError:  nil,
+	}
+
+	// Setup
+	handler := setupTestWebhookHandler(mockService)
+	router := setupTestWebhookRouter(handler)
+
+	// Create request body
+	reqBody, err := json.Marshal(mintmakerResolveRequest)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	// Make request
+	req, err := net_http.NewRequest("POST", "/webhooks/mintmaker-resolve", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", w.Code)
+	}
+
+	// Extract response onto map
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	// Check message in response
+	expectedMessage := "Resolved 2 issue(s) for mintmaker run org/repo/main"
+	if response["message"] != expectedMessage {
+		t.Errorf("expected response with message '%s', got '%s'", expectedMessage, response["message"])
+	}
+}
+
+func TestWebhookHandler_IgnoredFailureReasons(t *testing.T) {
+	ignoredFailureReasons, err := compileFailureReasonPatterns([]string{
+		`(?i)cancelled by user`,
+		`^ManagedPipelineCancelled$`,
+	})
+	if err != nil {
+		t.Fatalf("Failed to compile patterns: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		request        interface{}
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name: "ignored pipeline failure",
+			path: "/webhooks/pipeline-failure",
+			request: PipelineFailureRequest{
+				PipelineName:  "pipeline-xyz",
+				Namespace:     "team-cancelled",
+				FailureReason: "PipelineRun Cancelled by user",
+			},
+			expectedStatus: net_http.StatusAccepted,
+			expectedCalls:  0,
+		},
+		{
+			name: "pipeline failure creating an issue",
+			path: "/webhooks/pipeline-failure",
+			request: PipelineFailureRequest{
+				PipelineName:  "pipeline-xyz",
+				Namespace:     "team-failed",
+				FailureReason: "task run timed out",
+			},
+			expectedStatus: net_http.StatusCreated,
+			expectedCalls:  1,
+		},
+		{
+			name: "ignored release failure",
+			path: "/webhooks/release-failure",
+			request: ReleaseFailureRequest{
+				Application:  "fancy-app",
+				Namespace:    "team-release",
+				FailurePhase: "ManagedPipelineCancelled",
+				ReleaseName:  "release-123",
+			},
+			expectedStatus: net_http.StatusAccepted,
+			expectedCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewWebhookHandler(mockService, logger, WithIgnoredFailureReasons(ignoredFailureReasons))
+			router := setupTestWebhookRouter(handler)
+
+			reqBody, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if mockService.createOrUpdateIssueCalls != tt.expectedCalls {
+				t.Errorf("expected %d issue(s) created, got %d", tt.expectedCalls, mockService.createOrUpdateIssueCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_MaxEventAge(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	fresh := now.Add(-30 * time.Minute)
+	stale := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name           string
+		eventTime      *time.Time
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{
+			name:           "fresh event is processed",
+			eventTime:      &fresh,
+			expectedStatus: net_http.StatusCreated,
+			expectedCalls:  1,
+		},
+		{
+			name:           "stale event is ignored",
+			eventTime:      &stale,
+			expectedStatus: net_http.StatusAccepted,
+			expectedCalls:  0,
+		},
+		{
+			name:           "event without a time is processed",
+			expectedStatus: net_http.StatusCreated,
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewWebhookHandler(mockService, logger,
+				WithMaxEventAge(time.Hour),
+				WithWebhookClock(testhelpers.NewFakeClock(now)),
+			)
+			router := setupTestWebhookRouter(handler)
+
+			reqBody, err := json.Marshal(PipelineFailureRequest{
+				PipelineName:  "frontend-build",
+				Namespace:     "team-alpha",
+				FailureReason: "Unit tests failed",
+				WebhookEvent:  WebhookEvent{EventTime: tt.eventTime},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if mockService.createOrUpdateIssueCalls != tt.expectedCalls {
+				t.Errorf("expected %d issue(s) created, got %d", tt.expectedCalls, mockService.createOrUpdateIssueCalls)
+			}
+			if tt.expectedStatus == net_http.StatusAccepted {
+				var response map[string]string
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to parse response: %v", err)
+				}
+				if response["status"] != "ignored" {
+					t.Errorf("expected status 'ignored', got '%s'", response["status"])
+				}
+				if !strings.Contains(response["message"], "Stale event ignored") {
+					t.Errorf("expected a stale event message, got '%s'", response["message"])
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PipelineFailure_Occurrences(t *testing.T) {
+	const maxOccurrences = 3
+
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	repo := repository.NewIssueRepository(db, logger,
+		repository.WithDedupOptions(repository.DedupOptions{
+			IncludeResolved: true,
+			MaxOccurrences:  maxOccurrences,
+		}),
+	)
+	handler := NewWebhookHandler(services.NewIssueService(repo, logger), logger)
+	router := setupTestWebhookRouter(handler)
+
+	// The first failure creates the issue, the following ones are recurrences
+	for idx := 0; idx < maxOccurrences+3; idx++ {
+		reqBody, err := json.Marshal(PipelineFailureRequest{
+			PipelineName:  "pipeline-xyz",
+			Namespace:     "team-recurring",
+			FailureReason: "task run timed out",
+			RunID:         "pipeline-xyz-123",
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+
+		req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != net_http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var count int64
+		if err := db.Model(&models.Occurrence{}).Count(&count).Error; err != nil {
+			t.Fatalf("Failed to count occurrences: %v", err)
+		}
+		expected := int64(min(idx, maxOccurrences))
+		if count != expected {
+			t.Errorf("after %d failures expected %d occurrences, got %d", idx+1, expected, count)
+		}
+	}
+}
+
+func TestWebhookHandler_Deliveries(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	issueService := services.NewIssueService(repository.NewIssueRepository(db, logger), logger)
+	deliveryService := services.NewWebhookDeliveryService(repository.NewWebhookDeliveryRepository(db, logger), logger)
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	handler := NewWebhookHandler(issueService, logger, WithDeliveryService(deliveryService),
+		WithWebhookClock(testhelpers.NewFakeClock(now)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	webhooks := router.Group("/webhooks")
+	webhooks.Use(handler.TrackDelivery())
+	webhooks.POST("/pipeline-failure", handler.PipelineFailure)
+	webhooks.GET("/deliveries/:id", handler.GetDelivery)
+
+	postFailure := func(t *testing.T, body any) *net_httptest.ResponseRecorder {
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	getDelivery := func(t *testing.T, id, namespace string) (*net_httptest.ResponseRecorder, models.WebhookDelivery) {
+		url := "/webhooks/deliveries/" + id
+		if namespace != "" {
+			url += "?namespace=" + namespace
+		}
+		req, err := net_http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var delivery models.WebhookDelivery
+		if w.Code == net_http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &delivery); err != nil {
+				t.Fatalf("Failed to unmarshal delivery: %v", err)
+			}
+		}
+		return w, delivery
+	}
+
+	t.Run("processed failure", func(t *testing.T) {
+		w := postFailure(t, PipelineFailureRequest{
+			PipelineName:  "pipeline-xyz",
+			Namespace:     "team-deliveries",
+			FailureReason: "build failed",
+			RunID:         "pipeline-xyz-123",
+		})
+		if w.Code != net_http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+		deliveryID := w.Header().Get(DeliveryIDHeader)
+		if deliveryID == "" {
+			t.Fatalf("expected the %s header to be set", DeliveryIDHeader)
+		}
+
+		var response struct {
+			Issue models.Issue `json:"issue"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		w, delivery := getDelivery(t, deliveryID, "")
+		if w.Code != net_http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if delivery.Status != models.DeliveryProcessed {
+			t.Errorf("expected status %q, got %q", models.DeliveryProcessed, delivery.Status)
+		}
+		if delivery.Endpoint != "/webhooks/pipeline-failure" {
+			t.Errorf("expected endpoint /webhooks/pipeline-failure, got %q", delivery.Endpoint)
+		}
+		if !delivery.ReceivedAt.Equal(now) {
+			t.Errorf("expected delivery received at %v, got %v", now, delivery.ReceivedAt)
+		}
+		if delivery.Namespace != "team-deliveries" {
+			t.Errorf("expected namespace team-deliveries, got %q", delivery.Namespace)
+		}
+		if delivery.IssueID == nil || *delivery.IssueID != response.Issue.ID {
+			t.Errorf("expected issue ID %q, got %v", response.Issue.ID, delivery.IssueID)
+		}
+
+		if w, _ := getDelivery(t, deliveryID, "other-team"); w.Code != net_http.StatusForbidden {
+			t.Errorf("expected status 403 for another namespace, got %d", w.Code)
+		}
+	})
+
+	t.Run("rejected failure", func(t *testing.T) {
+		w := postFailure(t, map[string]string{"pipelineName": "pipeline-xyz"})
+		if w.Code != net_http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+		}
+
+		w, delivery := getDelivery(t, w.Header().Get(DeliveryIDHeader), "")
+		if w.Code != net_http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if delivery.Status != models.DeliveryRejected {
+			t.Errorf("expected status %q, got %q", models.DeliveryRejected, delivery.Status)
+		}
+		if delivery.IssueID != nil {
+			t.Errorf("expected no issue ID, got %q", *delivery.IssueID)
+		}
+	})
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		if w, _ := getDelivery(t, "00000000-0000-0000-0000-000000000000", ""); w.Code != net_http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestWebhookHandler_Async(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	repo := repository.NewIssueRepository(db, logger)
+	issueService := services.NewIssueService(repo, logger)
+
+	postFailure := func(t *testing.T, router *gin.Engine, pipelineName string) *net_httptest.ResponseRecorder {
+		reqBody, err := json.Marshal(PipelineFailureRequest{
+			PipelineName:  pipelineName,
+			Namespace:     "team-async",
+			FailureReason: "build failed",
+			RunID:         pipelineName + "-123",
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		w := net_httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("accepted and persisted in the background", func(t *testing.T) {
+		queue := services.NewIngestQueue(logger, 10, 1)
+		queue.Start(issueService)
+		defer queue.Drain(context.Background())
+		router := setupTestWebhookRouter(NewWebhookHandler(issueService, logger, WithIngestQueue(queue)))
+
+		w := postFailure(t, router, "pipeline-async")
+		if w.Code != net_http.StatusAccepted {
+			t.Fatalf("expected status 202, got %d: %s", w.Code, w.Body.String())
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			issues, _, err := repo.FindAll(context.Background(), repository.IssueQueryFilters{Namespace: "team-async", ResourceName: "pipeline-async"})
+			if err != nil {
+				t.Fatalf("Failed to find issues: %v", err)
+			}
+			if len(issues) == 1 {
+				if issues[0].Title != "Pipeline run failed: pipeline-async" {
+					t.Errorf("expected the issue of the pipeline, got '%s'", issues[0].Title)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the queued issue to be created")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("rejected when the queue is full", func(t *testing.T) {
+		// Not started, so queued issues stay in the queue
+		queue := services.NewIngestQueue(logger, 1, 1)
+		router := setupTestWebhookRouter(NewWebhookHandler(issueService, logger, WithIngestQueue(queue)))
+
+		if w := postFailure(t, router, "pipeline-first"); w.Code != net_http.StatusAccepted {
+			t.Fatalf("expected status 202, got %d: %s", w.Code, w.Body.String())
+		}
+		w := postFailure(t, router, "pipeline-second")
+		if w.Code != net_http.StatusServiceUnavailable {
+			t.Fatalf("expected status 503, got %d: %s", w.Code, w.Body.String())
+		}
+		if w.Header().Get("Retry-After") == "" {
+			t.Error("expected the Retry-After header to be set")
+		}
+	})
+
+	t.Run("rejected while draining", func(t *testing.T) {
+		queue := services.NewIngestQueue(logger, 10, 1)
+		queue.Start(issueService)
+		if err := queue.Drain(context.Background()); err != nil {
+			t.Fatalf("Failed to drain the queue: %v", err)
+		}
+		router := setupTestWebhookRouter(NewWebhookHandler(issueService, logger, WithIngestQueue(queue)))
+
+		if w := postFailure(t, router, "pipeline-late"); w.Code != net_http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestParseIssueTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		expectErr bool
+	}{
+		{
+			name:      "no templates",
+			templates: nil,
+		},
+		{
+			name: "valid templates",
+			templates: map[string]string{
+				"pipeline-failure.title":       "CI failed: {{.PipelineName}}",
+				"mintmaker-custom.description": `{{join .Logs "\n"}}`,
+			},
+		},
+		{
+			name:      "syntax error",
+			templates: map[string]string{"pipeline-failure.title": "CI failed: {{.PipelineName"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown request field",
+			templates: map[string]string{"release-failure.title": "{{.PipelineName}} failed"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown webhook",
+			templates: map[string]string{"pipeline-success.title": "Fixed"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown issue field",
+			templates: map[string]string{"pipeline-failure.severity": "critical"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIssueTemplates(tt.templates)
+			if tt.expectErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_IssueTemplates(t *testing.T) {
+	templates, err := ParseIssueTemplates(map[string]string{
+		"pipeline-failure.title":      "Échec du pipeline {{.PipelineName}} ({{upper .Namespace}})",
+		"release-failure.description": "Phase: {{.FailurePhase}}{{if .PipelineRunURL}}, logs: {{.PipelineRunURL}}{{end}}",
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		request             interface{}
+		expectedTitle       string
+		expectedDescription string
+	}{
+		{
+			name: "custom title, default description",
+			path: "/webhooks/pipeline-failure",
+			request: PipelineFailureRequest{
+				PipelineName:  "frontend-build",
+				Namespace:     "team-alpha",
+				FailureReason: "Docker build failed",
+			},
+			expectedTitle:       "Échec du pipeline frontend-build (TEAM-ALPHA)",
+			expectedDescription: "The pipeline run frontend-build failed with reason: Docker build failed",
+		},
+		{
+			name: "default title, custom description",
+			path: "/webhooks/release-failure",
+			request: ReleaseFailureRequest{
+				Application:    "fancy-app",
+				Namespace:      "team-alpha",
+				FailurePhase:   "Validation",
+				ReleaseName:    "release-1",
+				PipelineRunURL: "https://konflux.dev/logs/1",
+			},
+			expectedTitle:       "Release release-1 failed for application fancy-app",
+			expectedDescription: "Phase: Validation, logs: https://konflux.dev/logs/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			handler := NewWebhookHandler(mockService, logger, WithIssueTemplates(templates))
+			router := setupTestWebhookRouter(handler)
+
+			reqBody, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d", net_http.StatusCreated, w.Code)
+			}
+			if title := mockService.createOrUpdateIssueRequest.Title; title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, title)
+			}
+			if description := mockService.createOrUpdateIssueRequest.Description; description != tt.expectedDescription {
+				t.Errorf("expected description %q, got %q", tt.expectedDescription, description)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_CommitContext(t *testing.T) {
+	commit := dto.CommitContext{
+		CommitSHA: "3f2a1c9e8b7d6a5f4e3d2c1b0a9f8e7d6c5b4a39",
+		RepoURL:   "https://github.com/org/frontend",
+		Branch:    "main",
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		request any
+	}{
+		{
+			name: "pipeline failure",
+			path: "/webhooks/pipeline-failure",
+			request: PipelineFailureRequest{
+				PipelineName:  "frontend-build",
+				Namespace:     "team-alpha",
+				FailureReason: "Docker build failed",
+				CommitContext: commit,
+			},
+		},
+		{
+			name: "release failure",
+			path: "/webhooks/release-failure",
+			request: ReleaseFailureRequest{
+				Application:   "fancy-app",
+				Namespace:     "team-alpha",
+				FailurePhase:  "Validation",
+				ReleaseName:   "release-1",
+				CommitContext: commit,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			reqBody, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d", net_http.StatusCreated, w.Code)
+			}
+			if got := mockService.createOrUpdateIssueRequest.CommitContext; got != commit {
+				t.Errorf("expected commit context %+v, got %+v", commit, got)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_Fingerprint(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		request any
+	}{
+		{
+			name: "pipeline failure",
+			path: "/webhooks/pipeline-failure",
+			request: PipelineFailureRequest{
+				PipelineName:  "frontend-build",
+				Namespace:     "team-alpha",
+				FailureReason: "TestCheckout failed",
+				Fingerprint:   "flaky/TestCheckout",
+			},
+		},
+		{
+			name: "release failure",
+			path: "/webhooks/release-failure",
+			request: ReleaseFailureRequest{
+				Application:  "fancy-app",
+				Namespace:    "team-alpha",
+				FailurePhase: "Validation",
+				ReleaseName:  "release-1",
+				Fingerprint:  "flaky/TestCheckout",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{
+				createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+			}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			reqBody, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("Failed to marshal request: %v", err)
+			}
+
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d", net_http.StatusCreated, w.Code)
+			}
+			if got := mockService.createOrUpdateIssueRequest.Fingerprint; got != "flaky/TestCheckout" {
+				t.Errorf("expected fingerprint flaky/TestCheckout, got %q", got)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_InvalidNamespace(t *testing.T) {
+	mockService := &MockIssueService{
+		createOrUpdateIssueResult: &models.Issue{ID: "created-abc"},
+	}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+	reqBody, err := json.Marshal(PipelineFailureRequest{
+		PipelineName:  "frontend-build",
+		Namespace:     "Team_Alpha",
+		FailureReason: "Docker build failed",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", net_http.StatusBadRequest, w.Code)
+	}
+	if mockService.createOrUpdateIssueCalls != 0 {
+		t.Errorf("expected no issue created, got %d", mockService.createOrUpdateIssueCalls)
+	}
+}
+
+func TestWebhookHandler_TrimsIssueScope(t *testing.T) {
+	mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-abc"}}
+	router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+	body := `{"pipelineName": " frontend-build\n", "namespace": "team-alpha", "failureReason": "failed"}`
+	req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	w := net_httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != net_http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", net_http.StatusCreated, w.Code, w.Body.String())
+	}
+	if name := mockService.createOrUpdateIssueRequest.Scope.ResourceName; name != "frontend-build" {
+		t.Errorf("expected the resource name to be trimmed, got %q", name)
+	}
+}
+
+func TestWebhookHandler_AllowedLinkDomains(t *testing.T) {
+	allowlist, err := linkdomain.New([]string{"konflux.test"})
+	if err != nil {
+		t.Fatalf("Failed to parse allowlist: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		logsURL       string
+		expectedLinks int
+	}{
+		{"allowed domain", "https://console.konflux.test/logs/run-1", 1},
+		{"disallowed domain", "https://phishing.example/logs/run-1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{createOrUpdateIssueResult: &models.Issue{ID: "issue-abc"}}
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+			router := setupTestWebhookRouter(NewWebhookHandler(mockService, logger, WithWebhookAllowedLinkDomains(allowlist)))
+
+			body := fmt.Sprintf(`{"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "failed", "logsUrl": %q}`, tt.logsURL)
+			req, err := net_http.NewRequest("POST", "/webhooks/pipeline-failure", bytes.NewBufferString(body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// The failure is still reported, without the link
+			if w.Code != net_http.StatusCreated {
+				t.Fatalf("expected status %d, got %d: %s", net_http.StatusCreated, w.Code, w.Body.String())
+			}
+			if links := mockService.createOrUpdateIssueRequest.Links; len(links) != tt.expectedLinks {
+				t.Errorf("expected %d links, got %+v", tt.expectedLinks, links)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedCode   string
+		expectedFields []dto.FieldError
+	}{
+		{
+			name:         "pipeline failure missing two fields",
+			path:         "/webhooks/pipeline-failure",
+			body:         `{"pipelineName": "frontend-build"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "namespace", Reason: "required"},
+				{Field: "failureReason", Reason: "required"},
+			},
+		},
+		{
+			name:         "release failure missing a field",
+			path:         "/webhooks/release-failure",
+			body:         `{"application": "app", "namespace": "team-alpha", "failurePhase": "validation"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "release", Reason: "required"},
+			},
+		},
+		{
+			name:         "pipeline failure with a fingerprint too long",
+			path:         "/webhooks/pipeline-failure",
+			body:         `{"pipelineName": "frontend-build", "namespace": "team-alpha", "failureReason": "failed", "fingerprint": "` + strings.Repeat("f", 256) + `"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "fingerprint", Reason: "max"},
+			},
+		},
+		{
+			name:         "pipeline failure with a whitespace-only pipeline name",
+			path:         "/webhooks/pipeline-failure",
+			body:         `{"pipelineName": "  \t ", "namespace": "team-alpha", "failureReason": "failed"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "pipelineName", Reason: "required"},
+			},
+		},
+		{
+			name:         "build failure with a component name too long",
+			path:         "/webhooks/build-failure",
+			body:         `{"component": "` + strings.Repeat("c", 256) + `", "namespace": "team-alpha", "failureReason": "failed"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "component", Reason: "max"},
+			},
+		},
+		{
+			name:         "release failure with a whitespace-only application",
+			path:         "/webhooks/release-failure",
+			body:         `{"application": " ", "namespace": "team-alpha", "failurePhase": "validation", "release": "app-release-1"}`,
+			expectedCode: dto.ErrorCodeValidationFailed,
+			expectedFields: []dto.FieldError{
+				{Field: "application", Reason: "required"},
+			},
+		},
+		{
+			name:         "malformed JSON",
+			path:         "/webhooks/pipeline-success",
+			body:         `{"pipelineName": `,
+			expectedCode: dto.ErrorCodeInvalidBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockIssueService{}
+			router := setupTestWebhookRouter(setupTestWebhookHandler(mockService))
+
+			req, err := net_http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != net_http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", net_http.StatusBadRequest, w.Code)
+			}
+
+			var response struct {
+				Code   string           `json:"code"`
+				Fields []dto.FieldError `json:"fields"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %s", tt.expectedCode, response.Code)
+			}
+			if !slices.Equal(response.Fields, tt.expectedFields) {
+				t.Errorf("expected fields %v, got %v", tt.expectedFields, response.Fields)
+			}
+			if mockService.createOrUpdateIssueCalls != 0 {
+				t.Errorf("expected no issue created, got %d", mockService.createOrUpdateIssueCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// issueTemplateData holds a sample request of each templated webhook.
+// Templates are rendered with the request of their webhook, and validated against the sample.
+var issueTemplateData = map[string]any{
+	"pipeline-failure": PipelineFailureRequest{},
+	"build-failure":    BuildFailureRequest{},
+	"mintmaker-custom": MintmakerRequest{Logs: []string{""}},
+	"release-failure":  ReleaseFailureRequest{},
+}
+
+// issueTemplateFuncs are the functions available to templates, on top of the builtin ones
+var issueTemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// IssueTemplates renders the title and description of the issues created by webhooks
+type IssueTemplates struct {
+	templates map[string]*template.Template
+}
+
+// ParseIssueTemplates parses and validates the templates of webhook issues.
+//
+// Templates are keyed by "<webhook>.<field>", e.g. "pipeline-failure.title", and
+// are validated by rendering them with an empty request of their webhook.
+func ParseIssueTemplates(sources map[string]string) (*IssueTemplates, error) {
+	templates := make(map[string]*template.Template, len(sources))
+	for key, source := range sources {
+		webhook, field, _ := strings.Cut(key, ".")
+		data, ok := issueTemplateData[webhook]
+		if !ok || (field != "title" && field != "description") {
+			return nil, fmt.Errorf("unknown issue template %q", key)
+		}
+
+		tmpl, err := template.New(key).Funcs(issueTemplateFuncs).Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue template %q: %w", key, err)
+		}
+		if err := tmpl.Execute(&bytes.Buffer{}, data); err != nil {
+			return nil, fmt.Errorf("invalid issue template %q: %w", key, err)
+		}
+		templates[key] = tmpl
+	}
+	return &IssueTemplates{templates: templates}, nil
+}
+
+// render renders the template of the key with the request, or returns the fallback
+// when there's no template for the key.
+func (t *IssueTemplates) render(key string, req any, fallback string) (string, error) {
+	if t == nil {
+		return fallback, nil
+	}
+	tmpl, ok := t.templates[key]
+	if !ok {
+		return fallback, nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, req); err != nil {
+		return fallback, err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ParseReleaseLogsURLTemplate parses the template of the logs URL linked from release
+// failures that don't provide their pipelineRunUrl. It's rendered with the webhook
+// request, and validated with an empty one. An empty source links no logs.
+func ParseReleaseLogsURLTemplate(source string) (*template.Template, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("release-failure.logsUrl").Funcs(issueTemplateFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release logs URL template: %w", err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, issueTemplateData["release-failure"]); err != nil {
+		return nil, fmt.Errorf("invalid release logs URL template: %w", err)
+	}
+	return tmpl, nil
+}
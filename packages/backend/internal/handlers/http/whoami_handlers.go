@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// NamespaceAccessReviewer reviews the access of the requester to a namespace
+type NamespaceAccessReviewer interface {
+	ReviewNamespaceAccess(c *gin.Context, namespace string) (middleware.AccessDecision, error)
+}
+
+// WhoAmIHandler tells requesters who they're authenticated as, to debug denied requests
+type WhoAmIHandler struct {
+	reviewer NamespaceAccessReviewer // Optional, access is always allowed if nil
+	logger   *logrus.Logger
+}
+
+// NewWhoAmIHandler returns a new handler for the whoami route
+func NewWhoAmIHandler(reviewer NamespaceAccessReviewer, logger *logrus.Logger) *WhoAmIHandler {
+	return &WhoAmIHandler{
+		reviewer: reviewer,
+		logger:   logger,
+	}
+}
+
+// WhoAmI handles GET /whoami
+//
+// Returns the user the request is authenticated as. Requests without a token are
+// handled as publishers, with Kite's own service account. With a namespace in the
+// query, the access of the requester to that namespace is reviewed too, without
+// denying the request.
+//
+// Response:
+//   - 200 OK: Who the requester is, and their access to the namespace
+//   - 400 Bad Request: Invalid namespace
+//   - 500 Internal Server Error: The access review failed
+func (h *WhoAmIHandler) WhoAmI(c *gin.Context) {
+	response := dto.WhoAmIResponse{Type: c.GetString("type")}
+	if requester, ok := c.Get("user"); ok {
+		if info, ok := requester.(user.Info); ok {
+			response.Authenticated = true
+			response.User = &dto.WhoAmIUser{
+				Name:   info.GetName(),
+				UID:    info.GetUID(),
+				Groups: info.GetGroups(),
+			}
+		}
+	}
+	if response.Type == "" {
+		// Requests aren't authenticated in development
+		response.Type = middleware.RequesterPublisher
+	}
+
+	if namespace := c.Query("namespace"); namespace != "" {
+		if err := middleware.ValidateNamespace(namespace); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+			return
+		}
+
+		decision := middleware.AccessDecision{Allowed: true, Reason: "namespace access isn't checked"}
+		if h.reviewer != nil {
+			var err error
+			if decision, err = h.reviewer.ReviewNamespaceAccess(c, namespace); err != nil {
+				h.logger.WithError(err).WithField("namespace", namespace).Error("Failed to review namespace access")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review namespace access"})
+				return
+			}
+		}
+		response.Access = &dto.NamespaceAccess{
+			Namespace: namespace,
+			Allowed:   decision.Allowed,
+			Reason:    decision.Reason,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+
+	net_http "net/http"
+	net_httptest "net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/middleware"
+	"github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeAccessReviewer returns a namespace checker backed by a fake clientset, where
+// test-user can access team-alpha and Kite's service account can access team-beta.
+func newFakeAccessReviewer(logger *logrus.Logger) *middleware.NamespaceChecker {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "test-user" && review.Spec.ResourceAttributes.Namespace == "team-alpha"
+		if review.Status.Allowed {
+			review.Status.Reason = `RBAC: allowed by RoleBinding "viewers/team-alpha"`
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Namespace == "team-beta"
+		return true, review, nil
+	})
+
+	return middleware.NewNamespaceCheckerWithClient(client, logger)
+}
+
+func TestWhoAmIHandler_WhoAmI(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	tests := []struct {
+		name           string
+		user           *user.DefaultInfo
+		query          string
+		expectedStatus int
+		expected       dto.WhoAmIResponse
+	}{
+		{
+			name:           "authenticated user",
+			user:           &user.DefaultInfo{Name: "test-user", UID: "1234", Groups: []string{"system:authenticated"}},
+			expectedStatus: net_http.StatusOK,
+			expected: dto.WhoAmIResponse{
+				Authenticated: true,
+				Type:          middleware.RequesterConsumer,
+				User:          &dto.WhoAmIUser{Name: "test-user", UID: "1234", Groups: []string{"system:authenticated"}},
+			},
+		},
+		{
+			name:           "authorized namespace",
+			user:           &user.DefaultInfo{Name: "test-user"},
+			query:          "?namespace=team-alpha",
+			expectedStatus: net_http.StatusOK,
+			expected: dto.WhoAmIResponse{
+				Authenticated: true,
+				Type:          middleware.RequesterConsumer,
+				User:          &dto.WhoAmIUser{Name: "test-user"},
+				Access: &dto.NamespaceAccess{
+					Namespace: "team-alpha",
+					Allowed:   true,
+					Reason:    `RBAC: allowed by RoleBinding "viewers/team-alpha"`,
+				},
+			},
+		},
+		{
+			name:           "denied namespace",
+			user:           &user.DefaultInfo{Name: "test-user"},
+			query:          "?namespace=team-beta",
+			expectedStatus: net_http.StatusOK,
+			expected: dto.WhoAmIResponse{
+				Authenticated: true,
+				Type:          middleware.RequesterConsumer,
+				User:          &dto.WhoAmIUser{Name: "test-user"},
+				Access:        &dto.NamespaceAccess{Namespace: "team-beta", Allowed: false},
+			},
+		},
+		{
+			name:           "unauthenticated requests use the service account",
+			query:          "?namespace=team-beta",
+			expectedStatus: net_http.StatusOK,
+			expected: dto.WhoAmIResponse{
+				Authenticated: false,
+				Type:          middleware.RequesterPublisher,
+				Access:        &dto.NamespaceAccess{Namespace: "team-beta", Allowed: true},
+			},
+		},
+		{
+			name:           "invalid namespace",
+			query:          "?namespace=Team_Alpha",
+			expectedStatus: net_http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+					c.Set("type", middleware.RequesterConsumer)
+				} else {
+					c.Set("type", middleware.RequesterPublisher)
+				}
+				c.Next()
+			})
+			router.GET("/api/v1/whoami", NewWhoAmIHandler(newFakeAccessReviewer(logger), logger).WhoAmI)
+
+			req := net_httptest.NewRequest("GET", "/api/v1/whoami"+tt.query, nil)
+			w := net_httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != net_http.StatusOK {
+				return
+			}
+
+			var response dto.WhoAmIResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			expected, _ := json.Marshal(tt.expected)
+			got, _ := json.Marshal(response)
+			if string(got) != string(expected) {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
@@ -87,6 +87,12 @@ func NewNamespaceChecker(logger *logrus.Logger) (*NamespaceChecker, error) {
 	return &NamespaceChecker{client: clientset, logger: logger}, nil
 }
 
+// NewNamespaceCheckerWithClient creates a namespace checker using the Kubernetes client passed.
+// This is mostly useful for tests, where a fake clientset can be used.
+func NewNamespaceCheckerWithClient(client kubernetes.Interface, logger *logrus.Logger) *NamespaceChecker {
+	return &NamespaceChecker{client: client, logger: logger}
+}
+
 func newDefaultInfoFromAuthN(info apiAuthnv1.UserInfo) user.Info {
 	extra := make(map[string][]string)
 	for k, v := range info.Extra {
@@ -114,12 +120,54 @@ func extractBearerToken(header string) (string, error) {
 	return jwtToken[1], nil
 }
 
-func (nc *NamespaceChecker) Authentication(cache *cache.Cache, cacheExpirationAuthorized, cacheExpirationUnauthorized time.Duration) gin.HandlerFunc {
+// Requester types set in the "type" context key by Authentication
+const (
+	// Requests without a token, e.g. producers reporting issues
+	RequesterPublisher = "publisher"
+	// Authenticated requests
+	RequesterConsumer = "consumer"
+	// Requests without a token when anonymous read access is enabled, they can only read
+	RequesterAnonymous = "anonymous"
+)
+
+// Authentication middleware authenticates the bearer token of requests.
+//
+// Requests without a token are handled as publishers. When anonymousRead is set
+// they're handled as anonymous instead: they can only read, and any write
+// operation requires a token.
+//
+// Routes can require another authentication level, see RoutePolicy: requests to
+// public routes are handled as anonymous without authenticating them, and requests
+// to authenticated routes require a token.
+func (nc *NamespaceChecker) Authentication(cache *cache.Cache, cacheExpirationAuthorized, cacheExpirationUnauthorized time.Duration, anonymousRead bool) gin.HandlerFunc {
 	tri := nc.client.AuthenticationV1().TokenReviews()
 	return func(c *gin.Context) {
+		level := c.GetString(AuthLevelKey)
+		if level == AuthLevelPublic {
+			c.Set("type", RequesterAnonymous)
+			c.Next()
+			return
+		}
+
 		token, err := extractBearerToken(c.GetHeader("Authorization"))
 		if err != nil {
-			c.Set("type", "publisher")
+			if level == AuthLevelAuthenticated {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+				c.Abort()
+				return
+			}
+			if !anonymousRead {
+				c.Set("type", RequesterPublisher)
+				c.Next()
+				return
+			}
+
+			if !isReadOnlyMethod(c.Request.Method) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+				c.Abort()
+				return
+			}
+			c.Set("type", RequesterAnonymous)
 			c.Next()
 			return
 		}
@@ -133,7 +181,7 @@ func (nc *NamespaceChecker) Authentication(cache *cache.Cache, cacheExpirationAu
 			}
 
 			c.Set("user", userInfo)
-			c.Set("type", "consumer")
+			c.Set("type", RequesterConsumer)
 			c.Next()
 			return
 		}
@@ -159,10 +207,15 @@ func (nc *NamespaceChecker) Authentication(cache *cache.Cache, cacheExpirationAu
 		cache.Set(token, userInfo, cacheExpirationAuthorized)
 
 		c.Set("user", userInfo)
-		c.Set("type", "consumer")
+		c.Set("type", RequesterConsumer)
 	}
 }
 
+// isReadOnlyMethod reports whether an HTTP method only reads
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
 func newImpersonatedData(c *gin.Context) (*impersonatedData, error) {
 
 	userInfo := &user.DefaultInfo{}
@@ -315,7 +368,7 @@ func (nc *NamespaceChecker) Impersonation(
 
 	return func(c *gin.Context) {
 		user_type, _ := c.Get("type")
-		if user_type == "publisher" {
+		if user_type == RequesterPublisher || user_type == RequesterAnonymous {
 			c.Next()
 			return
 	}
@@ -370,12 +423,70 @@ func (nc *NamespaceChecker) Impersonation(
 	}
 }
 
-func (nc *NamespaceChecker) CheckNamespacessAccess() gin.HandlerFunc {
+// AllowedNamespacesKey is the context key holding the namespaces the requester
+// can access, when several namespaces are requested at once.
+const AllowedNamespacesKey = "allowed_namespaces"
+
+// VerifiedNamespaceKey is the context key holding the namespace of the request, once
+// the requester's access to it was checked.
+const VerifiedNamespaceKey = "verified_namespace"
+
+// RequestNamespace returns the namespace of the request: the one verified by
+// CheckNamespacessAccess when it ran, the namespace query parameter otherwise.
+// Handlers must read it rather than the query, which can differ from the namespace
+// access was checked for. It's empty when several namespaces were requested, see
+// AllowedNamespacesKey.
+func RequestNamespace(c *gin.Context) string {
+	if namespace := c.GetString(VerifiedNamespaceKey); namespace != "" {
+		return namespace
+	}
+	if _, ok := c.Get(AllowedNamespacesKey); ok {
+		return ""
+	}
+	return c.Query("namespace")
+}
+
+// CheckNamespacessAccess checks that the requester can access the namespace of the request,
+// unless its route is public, see RoutePolicy. The namespace checked is stored under
+// VerifiedNamespaceKey.
+//
+// The namespace query parameter can only be repeated on the GET routes of multiNamespaceRoutes,
+// full paths like "/api/v1/issues/", which list issues across namespaces. The namespaces the
+// requester can access are stored under AllowedNamespacesKey instead.
+func (nc *NamespaceChecker) CheckNamespacessAccess(multiNamespaceRoutes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if c.GetString(AuthLevelKey) == AuthLevelPublic {
+			c.Next()
+			return
+		}
+
+		// Several namespaces can be queried at once, only keep the ones the requester can access
+		if namespaces := c.QueryArray("namespace"); len(namespaces) > 1 {
+			if c.Request.Method != http.MethodGet || !slices.Contains(multiNamespaceRoutes, c.FullPath()) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Only one namespace can be requested on this route"})
+				c.Abort()
+				return
+			}
+			for _, namespace := range namespaces {
+				if err := ValidateNamespace(namespace); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+					c.Abort()
+					return
+				}
+			}
+			nc.checkNamespacesAccess(c, namespaces)
+			return
+		}
+
 		// Get namespaces from params, body or query
 		namespace := c.Param("namespace")
-		if namespace == "" {
-			namespace = c.Query("namespace")
+		if query := c.Query("namespace"); namespace == "" {
+			namespace = query
+		} else if query != "" && query != namespace {
+			// Handlers may act on either, so they must be the same
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Conflicting namespaces in path and query"})
+			c.Abort()
+			return
 		}
 		if namespace == "" {
 			// Try to get from request body
@@ -395,10 +506,17 @@ func (nc *NamespaceChecker) CheckNamespacessAccess() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		// Malformed namespaces are rejected before asking the API server about them
+		if err := ValidateNamespace(namespace); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid namespace", "details": err.Error()})
+			c.Abort()
+			return
+		}
 
 		// If K8s client is not available, skip check
 		if nc.client == nil {
 			nc.logger.Debug("Kubernetes client not available, skipping namespace access check")
+			c.Set(VerifiedNamespaceKey, namespace)
 			c.Next()
 			return
 		}
@@ -429,15 +547,153 @@ func (nc *NamespaceChecker) CheckNamespacessAccess() gin.HandlerFunc {
 		}
 
 		nc.logger.WithField("namespace", namespace).Debug("Access allowed")
+		c.Set(VerifiedNamespaceKey, namespace)
 		c.Next()
 	}
 }
 
+// checkNamespacesAccess filters out the namespaces the requester can't access,
+// only denying the request if none of them can be accessed.
+func (nc *NamespaceChecker) checkNamespacesAccess(c *gin.Context, namespaces []string) {
+	var allowed []string
+	for _, namespace := range namespaces {
+		if slices.Contains(allowed, namespace) {
+			continue
+		}
+		if nc.CanAccessNamespace(c, namespace) {
+			allowed = append(allowed, namespace)
+		} else {
+			nc.logger.WithField("namespace", namespace).Warn("Access Denied, namespace filtered out")
+		}
+	}
+
+	if len(allowed) == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied to these namespaces"})
+		c.Abort()
+		return
+	}
+
+	nc.logger.WithField("namespaces", allowed).Debug("Access allowed")
+	c.Set(AllowedNamespacesKey, allowed)
+	c.Next()
+}
+
+// CanAccessNamespace reports whether the requester can access the namespace.
+//
+// The authenticated user in the request context is checked if there is one,
+// otherwise Kite's own service account is checked (e.g. for publishers).
+// Access is always granted when the Kubernetes client isn't available.
+func (nc *NamespaceChecker) CanAccessNamespace(c *gin.Context, namespace string) bool {
+	if nc.client == nil {
+		return true
+	}
+
+	var err error
+	if requester, ok := c.Get("user"); ok {
+		requesterInfo, okCast := requester.(user.Info)
+		if !okCast {
+			nc.logger.WithField("namespace", namespace).Warn("Unexpected user type in context")
+			return false
+		}
+		err = nc.checkUserPodAccess(namespace, requesterInfo)
+	} else {
+		err = nc.checkPodAccess(namespace)
+	}
+
+	if err != nil {
+		nc.logger.WithError(err).WithField("namespace", namespace).Debug("Access Denied")
+		return false
+	}
+	return true
+}
+
+// CanPerformInNamespace reports whether the authenticated requester can perform the verb
+// on pods in the namespace, e.g. to grant elevated access beyond the namespace gate.
+//
+// Requests without an authenticated user, including publishers and anonymous readers,
+// are never granted it. It's always granted when the Kubernetes client isn't available.
+func (nc *NamespaceChecker) CanPerformInNamespace(c *gin.Context, namespace, verb string) bool {
+	if nc.client == nil {
+		return true
+	}
+
+	requester, ok := c.Get("user")
+	if !ok {
+		return false
+	}
+	requesterInfo, okCast := requester.(user.Info)
+	if !okCast {
+		nc.logger.WithField("namespace", namespace).Warn("Unexpected user type in context")
+		return false
+	}
+
+	status, err := nc.reviewUserPodVerb(namespace, verb, requesterInfo)
+	if err != nil {
+		nc.logger.WithError(err).WithFields(logrus.Fields{
+			"namespace": namespace,
+			"verb":      verb,
+		}).Warn("Failed to review elevated access")
+		return false
+	}
+	return status.Allowed
+}
+
+// AccessDecision is the outcome of reviewing the access to a namespace
+type AccessDecision struct {
+	Allowed bool
+	// Why access was allowed or denied, as given by the API server
+	Reason string
+}
+
+// ReviewNamespaceAccess reviews whether the requester can access the namespace, like
+// CanAccessNamespace, returning the decision of the API server along with its reason.
+// Reviews aren't cached, and have no side effects.
+func (nc *NamespaceChecker) ReviewNamespaceAccess(c *gin.Context, namespace string) (AccessDecision, error) {
+	if nc.client == nil {
+		return AccessDecision{Allowed: true, Reason: "namespace access isn't checked"}, nil
+	}
+
+	var status *authv1.SubjectAccessReviewStatus
+	var err error
+	if requester, ok := c.Get("user"); ok {
+		requesterInfo, okCast := requester.(user.Info)
+		if !okCast {
+			return AccessDecision{}, errors.New("unexpected user type in context")
+		}
+		status, err = nc.reviewUserPodAccess(namespace, requesterInfo)
+	} else {
+		status, err = nc.reviewPodAccess(namespace)
+	}
+	if err != nil {
+		return AccessDecision{}, fmt.Errorf("failed to review namespace access: %w", err)
+	}
+
+	reason := status.Reason
+	if status.EvaluationError != "" {
+		reason = strings.TrimSpace(reason + " " + status.EvaluationError)
+	}
+	return AccessDecision{Allowed: status.Allowed, Reason: reason}, nil
+}
+
 func (nc *NamespaceChecker) checkPodAccess(namespace string) error {
 	if nc.client == nil {
 		return nil // Skip check if client is not available
 	}
 
+	result, err := nc.reviewPodAccess(namespace)
+	if err != nil {
+		return fmt.Errorf("failed to check kite namespace access: %w", err)
+	}
+
+	if !result.Allowed {
+		return fmt.Errorf("access denied for kite to namespace %s", namespace)
+	}
+
+	return nil
+}
+
+// reviewPodAccess reviews whether Kite's service account can get pods in the namespace
+func (nc *NamespaceChecker) reviewPodAccess(namespace string) (*authv1.SubjectAccessReviewStatus, error) {
 	// Create a SelfSubjectAccessReview to check if the user can get pods in the namespace
 	accessReview := &authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
@@ -455,24 +711,37 @@ func (nc *NamespaceChecker) checkPodAccess(namespace string) error {
 
 	result, err := nc.client.AuthorizationV1().SelfSubjectAccessReviews().Create(
 		ctx, accessReview, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.Status, nil
+}
+
+func (nc *NamespaceChecker) checkUserPodAccess(namespace string, requester user.Info) error {
+	if nc.client == nil {
+		return nil // Skip check if client is not available
+	}
 
+	result, err := nc.reviewUserPodAccess(namespace, requester)
 	if err != nil {
-		return fmt.Errorf("failed to check kite namespace access: %w", err)
+		return fmt.Errorf("failed to check user namespace access: %w", err)
 	}
 
-	if !result.Status.Allowed {
-		return fmt.Errorf("access denied for kite to namespace %s", namespace)
+	if !result.Allowed {
+		return fmt.Errorf("access denied for %s to namespace %s", requester.GetName(), namespace)
 	}
 
 	return nil
 }
 
-func (nc *NamespaceChecker) checkUserPodAccess(namespace string, requester user.Info) error {
-	if nc.client == nil {
-		return nil // Skip check if client is not available
-	}
+// reviewUserPodAccess reviews whether the requester can get pods in the namespace
+func (nc *NamespaceChecker) reviewUserPodAccess(namespace string, requester user.Info) (*authv1.SubjectAccessReviewStatus, error) {
+	return nc.reviewUserPodVerb(namespace, "get", requester)
+}
 
-	// Create a SubjectAccessReview to check if the user can get pods in the namespace
+// reviewUserPodVerb reviews whether the requester can perform the verb on pods in the namespace
+func (nc *NamespaceChecker) reviewUserPodVerb(namespace, verb string, requester user.Info) (*authv1.SubjectAccessReviewStatus, error) {
+	// Create a SubjectAccessReview to check if the user can perform the verb on pods in the namespace
 	accessReview := &authv1.SubjectAccessReview{
 		Spec: authv1.SubjectAccessReviewSpec{
 			User: requester.GetName(),
@@ -480,7 +749,7 @@ func (nc *NamespaceChecker) checkUserPodAccess(namespace string, requester user.
 			Groups: requester.GetGroups(),
 			ResourceAttributes: &authv1.ResourceAttributes{
 				Namespace: namespace,
-				Verb:      "get",
+				Verb:      verb,
 				Resource:  "pods",
 			},
 		},
@@ -492,14 +761,8 @@ func (nc *NamespaceChecker) checkUserPodAccess(namespace string, requester user.
 
 	result, err := nc.client.AuthorizationV1().SubjectAccessReviews().Create(
 		ctx, accessReview, metav1.CreateOptions{})
-
 	if err != nil {
-		return fmt.Errorf("failed to check user namespace access: %w", err)
+		return nil, err
 	}
-
-	if !result.Status.Allowed {
-		return fmt.Errorf("access denied for %s to namespace %s", requester.GetName(), namespace)
-	}
-
-	return nil
+	return &result.Status, nil
 }
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/cache"
+	"github.com/sirupsen/logrus"
+	apiAuthnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// setupAuthRouter returns a router authenticating requests, where any token is valid
+func setupAuthRouter(anonymousRead bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*apiAuthnv1.TokenReview)
+		review.Status = apiAuthnv1.TokenReviewStatus{
+			Authenticated: true,
+			User:          apiAuthnv1.UserInfo{Username: "jane"},
+		}
+		return true, review, nil
+	})
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	checker := NewNamespaceCheckerWithClient(client, logger)
+
+	router := gin.New()
+	router.Use(checker.Authentication(cache.New(), time.Second, time.Second, anonymousRead))
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"type": c.GetString("type")})
+	}
+	router.GET("/api/v1/issues", handler)
+	router.POST("/api/v1/issues", handler)
+	return router
+}
+
+func TestAuthentication_AnonymousRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		anonymousRead  bool
+		method         string
+		token          string
+		expectedStatus int
+		expectedType   string
+	}{
+		{"anonymous GET allowed", true, "GET", "", http.StatusOK, RequesterAnonymous},
+		{"anonymous POST rejected", true, "POST", "", http.StatusUnauthorized, ""},
+		{"authenticated POST allowed", true, "POST", "valid-token", http.StatusOK, RequesterConsumer},
+		{"publisher POST without anonymous read", false, "POST", "", http.StatusOK, RequesterPublisher},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupAuthRouter(tt.anonymousRead)
+
+			req := httptest.NewRequest(tt.method, "/api/v1/issues?namespace=team-alpha", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if expected := `{"type":"` + tt.expectedType + `"}`; tt.expectedType != "" && w.Body.String() != expected {
+				t.Errorf("Expected requester type %q, got %s", tt.expectedType, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCheckNamespacesAccess_InvalidNamespace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := fake.NewSimpleClientset()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	checker := NewNamespaceCheckerWithClient(client, logger)
+
+	router := gin.New()
+	router.Use(checker.CheckNamespacessAccess())
+	router.GET("/api/v1/issues", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, query := range []string{"namespace=Team-Alpha", "namespace=team-alpha&namespace=team_beta"} {
+		req := httptest.NewRequest("GET", "/api/v1/issues?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", query, w.Code)
+		}
+	}
+
+	// Malformed namespaces never reach the API server
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no access reviews, got %d", len(actions))
+	}
+}
+
+func TestCheckNamespacesAccess_RequestNamespace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Namespace == "team-alpha"
+		return true, review, nil
+	})
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	checker := NewNamespaceCheckerWithClient(client, logger)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &user.DefaultInfo{Name: "jane"})
+		c.Next()
+	})
+	router.Use(checker.CheckNamespacessAccess("/api/v1/issues"))
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"namespace": RequestNamespace(c), "allowed": c.GetStringSlice(AllowedNamespacesKey)})
+	}
+	router.GET("/api/v1/issues", handler)
+	router.DELETE("/api/v1/issues/:id", handler)
+	router.POST("/api/v1/namespaces/:namespace/acknowledge", handler)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "single namespace is verified",
+			method:         "DELETE",
+			path:           "/api/v1/issues/abc?namespace=team-alpha",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"allowed":null,"namespace":"team-alpha"}`,
+		},
+		{
+			name:           "repeated namespace on a listing route",
+			method:         "GET",
+			path:           "/api/v1/issues?namespace=team-beta&namespace=team-alpha",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"allowed":["team-alpha"],"namespace":""}`,
+		},
+		{
+			name:           "repeated namespace on another route",
+			method:         "DELETE",
+			path:           "/api/v1/issues/abc?namespace=team-beta&namespace=team-alpha",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "repeated namespace along a path namespace",
+			method:         "POST",
+			path:           "/api/v1/namespaces/team-beta/acknowledge?namespace=team-alpha&namespace=team-alpha",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "query namespace conflicting with the path",
+			method:         "POST",
+			path:           "/api/v1/namespaces/team-beta/acknowledge?namespace=team-alpha",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "path namespace is checked",
+			method:         "POST",
+			path:           "/api/v1/namespaces/team-beta/acknowledge",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedBody != "" && w.Body.String() != tt.expectedBody {
+				t.Errorf("Expected %s, got %s", tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
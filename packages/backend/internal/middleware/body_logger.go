@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/redact"
+	"github.com/sirupsen/logrus"
+)
+
+// truncatedSuffix marks logged bodies that were cut at the maximum size
+const truncatedSuffix = "...(truncated)"
+
+// bodyLogWriter keeps a copy of the start of a response while writing it.
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	maxSize int
+	size    int
+}
+
+func (w *bodyLogWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyLogWriter) capture(data []byte) {
+	w.size += len(data)
+	if remaining := w.maxSize - w.body.Len(); remaining > 0 {
+		w.body.Write(data[:min(len(data), remaining)])
+	}
+}
+
+// BodyLogger middleware logs the request and response bodies at debug level.
+//
+// This is a debugging aid to see exactly what a producer sends, it must not be
+// enabled in normal operation. Bodies go through the redactor before being
+// logged, and only their first maxSize bytes are logged. The request body is
+// restored so handlers can still read it.
+func BodyLogger(logger *logrus.Logger, redactor *redact.Redactor, maxSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !logger.IsLevelEnabled(logrus.DebugLevel) {
+			c.Next()
+			return
+		}
+
+		var requestBody []byte
+		truncated := false
+		if c.Request.Body != nil {
+			// Read one more byte than logged to tell whether the body is truncated
+			head, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(maxSize)+1))
+			if err != nil {
+				logger.WithError(err).Debug("Failed to read request body for logging")
+			}
+			c.Request.Body = readCloser{
+				Reader: io.MultiReader(bytes.NewReader(head), c.Request.Body),
+				Closer: c.Request.Body,
+			}
+			truncated = len(head) > maxSize
+			requestBody = head[:min(len(head), maxSize)]
+		}
+
+		writer := &bodyLogWriter{ResponseWriter: c.Writer, maxSize: maxSize}
+		c.Writer = writer
+
+		c.Next()
+
+		logger.WithFields(logrus.Fields{
+			"method":        c.Request.Method,
+			"path":          c.Request.URL.Path,
+			"status":        c.Writer.Status(),
+			"request_id":    c.GetString("request_id"),
+			"request_body":  formatLoggedBody(redactor, requestBody, truncated),
+			"response_body": formatLoggedBody(redactor, writer.body.Bytes(), writer.size > maxSize),
+		}).Debug("Request and response bodies")
+	}
+}
+
+// readCloser reads from a reader while closing the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// formatLoggedBody redacts a body and marks it if it was truncated
+func formatLoggedBody(redactor *redact.Redactor, body []byte, truncated bool) string {
+	logged := redactor.Redact(string(body))
+	if truncated {
+		logged += truncatedSuffix
+	}
+	return logged
+}
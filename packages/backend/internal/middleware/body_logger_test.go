@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/redact"
+	"github.com/sirupsen/logrus"
+)
+
+// serveBodyLoggedRequest posts a body through the body logger middleware, and returns
+// the body read by the handler and the logs written
+func serveBodyLoggedRequest(t *testing.T, level logrus.Level, maxSize int, body string) (string, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetLevel(level)
+
+	redactor, err := redact.New(redact.DefaultPatterns)
+	if err != nil {
+		t.Fatalf("Failed to create redactor: %v", err)
+	}
+
+	var received string
+	router := gin.New()
+	router.Use(BodyLogger(logger, redactor, maxSize))
+	router.POST("/api/v1/webhooks/pipeline-failure", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			t.Fatalf("Failed to read request body: %v", err)
+		}
+		received = string(data)
+		c.JSON(http.StatusCreated, gin.H{"status": "created", "token": "s3cr3t-response"})
+	})
+
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/pipeline-failure", strings.NewReader(body))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	return received, out.String()
+}
+
+func TestBodyLogger_LogsRedactedBodies(t *testing.T) {
+	body := `{"pipelineName":"frontend-build","failureReason":"login failed with password=hunter2"}`
+	received, logs := serveBodyLoggedRequest(t, logrus.DebugLevel, 4096, body)
+
+	if received != body {
+		t.Errorf("Expected the handler to read %q, got %q", body, received)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(logs), &entry); err != nil {
+		t.Fatalf("Expected a JSON log entry, got %q: %v", logs, err)
+	}
+
+	requestBody, _ := entry["request_body"].(string)
+	if !strings.Contains(requestBody, "frontend-build") {
+		t.Errorf("Expected the request body to be logged, got %q", requestBody)
+	}
+	if strings.Contains(requestBody, "hunter2") || !strings.Contains(requestBody, redact.Replacement) {
+		t.Errorf("Expected the password to be redacted from the request body, got %q", requestBody)
+	}
+
+	responseBody, _ := entry["response_body"].(string)
+	if !strings.Contains(responseBody, "created") {
+		t.Errorf("Expected the response body to be logged, got %q", responseBody)
+	}
+	if strings.Contains(responseBody, "s3cr3t-response") {
+		t.Errorf("Expected the token to be redacted from the response body, got %q", responseBody)
+	}
+}
+
+func TestBodyLogger_TruncatesLargeBodies(t *testing.T) {
+	body := strings.Repeat("a", 100)
+	received, logs := serveBodyLoggedRequest(t, logrus.DebugLevel, 10, body)
+
+	if received != body {
+		t.Errorf("Expected the handler to read the whole body, got %d bytes", len(received))
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(logs), &entry); err != nil {
+		t.Fatalf("Expected a JSON log entry, got %q: %v", logs, err)
+	}
+	if expected := strings.Repeat("a", 10) + truncatedSuffix; entry["request_body"] != expected {
+		t.Errorf("Expected the request body to be logged as %q, got %v", expected, entry["request_body"])
+	}
+}
+
+func TestBodyLogger_NothingLoggedAboveDebug(t *testing.T) {
+	body := `{"pipelineName":"frontend-build"}`
+	received, logs := serveBodyLoggedRequest(t, logrus.InfoLevel, 4096, body)
+
+	if received != body {
+		t.Errorf("Expected the handler to read %q, got %q", body, received)
+	}
+	if logs != "" {
+		t.Errorf("Expected nothing to be logged, got %q", logs)
+	}
+}
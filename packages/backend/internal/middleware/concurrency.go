@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimit middleware serves at most maxInFlight requests at once, so a burst of
+// requests (e.g. webhooks for a mass pipeline failure) can't saturate the database pool.
+//
+// Excess requests are queued for up to wait, then rejected with 429 and a Retry-After header.
+// A maxInFlight below 1 disables the limit.
+func ConcurrencyLimit(maxInFlight int, wait time.Duration) gin.HandlerFunc {
+	if maxInFlight < 1 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	slots := make(chan struct{}, maxInFlight)
+	return func(c *gin.Context) {
+		if !acquireSlot(c, slots, wait) {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many concurrent requests, try again shortly"})
+			return
+		}
+		defer func() { <-slots }()
+		c.Next()
+	}
+}
+
+// acquireSlot waits up to wait for a free slot, giving up early if the client goes away
+func acquireSlot(c *gin.Context, slots chan struct{}, wait time.Duration) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
+
+// LoadShedding middleware serves at most maxInFlight requests at once across all routes,
+// so the process isn't overloaded. Excess requests are rejected right away with 503 and
+// a Retry-After header, rather than queued like by ConcurrencyLimit.
+//
+// Requests to paths starting with one of the exempt prefixes are always served, e.g.
+// health checks, so an overloaded server isn't restarted. A maxInFlight below 1
+// disables the limit.
+func LoadShedding(maxInFlight int, exempt ...string) gin.HandlerFunc {
+	if maxInFlight < 1 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	slots := make(chan struct{}, maxInFlight)
+	return func(c *gin.Context) {
+		if hasAnyPrefix(c.Request.URL.Path, exempt) {
+			c.Next()
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server is overloaded, try again shortly"})
+			return
+		}
+		defer func() { <-slots }()
+		c.Next()
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// concurrencyTestRouter serves a handler recording the highest number of requests
+// served at once, blocking until release is closed.
+func concurrencyTestRouter(limit int, wait time.Duration, release <-chan struct{}) (*gin.Engine, *atomic.Int32, *atomic.Int32) {
+	gin.SetMode(gin.TestMode)
+
+	var current, peak atomic.Int32
+	router := gin.New()
+	router.Use(ConcurrencyLimit(limit, wait))
+	router.POST("/webhooks/pipeline-failure", func(c *gin.Context) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	return router, &current, &peak
+}
+
+// fireRequests sends count concurrent requests, returning their status codes
+func fireRequests(router *gin.Engine, count int) <-chan int {
+	codes := make(chan int, count)
+	var wg sync.WaitGroup
+	for range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks/pipeline-failure", nil))
+			codes <- w.Code
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(codes)
+	}()
+	return codes
+}
+
+func TestConcurrencyLimit_ThrottlesExcessRequests(t *testing.T) {
+	release := make(chan struct{})
+	router, current, peak := concurrencyTestRouter(2, 50*time.Millisecond, release)
+
+	codes := fireRequests(router, 6)
+
+	// The requests over the limit give up waiting while the others are still served
+	counts := map[int]int{}
+	for range 4 {
+		counts[<-codes]++
+	}
+	if counts[http.StatusTooManyRequests] != 4 {
+		t.Errorf("Expected 4 throttled requests, got %v", counts)
+	}
+	if current.Load() != 2 {
+		t.Errorf("Expected 2 requests in flight, got %d", current.Load())
+	}
+
+	close(release)
+	for code := range codes {
+		counts[code]++
+	}
+	if counts[http.StatusOK] != 2 {
+		t.Errorf("Expected 2 served requests, got %v", counts)
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+}
+
+func TestConcurrencyLimit_QueuesRequests(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	router, _, peak := concurrencyTestRouter(1, 5*time.Second, release)
+
+	// Requests wait for a free slot instead of being rejected
+	for code := range fireRequests(router, 5) {
+		if code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, code)
+		}
+	}
+	if peak.Load() != 1 {
+		t.Errorf("Expected requests to be served one at a time, got %d at once", peak.Load())
+	}
+}
+
+func TestConcurrencyLimit_Disabled(t *testing.T) {
+	release := make(chan struct{})
+	router, current, _ := concurrencyTestRouter(0, 0, release)
+
+	codes := fireRequests(router, 5)
+	deadline := time.Now().Add(5 * time.Second)
+	for current.Load() < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if current.Load() != 5 {
+		t.Errorf("Expected all 5 requests in flight, got %d", current.Load())
+	}
+
+	close(release)
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, code)
+		}
+	}
+}
+
+func TestLoadShedding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 3)
+	router := gin.New()
+	router.Use(LoadShedding(2, "/api/v1/health"))
+	router.GET("/api/v1/issues", func(c *gin.Context) {
+		started <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/v1/health/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Saturate the limit with requests blocked until released
+	codes := make(chan int, 2)
+	for range 2 {
+		go func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/issues", nil))
+			codes <- w.Code
+		}()
+	}
+	<-started
+	<-started
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/issues", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d for an excess request, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/health/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected health checks to be served, got status %d", w.Code)
+	}
+
+	close(release)
+	for range 2 {
+		if code := <-codes; code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, code)
+		}
+	}
+
+	// Slots are freed once the requests are served
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/issues", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
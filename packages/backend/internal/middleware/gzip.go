@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// gzipWriter buffers a response to decide whether it's worth compressing once complete.
+type gzipWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buf.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush switches to writing the response as is, so streamed responses aren't held back.
+func (w *gzipWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		if w.buf.Len() > 0 {
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+			w.buf.Reset()
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Gzip middleware compresses responses for clients accepting gzip.
+//
+// Responses smaller than minSize bytes are sent uncompressed, as are
+// Server-Sent Events and any response flushed by its handler while streaming.
+func Gzip(minSize int, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) ||
+			strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &gzipWriter{ResponseWriter: original}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+		}()
+
+		c.Next()
+
+		if writer.passthrough {
+			return
+		}
+
+		body := writer.buf.Bytes()
+		header := original.Header()
+		if len(body) == 0 || len(body) < minSize || header.Get("Content-Encoding") != "" ||
+			strings.HasPrefix(header.Get("Content-Type"), "text/event-stream") {
+			if _, err := original.Write(body); err != nil {
+				logger.WithError(err).Error("Failed to write response")
+			}
+			return
+		}
+
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+
+		gz := gzip.NewWriter(original)
+		if _, err := gz.Write(body); err != nil {
+			logger.WithError(err).Error("Failed to write compressed response")
+		}
+		if err := gz.Close(); err != nil {
+			logger.WithError(err).Error("Failed to write compressed response")
+		}
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip responses
+func acceptsGzip(acceptEncoding string) bool {
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(encoding, ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		// An encoding with a zero weight is explicitly refused
+		key, value, _ := strings.Cut(params, "=")
+		if strings.TrimSpace(key) == "q" {
+			quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return err == nil && quality > 0
+		}
+		return true
+	}
+	return false
+}
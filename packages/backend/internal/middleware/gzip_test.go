@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// setupGzipRouter creates a router returning a large JSON list and a tiny response
+func setupGzipRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	router := gin.New()
+	router.Use(Gzip(1024, logger))
+	router.GET("/large", func(c *gin.Context) {
+		items := make([]gin.H, 200)
+		for i := range items {
+			items[i] = gin.H{"id": fmt.Sprintf("issue-%d", i), "title": "Pipeline run failed"}
+		}
+		c.JSON(http.StatusOK, gin.H{"data": items})
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		for i := 0; i < 200; i++ {
+			c.SSEvent("message", "Pipeline run failed")
+			c.Writer.Flush()
+		}
+	})
+
+	return router
+}
+
+func TestGzip_LargeResponse(t *testing.T) {
+	router := setupGzipRouter()
+
+	req := httptest.NewRequest("GET", "/large", nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected a gzip encoded response, got encoding '%s'", w.Header().Get("Content-Encoding"))
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to read compressed response: %v", err)
+	}
+	var response struct {
+		Data []map[string]string `json:"data"`
+	}
+	if err := json.NewDecoder(reader).Decode(&response); err != nil {
+		t.Fatalf("Failed to parse decompressed response: %v", err)
+	}
+	if len(response.Data) != 200 {
+		t.Errorf("Expected 200 items, got %d", len(response.Data))
+	}
+}
+
+func TestGzip_Uncompressed(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+	}{
+		{name: "client without gzip support", path: "/large"},
+		{name: "client refusing gzip", path: "/large", acceptEncoding: "gzip;q=0"},
+		{name: "response below threshold", path: "/small", acceptEncoding: "gzip"},
+		{name: "streamed events", path: "/events", acceptEncoding: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupGzipRouter()
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if encoding := w.Header().Get("Content-Encoding"); encoding != "" {
+				t.Errorf("Expected an uncompressed response, got encoding '%s'", encoding)
+			}
+			if tt.path != "/events" && !json.Valid(w.Body.Bytes()) {
+				t.Errorf("Expected a plain JSON body, got %q", w.Body.String())
+			}
+		})
+	}
+}
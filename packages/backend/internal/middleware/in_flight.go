@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+)
+
+// InFlight middleware counts the requests being served, so shutdown can report
+// how many requests are left to drain.
+func InFlight(state *readiness.State) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state.RequestStarted()
+		defer state.RequestFinished()
+		c.Next()
+	}
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// jsonNamingWriter buffers a JSON response to rename its fields once complete.
+type jsonNamingWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func (w *jsonNamingWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buf.Write(data)
+}
+
+func (w *jsonNamingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush switches to writing the response as is, so streamed responses aren't held back.
+func (w *jsonNamingWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		if w.buf.Len() > 0 {
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+			w.buf.Reset()
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// SnakeCaseJSON middleware renames the fields of JSON responses from camelCase to
+// snake_case, e.g. "issueType" to "issue_type", for clients expecting snake_case.
+// The models keep a single set of JSON tags this way.
+//
+// Only the object keys starting with a lowercase letter are renamed, keys holding
+// data like "ACTIVE" are kept. Values are never changed. Responses that aren't JSON,
+// or are flushed by their handler while streaming, are sent as is.
+func SnakeCaseJSON(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Writer
+		writer := &jsonNamingWriter{ResponseWriter: original}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+		}()
+
+		c.Next()
+
+		if writer.passthrough {
+			return
+		}
+
+		body := writer.buf.Bytes()
+		if len(body) > 0 && strings.HasPrefix(original.Header().Get("Content-Type"), "application/json") {
+			renamed, err := snakeCaseKeys(body)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to rename the fields of a JSON response, sending it as is")
+			} else {
+				body = renamed
+				original.Header().Del("Content-Length")
+			}
+		}
+		if _, err := original.Write(body); err != nil {
+			logger.WithError(err).Error("Failed to write response")
+		}
+	}
+}
+
+// jsonContainer is an object or array being rewritten by snakeCaseKeys
+type jsonContainer struct {
+	object    bool
+	expectKey bool // The next token of the object is a key
+	empty     bool // No member or element was written yet
+}
+
+// snakeCaseKeys rewrites a JSON document with its object keys in snake_case, keeping
+// the order of the fields
+func snakeCaseKeys(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var out bytes.Buffer
+	var stack []*jsonContainer
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			if len(stack) > 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var parent *jsonContainer
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+
+		// Object keys
+		if key, ok := token.(string); ok && parent != nil && parent.object && parent.expectKey {
+			if !parent.empty {
+				out.WriteByte(',')
+			}
+			parent.empty = false
+			parent.expectKey = false
+			if err := writeJSONValue(&out, snakeCase(key)); err != nil {
+				return nil, err
+			}
+			out.WriteByte(':')
+			continue
+		}
+
+		// End of the current object or array
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteByte(byte(delim))
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+			}
+			continue
+		}
+
+		// Values, and the start of objects and arrays
+		if parent != nil && !parent.object {
+			if !parent.empty {
+				out.WriteByte(',')
+			}
+			parent.empty = false
+		}
+		if delim, ok := token.(json.Delim); ok {
+			out.WriteByte(byte(delim))
+			stack = append(stack, &jsonContainer{object: delim == '{', expectKey: delim == '{', empty: true})
+			continue
+		}
+		if err := writeJSONValue(&out, token); err != nil {
+			return nil, err
+		}
+		if parent != nil && parent.object {
+			parent.expectKey = true
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// writeJSONValue writes a scalar JSON value, escaped like gin escapes JSON responses
+func writeJSONValue(out *bytes.Buffer, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	out.Write(encoded)
+	return nil
+}
+
+// snakeCase converts a camelCase key to snake_case, e.g. "issueId" and "repoURL" to
+// "issue_id" and "repo_url". Keys that don't start with a lowercase letter are kept.
+func snakeCase(key string) string {
+	runes := []rune(key)
+	if len(runes) == 0 || !unicode.IsLower(runes[0]) {
+		return key
+	}
+
+	var b strings.Builder
+	for idx, r := range runes {
+		if unicode.IsUpper(r) {
+			previous := runes[idx-1]
+			nextLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// testJSONNamingIssue is the issue served to test the naming of its fields
+var testJSONNamingIssue = models.Issue{
+	ID:          "issue-abc",
+	Title:       "Pipeline run failed",
+	Description: "<b>Task</b> failed",
+	Severity:    models.SeverityMajor,
+	IssueType:   models.IssueTypePipeline,
+	State:       models.IssueStateResolved,
+	DetectedAt:  time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC),
+	ResolvedAt:  &time.Time{},
+	Namespace:   "team-alpha",
+	ScopeID:     "scope-abc",
+	Scope: models.IssueScope{
+		ID:                "scope-abc",
+		ResourceType:      "pipelinerun",
+		ResourceName:      "build-x7k2p",
+		ResourceNamespace: "team-alpha",
+	},
+	Links: []models.Link{
+		{ID: "link-abc", Title: "Logs", URL: "https://konflux.test/logs", IssueID: "issue-abc"},
+	},
+}
+
+// setupJSONNamingRouter creates a router serving an issue, optionally with snake_case fields
+func setupJSONNamingRouter(snakeCase bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	router := gin.New()
+	if snakeCase {
+		router.Use(SnakeCaseJSON(logger))
+	}
+	router.GET("/issue", func(c *gin.Context) {
+		c.JSON(http.StatusOK, testJSONNamingIssue)
+	})
+	router.GET("/counts", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"byState": gin.H{"ACTIVE": 2, "RESOLVED": 1}, "totalCount": 3})
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusOK, `{"issueType": "build"}`)
+	})
+	return router
+}
+
+// serveJSON returns the fields of the JSON response to a request
+func serveJSON(t *testing.T, router *gin.Engine, path string) map[string]any {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return fields
+}
+
+func TestSnakeCaseJSON_Issue(t *testing.T) {
+	tests := []struct {
+		name           string
+		snakeCase      bool
+		expectedFields []string
+		expectedScope  []string
+		expectedLink   []string
+	}{
+		{
+			name:           "camelCase",
+			snakeCase:      false,
+			expectedFields: []string{"issueType", "resolvedAt", "detectedAt", "lastSeenAt", "occurrenceCount", "scopeId", "relatedFrom"},
+			expectedScope:  []string{"resourceType", "resourceName", "resourceNamespace"},
+			expectedLink:   []string{"id", "title", "url", "issueId"},
+		},
+		{
+			name:           "snake_case",
+			snakeCase:      true,
+			expectedFields: []string{"issue_type", "resolved_at", "detected_at", "last_seen_at", "occurrence_count", "scope_id", "related_from"},
+			expectedScope:  []string{"resource_type", "resource_name", "resource_namespace"},
+			expectedLink:   []string{"id", "title", "url", "issue_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := serveJSON(t, setupJSONNamingRouter(tt.snakeCase), "/issue")
+
+			for _, field := range tt.expectedFields {
+				if _, ok := fields[field]; !ok {
+					t.Errorf("Expected field %s, got %v", field, reflect.ValueOf(fields).MapKeys())
+				}
+			}
+			scope, _ := fields["scope"].(map[string]any)
+			for _, field := range tt.expectedScope {
+				if _, ok := scope[field]; !ok {
+					t.Errorf("Expected scope field %s, got %v", field, scope)
+				}
+			}
+			links, _ := fields["links"].([]any)
+			if len(links) != 1 {
+				t.Fatalf("Expected 1 link, got %v", fields["links"])
+			}
+			link, _ := links[0].(map[string]any)
+			for _, field := range tt.expectedLink {
+				if _, ok := link[field]; !ok {
+					t.Errorf("Expected link field %s, got %v", field, link)
+				}
+			}
+
+			// Values are kept
+			if fields["description"] != testJSONNamingIssue.Description || fields["namespace"] != "team-alpha" {
+				t.Errorf("Expected the values of the issue to be kept, got %v", fields)
+			}
+		})
+	}
+}
+
+func TestSnakeCaseJSON_KeepsDataKeysAndOtherResponses(t *testing.T) {
+	router := setupJSONNamingRouter(true)
+
+	fields := serveJSON(t, router, "/counts")
+	expected := map[string]any{
+		"by_state":    map[string]any{"ACTIVE": float64(2), "RESOLVED": float64(1)},
+		"total_count": float64(3),
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/text", nil))
+	if w.Body.String() != `{"issueType": "build"}` {
+		t.Errorf("Expected responses other than JSON to be kept, got %s", w.Body.String())
+	}
+}
+
+func TestSnakeCaseKeys(t *testing.T) {
+	input := `{"issueId":"a","nested":{"repoURL":"https://konflux.test/?a=1&b=2","items":[{"someKey":1.50},[],{}]},"empty":null,"ok":true}`
+	expected := `{"issue_id":"a","nested":{"repo_url":"https://konflux.test/?a=1\u0026b=2","items":[{"some_key":1.50},[],{}]},"empty":null,"ok":true}`
+
+	got, err := snakeCaseKeys([]byte(input))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(got) != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	if _, err := snakeCaseKeys([]byte(`{"issueId":`)); err == nil || !strings.Contains(err.Error(), "EOF") {
+		t.Errorf("Expected an error for truncated JSON, got %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"issueType":         "issue_type",
+		"resourceNamespace": "resource_namespace",
+		"storageUrl":        "storage_url",
+		"repoURL":           "repo_url",
+		"httpStatusCode":    "http_status_code",
+		"sha256Sum":         "sha256_sum",
+		"id":                "id",
+		"already_snake":     "already_snake",
+		"ACTIVE":            "ACTIVE",
+		"Team":              "Team",
+		"":                  "",
+	}
+
+	for key, expected := range tests {
+		if got := snakeCase(key); got != expected {
+			t.Errorf("Expected %s to be %s, got %s", key, expected, got)
+		}
+	}
+}
@@ -1,39 +1,128 @@
 package middleware
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/authentication/user"
 )
 
+// AccessLogFormat is the format used to write access logs
+type AccessLogFormat string
+
+const (
+	AccessLogJSON     AccessLogFormat = "json"
+	AccessLogCombined AccessLogFormat = "combined" // Apache combined log format
+	AccessLogOff      AccessLogFormat = "off"
+)
+
+// accessLogEntry holds the details of a request written to the access logs
+type accessLogEntry struct {
+	Method    string
+	Path      string
+	Status    int
+	LatencyMs float64
+	IP        string
+	User      string
+	RequestID string
+	UserAgent string
+	Bytes     int
+}
+
+// fields returns the details of the request as the fields of a log entry
+func (e accessLogEntry) fields() logrus.Fields {
+	return logrus.Fields{
+		"method":     e.Method,
+		"path":       e.Path,
+		"status":     e.Status,
+		"latency_ms": e.LatencyMs,
+		"ip":         e.IP,
+		"user":       e.User,
+		"request_id": e.RequestID,
+		"user_agent": e.UserAgent,
+		"bytes":      e.Bytes,
+	}
+}
+
 // Logger middleware for request logging
-func Logger(logger *logrus.Logger) gin.HandlerFunc {
+//
+// Access logs are written through the logger, so they follow its format, hooks and
+// level. With the json format, the details of the request are fields of the entry,
+// with the combined format they're its message. Nothing is logged when the format is
+// "off". Failed requests are logged as warnings.
+func Logger(logger *logrus.Logger, format AccessLogFormat) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if format == AccessLogOff {
+			c.Next()
+			return
+		}
+
 		start := time.Now()
 		path := c.Request.URL.Path
-		method := c.Request.Method
+		requestURI := c.Request.URL.RequestURI()
 
 		// Process request
 		c.Next()
 
-		// Log request
-		duration := time.Since(start)
-		statusCode := c.Writer.Status()
-
-		logEntry := logger.WithFields(logrus.Fields{
-			"method":     method,
-			"path":       path,
-			"status":     statusCode,
-			"duration":   duration,
-			"ip":         c.ClientIP(),
-			"user_agent": c.Request.UserAgent(),
-		})
-
-		if statusCode >= 400 {
-			logEntry.Warn("HTTP Request")
-		} else {
-			logEntry.Info("HTTP Request")
+		entry := accessLogEntry{
+			Method:    c.Request.Method,
+			Path:      path,
+			Status:    c.Writer.Status(),
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			IP:        c.ClientIP(),
+			User:      requestUser(c),
+			RequestID: c.GetString("request_id"),
+			UserAgent: c.Request.UserAgent(),
+			Bytes:     max(c.Writer.Size(), 0),
+		}
+
+		level := logrus.InfoLevel
+		if entry.Status >= 400 {
+			level = logrus.WarnLevel
+		}
+		switch format {
+		case AccessLogCombined:
+			logger.Log(level, formatCombined(entry, start, requestURI, c.Request.Proto, c.Request.Referer()))
+		default:
+			logger.WithFields(entry.fields()).Log(level, "HTTP request")
 		}
 	}
 }
+
+// formatCombined formats an access log entry in the Apache combined log format,
+// followed by the latency in milliseconds and the request ID.
+func formatCombined(entry accessLogEntry, start time.Time, requestURI, proto, referer string) string {
+	return fmt.Sprintf(`%s - %s [%s] "%s %s %s" %d %d "%s" "%s" %.3f "%s"`,
+		entry.IP,
+		orDash(entry.User),
+		start.Format("02/Jan/2006:15:04:05 -0700"),
+		entry.Method,
+		requestURI,
+		proto,
+		entry.Status,
+		entry.Bytes,
+		orDash(referer),
+		orDash(entry.UserAgent),
+		entry.LatencyMs,
+		orDash(entry.RequestID),
+	)
+}
+
+// requestUser returns the name of the authenticated user, if any
+func requestUser(c *gin.Context) string {
+	if requester, ok := c.Get("user"); ok {
+		if info, ok := requester.(user.Info); ok {
+			return info.GetName()
+		}
+	}
+	return ""
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
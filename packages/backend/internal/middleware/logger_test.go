@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+// serveLoggedRequest serves a sample request through the logger middleware and returns the logs written
+func serveLoggedRequest(t *testing.T, format AccessLogFormat) string {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &user.DefaultInfo{Name: "jane"})
+		c.Next()
+	})
+	router.Use(Logger(logger, format))
+	router.GET("/api/v1/issues", func(c *gin.Context) {
+		c.String(http.StatusTeapot, "short and stout")
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/issues?namespace=team-alpha", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set("User-Agent", "kite-test")
+	req.RemoteAddr = "10.0.0.1:4242"
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	return out.String()
+}
+
+func TestLogger_JSONFormat(t *testing.T) {
+	logs := serveLoggedRequest(t, AccessLogJSON)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(logs), &entry); err != nil {
+		t.Fatalf("Expected a JSON access log, got %q: %v", logs, err)
+	}
+
+	expected := map[string]interface{}{
+		"method":     "GET",
+		"path":       "/api/v1/issues",
+		"status":     float64(http.StatusTeapot),
+		"ip":         "10.0.0.1",
+		"user":       "jane",
+		"request_id": "req-123",
+		"user_agent": "kite-test",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s to be %v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["latency_ms"].(float64); !ok {
+		t.Errorf("Expected a numeric latency, got %v", entry["latency_ms"])
+	}
+	// Written by the formatter of the logger, at the level of a failed request
+	if entry["level"] != "warning" || entry["msg"] != "HTTP request" {
+		t.Errorf("Expected a warning formatted by the logger, got %v", entry)
+	}
+}
+
+func TestLogger_CombinedFormat(t *testing.T) {
+	logs := serveLoggedRequest(t, AccessLogCombined)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(logs), &entry); err != nil {
+		t.Fatalf("Expected an access log formatted by the logger, got %q: %v", logs, err)
+	}
+	message, _ := entry["msg"].(string)
+	pattern := regexp.MustCompile(`^10\.0\.0\.1 - jane \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] ` +
+		`"GET /api/v1/issues\?namespace=team-alpha HTTP/1\.1" 418 15 "-" "kite-test" \d+\.\d{3} "req-123"$`)
+	if !pattern.MatchString(message) {
+		t.Errorf("Unexpected combined access log: %q", message)
+	}
+}
+
+func TestLogger_Level(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetLevel(logrus.WarnLevel)
+
+	router := gin.New()
+	router.Use(Logger(logger, AccessLogJSON))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/ok", nil))
+	if logs := out.String(); logs != "" {
+		t.Errorf("Expected successful requests to be filtered out by the level, got %q", logs)
+	}
+}
+
+func TestLogger_Off(t *testing.T) {
+	if logs := serveLoggedRequest(t, AccessLogOff); logs != "" {
+		t.Errorf("Expected no access logs, got %q", logs)
+	}
+}
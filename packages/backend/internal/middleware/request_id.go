@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/konflux-ci/kite/internal/pkg/requestid"
+)
+
+// RequestIDHeader is the header carrying the ID of a request
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID middleware assigns an ID to every request.
+//
+// The ID sent by the client (or a proxy) is kept if there is one, otherwise a new one is generated.
+// It is stored in the context as "request_id" and returned in the response headers.
+// The request context carries it too, see requestid.FromContext, so database logs can
+// tell which request ran a query.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authentication levels routes can require
+const (
+	// Neither authentication nor namespace access checks, anyone can call the route
+	AuthLevelPublic = "public"
+	// The authentication configured for the API, requests without a token are
+	// handled as publishers or anonymous readers
+	AuthLevelDefault = "default"
+	// A valid token is required, whatever the method
+	AuthLevelAuthenticated = "authenticated"
+)
+
+// AuthLevelKey is the context key holding the authentication level the route of a request requires
+const AuthLevelKey = "auth_level"
+
+// routePolicy is the authentication level required by the requests matching a method and a path pattern
+type routePolicy struct {
+	// HTTP method, "*" matches any method
+	method string
+	// Pattern of the path, in the syntax of path.Match
+	pattern string
+	level   string
+}
+
+// exact reports whether the pattern of the policy only matches a single path
+func (p routePolicy) exact() bool {
+	return !strings.ContainsAny(p.pattern, `*?[\`)
+}
+
+// RoutePolicies holds the authentication levels required by routes, the routes
+// without a policy require the default level.
+type RoutePolicies struct {
+	policies []routePolicy
+}
+
+// NewRoutePolicies parses the policies of routes, keyed by method and path pattern
+// separated by a space, e.g. "GET /api/v1/issues/*/status" => "public".
+//
+// Patterns follow the syntax of path.Match, where * doesn't match slashes, and
+// trailing slashes are ignored. When several policies match a request, exact paths
+// win over patterns, then longer patterns win, then methods win over "*".
+//
+// Returns an error if a key, pattern or level is invalid.
+func NewRoutePolicies(policies map[string]string) (*RoutePolicies, error) {
+	parsed := make([]routePolicy, 0, len(policies))
+	for key, level := range policies {
+		method, pattern, ok := strings.Cut(strings.TrimSpace(key), " ")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || method == "" || !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("invalid route %q: expected a method and a path, e.g. \"GET /api/v1/issues\"", key)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid path pattern of route %q: %w", key, err)
+		}
+		if !slices.Contains([]string{AuthLevelPublic, AuthLevelDefault, AuthLevelAuthenticated}, level) {
+			return nil, fmt.Errorf("invalid authentication level %q of route %q (must be one of: %s, %s, %s)",
+				level, key, AuthLevelPublic, AuthLevelDefault, AuthLevelAuthenticated)
+		}
+		parsed = append(parsed, routePolicy{
+			method:  strings.ToUpper(method),
+			pattern: trimTrailingSlash(pattern),
+			level:   level,
+		})
+	}
+
+	// The most specific policies first, the keys of the map make the order deterministic
+	slices.SortFunc(parsed, func(a, b routePolicy) int {
+		if a.exact() != b.exact() {
+			if a.exact() {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(len(b.pattern), len(a.pattern)); c != 0 {
+			return c
+		}
+		if (a.method == "*") != (b.method == "*") {
+			if b.method == "*" {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(cmp.Compare(a.pattern, b.pattern), cmp.Compare(a.method, b.method))
+	})
+	return &RoutePolicies{policies: parsed}, nil
+}
+
+// Level returns the authentication level required by a request, the default level
+// if no policy matches it
+func (p *RoutePolicies) Level(method, requestPath string) string {
+	requestPath = trimTrailingSlash(requestPath)
+	for _, policy := range p.policies {
+		if policy.method != "*" && policy.method != method {
+			continue
+		}
+		if matched, _ := path.Match(policy.pattern, requestPath); matched {
+			return policy.level
+		}
+	}
+	return AuthLevelDefault
+}
+
+// trimTrailingSlash removes the trailing slash of a path, other than the root path
+func trimTrailingSlash(p string) string {
+	if len(p) > 1 {
+		return strings.TrimSuffix(p, "/")
+	}
+	return p
+}
+
+// RoutePolicy sets the authentication level required by the route of each request,
+// which Authentication and CheckNamespacessAccess enforce. It must run before them.
+func RoutePolicy(policies *RoutePolicies) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(AuthLevelKey, policies.Level(c.Request.Method, c.Request.URL.Path))
+		c.Next()
+	}
+}
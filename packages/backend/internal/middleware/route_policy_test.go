@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/cache"
+	"github.com/sirupsen/logrus"
+	apiAuthnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewRoutePolicies_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"missing path":     {"GET": AuthLevelPublic},
+		"relative path":    {"GET api/v1/issues": AuthLevelPublic},
+		"invalid pattern":  {"GET /api/v1/[issues": AuthLevelPublic},
+		"unknown level":    {"GET /api/v1/issues": "anonymous"},
+		"missing level":    {"GET /api/v1/issues": ""},
+		"missing method":   {" /api/v1/issues": AuthLevelPublic},
+		"valid and broken": {"GET /api/v1/issues": AuthLevelPublic, "POST /api/v1/issues": "none"},
+	}
+
+	for name, policies := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRoutePolicies(policies); err == nil {
+				t.Errorf("Expected an error for policies %v", policies)
+			}
+		})
+	}
+}
+
+func TestRoutePolicies_Level(t *testing.T) {
+	policies, err := NewRoutePolicies(map[string]string{
+		"GET /api/v1/issues/stats":    AuthLevelPublic,
+		"GET /api/v1/issues/*":        AuthLevelAuthenticated,
+		"GET /api/v1/issues/*/status": AuthLevelPublic,
+		"* /api/v1/admin/*":           AuthLevelAuthenticated,
+		"get /api/v1/version/":        AuthLevelPublic,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		// Exact paths win over patterns
+		{"GET", "/api/v1/issues/stats", AuthLevelPublic},
+		{"GET", "/api/v1/issues/stats/", AuthLevelPublic},
+		{"GET", "/api/v1/issues/1234", AuthLevelAuthenticated},
+		// Longer patterns win, and * doesn't match slashes
+		{"GET", "/api/v1/issues/1234/status", AuthLevelPublic},
+		{"GET", "/api/v1/issues/1234/graph", AuthLevelDefault},
+		// Any method
+		{"POST", "/api/v1/admin/dedup-scan", AuthLevelAuthenticated},
+		{"GET", "/api/v1/version", AuthLevelPublic},
+		// Routes without a policy
+		{"POST", "/api/v1/issues/stats", AuthLevelDefault},
+		{"GET", "/api/v1/issues", AuthLevelDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if level := policies.Level(tt.method, tt.path); level != tt.expected {
+				t.Errorf("Expected level %s, got %s", tt.expected, level)
+			}
+		})
+	}
+}
+
+// setupRoutePolicyRouter returns a router enforcing route policies, where only "valid-token"
+// is authenticated and namespace access is always granted. The number of token reviews
+// is counted in reviews.
+func setupRoutePolicyRouter(t *testing.T, policies map[string]string, reviews *int) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*apiAuthnv1.TokenReview)
+		if review.Spec.Token == "valid-token" {
+			review.Status = apiAuthnv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          apiAuthnv1.UserInfo{Username: "jane"},
+			}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	checker := NewNamespaceCheckerWithClient(client, logger)
+
+	routePolicies, err := NewRoutePolicies(policies)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	router := gin.New()
+	router.Use(RoutePolicy(routePolicies))
+	router.Use(checker.Authentication(cache.New(), time.Second, time.Second, false))
+	router.Use(checker.CheckNamespacessAccess())
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"type": c.GetString("type")})
+	}
+	router.GET("/api/v1/issues/stats", handler)
+	router.GET("/api/v1/issues", handler)
+	router.POST("/api/v1/issues", handler)
+	return router
+}
+
+func TestRoutePolicy_Authentication(t *testing.T) {
+	policies := map[string]string{
+		"GET /api/v1/issues/stats": AuthLevelPublic,
+		"POST /api/v1/issues":      AuthLevelAuthenticated,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		token           string
+		expectedStatus  int
+		expectedType    string
+		expectedReviews int
+	}{
+		// Neither the token nor the namespace are checked
+		{"public route without token", "GET", "/api/v1/issues/stats", "", http.StatusOK, RequesterAnonymous, 0},
+		{"public route with an invalid token", "GET", "/api/v1/issues/stats", "invalid-token", http.StatusOK, RequesterAnonymous, 0},
+		// Publishers aren't let through
+		{"protected route without token", "POST", "/api/v1/issues?namespace=team-alpha", "", http.StatusUnauthorized, "", 0},
+		{"protected route with an invalid token", "POST", "/api/v1/issues?namespace=team-alpha", "invalid-token", http.StatusUnauthorized, "", 1},
+		{"protected route with a valid token", "POST", "/api/v1/issues?namespace=team-alpha", "valid-token", http.StatusOK, RequesterConsumer, 1},
+		// Routes without a policy keep the default authentication
+		{"default route without token", "GET", "/api/v1/issues?namespace=team-alpha", "", http.StatusOK, RequesterPublisher, 0},
+		{"default route without namespace", "GET", "/api/v1/issues", "", http.StatusBadRequest, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reviews int
+			router := setupRoutePolicyRouter(t, policies, &reviews)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if expected := `{"type":"` + tt.expectedType + `"}`; tt.expectedType != "" && w.Body.String() != expected {
+				t.Errorf("Expected requester type %q, got %s", tt.expectedType, w.Body.String())
+			}
+			if reviews != tt.expectedReviews {
+				t.Errorf("Expected %d token reviews, got %d", tt.expectedReviews, reviews)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+)
+
+// WaitForStartup middleware rejects requests with 503 while the server is starting,
+// so no request is served against a database that isn't migrated yet.
+//
+// Requests to paths starting with one of the exempt prefixes are always served,
+// e.g. health checks reporting that the server is starting.
+func WaitForStartup(state *readiness.State, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if state.Starting() && !hasAnyPrefix(c.Request.URL.Path, exempt) {
+			c.Header("Retry-After", "5")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Server is starting, try again shortly"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// hasAnyPrefix reports whether the path starts with any of the prefixes
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
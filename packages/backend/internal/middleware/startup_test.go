@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/konflux-ci/kite/internal/pkg/readiness"
+)
+
+func TestWaitForStartup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	state := readiness.NewStarting()
+	router := gin.New()
+	router.Use(WaitForStartup(state, "/api/v1/health"))
+	router.GET("/api/v1/issues", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/v1/health/live", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	expectStatus := func(path string, expected int) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != expected {
+			t.Errorf("Expected status %d for %s, got %d", expected, path, w.Code)
+		}
+	}
+
+	// Until the schema is ready, only health checks are served
+	expectStatus("/api/v1/issues", http.StatusServiceUnavailable)
+	expectStatus("/api/v1/health/live", http.StatusOK)
+
+	state.Started()
+	expectStatus("/api/v1/issues", http.StatusOK)
+	expectStatus("/api/v1/health/live", http.StatusOK)
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Validation middleware for request validation
@@ -18,3 +21,13 @@ func ValidateID() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ValidateNamespace checks that a namespace is a valid Kubernetes namespace name:
+// a DNS label of at most 63 lowercase alphanumeric characters or '-', starting and
+// ending with an alphanumeric character.
+func ValidateNamespace(namespace string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		expectErr bool
+	}{
+		{"simple name", "team-alpha", false},
+		{"digits", "tenant-42", false},
+		{"single character", "a", false},
+		{"longest name", strings.Repeat("a", 63), false},
+		{"uppercase", "Team-Alpha", true},
+		{"over 63 characters", strings.Repeat("a", 64), true},
+		{"leading dash", "-team", true},
+		{"trailing dash", "team-", true},
+		{"dots", "team.alpha", true},
+		{"path traversal", "../kube-system", true},
+		{"whitespace", " team-alpha", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNamespace(tt.namespace)
+			if tt.expectErr && err == nil {
+				t.Errorf("Expected namespace %q to be rejected", tt.namespace)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected namespace %q to be valid, got %v", tt.namespace, err)
+			}
+		})
+	}
+}
@@ -17,6 +17,33 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// Rank orders severities from the least to the most severe, unknown severities rank lowest
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityMinor:
+		return 2
+	case SeverityMajor:
+		return 3
+	case SeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Severities lists the known severities, least severe first
+var Severities = []Severity{SeverityInfo, SeverityMinor, SeverityMajor, SeverityCritical}
+
+// Lower returns the next less severe severity, info and unknown severities are returned as is
+func (s Severity) Lower() Severity {
+	if s.Rank() <= 1 {
+		return s
+	}
+	return Severities[s.Rank()-2]
+}
+
 type IssueType string
 
 const (
@@ -27,13 +54,86 @@ const (
 	IssueTypePipeline   IssueType = "pipeline"
 )
 
+// IssueTypes lists the known issue types
+var IssueTypes = []IssueType{IssueTypeBuild, IssueTypeTest, IssueTypeRelease, IssueTypeDependency, IssueTypePipeline}
+
 type IssueState string
 
 const (
-	IssueStateActive   IssueState = "ACTIVE"
-	IssueStateResolved IssueState = "RESOLVED"
+	IssueStateActive IssueState = "ACTIVE"
+	// Still open, but triaged, e.g. during a known incident
+	IssueStateAcknowledged IssueState = "ACKNOWLEDGED"
+	IssueStateResolved     IssueState = "RESOLVED"
+)
+
+// OpenStates lists the states of the issues that aren't resolved yet
+var OpenStates = []IssueState{IssueStateActive, IssueStateAcknowledged}
+
+// ClosedStates lists the states of the issues that are resolved
+var ClosedStates = []IssueState{IssueStateResolved}
+
+// IssueStateGroup is a group of issue states, so clients can filter on e.g. open
+// issues without listing their states
+type IssueStateGroup string
+
+const (
+	IssueStateGroupOpen   IssueStateGroup = "open"
+	IssueStateGroupClosed IssueStateGroup = "closed"
+	IssueStateGroupAll    IssueStateGroup = "all"
+)
+
+// States returns the states of the group, nil for all states. ok is false when the
+// group is unknown.
+func (g IssueStateGroup) States() (states []IssueState, ok bool) {
+	switch g {
+	case IssueStateGroupOpen:
+		return OpenStates, true
+	case IssueStateGroupClosed:
+		return ClosedStates, true
+	case IssueStateGroupAll:
+		return nil, true
+	}
+	return nil, false
+}
+
+// IsValid reports whether the issue state is known
+func (s IssueState) IsValid() bool {
+	switch s {
+	case IssueStateActive, IssueStateAcknowledged, IssueStateResolved:
+		return true
+	}
+	return false
+}
+
+// ResolutionSource is how an issue was resolved, telling self-healing apart from
+// human intervention
+type ResolutionSource string
+
+const (
+	// Resolved by a user, through the API
+	ResolutionSourceManual ResolutionSource = "manual"
+	// Resolved by a success webhook for the resource the issue is scoped to
+	ResolutionSourceSuccessWebhook ResolutionSource = "success-webhook"
+	// Resolved automatically, not reported within the TTL of its type
+	ResolutionSourceAutoResolve ResolutionSource = "auto-resolve"
+	// Resolved because the resource it's scoped to no longer exists
+	ResolutionSourceOrphanReconcile ResolutionSource = "orphan-reconcile"
+	// Resolved along with the issue that caused it
+	ResolutionSourceCascade ResolutionSource = "cascade"
+	// Resolved along with all the issues of its namespace, when it was decommissioned
+	ResolutionSourceDecommission ResolutionSource = "decommission"
 )
 
+// IsValid reports whether the resolution source is known
+func (s ResolutionSource) IsValid() bool {
+	switch s {
+	case ResolutionSourceManual, ResolutionSourceSuccessWebhook, ResolutionSourceAutoResolve,
+		ResolutionSourceOrphanReconcile, ResolutionSourceCascade, ResolutionSourceDecommission:
+		return true
+	}
+	return false
+}
+
 // Issue represents an issue in the cluster
 type Issue struct {
 	ID          string     `gorm:"type:uuid;primaryKey;" json:"id"`
@@ -44,7 +144,34 @@ type Issue struct {
 	State       IssueState `gorm:"type:varchar(20);default:ACTIVE" json:"state"`
 	DetectedAt  time.Time  `gorm:"not null" json:"detectedAt"`
 	ResolvedAt  *time.Time `json:"resolvedAt"`
-	Namespace   string     `gorm:"not null" json:"namespace"`
+	// How the issue was last resolved, empty while it's open
+	ResolvedBy ResolutionSource `gorm:"type:varchar(32)" json:"resolvedBy,omitempty"`
+	// When the issue is expected to be resolved by, overdue once passed while active
+	DueAt *time.Time `gorm:"index" json:"dueAt"`
+	// Severity the issue was first reported with, de-escalation doesn't go below it
+	BaseSeverity Severity `gorm:"type:varchar(20)" json:"baseSeverity,omitempty"`
+	// When the severity was last lowered because the issue went quiet
+	DeescalatedAt *time.Time `json:"deescalatedAt,omitempty"`
+	// When the underlying condition was last reported, unaffected by manual edits
+	LastSeenAt time.Time `gorm:"not null" json:"lastSeenAt"`
+	Namespace  string    `gorm:"not null" json:"namespace"`
+	// Number of times the underlying condition was reported, including the first
+	OccurrenceCount int `gorm:"not null;default:1" json:"occurrenceCount"`
+	// Hidden from the default issue list until it's reported again, its next occurrence clears it
+	SnoozedUntilRecurrence bool `gorm:"not null;default:false" json:"snoozedUntilRecurrence"`
+	// Code change the issue was reported for, if known
+	CommitContext `gorm:"embedded"`
+	// Identity of the issue supplied by its producer, duplicates are matched on it when set
+	Fingerprint string `gorm:"index" json:"fingerprint,omitempty"`
+	// Hash of the normalized description, duplicates are matched on it with the reason dedup strategy
+	ReasonHash string `gorm:"index" json:"-"`
+	// Hash of the key duplicates are matched on, unique among open issues so the database
+	// rejects duplicates missed by the application. Nil when enforcement is disabled.
+	DedupKey *string `gorm:"uniqueIndex:idx_issues_open_dedup_key,where:state <> 'RESOLVED'" json:"-"`
+	// Environment the issue pertains to, e.g. dev, staging or prod, empty if unknown
+	Environment string `gorm:"index" json:"environment,omitempty"`
+	// Structured fields of the issue, defined by the custom field schema of its type
+	CustomFields map[string]any `gorm:"type:jsonb;serializer:json" json:"customFields,omitempty"`
 
 	// Foreign key to IssueScope
 	ScopeID string     `gorm:"type:uuid;not null;unique" json:"scopeId"`
@@ -54,10 +181,20 @@ type Issue struct {
 	Links       []Link         `gorm:"foreignKey:IssueID" json:"links"`
 	RelatedFrom []RelatedIssue `gorm:"foreignKey:SourceID" json:"relatedFrom"`
 	RelatedTo   []RelatedIssue `gorm:"foreignKey:TargetID" json:"relatedTo"`
+	Notes       []IssueNote    `gorm:"foreignKey:IssueID" json:"notes,omitempty"`
+	Occurrences []Occurrence   `gorm:"foreignKey:IssueID" json:"-"`
+	Attachments []Attachment   `gorm:"foreignKey:IssueID" json:"-"`
 
 	// Timestamps
 	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedAt time.Time `gorm:"index" json:"updatedAt"`
+}
+
+// CommitContext ties an issue to the code change it was reported for
+type CommitContext struct {
+	CommitSHA string `json:"commitSha,omitempty"`
+	RepoURL   string `json:"repoUrl,omitempty"`
+	Branch    string `json:"branch,omitempty"`
 }
 
 // BeforeCreate hook to set UUID if not provided
@@ -65,6 +202,36 @@ func (i *Issue) BeforeCreate(tx *gorm.DB) error {
 	if i.ID == "" {
 		i.ID = uuid.New().String()
 	}
+	// An issue is seen for the first time when it's detected
+	if i.LastSeenAt.IsZero() {
+		i.LastSeenAt = i.DetectedAt
+	}
+	// Its severity is the floor of de-escalation
+	if i.BaseSeverity == "" {
+		i.BaseSeverity = i.Severity
+	}
+	return nil
+}
+
+// AfterFind hook to normalize timestamps to UTC, drivers return them in the local
+// timezone of the server, so they're serialized in RFC 3339 with a Z suffix.
+func (i *Issue) AfterFind(tx *gorm.DB) error {
+	i.DetectedAt = i.DetectedAt.UTC()
+	i.LastSeenAt = i.LastSeenAt.UTC()
+	i.CreatedAt = i.CreatedAt.UTC()
+	i.UpdatedAt = i.UpdatedAt.UTC()
+	if i.ResolvedAt != nil {
+		resolvedAt := i.ResolvedAt.UTC()
+		i.ResolvedAt = &resolvedAt
+	}
+	if i.DueAt != nil {
+		dueAt := i.DueAt.UTC()
+		i.DueAt = &dueAt
+	}
+	if i.DeescalatedAt != nil {
+		deescalatedAt := i.DeescalatedAt.UTC()
+		i.DeescalatedAt = &deescalatedAt
+	}
 	return nil
 }
 
@@ -74,6 +241,9 @@ type IssueScope struct {
 	ResourceType      string `gorm:"not null" json:"resourceType"`
 	ResourceName      string `gorm:"not null" json:"resourceName"`
 	ResourceNamespace string `gorm:"not null" json:"resourceNamespace"`
+	// Resource name duplicates are matched on, stripped of transient suffixes like the
+	// random suffix of pipeline runs. The same as the resource name when nothing is stripped.
+	NormalizedResourceName string `gorm:"index" json:"normalizedResourceName,omitempty"`
 
 	// Relationship - one issue scope has one issue
 	Issue *Issue `gorm:"foreignKey:ScopeID" json:"issue,omitempty"`
@@ -87,11 +257,32 @@ func (s *IssueScope) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// RelationshipKind describes how two related issues depend on each other
+type RelationshipKind string
+
+const (
+	RelationshipRelatesTo RelationshipKind = "RELATES_TO"
+	// The source issue is the root cause of the target issue
+	RelationshipCausedBy RelationshipKind = "CAUSED_BY"
+	// The source issue blocks the target issue
+	RelationshipBlocks RelationshipKind = "BLOCKS"
+)
+
+// IsValid reports whether the relationship kind is known
+func (k RelationshipKind) IsValid() bool {
+	switch k {
+	case RelationshipRelatesTo, RelationshipCausedBy, RelationshipBlocks:
+		return true
+	}
+	return false
+}
+
 // RelatedIssue represents relationships between issues
 type RelatedIssue struct {
-	ID       string `gorm:"type:uuid;primaryKey" json:"id"`
-	SourceID string `gorm:"type:uuid;not null" json:"sourceId"`
-	TargetID string `gorm:"type:uuid;not null" json:"targetId"`
+	ID       string           `gorm:"type:uuid;primaryKey" json:"id"`
+	SourceID string           `gorm:"type:uuid;not null" json:"sourceId"`
+	TargetID string           `gorm:"type:uuid;not null" json:"targetId"`
+	Kind     RelationshipKind `gorm:"type:varchar(32);not null;default:RELATES_TO" json:"kind"`
 
 	// Relationships
 	Source Issue `gorm:"foreignKey:SourceID" json:"source,omitempty"`
@@ -103,6 +294,111 @@ func (r *RelatedIssue) BeforeCreate(tx *gorm.DB) error {
 	if r.ID == "" {
 		r.ID = uuid.New().String()
 	}
+	if r.Kind == "" {
+		r.Kind = RelationshipRelatesTo
+	}
+	return nil
+}
+
+// IssueNote represents a note recorded on an issue, e.g. explaining an automatic change
+type IssueNote struct {
+	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
+	IssueID   string    `gorm:"type:uuid;not null;index" json:"issueId"`
+	Content   string    `gorm:"not null" json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (n *IssueNote) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Occurrence represents a recurrence of an issue, reported again while it was already tracked
+type Occurrence struct {
+	ID         string    `gorm:"type:uuid;primaryKey" json:"id"`
+	IssueID    string    `gorm:"type:uuid;not null;index" json:"issueId"`
+	OccurredAt time.Time `gorm:"not null" json:"occurredAt"`
+	Detail     string    `json:"detail"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (o *Occurrence) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Attachment references a file attached to an issue, e.g. logs or a screenshot. The file
+// itself is kept in an external object storage, only its metadata is stored.
+type Attachment struct {
+	ID          string    `gorm:"type:uuid;primaryKey" json:"id"`
+	IssueID     string    `gorm:"type:uuid;not null;index" json:"issueId"`
+	Name        string    `gorm:"not null" json:"name"`
+	ContentType string    `gorm:"not null" json:"contentType"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageURL  string    `gorm:"not null" json:"storageUrl"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (a *Attachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// DeletedIssue is a tombstone left by a deleted issue, so clients syncing changes can remove it
+type DeletedIssue struct {
+	IssueID   string    `gorm:"type:uuid;primaryKey" json:"id"`
+	Namespace string    `gorm:"not null" json:"namespace"`
+	DeletedAt time.Time `gorm:"not null;index" json:"deletedAt"`
+}
+
+// Setting is a value stored by the service itself, e.g. the configuration some stored
+// data was computed with, to tell when it's stale
+type Setting struct {
+	Name      string    `gorm:"primaryKey" json:"name"`
+	Value     string    `gorm:"not null" json:"value"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeliveryStatus is the outcome of a webhook delivery
+type DeliveryStatus string
+
+const (
+	// The webhook was processed, creating, updating or resolving issues
+	DeliveryProcessed DeliveryStatus = "processed"
+	// The webhook matched an ignored failure and didn't create an issue
+	DeliveryIgnored DeliveryStatus = "ignored"
+	// The webhook payload was invalid
+	DeliveryRejected DeliveryStatus = "rejected"
+	// The webhook couldn't be processed because of an internal error
+	DeliveryFailed DeliveryStatus = "failed"
+	// The webhook was accepted and its issue queued, to be persisted in the background
+	DeliveryQueued DeliveryStatus = "queued"
+)
+
+// WebhookDelivery records a webhook call and its outcome
+type WebhookDelivery struct {
+	ID         string    `gorm:"type:uuid;primaryKey" json:"id"`
+	Endpoint   string    `gorm:"not null" json:"endpoint"`
+	ReceivedAt time.Time `gorm:"not null" json:"receivedAt"`
+	Namespace  string    `json:"namespace"`
+	// The issue created or updated by the webhook, if any
+	IssueID *string        `gorm:"type:uuid" json:"issueId"`
+	Status  DeliveryStatus `gorm:"type:varchar(20);not null" json:"status"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
 	return nil
 }
 
@@ -111,6 +407,8 @@ type Link struct {
 	ID      string `gorm:"type:uuid;primaryKey" json:"id"`
 	Title   string `gorm:"not null" json:"title"`
 	URL     string `gorm:"not null" json:"url"`
+	Order   int    `gorm:"column:position;not null;default:0" json:"order"`
+	Primary bool   `gorm:"column:is_primary;not null;default:false" json:"primary"`
 	IssueID string `gorm:"type:uuid;not null" json:"issueId"`
 	// Omit field when converting to JSON or deconverting from JSON
 	Issue Issue `gorm:"foreignKey:IssueID" json:"-"`
@@ -123,3 +421,19 @@ func (l *Link) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// All returns every model stored in the database, tables referenced by others first
+func All() []any {
+	return []any{
+		&IssueScope{},
+		&Issue{},
+		&Link{},
+		&RelatedIssue{},
+		&IssueNote{},
+		&Occurrence{},
+		&Attachment{},
+		&WebhookDelivery{},
+		&DeletedIssue{},
+		&Setting{},
+	}
+}
@@ -0,0 +1,59 @@
+// Package buildinfo holds the metadata of the build, so deployed binaries can be
+// correlated with their commit. It's injected when building the binary, e.g.
+//
+//	go build -ldflags "\
+//	  -X github.com/konflux-ci/kite/internal/pkg/buildinfo.Version=v1.2.0 \
+//	  -X github.com/konflux-ci/kite/internal/pkg/buildinfo.GitCommit=$(git rev-parse HEAD) \
+//	  -X github.com/konflux-ci/kite/internal/pkg/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//	  -o server cmd/server/main.go
+package buildinfo
+
+import (
+	"os"
+	"runtime"
+)
+
+// Set with -ldflags "-X" at build time, empty otherwise
+var (
+	// Version of the binary, the VERSION environment variable is used when it isn't set
+	Version string
+	// Commit the binary was built from
+	GitCommit string
+	// When the binary was built, in RFC 3339
+	BuildDate string
+)
+
+// unknown replaces the metadata that wasn't injected
+const unknown = "unknown"
+
+// Info is the metadata of the build
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the metadata of the build. The version falls back to the VERSION
+// environment variable, then to "dev", and the rest to "unknown".
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+	if info.Version == "" {
+		info.Version = os.Getenv("VERSION")
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.GitCommit == "" {
+		info.GitCommit = unknown
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = unknown
+	}
+	return info
+}
@@ -0,0 +1,36 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("not injected", func(t *testing.T) {
+		t.Setenv("VERSION", "")
+		info := Get()
+		expected := Info{Version: "dev", GitCommit: "unknown", BuildDate: "unknown", GoVersion: runtime.Version()}
+		if info != expected {
+			t.Errorf("expected %+v, got %+v", expected, info)
+		}
+	})
+
+	t.Run("version from the environment", func(t *testing.T) {
+		t.Setenv("VERSION", "v1.1.0")
+		if info := Get(); info.Version != "v1.1.0" {
+			t.Errorf("expected version v1.1.0, got %s", info.Version)
+		}
+	})
+
+	t.Run("injected", func(t *testing.T) {
+		Version, GitCommit, BuildDate = "v1.2.0", "0a1b2c3d", "2026-10-17T00:00:00Z"
+		t.Cleanup(func() { Version, GitCommit, BuildDate = "", "", "" })
+		t.Setenv("VERSION", "v1.1.0")
+
+		info := Get()
+		expected := Info{Version: "v1.2.0", GitCommit: "0a1b2c3d", BuildDate: "2026-10-17T00:00:00Z", GoVersion: runtime.Version()}
+		if info != expected {
+			t.Errorf("expected %+v, got %+v", expected, info)
+		}
+	})
+}
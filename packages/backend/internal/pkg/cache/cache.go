@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// sweepInterval is how often expired entries are removed, when entries are set
+const sweepInterval = time.Minute
+
 type cacheEntry struct {
 	value      any
 	expiration int64
@@ -14,14 +17,30 @@ type cacheEntry struct {
 type Cache struct {
 	items map[[32]byte]cacheEntry
 	mutex sync.RWMutex
+	// Maximum number of entries, no limit when 0
+	maxEntries int
+	// Unix time of the next sweep of expired entries
+	nextSweep int64
 }
 
 func (c *Cache) Set(key string, value any, duration time.Duration) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	now := time.Now()
 	hashedKey := sha256.Sum256([]byte(key))
-	c.items[hashedKey] = cacheEntry{value: value, expiration: time.Now().Add(duration).Unix()}
+	if now.Unix() >= c.nextSweep {
+		c.deleteExpired(now.Unix())
+		c.nextSweep = now.Add(sweepInterval).Unix()
+	}
+	if _, ok := c.items[hashedKey]; !ok && c.maxEntries > 0 && len(c.items) >= c.maxEntries {
+		c.deleteExpired(now.Unix())
+		if len(c.items) >= c.maxEntries {
+			c.evictSoonestExpiring()
+		}
+	}
+
+	c.items[hashedKey] = cacheEntry{value: value, expiration: now.Add(duration).Unix()}
 }
 
 func (c *Cache) Get(key string) any {
@@ -38,8 +57,47 @@ func (c *Cache) Get(key string) any {
 	return entry.value
 }
 
+// Len returns the number of entries, including the expired ones not removed yet
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.items)
+}
+
+// deleteExpired removes the entries expired at the Unix time passed
+func (c *Cache) deleteExpired(now int64) {
+	for key, entry := range c.items {
+		if now > entry.expiration {
+			delete(c.items, key)
+		}
+	}
+}
+
+// evictSoonestExpiring removes the entry expiring first, making room for a new one
+func (c *Cache) evictSoonestExpiring() {
+	var soonest [32]byte
+	found := false
+	for key, entry := range c.items {
+		if !found || entry.expiration < c.items[soonest].expiration {
+			soonest = key
+			found = true
+		}
+	}
+	if found {
+		delete(c.items, soonest)
+	}
+}
+
 func New() *Cache {
 	return &Cache{
 		items: make(map[[32]byte]cacheEntry),
 	}
 }
+
+// NewBounded returns a cache holding at most maxEntries entries. Once full, the
+// expired entries are removed, then the entry expiring first if it's still full.
+func NewBounded(maxEntries int) *Cache {
+	c := New()
+	c.maxEntries = maxEntries
+	return c
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCache_GetSet(t *testing.T) {
+	c := New()
+	c.Set("key", "value", time.Minute)
+
+	if value := c.Get("key"); value != "value" {
+		t.Errorf("expected value, got %v", value)
+	}
+	if value := c.Get("other"); value != nil {
+		t.Errorf("expected nil for a missing key, got %v", value)
+	}
+
+	// Entries expire a second after their duration at most
+	c.Set("expired", "value", -2*time.Second)
+	if value := c.Get("expired"); value != nil {
+		t.Errorf("expected nil for an expired key, got %v", value)
+	}
+}
+
+func TestCache_Sweep(t *testing.T) {
+	c := New()
+	c.Set("expired", "value", -2*time.Second)
+	c.nextSweep = 0
+
+	c.Set("key", "value", time.Minute)
+	if c.Len() != 1 {
+		t.Errorf("expected the expired entry to be swept, got %d entries", c.Len())
+	}
+}
+
+func TestNewBounded(t *testing.T) {
+	c := NewBounded(3)
+	c.Set("expired", "value", -2*time.Second)
+	c.Set("soonest", "value", time.Minute)
+	for idx := range 2 {
+		c.Set("key-"+strconv.Itoa(idx), "value", time.Hour)
+	}
+
+	// The expired entry makes room first, then the entry expiring first
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", c.Len())
+	}
+	c.Set("key-2", "value", time.Hour)
+	if c.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", c.Len())
+	}
+	if value := c.Get("soonest"); value != nil {
+		t.Errorf("expected the entry expiring first to be evicted, got %v", value)
+	}
+	for idx := range 3 {
+		if value := c.Get("key-" + strconv.Itoa(idx)); value != "value" {
+			t.Errorf("expected key-%d to be kept, got %v", idx, value)
+		}
+	}
+
+	// Replacing an entry doesn't evict another
+	c.Set("key-0", "updated", time.Hour)
+	if c.Len() != 3 || c.Get("key-1") != "value" {
+		t.Errorf("expected the entries to be kept when replacing one, got %d entries", c.Len())
+	}
+}
@@ -0,0 +1,16 @@
+package clock
+
+import "time"
+
+// Clock tells the current time, so time-dependent code can be tested with a fake clock
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the Clock of the system time
+type Real struct{}
+
+// Now returns the current system time
+func (Real) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,87 @@
+package commitlink
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate links to commits on GitHub, and hosts sharing its URL layout
+const DefaultTemplate = "{{.RepoURL}}/commit/{{.CommitSHA}}"
+
+// commitSHAPattern matches abbreviated and full SHA-1 or SHA-256 commit hashes
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+
+// scpLikeURLPattern matches the scp-like syntax of SSH remotes, e.g. git@github.com:org/repo.git
+var scpLikeURLPattern = regexp.MustCompile(`^[\w.-]+@([\w.-]+):(.+)$`)
+
+// Commit is the git context a link is generated from
+type Commit struct {
+	// Web URL of the repository, normalized from the remote URL
+	RepoURL   string
+	CommitSHA string
+	Branch    string
+}
+
+// Linker generates the URL of commits from a template
+type Linker struct {
+	tmpl *template.Template
+}
+
+// New parses the template of commit URLs, rendered with a Commit.
+//
+// Returns an error if the template is not a valid Go template.
+func New(urlTemplate string) (*Linker, error) {
+	tmpl, err := template.New("commit").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit link template %q: %w", urlTemplate, err)
+	}
+	return &Linker{tmpl: tmpl}, nil
+}
+
+// IsValidCommitSHA reports whether the value looks like a commit hash
+func IsValidCommitSHA(sha string) bool {
+	return commitSHAPattern.MatchString(sha)
+}
+
+// NormalizeRepoURL turns a git remote URL into the web URL of the repository,
+// e.g. git@github.com:org/repo.git into https://github.com/org/repo.
+func NormalizeRepoURL(repoURL string) string {
+	repoURL = strings.TrimSpace(repoURL)
+	if match := scpLikeURLPattern.FindStringSubmatch(repoURL); match != nil {
+		repoURL = "https://" + match[1] + "/" + match[2]
+	} else if u, err := url.Parse(repoURL); err == nil && (u.Scheme == "ssh" || u.Scheme == "git") {
+		u.Scheme = "https"
+		u.User = nil
+		u.Host = u.Hostname()
+		repoURL = u.String()
+	}
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	return strings.TrimSuffix(repoURL, ".git")
+}
+
+// URL returns the URL of a commit of a repository. Returns an empty URL when
+// the Linker is nil, or the repository or commit is missing.
+func (l *Linker) URL(repoURL, commitSHA, branch string) (string, error) {
+	if l == nil || repoURL == "" || commitSHA == "" {
+		return "", nil
+	}
+	if !IsValidCommitSHA(commitSHA) {
+		return "", fmt.Errorf("invalid commit SHA %q", commitSHA)
+	}
+
+	var b strings.Builder
+	commit := Commit{RepoURL: NormalizeRepoURL(repoURL), CommitSHA: commitSHA, Branch: branch}
+	if err := l.tmpl.Execute(&b, commit); err != nil {
+		return "", fmt.Errorf("failed to render commit link: %w", err)
+	}
+
+	link := b.String()
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid commit link %q", link)
+	}
+	return link, nil
+}
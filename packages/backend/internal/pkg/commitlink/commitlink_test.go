@@ -0,0 +1,99 @@
+package commitlink
+
+import "testing"
+
+func TestNormalizeRepoURL(t *testing.T) {
+	tests := []struct {
+		repoURL  string
+		expected string
+	}{
+		{"https://github.com/konflux-ci/kite", "https://github.com/konflux-ci/kite"},
+		{"https://github.com/konflux-ci/kite.git", "https://github.com/konflux-ci/kite"},
+		{"https://github.com/konflux-ci/kite/", "https://github.com/konflux-ci/kite"},
+		{"git@github.com:konflux-ci/kite.git", "https://github.com/konflux-ci/kite"},
+		{"ssh://git@gitlab.com:22/group/project.git", "https://gitlab.com/group/project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoURL, func(t *testing.T) {
+			if got := NormalizeRepoURL(tt.repoURL); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLinker_URL(t *testing.T) {
+	const sha = "3f2a1c9e8b7d6a5f4e3d2c1b0a9f8e7d6c5b4a39"
+
+	tests := []struct {
+		name      string
+		template  string
+		repoURL   string
+		commitSHA string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "default template",
+			template:  DefaultTemplate,
+			repoURL:   "git@github.com:konflux-ci/kite.git",
+			commitSHA: sha,
+			expected:  "https://github.com/konflux-ci/kite/commit/" + sha,
+		},
+		{
+			name:      "custom template",
+			template:  "{{.RepoURL}}/-/commit/{{.CommitSHA}}?ref={{.Branch}}",
+			repoURL:   "https://gitlab.com/group/project",
+			commitSHA: sha,
+			expected:  "https://gitlab.com/group/project/-/commit/" + sha + "?ref=main",
+		},
+		{
+			name:     "missing commit",
+			template: DefaultTemplate,
+			repoURL:  "https://github.com/konflux-ci/kite",
+		},
+		{
+			name:      "invalid commit",
+			template:  DefaultTemplate,
+			repoURL:   "https://github.com/konflux-ci/kite",
+			commitSHA: "main",
+			expectErr: true,
+		},
+		{
+			name:      "not a web URL",
+			template:  DefaultTemplate,
+			repoURL:   "/srv/git/kite",
+			commitSHA: sha,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linker, err := New(tt.template)
+			if err != nil {
+				t.Fatalf("Failed to parse template: %v", err)
+			}
+			got, err := linker.URL(tt.repoURL, tt.commitSHA, "main")
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidTemplate(t *testing.T) {
+	if _, err := New("{{.RepoURL"); err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
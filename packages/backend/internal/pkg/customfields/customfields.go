@@ -0,0 +1,155 @@
+// Package customfields validates the custom fields of issues against the schema of
+// their issue type, e.g. the build number of build issues or the environment of
+// release issues.
+package customfields
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"sigs.k8s.io/yaml"
+)
+
+// Type is the type of the value of a custom field
+type Type string
+
+const (
+	TypeString  Type = "string"
+	TypeNumber  Type = "number"
+	TypeInteger Type = "integer"
+	TypeBoolean Type = "boolean"
+)
+
+// types lists the known types of custom fields
+var types = []Type{TypeString, TypeNumber, TypeInteger, TypeBoolean}
+
+// fieldName matches the names of custom fields
+var fieldName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// Field is the definition of a custom field
+type Field struct {
+	Type     Type `json:"type"`
+	Required bool `json:"required"`
+	// Values a string field is restricted to, any string when empty
+	Enum []string `json:"enum,omitempty"`
+}
+
+// Schemas are the custom fields of each issue type. Issues of a type without custom
+// fields can't have any, and a nil Schemas allows no custom field at all.
+type Schemas struct {
+	types map[models.IssueType]map[string]Field
+}
+
+// Error reports a custom field not matching the schema of its issue type
+type Error struct {
+	Field string // Name of the custom field
+	// "required", "unknown", "type" or "oneof", like the binding tags
+	Reason   string
+	expected string // Type or values expected, for type and oneof
+}
+
+func (e *Error) Error() string {
+	switch e.Reason {
+	case "required":
+		return fmt.Sprintf("customFields.%s is required", e.Field)
+	case "unknown":
+		return fmt.Sprintf("customFields.%s is not defined for this issue type", e.Field)
+	case "oneof":
+		return fmt.Sprintf("customFields.%s must be one of %s", e.Field, e.expected)
+	default:
+		return fmt.Sprintf("customFields.%s must be a %s", e.Field, e.expected)
+	}
+}
+
+// Parse parses the custom fields of each issue type, in YAML or JSON keyed by issue
+// type then field name, e.g. {"build": {"buildNumber": {"type": "integer", "required": true}}}.
+// An empty source defines no custom field.
+func Parse(source string) (*Schemas, error) {
+	var sources map[models.IssueType]map[string]Field
+	if err := yaml.UnmarshalStrict([]byte(source), &sources); err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+
+	for issueType, fields := range sources {
+		if !slices.Contains(models.IssueTypes, issueType) {
+			return nil, fmt.Errorf("invalid custom fields: unknown issue type %q", issueType)
+		}
+		for name, field := range fields {
+			if !fieldName.MatchString(name) {
+				return nil, fmt.Errorf("invalid custom field name %q of %s issues", name, issueType)
+			}
+			if !slices.Contains(types, field.Type) {
+				return nil, fmt.Errorf("custom field %q of %s issues: invalid type %q, expected one of %v", name, issueType, field.Type, types)
+			}
+			if len(field.Enum) > 0 && field.Type != TypeString {
+				return nil, fmt.Errorf("custom field %q of %s issues: only string fields can have an enum", name, issueType)
+			}
+		}
+	}
+	return &Schemas{types: sources}, nil
+}
+
+// Validate checks the custom fields of an issue of the type: the required fields must
+// be set, and every field must be defined for the type, with a value of its type.
+//
+// Returns an *Error for the first invalid field, by name.
+func (s *Schemas) Validate(issueType models.IssueType, values map[string]any) error {
+	var fields map[string]Field
+	if s != nil {
+		fields = s.types[issueType]
+	}
+
+	for _, name := range sortedKeys(fields) {
+		if _, ok := values[name]; !ok && fields[name].Required {
+			return &Error{Field: name, Reason: "required"}
+		}
+	}
+	for _, name := range sortedKeys(values) {
+		field, ok := fields[name]
+		if !ok {
+			return &Error{Field: name, Reason: "unknown"}
+		}
+		if err := field.check(name, values[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check returns an *Error if the value doesn't match the field
+func (f Field) check(name string, value any) error {
+	valid := false
+	switch f.Type {
+	case TypeString:
+		str, ok := value.(string)
+		if ok && len(f.Enum) > 0 && !slices.Contains(f.Enum, str) {
+			return &Error{Field: name, Reason: "oneof", expected: fmt.Sprint(f.Enum)}
+		}
+		valid = ok
+	case TypeNumber:
+		_, valid = value.(float64)
+	case TypeInteger:
+		number, ok := value.(float64)
+		valid = ok && number == math.Trunc(number)
+	case TypeBoolean:
+		_, valid = value.(bool)
+	}
+	if !valid {
+		return &Error{Field: name, Reason: "type", expected: string(f.Type)}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in order, so the same field is reported first
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,108 @@
+package customfields
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+)
+
+const testSchemas = `
+build:
+  buildNumber:
+    type: integer
+    required: true
+  flaky:
+    type: boolean
+release:
+  environment:
+    type: string
+    required: true
+    enum: [staging, production]
+  durationSeconds:
+    type: number
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		expectErr bool
+	}{
+		{name: "empty", source: ""},
+		{name: "valid", source: testSchemas},
+		{name: "JSON", source: `{"test": {"suite": {"type": "string"}}}`},
+		{name: "unknown issue type", source: `{"deploy": {"target": {"type": "string"}}}`, expectErr: true},
+		{name: "unknown field type", source: `{"build": {"buildNumber": {"type": "uint"}}}`, expectErr: true},
+		{name: "invalid field name", source: `{"build": {"build number": {"type": "integer"}}}`, expectErr: true},
+		{name: "enum of a number", source: `{"build": {"buildNumber": {"type": "integer", "enum": ["1"]}}}`, expectErr: true},
+		{name: "unknown setting", source: `{"build": {"buildNumber": {"type": "integer", "min": 1}}}`, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.source)
+			if tt.expectErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSchemas_Validate(t *testing.T) {
+	schemas, err := Parse(testSchemas)
+	if err != nil {
+		t.Fatalf("failed to parse schemas: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		issueType      models.IssueType
+		values         map[string]any
+		expectedField  string
+		expectedReason string
+	}{
+		{name: "valid", issueType: models.IssueTypeBuild, values: map[string]any{"buildNumber": float64(42), "flaky": true}},
+		{name: "missing required field", issueType: models.IssueTypeBuild, values: map[string]any{"flaky": true}, expectedField: "buildNumber", expectedReason: "required"},
+		{name: "no fields", issueType: models.IssueTypeBuild, values: nil, expectedField: "buildNumber", expectedReason: "required"},
+		{name: "unknown field", issueType: models.IssueTypeBuild, values: map[string]any{"buildNumber": float64(1), "commit": "abc"}, expectedField: "commit", expectedReason: "unknown"},
+		{name: "fractional integer", issueType: models.IssueTypeBuild, values: map[string]any{"buildNumber": 1.5}, expectedField: "buildNumber", expectedReason: "type"},
+		{name: "string for a boolean", issueType: models.IssueTypeBuild, values: map[string]any{"buildNumber": float64(1), "flaky": "yes"}, expectedField: "flaky", expectedReason: "type"},
+		{name: "enum value", issueType: models.IssueTypeRelease, values: map[string]any{"environment": "production", "durationSeconds": 12.5}},
+		{name: "value outside the enum", issueType: models.IssueTypeRelease, values: map[string]any{"environment": "dev"}, expectedField: "environment", expectedReason: "oneof"},
+		{name: "type without custom fields", issueType: models.IssueTypeTest, values: nil},
+		{name: "field of a type without custom fields", issueType: models.IssueTypeTest, values: map[string]any{"suite": "e2e"}, expectedField: "suite", expectedReason: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schemas.Validate(tt.issueType, tt.values)
+			if tt.expectedReason == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var fieldErr *Error
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("expected a custom field error, got %v", err)
+			}
+			if fieldErr.Field != tt.expectedField || fieldErr.Reason != tt.expectedReason {
+				t.Errorf("expected %s to be %s, got %s %s", tt.expectedField, tt.expectedReason, fieldErr.Field, fieldErr.Reason)
+			}
+		})
+	}
+}
+
+func TestSchemas_Nil(t *testing.T) {
+	var schemas *Schemas
+	if err := schemas.Validate(models.IssueTypeBuild, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := schemas.Validate(models.IssueTypeBuild, map[string]any{"buildNumber": float64(1)}); err == nil {
+		t.Error("expected custom fields to be rejected without schemas")
+	}
+}
@@ -0,0 +1,101 @@
+// Package dblog logs the queries run by GORM with logrus, so they show up in the
+// structured logs of the service along with the request they were run for.
+package dblog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/pkg/requestid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Logger is a GORM logger writing to a logrus logger.
+//
+// Failed queries are logged at error level, and queries slower than the slow
+// threshold at warn level, with their SQL and duration. With the Info log mode,
+// every query is logged.
+type Logger struct {
+	logger        *logrus.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration // 0 disables the logging of slow queries
+}
+
+// New returns a GORM logger writing to logger at the log mode passed
+func New(logger *logrus.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) *Logger {
+	return &Logger{
+		logger:        logger,
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode returns a copy of the logger with another log mode
+func (l *Logger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info logs a message from GORM at info level
+func (l *Logger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.entry(ctx).Infof(msg, data...)
+	}
+}
+
+// Warn logs a message from GORM at warn level
+func (l *Logger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.entry(ctx).Warnf(msg, data...)
+	}
+}
+
+// Error logs a message from GORM at error level
+func (l *Logger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.entry(ctx).Errorf(msg, data...)
+	}
+}
+
+// Trace logs a query once it's run, depending on its outcome and duration
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	// Not finding a record is expected, callers handle it
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.queryEntry(ctx, elapsed, fc).WithError(err).Error("Database query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.queryEntry(ctx, elapsed, fc).
+			WithField("threshold_ms", l.slowThreshold.Milliseconds()).
+			Warn("Slow database query")
+	case l.level >= gormlogger.Info:
+		l.queryEntry(ctx, elapsed, fc).Info("Database query")
+	}
+}
+
+// entry returns a log entry with the ID of the request running the query, if any
+func (l *Logger) entry(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(l.logger)
+	if id := requestid.FromContext(ctx); id != "" {
+		entry = entry.WithField("request_id", id)
+	}
+	return entry
+}
+
+// queryEntry returns a log entry describing a query
+func (l *Logger) queryEntry(ctx context.Context, elapsed time.Duration, fc func() (string, int64)) *logrus.Entry {
+	sql, rows := fc()
+	return l.entry(ctx).WithFields(logrus.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+	})
+}
@@ -0,0 +1,119 @@
+package dblog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/pkg/requestid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// setupLoggedDB opens a database logging its queries to the returned buffer, as JSON
+func setupLoggedDB(t *testing.T, level gormlogger.LogLevel, slowThreshold time.Duration) (*gorm.DB, *bytes.Buffer) {
+	t.Helper()
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: New(logger, level, slowThreshold)})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	return db, &out
+}
+
+// logEntries parses the JSON log entries written
+func logEntries(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected a JSON log entry, got %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLogger_SlowQuery(t *testing.T) {
+	// Any query is slower than a nanosecond
+	db, out := setupLoggedDB(t, gormlogger.Warn, time.Nanosecond)
+
+	ctx := requestid.NewContext(context.Background(), "req-123")
+	var result int
+	if err := db.WithContext(ctx).Raw("SELECT 42").Scan(&result).Error; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entries := logEntries(t, out)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d: %s", len(entries), out.String())
+	}
+	entry := entries[0]
+	if entry["level"] != "warning" || entry["msg"] != "Slow database query" {
+		t.Errorf("Expected a slow query warning, got %v", entry)
+	}
+	if entry["sql"] != "SELECT 42" {
+		t.Errorf("Expected the SQL of the query, got %v", entry["sql"])
+	}
+	if _, ok := entry["duration_ms"].(float64); !ok {
+		t.Errorf("Expected the duration of the query, got %v", entry["duration_ms"])
+	}
+	if entry["request_id"] != "req-123" {
+		t.Errorf("Expected the request ID, got %v", entry["request_id"])
+	}
+}
+
+func TestLogger_FastQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		slowThreshold time.Duration
+	}{
+		{"under the threshold", time.Hour},
+		{"logging disabled", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, out := setupLoggedDB(t, gormlogger.Warn, tt.slowThreshold)
+
+			var result int
+			if err := db.Raw("SELECT 42").Scan(&result).Error; err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("Expected no logs, got %s", out.String())
+			}
+		})
+	}
+}
+
+func TestLogger_FailedQuery(t *testing.T) {
+	db, out := setupLoggedDB(t, gormlogger.Warn, time.Hour)
+
+	if err := db.Exec("SELECT * FROM missing_table").Error; err == nil {
+		t.Fatal("Expected an error querying a missing table")
+	}
+
+	entries := logEntries(t, out)
+	if len(entries) != 1 || entries[0]["level"] != "error" || entries[0]["msg"] != "Database query failed" {
+		t.Fatalf("Expected a failed query error, got %s", out.String())
+	}
+	if _, ok := entries[0]["request_id"]; ok {
+		t.Errorf("Expected no request ID outside of requests, got %v", entries[0]["request_id"])
+	}
+}
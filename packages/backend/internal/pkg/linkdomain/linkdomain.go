@@ -0,0 +1,86 @@
+// Package linkdomain restricts the domains the links of issues can point to, so issues
+// can't carry links to phishing or internal hosts.
+package linkdomain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrDomainNotAllowed is returned for links to a domain missing from the allowlist
+var ErrDomainNotAllowed = errors.New("link domain not allowed")
+
+// Allowlist is the set of domains links can point to, along with their subdomains.
+// A nil Allowlist allows every domain.
+type Allowlist struct {
+	domains []string
+}
+
+// New parses the domains links can point to, e.g. "github.com" or "*.konflux.dev".
+// A domain allows its subdomains too, so the leading "*." is optional.
+//
+// Returns nil when no domain is passed, allowing every domain, and an error if a
+// domain isn't a bare host name.
+func New(domains []string) (*Allowlist, error) {
+	allowlist := &Allowlist{}
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		domain = strings.TrimPrefix(domain, "*.")
+		domain = strings.Trim(domain, ".")
+		if domain == "" {
+			continue
+		}
+		if strings.ContainsAny(domain, "/:*@ ") {
+			return nil, fmt.Errorf("invalid link domain %q: expected a host name, e.g. github.com", domain)
+		}
+		allowlist.domains = append(allowlist.domains, domain)
+	}
+	if len(allowlist.domains) == 0 {
+		return nil, nil
+	}
+	return allowlist, nil
+}
+
+// Allows reports whether the URL points to an allowed domain, or one of its subdomains
+func (a *Allowlist) Allows(rawURL string) bool {
+	if a == nil {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return false
+	}
+	for _, domain := range a.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns ErrDomainNotAllowed, naming the host, if the URL doesn't point to an
+// allowed domain
+func (a *Allowlist) Check(rawURL string) error {
+	if a.Allows(rawURL) {
+		return nil
+	}
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return fmt.Errorf("%w: %s", ErrDomainNotAllowed, host)
+}
+
+// Domains returns the domains allowed, nil if every domain is
+func (a *Allowlist) Domains() []string {
+	if a == nil {
+		return nil
+	}
+	return a.domains
+}
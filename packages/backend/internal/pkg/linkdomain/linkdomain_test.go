@@ -0,0 +1,101 @@
+package linkdomain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		domains   []string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "none", domains: nil, expected: nil},
+		{name: "blank entries", domains: []string{" ", ""}, expected: nil},
+		{name: "normalized", domains: []string{" GitHub.com ", "*.konflux.dev", "quay.io."}, expected: []string{"github.com", "konflux.dev", "quay.io"}},
+		{name: "URL", domains: []string{"https://github.com"}, expectErr: true},
+		{name: "path", domains: []string{"github.com/org"}, expectErr: true},
+		{name: "port", domains: []string{"github.com:443"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowlist, err := New(tt.domains)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			domains := allowlist.Domains()
+			if len(domains) != len(tt.expected) {
+				t.Fatalf("expected domains %v, got %v", tt.expected, domains)
+			}
+			for idx := range domains {
+				if domains[idx] != tt.expected[idx] {
+					t.Errorf("expected domains %v, got %v", tt.expected, domains)
+				}
+			}
+		})
+	}
+}
+
+func TestAllowlist_Allows(t *testing.T) {
+	allowlist, err := New([]string{"github.com", "konflux.dev"})
+	if err != nil {
+		t.Fatalf("Failed to parse allowlist: %v", err)
+	}
+
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://github.com/org/repo", true},
+		{"https://GITHUB.com/org/repo", true},
+		{"https://github.com:8443/org/repo", true},
+		{"https://console.konflux.dev/pipelineruns/run-1", true},
+		{"https://evilgithub.com/org/repo", false},
+		{"https://github.com.evil.example/org/repo", false},
+		{"https://intranet.corp/secrets", false},
+		{"https://user@intranet.corp/github.com", false},
+		{"not a url", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if allowed := allowlist.Allows(tt.url); allowed != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, allowed)
+			}
+		})
+	}
+}
+
+func TestAllowlist_Nil(t *testing.T) {
+	var allowlist *Allowlist
+	if !allowlist.Allows("https://anything.example/path") {
+		t.Error("expected a nil allowlist to allow every domain")
+	}
+	if err := allowlist.Check("https://anything.example/path"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAllowlist_Check(t *testing.T) {
+	allowlist, err := New([]string{"github.com"})
+	if err != nil {
+		t.Fatalf("Failed to parse allowlist: %v", err)
+	}
+
+	err = allowlist.Check("https://intranet.corp/secrets")
+	if !errors.Is(err, ErrDomainNotAllowed) {
+		t.Fatalf("expected ErrDomainNotAllowed, got %v", err)
+	}
+	if err.Error() != "link domain not allowed: intranet.corp" {
+		t.Errorf("expected the host in the error, got %q", err.Error())
+	}
+}
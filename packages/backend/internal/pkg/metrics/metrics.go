@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ResolutionBuckets are the bounds of the resolution time histogram, in seconds,
+// chosen so common SLOs (e.g. "resolved within a day") fall on a bucket.
+var ResolutionBuckets = []float64{
+	5 * 60,            // 5m
+	15 * 60,           // 15m
+	60 * 60,           // 1h
+	4 * 60 * 60,       // 4h
+	12 * 60 * 60,      // 12h
+	24 * 60 * 60,      // 1d
+	3 * 24 * 60 * 60,  // 3d
+	7 * 24 * 60 * 60,  // 7d
+	30 * 24 * 60 * 60, // 30d
+}
+
+// Metrics holds the Prometheus metrics exported by Kite.
+// Each instance has its own registry, so tests don't share state.
+type Metrics struct {
+	registry *prometheus.Registry
+	// Number of active issues, per namespace, issue type and severity
+	OpenIssues *prometheus.GaugeVec
+	// Number of active issues past their resolution deadline, per namespace and severity
+	OverdueIssues *prometheus.GaugeVec
+	// Time taken to resolve issues, from detection to resolution, per issue type
+	ResolutionSeconds *prometheus.HistogramVec
+	// Number of issues rejected because their namespace reached its active issue limit
+	IssueLimitRejections *prometheus.CounterVec
+	// Number of webhook issues waiting in the ingest queue
+	WebhookQueueDepth prometheus.Gauge
+	// Number of webhook issues rejected by the ingest queue, per reason
+	WebhookQueueDropped *prometheus.CounterVec
+	// Number of attempts to deliver notifications, per result
+	NotificationDeliveries *prometheus.CounterVec
+	// Number of notifications given up on, per reason
+	NotificationDeadLetters *prometheus.CounterVec
+}
+
+// Reasons the ingest queue drops webhook issues
+const (
+	// The queue had no room left
+	QueueDropFull = "full"
+	// The queue was draining, on shutdown
+	QueueDropClosed = "closed"
+)
+
+// Results of notification delivery attempts
+const (
+	NotificationDelivered = "delivered"
+	NotificationFailed    = "failed"
+)
+
+// Reasons notifications are given up on
+const (
+	// The notification failed on every attempt
+	DeadLetterMaxAttempts = "max_attempts"
+	// The retry queue had no room left
+	DeadLetterQueueFull = "queue_full"
+)
+
+// New creates the metrics, along with the Go runtime and process metrics
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		OpenIssues: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kite_open_issues",
+			Help: "Number of active issues.",
+		}, []string{"namespace", "issueType", "severity"}),
+		OverdueIssues: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kite_overdue_issues",
+			Help: "Number of active issues past their resolution deadline.",
+		}, []string{"namespace", "severity"}),
+		ResolutionSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "kite_issue_resolution_seconds",
+			Help:    "Time taken to resolve issues, from detection to resolution.",
+			Buckets: ResolutionBuckets,
+		}, []string{"issueType"}),
+		IssueLimitRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kite_issue_limit_rejections_total",
+			Help: "Number of issues rejected because their namespace reached its active issue limit.",
+		}, []string{"namespace"}),
+		WebhookQueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "kite_webhook_queue_depth",
+			Help: "Number of webhook issues waiting in the ingest queue.",
+		}),
+		WebhookQueueDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kite_webhook_queue_dropped_total",
+			Help: "Number of webhook issues rejected by the ingest queue.",
+		}, []string{"reason"}),
+		NotificationDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kite_notification_deliveries_total",
+			Help: "Number of attempts to deliver notifications.",
+		}, []string{"result"}),
+		NotificationDeadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kite_notification_dead_letters_total",
+			Help: "Number of notifications given up on without being delivered.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.OpenIssues,
+		m.OverdueIssues,
+		m.ResolutionSeconds,
+		m.IssueLimitRejections,
+		m.WebhookQueueDepth,
+		m.WebhookQueueDropped,
+		m.NotificationDeliveries,
+		m.NotificationDeadLetters,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
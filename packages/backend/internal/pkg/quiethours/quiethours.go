@@ -0,0 +1,70 @@
+package quiethours
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a daily time window, e.g. 22:00-06:00, in a time zone.
+// Windows ending before they start span midnight.
+type Window struct {
+	start    time.Duration // Offset of the start from midnight
+	end      time.Duration // Offset of the end from midnight
+	location *time.Location
+}
+
+// Parse parses a window written as "HH:MM-HH:MM", in the named time zone,
+// e.g. "UTC" or "Europe/Paris". The time zone defaults to UTC.
+func Parse(window, timezone string) (*Window, error) {
+	startText, endText, ok := strings.Cut(window, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid quiet hours %q: expected HH:MM-HH:MM", window)
+	}
+	start, err := parseTimeOfDay(startText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours %q: %w", window, err)
+	}
+	end, err := parseTimeOfDay(endText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours %q: %w", window, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("invalid quiet hours %q: start and end are the same", window)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet hours time zone %q: %w", timezone, err)
+	}
+	return &Window{start: start, end: end, location: location}, nil
+}
+
+// parseTimeOfDay parses a time of day written as HH:MM into its offset from midnight
+func parseTimeOfDay(text string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether the time falls within the window
+func (w *Window) Contains(t time.Time) bool {
+	// The wall clock is used rather than the time since midnight, which shifts on DST changes
+	hour, minute, second := t.In(w.location).Clock()
+	offset := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	// The window spans midnight
+	return offset >= w.start || offset < w.end
+}
+
+// String returns the window as it's parsed, with its time zone
+func (w *Window) String() string {
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%s-%s %s", format(w.start), format(w.end), w.location)
+}
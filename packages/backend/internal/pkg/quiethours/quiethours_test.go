@@ -0,0 +1,66 @@
+package quiethours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    string
+		timezone  string
+		expectErr bool
+	}{
+		{name: "same day", window: "12:00-14:00", timezone: "UTC"},
+		{name: "spanning midnight", window: "22:00-06:00", timezone: "Europe/Paris"},
+		{name: "missing end", window: "22:00", timezone: "UTC", expectErr: true},
+		{name: "invalid time", window: "22:00-25:00", timezone: "UTC", expectErr: true},
+		{name: "empty window", window: "06:00-06:00", timezone: "UTC", expectErr: true},
+		{name: "unknown time zone", window: "22:00-06:00", timezone: "Mars/Olympus", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.window, tt.timezone)
+			if tt.expectErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWindow_Contains(t *testing.T) {
+	window, err := Parse("22:00-06:00", "Europe/Paris")
+	if err != nil {
+		t.Fatalf("Failed to parse window: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2026, 10, 16, 21, 59, 0, 0, paris), false},
+		{time.Date(2026, 10, 16, 22, 0, 0, 0, paris), true},
+		{time.Date(2026, 10, 17, 3, 0, 0, 0, paris), true},
+		{time.Date(2026, 10, 17, 5, 59, 59, 0, paris), true},
+		{time.Date(2026, 10, 17, 6, 0, 0, 0, paris), false},
+		{time.Date(2026, 10, 17, 12, 0, 0, 0, paris), false},
+		// Times in other zones are compared in the zone of the window
+		{time.Date(2026, 10, 16, 20, 30, 0, 0, time.UTC), true},
+		{time.Date(2026, 10, 17, 4, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := window.Contains(tt.time); got != tt.expected {
+			t.Errorf("Contains(%s) = %v, expected %v", tt.time, got, tt.expected)
+		}
+	}
+}
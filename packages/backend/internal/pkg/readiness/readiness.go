@@ -0,0 +1,89 @@
+package readiness
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// State tracks whether the server should receive new requests, along with
+// the number of requests currently being served.
+//
+// A server is ready once started, until it starts shutting down. It keeps serving
+// requests while draining, so load balancers have time to stop sending new ones.
+type State struct {
+	starting atomic.Bool
+	draining atomic.Bool
+	inFlight atomic.Int64
+}
+
+// New creates a ready state
+func New() *State {
+	return &State{}
+}
+
+// NewStarting creates a state that isn't ready until Started is called,
+// e.g. once the database schema is ready
+func NewStarting() *State {
+	s := &State{}
+	s.starting.Store(true)
+	return s
+}
+
+// Started marks the server as done starting
+func (s *State) Started() {
+	s.starting.Store(false)
+}
+
+// Starting reports whether the server is still starting
+func (s *State) Starting() bool {
+	return s.starting.Load()
+}
+
+// Ready reports whether the server should receive new requests
+func (s *State) Ready() bool {
+	return !s.starting.Load() && !s.draining.Load()
+}
+
+// Drain marks the server as not ready anymore
+func (s *State) Drain() {
+	s.draining.Store(true)
+}
+
+// RequestStarted records a request being served
+func (s *State) RequestStarted() {
+	s.inFlight.Add(1)
+}
+
+// RequestFinished records a request done being served
+func (s *State) RequestFinished() {
+	s.inFlight.Add(-1)
+}
+
+// InFlight returns the number of requests currently being served
+func (s *State) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+// GracefulShutdown shuts the server down in two phases.
+//
+// The server is first marked as not ready, then keeps accepting requests for
+// the delay while load balancers deregister it (e.g. during a Kubernetes preStop
+// hook). Only then shutdown is called to stop accepting new requests and drain
+// the ones in flight, within the timeout.
+//
+// Parameters:
+//   - delay: How long to wait before shutting down
+//   - timeout: How long to wait for in-flight requests to finish
+//   - shutdown: Stops the server, e.g. http.Server.Shutdown
+//
+// Returns:
+//   - error: The error returned by shutdown
+func (s *State) GracefulShutdown(delay, timeout time.Duration, shutdown func(context.Context) error) error {
+	s.Drain()
+	time.Sleep(delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return shutdown(ctx)
+}
@@ -0,0 +1,69 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestState_GracefulShutdown(t *testing.T) {
+	state := New()
+	if !state.Ready() {
+		t.Fatal("expected a new state to be ready")
+	}
+
+	const delay = 50 * time.Millisecond
+	start := time.Now()
+	shutdownErr := errors.New("shutdown called")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- state.GracefulShutdown(delay, time.Second, func(ctx context.Context) error {
+			if elapsed := time.Since(start); elapsed < delay {
+				t.Errorf("expected shutdown after the delay, called after %s", elapsed)
+			}
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("expected shutdown to be bounded by the timeout")
+			}
+			return shutdownErr
+		})
+	}()
+
+	// Readiness must be lost right away, while still waiting for the delay
+	deadline := time.Now().Add(delay / 2)
+	for state.Ready() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if state.Ready() {
+		t.Error("expected the state not to be ready once shutting down")
+	}
+
+	if err := <-done; !errors.Is(err, shutdownErr) {
+		t.Errorf("expected the shutdown error to be returned, got %v", err)
+	}
+}
+
+func TestState_InFlight(t *testing.T) {
+	state := New()
+
+	state.RequestStarted()
+	state.RequestStarted()
+	state.RequestFinished()
+
+	if state.InFlight() != 1 {
+		t.Errorf("expected 1 request in flight, got %d", state.InFlight())
+	}
+}
+
+func TestState_Starting(t *testing.T) {
+	state := NewStarting()
+	if state.Ready() || !state.Starting() {
+		t.Fatal("expected a starting state not to be ready")
+	}
+
+	state.Started()
+	if !state.Ready() || state.Starting() {
+		t.Error("expected the state to be ready once started")
+	}
+}
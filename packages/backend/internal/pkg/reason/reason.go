@@ -0,0 +1,55 @@
+// Package reason normalizes the failure reasons of issues, so reports of the same
+// failure can be told from reports of different failures on the same resource.
+package reason
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// volatilePatterns match the tokens that change from one report of a failure to the
+// next, with their placeholders. They're applied in order, timestamps first since
+// their digits would otherwise be taken for other tokens.
+var volatilePatterns = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	// RFC 3339 and similar timestamps, e.g. 2026-10-17T08:00:00.123Z or 2026-10-17 08:00:00
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}(?:[t ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:z|[+-]\d{2}:?\d{2})?)?\b`), "<time>"},
+	// Times of day, e.g. 08:00:00.123
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(?:\.\d+)?\b`), "<time>"},
+	// UUIDs, e.g. the UID of a run
+	{regexp.MustCompile(`\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<id>"},
+	// Suffixes generated by Kubernetes for the names of runs and pods, e.g. build-x7k2p
+	{regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}\b`), "-<id>"},
+	// Commit hashes and digests
+	{regexp.MustCompile(`\b[0-9a-f]*[0-9][0-9a-f]*\b`), "<hex>"},
+}
+
+// hexTokenMinLength is the minimum length of the hexadecimal tokens taken for hashes,
+// shorter ones like exit codes are kept
+const hexTokenMinLength = 7
+
+// Normalize returns the failure reason with its case and whitespace normalized, and
+// the tokens that change from one report to the next (timestamps, run names and IDs,
+// hashes) replaced with placeholders.
+func Normalize(reason string) string {
+	normalized := strings.ToLower(reason)
+	for _, volatile := range volatilePatterns {
+		normalized = volatile.pattern.ReplaceAllStringFunc(normalized, func(token string) string {
+			if volatile.placeholder == "<hex>" && len(token) < hexTokenMinLength {
+				return token
+			}
+			return volatile.placeholder
+		})
+	}
+	return strings.Join(strings.Fields(normalized), " ")
+}
+
+// Hash returns the SHA-256 of the normalized failure reason, in hexadecimal
+func Hash(reason string) string {
+	sum := sha256.Sum256([]byte(Normalize(reason)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package reason
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected string
+	}{
+		{"  Build   failed:\n\tmissing  dependency ", "build failed: missing dependency"},
+		{"Task failed at 2026-10-17T08:00:00.123Z", "task failed at <time>"},
+		{"Task failed at 2026-10-17 08:00:00+02:00", "task failed at <time>"},
+		{"Timed out at 08:00:00", "timed out at <time>"},
+		{"PipelineRun build-x7k2p failed", "pipelinerun build-<id> failed"},
+		{"Pod build-x7k2p-build-container-pod was evicted", "pod build-<id>-build-container-pod was evicted"},
+		{"Run 1b4e28ba-2fa1-11d2-883f-0016d3cca427 failed", "run <id> failed"},
+		{"Image sha256:3f2a1c9e8b7d6a5f4e3d failed to pull", "image sha256:<hex> failed to pull"},
+		{"Commit 3f2a1c9 failed the tests", "commit <hex> failed the tests"},
+		{"Run 1234567 failed", "run <hex> failed"},
+		// Short tokens may be meaningful, they're kept
+		{"Step exited with code 137", "step exited with code 137"},
+		{"Build failed on linux-amd64", "build failed on linux-amd64"},
+		{"Task build-tests failed", "task build-tests failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			if got := Normalize(tt.reason); got != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	first := Hash("PipelineRun build-x7k2p failed at 2026-10-17T08:00:00Z: missing dependency")
+	second := Hash("pipelinerun build-b4zq9 failed at 2026-10-17T09:30:00Z:  missing dependency")
+	if first != second {
+		t.Errorf("expected reports of the same failure to have the same hash, got %s and %s", first, second)
+	}
+	if other := Hash("PipelineRun build-x7k2p failed at 2026-10-17T08:00:00Z: out of memory"); other == first {
+		t.Error("expected different failures to have different hashes")
+	}
+	if len(first) != 64 {
+		t.Errorf("expected a SHA-256 in hexadecimal, got '%s'", first)
+	}
+}
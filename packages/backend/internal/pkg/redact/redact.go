@@ -0,0 +1,76 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Replacement is the value that replaces any sensitive data found
+const Replacement = "***REDACTED***"
+
+// secretGroup is the name of the capture group that marks the sensitive part of a match.
+// When a pattern has this group, only that part is replaced (e.g. the value in "token=abc").
+const secretGroup = "secret"
+
+// DefaultPatterns are the patterns used when none are configured.
+// They cover common secret shapes found in logs.
+var DefaultPatterns = []string{
+	// AWS access key IDs
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	// AWS secret access keys
+	`(?i)aws_secret_access_key["']?\s*[=:]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})`,
+	// Bearer tokens in authorization headers
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]+=*)`,
+	// GitHub tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	// Credentials passed as key/value pairs
+	`(?i)\b(?:password|passwd|secret|token|api[_-]?key)["']?\s*[=:]\s*["']?(?P<secret>[^\s"',;]+)`,
+	// Private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+}
+
+// Redactor replaces sensitive data matching a set of patterns
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New compiles the patterns passed and returns a Redactor using them.
+//
+// Returns an error if any of the patterns is not a valid regular expression.
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns the value with all matches of the patterns replaced.
+// A nil Redactor returns the value unchanged.
+func (r *Redactor) Redact(value string) string {
+	if r == nil {
+		return value
+	}
+	for _, re := range r.patterns {
+		groupIdx := re.SubexpIndex(secretGroup)
+		if groupIdx < 0 {
+			value = re.ReplaceAllString(value, Replacement)
+			continue
+		}
+
+		// Only replace the sensitive part of each match
+		value = re.ReplaceAllStringFunc(value, func(match string) string {
+			loc := re.FindStringSubmatchIndex(match)
+			start, end := loc[2*groupIdx], loc[2*groupIdx+1]
+			if start < 0 {
+				return match
+			}
+			return match[:start] + Replacement + match[end:]
+		})
+	}
+	return value
+}
@@ -0,0 +1,19 @@
+// Package requestid carries the ID of a request in its context, so code below the
+// handlers, e.g. database logging, can tell which request it's working for.
+package requestid
+
+import "context"
+
+// contextKey is the key of the request ID in contexts
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, empty if there's none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
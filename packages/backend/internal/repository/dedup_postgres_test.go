@@ -0,0 +1,92 @@
+//go:build postgres
+
+package repository
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestIssueRepository_EnforceUnique_ConcurrentCreates_Postgres(t *testing.T) {
+	const numRequests = 20
+
+	db := setupPostgresTestDB(t)
+	repo := NewIssueRepository(db, logrus.New(), WithDedupOptions(DedupOptions{EnforceUnique: true}))
+	ctx := context.Background()
+
+	// Every report of the new issue misses the others in its duplicate lookup, as there's
+	// no row to lock yet, so all of them attempt to create it
+	req := createTestIssue("Created concurrently", "team-unique")
+	var wg sync.WaitGroup
+	errs := make([]error, numRequests)
+	for i := range numRequests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, errs[i] = repo.CreateOrUpdate(ctx, req)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	var issues []models.Issue
+	if err := db.Where("namespace = ?", "team-unique").Find(&issues).Error; err != nil {
+		t.Fatalf("Failed to find issues: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected a single issue, got %d", len(issues))
+	}
+	if issues[0].OccurrenceCount != numRequests {
+		t.Errorf("Expected %d occurrences, got %d", numRequests, issues[0].OccurrenceCount)
+	}
+}
+
+func TestIssueRepository_NormalizeResourceNames_Concurrent_Postgres(t *testing.T) {
+	const numReplicas = 5
+
+	db := setupPostgresTestDB(t)
+	logger := logrus.New()
+	ctx := context.Background()
+
+	req := createTestIssue("Pipeline run failed", "team-normalize")
+	req.Scope.ResourceName = "build-xyz-a1b2c3"
+	if _, _, err := NewIssueRepository(db, logger).Create(ctx, req); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Replicas start together with a new pattern, only one of them normalizes the scopes
+	strip := regexp.MustCompile(`-[a-z0-9]{6}$`)
+	var wg sync.WaitGroup
+	updated := make([]int64, numReplicas)
+	errs := make([]error, numReplicas)
+	for i := range numReplicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo := NewIssueRepository(db, logger, WithDedupOptions(DedupOptions{ResourceNameStrip: strip}))
+			updated[i], errs[i] = repo.NormalizeResourceNames(ctx)
+		}()
+	}
+	wg.Wait()
+
+	var total int64
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		total += updated[i]
+	}
+	if total != 1 {
+		t.Errorf("Expected the scope to be normalized once, got %d updates", total)
+	}
+}
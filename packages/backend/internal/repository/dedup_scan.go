@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// dedupKey returns the key issues are matched on by duplicate detection within a
+// namespace: the fingerprint when the producer supplied one, the type and scope otherwise,
+// along with the failure reason with the reason dedup strategy.
+func (i *issueRepository) dedupKey(issue models.Issue) string {
+	if issue.Fingerprint != "" {
+		return "fingerprint\x00" + issue.Fingerprint
+	}
+	key := []string{
+		"scope",
+		string(issue.IssueType),
+		issue.Scope.ResourceType,
+		i.normalizeResourceName(issue.Scope.ResourceName),
+		issue.Scope.ResourceNamespace,
+	}
+	if i.dedup.Strategy == DedupByReason {
+		key = append(key, issue.ReasonHash)
+	}
+	return strings.Join(key, "\x00")
+}
+
+// MergeDuplicates finds the open issues of a namespace that duplicate detection would
+// match with each other, e.g. created before it was tuned, and merges each group into
+// its oldest issue.
+//
+// The oldest issue takes over the notes, occurrences and occurrence counts of its
+// duplicates, and gets a note listing them. The duplicates are then deleted along with
+// their links and relationships. The open issues of the namespace are locked until
+// they're merged, so the occurrences counted meanwhile aren't lost.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace to scan
+//   - dryRun: Only report the groups of duplicates, without merging them
+//
+// Returns:
+//   - []dto.DuplicateMerge: The groups of duplicates, by oldest issue
+//   - error: Database error or nil
+func (i *issueRepository) MergeDuplicates(ctx context.Context, namespace string, dryRun bool) ([]dto.DuplicateMerge, error) {
+	merges := []dto.DuplicateMerge{}
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var issues []models.Issue
+		err := tx.Preload("Scope").
+			Where("state IN ? AND namespace = ?", models.OpenStates, namespace).
+			Order("detected_at, created_at, id").
+			Clauses(lockIssues).
+			Find(&issues).Error
+		if err != nil {
+			return fmt.Errorf("failed to find open issues: %w", err)
+		}
+
+		// Issues are ordered oldest first, so the first issue of a group is kept
+		groups := make(map[string][]models.Issue)
+		var keys []string
+		for _, issue := range issues {
+			key := i.dedupKey(issue)
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], issue)
+		}
+
+		for _, key := range keys {
+			group := groups[key]
+			if len(group) < 2 {
+				continue
+			}
+
+			merge := dto.DuplicateMerge{IssueID: group[0].ID}
+			for _, duplicate := range group[1:] {
+				merge.MergedIDs = append(merge.MergedIDs, duplicate.ID)
+			}
+			merges = append(merges, merge)
+
+			if dryRun {
+				continue
+			}
+			if err := i.mergeIssuesInTx(tx, &group[0], group[1:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).WithField("namespace", namespace).Error("Failed to merge duplicate issues")
+		return nil, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"groups":    len(merges),
+		"dry_run":   dryRun,
+	}).Info("Scanned issues for duplicates")
+	return merges, nil
+}
+
+// mergeIssuesInTx merges duplicates into an issue, then deletes them.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issue: The issue to keep
+//   - duplicates: The issues merged into it
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) mergeIssuesInTx(tx *gorm.DB, issue *models.Issue, duplicates []models.Issue) error {
+	ids := make([]string, 0, len(duplicates))
+	occurrenceCount := issue.OccurrenceCount
+	lastSeenAt := issue.LastSeenAt
+	for _, duplicate := range duplicates {
+		ids = append(ids, duplicate.ID)
+		occurrenceCount += duplicate.OccurrenceCount
+		if duplicate.LastSeenAt.After(lastSeenAt) {
+			lastSeenAt = duplicate.LastSeenAt
+		}
+	}
+
+	// Move the history of the duplicates before they're deleted
+	if err := tx.Model(&models.IssueNote{}).Where("issue_id IN ?", ids).Update("issue_id", issue.ID).Error; err != nil {
+		return fmt.Errorf("failed to move notes: %w", err)
+	}
+	if err := tx.Model(&models.Occurrence{}).Where("issue_id IN ?", ids).Update("issue_id", issue.ID).Error; err != nil {
+		return fmt.Errorf("failed to move occurrences: %w", err)
+	}
+	if err := tx.Model(&models.Attachment{}).Where("issue_id IN ?", ids).Update("issue_id", issue.ID).Error; err != nil {
+		return fmt.Errorf("failed to move attachments: %w", err)
+	}
+	if i.dedup.MaxOccurrences > 0 {
+		if err := i.pruneOccurrencesInTx(tx, issue.ID); err != nil {
+			return err
+		}
+	}
+
+	err := tx.Model(&models.Issue{}).
+		Where("id = ?", issue.ID).
+		Updates(map[string]any{
+			"occurrence_count": occurrenceCount,
+			"last_seen_at":     lastSeenAt,
+			"updated_at":       i.now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update merged issue: %w", err)
+	}
+
+	note := models.IssueNote{
+		IssueID: issue.ID,
+		Content: "Merged duplicate issues " + strings.Join(ids, ", "),
+	}
+	if err := tx.Create(&note).Error; err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	for idx := range duplicates {
+		if err := i.deleteIssueInTx(tx, &duplicates[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceNameStripSetting is the setting holding the hash of the pattern the normalized
+// resource names were computed with
+const resourceNameStripSetting = "resource_name_strip_hash"
+
+// normalizeBatchSize is the number of scopes normalized at once
+const normalizeBatchSize = 500
+
+// normalizeLockKey is the Postgres advisory lock held while normalizing resource names
+const normalizeLockKey = 0x6b697465
+
+// resourceNameStripHash returns the hash of the pattern stripped from resource names,
+// the hash of an empty pattern when nothing is stripped
+func (i *issueRepository) resourceNameStripHash() string {
+	pattern := ""
+	if i.dedup.ResourceNameStrip != nil {
+		pattern = i.dedup.ResourceNameStrip.String()
+	}
+	sum := sha256.Sum256([]byte(pattern))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeResourceNames recomputes the normalized resource names of every scope when
+// DedupOptions.ResourceNameStrip changed since they were last computed, so duplicates
+// keep matching the scopes that weren't updated since. The hash of the pattern is stored
+// so the scopes are only scanned once per change, replicas starting together wait for
+// the first one to normalize them.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - int64: The number of scopes whose normalized name changed
+//   - error: Database error or nil
+func (i *issueRepository) NormalizeResourceNames(ctx context.Context) (int64, error) {
+	hash := i.resourceNameStripHash()
+	var updated int64
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The setting may not exist yet, so there's no row to lock
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", normalizeLockKey).Error; err != nil {
+				return fmt.Errorf("failed to lock resource names: %w", err)
+			}
+		}
+
+		var setting models.Setting
+		err := tx.Where("name = ?", resourceNameStripSetting).First(&setting).Error
+		if err == nil && setting.Value == hash {
+			return nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to find setting: %w", err)
+		}
+
+		var scopes []models.IssueScope
+		err = tx.Select("id", "resource_name", "normalized_resource_name").
+			FindInBatches(&scopes, normalizeBatchSize, func(_ *gorm.DB, _ int) error {
+				for _, scope := range scopes {
+					normalized := i.normalizeResourceName(scope.ResourceName)
+					if scope.NormalizedResourceName == normalized {
+						continue
+					}
+					err := tx.Model(&models.IssueScope{}).
+						Where("id = ?", scope.ID).
+						UpdateColumn("normalized_resource_name", normalized).Error
+					if err != nil {
+						return fmt.Errorf("failed to update scope: %w", err)
+					}
+					updated++
+				}
+				return nil
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to normalize resource names: %w", err)
+		}
+
+		if err := tx.Save(&models.Setting{Name: resourceNameStripSetting, Value: hash}).Error; err != nil {
+			return fmt.Errorf("failed to save setting: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
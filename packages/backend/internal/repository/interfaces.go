@@ -2,23 +2,61 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
 )
 
 type IssueRepository interface {
-	Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
+	Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, bool, error)
 	FindByID(ctx context.Context, id string) (*models.Issue, error)
+	FindByIDs(ctx context.Context, ids []string) ([]models.Issue, error)
+	FindStatusByID(ctx context.Context, id string) (*dto.IssueStatus, error)
 	Update(ctx context.Context, id string, updates dto.IssuePayload) (*models.Issue, error)
+	Patch(ctx context.Context, id string, patch IssuePatch) (*models.Issue, error)
 	Delete(ctx context.Context, id string) error
+	BulkDelete(ctx context.Context, namespace string, ids []string) ([]dto.BulkIssueResult, error)
 	// TODO - move IssueQueryFilters somewhere else
 	FindAll(ctx context.Context, filters IssueQueryFilters) ([]models.Issue, int64, error)
+	GroupByResource(ctx context.Context, filters IssueQueryFilters, maxGroups, maxIssues int) ([]dto.IssueGroup, error)
+	FindDeletedSince(ctx context.Context, filters IssueQueryFilters, since time.Time) ([]models.DeletedIssue, error)
+	Search(ctx context.Context, filters IssueQueryFilters) ([]dto.SearchResult, error)
+	MTTR(ctx context.Context, filters IssueQueryFilters, since time.Time, groupBy string) ([]dto.MTTRGroup, error)
+	CountOpen(ctx context.Context) ([]dto.OpenIssueCount, error)
+	CountOverdue(ctx context.Context, now time.Time) ([]dto.OverdueIssueCount, error)
+	FindResolutions(ctx context.Context, after, until time.Time) ([]dto.IssueResolution, error)
 	FindDuplicate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
+	ResolveWithCascade(ctx context.Context, id string) ([]string, error)
 	ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error)
+	ResolveByScopes(ctx context.Context, resourceTypes []string, resourceName, namespace string) (int64, error)
+	ResolveByScopePrefix(ctx context.Context, resourceType, namePrefix, namespace string) (int64, error)
+	AcknowledgeByScope(ctx context.Context, resourceType, resourceName, namespace, note string) (int64, error)
+	ResolveStale(ctx context.Context, issueType models.IssueType, lastSeenBefore time.Time, note string) (int64, error)
+	ResolveNamespace(ctx context.Context, namespace, note string) (int64, error)
+	DeescalateQuiet(ctx context.Context, lastSeenBefore time.Time, note string) (int64, error)
 	AddRelatedIssue(ctx context.Context, sourceID, targetID string) error
 	RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error
-	CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error)
+	RemoveAllRelated(ctx context.Context, id string) (int64, error)
+	FindRelatedGraph(ctx context.Context, id string, maxSize int) (*dto.IssueGraph, error)
+	BatchAddRelatedIssues(ctx context.Context, edges []dto.RelationshipEdgeRequest) ([]dto.RelationshipEdgeResult, error)
+	CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, bool, error)
+	MergeDuplicates(ctx context.Context, namespace string, dryRun bool) ([]dto.DuplicateMerge, error)
+	NormalizeResourceNames(ctx context.Context) (int64, error)
+	FindNamespaces(ctx context.Context) ([]dto.NamespaceSummary, error)
+	RankNamespaces(ctx context.Context, states []models.IssueState, limit int) ([]dto.NamespaceIssueCount, error)
+	FindOccurrences(ctx context.Context, issueID string) ([]models.Occurrence, error)
+	OccurrenceHistogram(ctx context.Context, issueID, unit string, since time.Time) ([]dto.OccurrenceBucket, error)
+	AddLink(ctx context.Context, issueID string, req dto.CreateLinkRequest) (*models.Link, error)
+	AddAttachment(ctx context.Context, issueID string, req dto.CreateAttachmentRequest) (*models.Attachment, error)
+	FindAttachments(ctx context.Context, issueID string) ([]models.Attachment, error)
+	UpdateLink(ctx context.Context, issueID, linkID string, req dto.CreateLinkRequest) (*models.Link, error)
+	DeleteLink(ctx context.Context, issueID, linkID string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindByID(ctx context.Context, id string) (*models.WebhookDelivery, error)
 }
 
 type LinkRepository interface {
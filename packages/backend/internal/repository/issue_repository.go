@@ -1,20 +1,82 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/reason"
+	"github.com/konflux-ci/kite/internal/pkg/requestid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// ErrMultiplePrimaryLinks is returned when more than one link of an issue is flagged as primary
+var ErrMultiplePrimaryLinks = errors.New("at most one link can be primary")
+
+// ErrIssuesNotFound is returned when issues referenced by a request don't exist
+var ErrIssuesNotFound = errors.New("issues not found")
+
+// ErrIssueNotFound is returned when the issue targeted by a request doesn't exist
+var ErrIssueNotFound = errors.New("issue not found")
+
+// ErrRelationshipLimitExceeded is returned when an issue already has the maximum number of relationships
+var ErrRelationshipLimitExceeded = errors.New("relationship limit exceeded")
+
+// ErrLinkNotFound is returned when a link doesn't exist or belongs to another issue
+var ErrLinkNotFound = errors.New("link not found")
+
+// ErrNamespaceIssueLimitExceeded is returned when a namespace already has the maximum number of active issues
+var ErrNamespaceIssueLimitExceeded = errors.New("namespace active issue limit exceeded")
+
+// ErrDuplicateOpenIssue is returned when a change would make an issue a duplicate of
+// another open issue, rejected by the unique index of open issues
+var ErrDuplicateOpenIssue = errors.New("an open issue with the same dedup key already exists")
+
+// errConcurrentDuplicate is returned when a new issue is rejected by the unique index of
+// open issues, its duplicate having been created since it was looked up
+var errConcurrentDuplicate = errors.New("duplicate issue created concurrently")
+
+// createIssueSavePoint is rolled back to when a new issue is rejected as a duplicate, so
+// the transaction can update the duplicate instead
+const createIssueSavePoint = "create_issue"
+
+// orderedLinks preloads the links of an issue following their configured order
+func orderedLinks(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
 type issueRepository struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	dedup  DedupOptions
+	// Maximum number of relationships per issue, in either direction
+	maxRelationships int
+	// Number of issues sharing its resource a new issue is related to, 0 disables it
+	maxAutoRelations int
+	// Maximum number of active issues per namespace, 0 disables it
+	maxActiveIssuesPerNamespace int
+	// How long after their detection issues of each severity are due
+	resolutionDeadlines map[models.Severity]time.Duration
+	// Failures reported within this window of a resolution by a success webhook win over it, 0 disables it
+	resolveConflictWindow time.Duration
+	// Clock timestamps are taken from, in UTC
+	clock clock.Clock
+	// Log the key of every duplicate lookup at debug level
+	logDedupKeys bool
 }
 
 // NewIssueRepository creates a new Issue repository
@@ -22,14 +84,29 @@ type issueRepository struct {
 // Parameters:
 //   - db: Pointer to a database (gorm.DB)
 //   - logger: Pointer to a logger (logrus.Logger)
+//   - opts: Optional settings (deduplication, etc.)
 //
 // Returns:
 //   - IssueRepository
-func NewIssueRepository(db *gorm.DB, logger *logrus.Logger) IssueRepository {
-	return &issueRepository{
+func NewIssueRepository(db *gorm.DB, logger *logrus.Logger, opts ...Option) IssueRepository {
+	repo := &issueRepository{
 		db:     db,
 		logger: logger,
+		dedup:  DefaultDedupOptions(),
+
+		maxRelationships: DefaultMaxRelationshipsPerIssue,
+		clock:            clock.Real{},
 	}
+	for _, opt := range opts {
+		opt(repo)
+	}
+	return repo
+}
+
+// now returns the current time of the repository clock, in UTC so timestamps
+// don't depend on the timezone of the server.
+func (i *issueRepository) now() time.Time {
+	return i.clock.Now().UTC()
 }
 
 // CreateOrUpdate atomically creates a new issue or updates an existing duplicate.
@@ -51,10 +128,12 @@ func NewIssueRepository(db *gorm.DB, logger *logrus.Logger) IssueRepository {
 //
 // Returns:
 //   - *models.Issue: The created or updated issue with all associations loaded
+//   - bool: Whether the issue was created, rather than a duplicate updated, as decided
+//     within the transaction
 //   - error: Database error, validation failure or nil
-func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, error) {
+func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePayload) (*models.Issue, bool, error) {
 	var issue *models.Issue
-	var isUpdate bool
+	var isUpdate, isCoalesced bool
 
 	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var existingIssue *models.Issue
@@ -67,32 +146,92 @@ func (i *issueRepository) CreateOrUpdate(ctx context.Context, req dto.IssuePaylo
 		// Create a new one
 		if existingIssue == nil {
 			newIssue, err := i.createNewIssueInTx(tx, req)
-			if err != nil {
+			if err == nil {
+				issue = newIssue
+				return nil
+			}
+			if !errors.Is(err, errConcurrentDuplicate) {
 				return fmt.Errorf("failed to create issue: %w", err)
 			}
-			issue = newIssue
-			return nil
+			// Created by a concurrent report since it was looked up, it's updated instead
+			if existingIssue, err = i.findConcurrentDuplicateInTx(tx, req); err != nil {
+				return err
+			}
 		}
 
 		// If no error, an existing issue should be found
 		isUpdate = true
 		issue = existingIssue
-		return i.updateIssueInTx(tx, existingIssue, req)
+		if i.coalescesUpdate(existingIssue, req) {
+			isCoalesced = true
+			return i.countOccurrenceInTx(tx, existingIssue.ID)
+		}
+		wasAcknowledged := existingIssue.State == models.IssueStateAcknowledged
+		if err := i.updateIssueInTx(tx, existingIssue, req, true); err != nil {
+			return err
+		}
+		// A recurrence of an acknowledged issue is part of what was triaged, it stays acknowledged
+		if wasAcknowledged && req.GetState() != models.IssueStateResolved {
+			err := tx.Model(&models.Issue{}).
+				Where("id = ?", existingIssue.ID).
+				Update("state", models.IssueStateAcknowledged).Error
+			if err != nil {
+				return fmt.Errorf("failed to keep issue acknowledged: %w", err)
+			}
+		}
+		return i.markSeenInTx(tx, existingIssue.ID, req.GetDescription())
 	})
 
 	if err != nil {
 		i.logger.WithError(err).Error("Failed to create or update issue")
-		return nil, err
+		return nil, false, err
 	}
 
-	if isUpdate {
+	if isCoalesced {
+		i.logger.WithField("issue_id", issue.ID).Debug("Counted duplicate of recently updated issue")
+	} else if isUpdate {
 		i.logger.WithField("issue_id", issue.ID).Info("Updated existing issue")
 	} else {
 		i.logger.WithField("issue_id", issue.ID).Info("Created new issue")
 	}
 
 	// Reload all associations
-	return i.FindByID(ctx, issue.ID)
+	issue, err = i.reloadByID(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	return issue, !isUpdate, nil
+}
+
+// coalescesUpdate reports whether a duplicate is reported too soon after the last
+// update of its issue to update it again. Duplicates changing the state of the issue
+// always update it.
+func (i *issueRepository) coalescesUpdate(existingIssue *models.Issue, req dto.IssuePayload) bool {
+	if i.dedup.MinUpdateInterval <= 0 {
+		return false
+	}
+	if state := req.GetState(); state != "" && state != existingIssue.State {
+		return false
+	}
+	if i.reopensConcurrentResolve(existingIssue, req) {
+		return false
+	}
+	return i.now().Sub(existingIssue.UpdatedAt) < i.dedup.MinUpdateInterval
+}
+
+// reopensConcurrentResolve reports whether a recurrence reopens its issue, resolved by a
+// success webhook within the resolve conflict window. The failure is fresher than the
+// success, so it wins over the concurrent resolution: the resource is still failing.
+// Recurrences setting a state of their own are left alone.
+func (i *issueRepository) reopensConcurrentResolve(existingIssue *models.Issue, req dto.IssuePayload) bool {
+	if i.resolveConflictWindow <= 0 || req.GetState() != "" {
+		return false
+	}
+	if existingIssue.State != models.IssueStateResolved || existingIssue.ResolvedBy != models.ResolutionSourceSuccessWebhook ||
+		existingIssue.ResolvedAt == nil {
+		return false
+	}
+	return i.now().Sub(*existingIssue.ResolvedAt) < i.resolveConflictWindow
 }
 
 // FindDuplicate uses the request payload for an issue to check if an issue matching
@@ -138,10 +277,20 @@ func (i *issueRepository) FindDuplicate(ctx context.Context, req dto.IssuePayloa
 // where multiple concurrent requests might create duplicate issues.
 //
 // The function considers an issue a duplicate if ALL of the following match:
-//   - Same namespace
+//   - Same namespace, or with DedupOptions.AcrossNamespaces, a namespace the context's
+//     NamespaceAuthorizer allows the report to update
 //   - Same issue type
-//   - Issue is in ACTIVE state
-//   - Same resource scope (type, name, namespace)
+//   - Issue is in ACTIVE or ACKNOWLEDGED state (or RESOLVED, if DedupOptions.IncludeResolved is set)
+//   - Same resource scope (type, name, namespace), the resource namespace defaulting to the namespace
+//     and the name stripped of transient suffixes if DedupOptions.ResourceNameStrip is set
+//   - Same failure reason, i.e. normalized description, if DedupOptions.Strategy is DedupByReason
+//
+// When the payload carries a fingerprint, the producer knows the identity of the issue
+// better: the issue type and scope are ignored, and an issue is a duplicate if it's in
+// the same namespace with the same fingerprint, in one of the states above.
+//
+// Which replica the issue was reported to is never part of the match: issues don't
+// record it, so replicas sharing a database update the same issues.
 //
 // Parameters:
 //   - tx: The database transaction to execute within
@@ -163,37 +312,271 @@ func (i *issueRepository) findDuplicateInTx(tx *gorm.DB, req dto.IssuePayload) (
 	// Lock any matching rows with "FOR UPDATE" to prevent other transactions
 	// from reading or modifying them until the transaction completes.
 	// Doc: https://www.postgresql.org/docs/current/explicit-locking.html#LOCKING-ROWS
-	err := tx.Preload("Links").
-		Joins("JOIN issue_scopes on issues.scope_id = issue_scopes.id").
-		Where("issues.namespace = ? AND issues.issue_type = ? AND issues.state IN ?",
-			req.GetNamespace(), req.GetIssueType(), []models.IssueState{models.IssueStateActive, models.IssueStateResolved}).
-		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ? AND issue_scopes.resource_namespace = ?",
-			req.GetScope().GetResourceType(), req.GetScope().GetResourceName(), req.GetNamespace()).
-		Set("gorm:query_option", "FOR UPDATE").
-		First(&existingIssue).Error
+	query := i.dedupCandidates(tx, req)
+	if i.dedup.AcrossNamespaces && req.GetFingerprint() == "" {
+		namespaces, err := i.authorizedDedupNamespacesInTx(tx, req)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("issues.namespace IN ?", namespaces)
+	} else {
+		query = query.Where("issues.namespace = ?", req.GetNamespace())
+	}
+	err := query.Preload("Links", orderedLinks).Clauses(lockIssues).First(&existingIssue).Error
 
 	if err != nil {
 		// Not finding a record is expected behavior (no duplicate exists)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			i.logDedupKey(tx, req, nil)
 			return nil, nil
 		}
 
 		// Actual database errors should be propagated.
 		return nil, fmt.Errorf("failed to check for duplicates: %w", err)
 	}
+	i.logDedupKey(tx, req, &existingIssue)
 	return &existingIssue, nil
 }
 
+// dedupCandidates returns a query on the issues a payload duplicates, whatever their namespace
+func (i *issueRepository) dedupCandidates(tx *gorm.DB, req dto.IssuePayload) *gorm.DB {
+	query := tx.Model(&models.Issue{})
+	if i.resolveConflictWindow > 0 && !i.dedup.IncludeResolved {
+		// Issues resolved by a concurrent success are reopened by the failure rather than duplicated
+		query = query.Where("(issues.state IN ? OR (issues.state = ? AND issues.resolved_by = ? AND issues.resolved_at >= ?))",
+			i.dedupStates(), models.IssueStateResolved, models.ResolutionSourceSuccessWebhook, i.now().Add(-i.resolveConflictWindow))
+	} else {
+		query = query.Where("issues.state IN ?", i.dedupStates())
+	}
+	if fingerprint := req.GetFingerprint(); fingerprint != "" {
+		return query.Where("issues.fingerprint = ?", fingerprint)
+	}
+
+	query = query.
+		Joins("JOIN issue_scopes on issues.scope_id = issue_scopes.id").
+		Where("issues.issue_type = ?", req.GetIssueType()).
+		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_namespace = ?",
+			req.GetScope().GetResourceType(), resourceNamespace(req))
+	if i.dedup.ResourceNameStrip != nil {
+		query = query.Where("issue_scopes.normalized_resource_name = ?", i.normalizeResourceName(req.GetScope().GetResourceName()))
+	} else {
+		query = query.Where("issue_scopes.resource_name = ?", req.GetScope().GetResourceName())
+	}
+	if i.dedup.Strategy == DedupByReason {
+		query = query.Where("issues.reason_hash = ?", reason.Hash(req.GetDescription()))
+	}
+	return query
+}
+
+// authorizedDedupNamespacesInTx returns the namespaces a payload's duplicates are matched
+// in when matched across namespaces: the namespace of the payload, and the namespaces of
+// other duplicates that the NamespaceAuthorizer of the context allows the report to update.
+// Reports can't update the issues of namespaces they couldn't report to themselves.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - req: The issue payload containing the criteria to match.
+//
+// Returns:
+//   - []string: The namespaces duplicates can be matched in
+//   - error: Database error or nil
+func (i *issueRepository) authorizedDedupNamespacesInTx(tx *gorm.DB, req dto.IssuePayload) ([]string, error) {
+	namespaces := []string{req.GetNamespace()}
+	authorize := namespaceAuthorizerFrom(tx.Statement.Context)
+	if authorize == nil {
+		return namespaces, nil
+	}
+
+	// Not locked, the duplicate is locked once its namespace is authorized
+	var candidates []string
+	err := i.dedupCandidates(tx, req).
+		Where("issues.namespace <> ?", req.GetNamespace()).
+		Distinct().
+		Pluck("issues.namespace", &candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find namespaces of duplicates: %w", err)
+	}
+	for _, namespace := range candidates {
+		if authorize(namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// logDedupKey logs the key a duplicate of the payload was looked up with, and the
+// duplicate found if any, to diagnose why reports were or weren't deduplicated.
+// Only logged at debug level, when enabled with WithDedupKeyLogging.
+func (i *issueRepository) logDedupKey(tx *gorm.DB, req dto.IssuePayload, duplicate *models.Issue) {
+	if !i.logDedupKeys || !i.logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+
+	fields := logrus.Fields{
+		"namespace": req.GetNamespace(),
+		"matched":   duplicate != nil,
+	}
+	if fingerprint := req.GetFingerprint(); fingerprint != "" {
+		fields["fingerprint"] = fingerprint
+	} else {
+		fields["issue_type"] = req.GetIssueType()
+		fields["resource_type"] = req.GetScope().GetResourceType()
+		fields["resource_name"] = req.GetScope().GetResourceName()
+		if i.dedup.ResourceNameStrip != nil {
+			fields["normalized_resource_name"] = i.normalizeResourceName(req.GetScope().GetResourceName())
+		}
+		fields["resource_namespace"] = resourceNamespace(req)
+		fields["across_namespaces"] = i.dedup.AcrossNamespaces
+		if i.dedup.Strategy == DedupByReason {
+			fields["reason_hash"] = reason.Hash(req.GetDescription())
+		}
+	}
+	if duplicate != nil {
+		fields["existing_issue_id"] = duplicate.ID
+	}
+	entry := i.logger.WithFields(fields)
+	if id := requestid.FromContext(tx.Statement.Context); id != "" {
+		entry = entry.WithField("request_id", id)
+	}
+	entry.Debug("Looked up duplicate issue")
+}
+
+// resourceNamespace returns the namespace of the resource an issue is scoped to,
+// defaulting to the namespace the issue is tracked in.
+func resourceNamespace(req dto.IssuePayload) string {
+	if namespace := req.GetScope().GetResourceNamespace(); namespace != "" {
+		return namespace
+	}
+	return req.GetNamespace()
+}
+
+// normalizeResourceName returns the resource name duplicates are matched on, stripped
+// of the parts matching DedupOptions.ResourceNameStrip. Names that would be stripped
+// entirely are kept as is.
+func (i *issueRepository) normalizeResourceName(name string) string {
+	if i.dedup.ResourceNameStrip == nil {
+		return name
+	}
+	if normalized := i.dedup.ResourceNameStrip.ReplaceAllString(name, ""); normalized != "" {
+		return normalized
+	}
+	return name
+}
+
+// openDedupKey returns the key of an issue in the unique index of open issues, nil when
+// it isn't enforced: the key duplicates are matched on, qualified by the namespace, hashed
+// to keep the index small. It's qualified even when duplicates are matched across
+// namespaces, as reports not authorized for the namespace of a duplicate get their own issue.
+func (i *issueRepository) openDedupKey(issue models.Issue) *string {
+	if !i.dedup.EnforceUnique {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(issue.Namespace + "\x00" + i.dedupKey(issue)))
+	key := hex.EncodeToString(sum[:])
+	return &key
+}
+
+// syncDedupKeyInTx stores the key of an issue in the unique index of open issues once
+// it changed, e.g. its scope or state, when the index is enforced.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the changed issue
+//
+// Returns:
+//   - error: ErrDuplicateOpenIssue if another open issue has the same key, database error or nil
+func (i *issueRepository) syncDedupKeyInTx(tx *gorm.DB, issueID string) error {
+	if !i.dedup.EnforceUnique {
+		return nil
+	}
+	var issue models.Issue
+	if err := tx.Preload("Scope").Where("id = ?", issueID).First(&issue).Error; err != nil {
+		return fmt.Errorf("failed to find issue: %w", err)
+	}
+	key := i.openDedupKey(issue)
+	if issue.DedupKey != nil && *issue.DedupKey == *key {
+		return nil
+	}
+	err := tx.Model(&models.Issue{}).Where("id = ?", issueID).UpdateColumn("dedup_key", key).Error
+	if isDedupKeyViolation(err) {
+		return ErrDuplicateOpenIssue
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update dedup key: %w", err)
+	}
+	return nil
+}
+
+// findConcurrentDuplicateInTx looks up the duplicate of a payload created by a concurrent
+// report, once the issue created from the payload was rejected by the unique index of
+// open issues.
+func (i *issueRepository) findConcurrentDuplicateInTx(tx *gorm.DB, req dto.IssuePayload) (*models.Issue, error) {
+	existingIssue, err := i.findDuplicateInTx(tx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing issue: %w", err)
+	}
+	if existingIssue == nil {
+		// Keyed with other dedup options, so not matched as a duplicate with the current ones
+		return nil, ErrDuplicateOpenIssue
+	}
+	i.logger.WithField("issue_id", existingIssue.ID).Info("Issue created concurrently, updating it instead")
+	return existingIssue, nil
+}
+
+// isDedupKeyViolation reports whether a database error is a violation of the unique
+// index of open issues
+func isDedupKeyViolation(err error) bool {
+	if err == nil || !strings.Contains(err.Error(), "dedup_key") {
+		return false
+	}
+	// 23505 is unique_violation on Postgres
+	return strings.Contains(err.Error(), "SQLSTATE 23505") || strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// dedupStates returns the issue states considered when looking for duplicates
+func (i *issueRepository) dedupStates() []models.IssueState {
+	states := slices.Clone(models.OpenStates)
+	if i.dedup.IncludeResolved {
+		states = append(states, models.IssueStateResolved)
+	}
+	return states
+}
+
 type IssueQueryFilters struct {
-	Namespace    string
-	Severity     *models.Severity
-	IssueType    *models.IssueType
-	State        *models.IssueState
-	ResourceType string
-	ResourceName string
-	Search       string
-	Limit        int
-	Offset       int
+	Namespace      string
+	Namespaces     []string // Issues from any of these namespaces
+	Severity       *models.Severity
+	IssueType      *models.IssueType
+	State          *models.IssueState
+	States         []models.IssueState // Issues in any of these states
+	ResolvedBy     *models.ResolutionSource
+	ResourceType   string
+	ResourceName   string
+	Environment    string
+	Search         string
+	LastSeenAfter  *time.Time
+	ChangedSince   *time.Time // Issues created, updated or resolved after this time
+	OverdueAt      *time.Time // Active issues whose deadline passed before this time
+	ExcludeSnoozed bool       // Leave out the issues snoozed until they recur
+	SortBy         string     // One of the keys of sortColumns, defaults to detectedAt
+	Limit          int        // No limit when 0
+	Offset         int
+
+	// Issues scoped to resources in any of these namespaces
+	ResourceNamespaces []string
+}
+
+// sortColumns maps the supported sort options to their columns
+var sortColumns = map[string]string{
+	"detectedAt": "detected_at",
+	"lastSeenAt": "last_seen_at",
+	"updatedAt":  "updated_at",
+}
+
+// IsValidSort reports whether issues can be sorted by the option passed
+func IsValidSort(sortBy string) bool {
+	_, ok := sortColumns[sortBy]
+	return ok
 }
 
 // FindAll finds any issues matching the query filters passed.
@@ -214,14 +597,153 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 	// Preload any associations
 	query := i.db.WithContext(ctx).Model(&models.Issue{}).
 		Preload("Scope").
-		Preload("Links").
+		Preload("Links", orderedLinks).
 		Preload("RelatedFrom.Target.Scope").
 		Preload("RelatedTo.Source.Scope")
+	query = applyIssueFilters(query, filters)
 
-	// Apply filters to the database query
+	// Get total count for pagination
+	if err := query.Count(&total).Error; err != nil {
+		i.logger.WithError(err).Error("Failed to count issues")
+		return nil, 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+
+	// Apply pagination and ordering, callers choose the page size
+	sortColumn, ok := sortColumns[filters.SortBy]
+	if !ok {
+		sortColumn = "detected_at"
+	}
+
+	query = query.Order(sortColumn + " DESC").Offset(filters.Offset)
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	if err := query.Find(&issues).Error; err != nil {
+		i.logger.WithError(err).Error("Failed to find issues")
+		return nil, 0, fmt.Errorf("failed to find issues: %w", err)
+	}
+
+	return issues, total, nil
+}
+
+// resourceGroup is a group of issues scoped to the same resource, as counted by GroupByResource
+type resourceGroup struct {
+	ResourceType string
+	ResourceName string
+	Namespace    string
+	IssueCount   int
+	ActiveCount  int
+	MaxRank      int
+}
+
+// groupedScopesJoin joins the scopes issues are grouped by. It's aliased so it doesn't
+// clash with the join of the scope filters.
+const groupedScopesJoin = "JOIN issue_scopes AS grouped_scopes ON issues.scope_id = grouped_scopes.id"
+
+// GroupByResource groups the issues matching the query filters by the resource they're
+// scoped to. Groups are counted in the database and ordered by their most severe issue,
+// then by their number of active issues, so only the groups returned are loaded.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: IssueQueryFilters used for filtering, pagination aside
+//   - maxGroups: The maximum number of groups returned, no limit when 0
+//   - maxIssues: The maximum number of issues loaded per group, the most recently detected
+//
+// Returns:
+//   - []dto.IssueGroup: The groups found, counting all of their issues
+//   - error: Database error or nil
+func (i *issueRepository) GroupByResource(ctx context.Context, filters IssueQueryFilters, maxGroups, maxIssues int) ([]dto.IssueGroup, error) {
+	filters.Limit = 0
+	filters.Offset = 0
+
+	rankSQL, rankArgs := severityRankSQL()
+	var rows []resourceGroup
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters).
+		Joins(groupedScopesJoin).
+		Select("grouped_scopes.resource_type, grouped_scopes.resource_name, issues.namespace, "+
+			"COUNT(*) AS issue_count, "+
+			"SUM(CASE WHEN state IN ? THEN 1 ELSE 0 END) AS active_count, "+
+			"MAX("+rankSQL+") AS max_rank", append([]any{models.OpenStates}, rankArgs...)...).
+		Group("grouped_scopes.resource_type, grouped_scopes.resource_name, issues.namespace").
+		Order("max_rank DESC, active_count DESC, grouped_scopes.resource_type, grouped_scopes.resource_name, issues.namespace")
+	if maxGroups > 0 {
+		query = query.Limit(maxGroups)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		i.logger.WithError(err).Error("Failed to group issues")
+		return nil, fmt.Errorf("failed to group issues: %w", err)
+	}
+
+	groups := make([]dto.IssueGroup, 0, len(rows))
+	for _, row := range rows {
+		group := dto.IssueGroup{
+			Resource: dto.GroupedResource{
+				Type:      row.ResourceType,
+				Name:      row.ResourceName,
+				Namespace: row.Namespace,
+			},
+			Issues:      []models.Issue{},
+			IssueCount:  row.IssueCount,
+			ActiveCount: row.ActiveCount,
+		}
+		if row.MaxRank > 0 && row.MaxRank <= len(models.Severities) {
+			group.MaxSeverity = models.Severities[row.MaxRank-1]
+		}
+
+		issuesQuery := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}).
+			Preload("Scope").
+			Preload("Links", orderedLinks).
+			Preload("RelatedFrom.Target.Scope").
+			Preload("RelatedTo.Source.Scope"), filters).
+			Joins(groupedScopesJoin).
+			Where("grouped_scopes.resource_type = ? AND grouped_scopes.resource_name = ? AND issues.namespace = ?",
+				row.ResourceType, row.ResourceName, row.Namespace).
+			Order("detected_at DESC")
+		if maxIssues > 0 {
+			issuesQuery = issuesQuery.Limit(maxIssues)
+		}
+		if err := issuesQuery.Find(&group.Issues).Error; err != nil {
+			i.logger.WithError(err).Error("Failed to find grouped issues")
+			return nil, fmt.Errorf("failed to find grouped issues: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// severityRankSQL returns an SQL expression of the rank of the severity of an issue,
+// matching models.Severity.Rank, along with its arguments
+func severityRankSQL() (string, []any) {
+	var b strings.Builder
+	args := make([]any, 0, len(models.Severities))
+	b.WriteString("CASE severity")
+	for _, severity := range models.Severities {
+		// Ranks are inlined, so the database types the expression as an integer
+		fmt.Fprintf(&b, " WHEN ? THEN %d", severity.Rank())
+		args = append(args, severity)
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String(), args
+}
+
+// applyIssueFilters applies the query filters to a query on issues, pagination aside.
+//
+// Parameters:
+//   - query: The query on issues
+//   - filters: IssueQueryFilters used for filtering
+//
+// Returns:
+//   - *gorm.DB: The filtered query
+func applyIssueFilters(query *gorm.DB, filters IssueQueryFilters) *gorm.DB {
 	if filters.Namespace != "" {
 		query = query.Where("namespace = ?", filters.Namespace)
 	}
+	if len(filters.Namespaces) > 0 {
+		query = query.Where("namespace IN ?", filters.Namespaces)
+	}
 	if filters.Severity != nil {
 		query = query.Where("severity = ?", *filters.Severity)
 	}
@@ -231,8 +753,17 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 	if filters.State != nil {
 		query = query.Where("state = ?", *filters.State)
 	}
+	if len(filters.States) > 0 {
+		query = query.Where("state IN ?", filters.States)
+	}
+	if filters.ResolvedBy != nil {
+		query = query.Where("resolved_by = ?", *filters.ResolvedBy)
+	}
+	if filters.Environment != "" {
+		query = query.Where("environment = ?", filters.Environment)
+	}
 	// Join issue_scopes once if any scope-related filter is present, then stack WHEREs
-	if filters.ResourceType != "" || filters.ResourceName != "" {
+	if filters.ResourceType != "" || filters.ResourceName != "" || len(filters.ResourceNamespaces) > 0 {
 		query = query.Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id")
 		if filters.ResourceType != "" {
 			query = query.Where("issue_scopes.resource_type = ?", filters.ResourceType)
@@ -240,6 +771,9 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 		if filters.ResourceName != "" {
 			query = query.Where("issue_scopes.resource_name = ?", filters.ResourceName)
 		}
+		if len(filters.ResourceNamespaces) > 0 {
+			query = query.Where("issue_scopes.resource_namespace IN ?", filters.ResourceNamespaces)
+		}
 	}
 	if filters.Search != "" {
 		searchPattern := "%" + filters.Search + "%"
@@ -247,28 +781,47 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 		// Use LOWER to prevent any case sensitivity issues
 		query = query.Where("LOWER(title) LIKE LOWER(?) OR LOWER(description) LIKE LOWER(?)", searchPattern, searchPattern)
 	}
-
-	// Get total count for pagination
-	if err := query.Count(&total).Error; err != nil {
-		i.logger.WithError(err).Error("Failed to count issues")
-		return nil, 0, fmt.Errorf("failed to count issues: %w", err)
+	if filters.LastSeenAfter != nil {
+		query = query.Where("last_seen_at > ?", *filters.LastSeenAfter)
 	}
-
-	// Apply pagination and ordering
-	if filters.Limit == 0 {
-		filters.Limit = 50
+	if filters.ChangedSince != nil {
+		query = query.Where("issues.updated_at > ?", *filters.ChangedSince)
+	}
+	if filters.OverdueAt != nil {
+		query = query.Where("state IN ? AND due_at < ?", models.OpenStates, *filters.OverdueAt)
 	}
+	if filters.ExcludeSnoozed {
+		query = query.Where("snoozed_until_recurrence = ?", false)
+	}
+	return query
+}
 
-	if err := query.Order("detected_at DESC").
-		Offset(filters.Offset).
-		Limit(filters.Limit).
-		Find(&issues).
-		Error; err != nil {
-		i.logger.WithError(err).Error("Failed to find issues")
-		return nil, 0, fmt.Errorf("failed to find issues: %w", err)
+// FindDeletedSince finds the tombstones of the issues deleted after a time.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: Only the namespace filters are applied
+//   - since: Only issues deleted after this time are returned
+//
+// Returns:
+//   - []models.DeletedIssue: The tombstones of the deleted issues, oldest first
+//   - error: Database error or nil
+func (i *issueRepository) FindDeletedSince(ctx context.Context, filters IssueQueryFilters, since time.Time) ([]models.DeletedIssue, error) {
+	var deleted []models.DeletedIssue
+
+	query := i.db.WithContext(ctx).Where("deleted_at > ?", since)
+	if filters.Namespace != "" {
+		query = query.Where("namespace = ?", filters.Namespace)
+	}
+	if len(filters.Namespaces) > 0 {
+		query = query.Where("namespace IN ?", filters.Namespaces)
 	}
 
-	return issues, total, nil
+	if err := query.Order("deleted_at ASC").Find(&deleted).Error; err != nil {
+		i.logger.WithError(err).Error("Failed to find deleted issues")
+		return nil, fmt.Errorf("failed to find deleted issues: %w", err)
+	}
+	return deleted, nil
 }
 
 // FindByID finds an issue using its ID.
@@ -281,15 +834,61 @@ func (i *issueRepository) FindAll(ctx context.Context, filters IssueQueryFilters
 //   - *models.Issue: The issue if found, nil if not
 //   - error: Database error or nil
 func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issue, error) {
+	return i.findByID(i.db.WithContext(ctx), id)
+}
+
+// FindByIDs finds the issues with the given IDs in a single query, along with their
+// associations. IDs of issues that don't exist are skipped.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - ids: The IDs of the issues to be found
+//
+// Returns:
+//   - []models.Issue: The issues found, in no particular order
+//   - error: Database error or nil
+func (i *issueRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Issue, error) {
+	issues := []models.Issue{}
+	if len(ids) == 0 {
+		return issues, nil
+	}
+
+	err := i.db.WithContext(ctx).
+		Preload("Scope").
+		Preload("Links", orderedLinks).
+		Preload("RelatedFrom.Target.Scope").
+		Preload("RelatedTo.Source.Scope").
+		Preload("Notes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id IN ?", ids).
+		Find(&issues).Error
+	if err != nil {
+		i.logger.WithError(err).WithField("count", len(ids)).Error("Failed to find issues by IDs")
+		return nil, fmt.Errorf("failed to find issues: %w", err)
+	}
+	return issues, nil
+}
+
+// reloadByID finds an issue right after writing it. It's read from the primary database,
+// since a read replica may not have received the write yet.
+func (i *issueRepository) reloadByID(ctx context.Context, id string) (*models.Issue, error) {
+	return i.findByID(i.db.WithContext(ctx).Clauses(dbresolver.Write), id)
+}
+
+// findByID finds an issue using its ID, along with its associations, nil if it doesn't exist
+func (i *issueRepository) findByID(db *gorm.DB, id string) (*models.Issue, error) {
 	var issue models.Issue
 
 	// Find issue, load associations
-	err := i.db.
-		WithContext(ctx).
+	err := db.
 		Preload("Scope").
-		Preload("Links").
+		Preload("Links", orderedLinks).
 		Preload("RelatedFrom.Target.Scope").
 		Preload("RelatedTo.Source.Scope").
+		Preload("Notes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
 		First(&issue, "id = ?", id).Error
 
 	if err != nil {
@@ -303,6 +902,34 @@ func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issu
 	return &issue, nil
 }
 
+// FindStatusByID retrieves the state and severity of an issue, without loading
+// any association, nil if it doesn't exist.
+func (i *issueRepository) FindStatusByID(ctx context.Context, id string) (*dto.IssueStatus, error) {
+	var status dto.IssueStatus
+	err := i.db.WithContext(ctx).
+		Model(&models.Issue{}).
+		Select("issues.id, issues.state, issues.severity, issues.updated_at, issues.resolved_at, "+
+			"issues.namespace, issue_scopes.resource_namespace").
+		Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
+		Where("issues.id = ?", id).
+		Take(&status).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		i.logger.WithError(err).WithField("issue_id", id).Error("failed to find issue status by ID")
+		return nil, fmt.Errorf("failed to find issue status: %w", err)
+	}
+
+	// Timestamps of the issue model are normalized by its hooks, these ones aren't
+	status.UpdatedAt = status.UpdatedAt.UTC()
+	if status.ResolvedAt != nil {
+		resolvedAt := status.ResolvedAt.UTC()
+		status.ResolvedAt = &resolvedAt
+	}
+	return &status, nil
+}
+
 // Create creates an Issue record and automatically updates an existing duplicate.
 // if one is found instead of creating a new issue.
 //
@@ -316,8 +943,9 @@ func (i *issueRepository) FindByID(ctx context.Context, id string) (*models.Issu
 //
 // Returns:
 //   - *models.Issue: The created issue
+//   - bool: Whether the issue was created, rather than a duplicate updated
 //   - error: Database error or nil
-func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, error) {
+func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*models.Issue, bool, error) {
 	var issue *models.Issue
 	// Check if the issue is being updated.
 	updatedIssue := false
@@ -328,49 +956,59 @@ func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*mo
 			return fmt.Errorf("failed to check for duplicates: %w", err)
 		}
 
-		if existingIssue != nil {
-			updatedIssue = true
-			// Update existing issue instead of creating a new one
-			updateReq := dto.UpdateIssueRequest{
-				Title:       req.GetTitle(),
-				Description: req.GetDescription(),
-				Severity:    req.GetSeverity(),
-				IssueType:   req.GetIssueType(),
-				Scope:       req.GetScope().AsOptional(),
-				Namespace:   req.GetNamespace(),
-				State:       req.GetState(),
+		if existingIssue == nil {
+			newIssue, err := i.createNewIssueInTx(tx, req)
+			if !errors.Is(err, errConcurrentDuplicate) {
+				issue = newIssue
+				return err
+			}
+			// Created by a concurrent report since it was looked up, it's updated instead
+			if existingIssue, err = i.findConcurrentDuplicateInTx(tx, req); err != nil {
+				return err
 			}
-			issue = existingIssue
-			return i.updateIssueInTx(tx, existingIssue, updateReq)
 		}
 
-		newIssue, err := i.createNewIssueInTx(tx, req)
-		if err != nil {
+		updatedIssue = true
+		// Update existing issue instead of creating a new one
+		updateReq := dto.UpdateIssueRequest{
+			Title:        req.GetTitle(),
+			Description:  req.GetDescription(),
+			Severity:     req.GetSeverity(),
+			IssueType:    req.GetIssueType(),
+			Scope:        req.GetScope().AsOptional(),
+			Namespace:    req.GetNamespace(),
+			State:        req.GetState(),
+			Environment:  req.GetEnvironment(),
+			CustomFields: req.GetCustomFields(),
+		}
+		issue = existingIssue
+		if err := i.updateIssueInTx(tx, existingIssue, updateReq, true); err != nil {
 			return err
 		}
-
-		issue = newIssue
-		return nil
+		return i.markSeenInTx(tx, existingIssue.ID, req.GetDescription())
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if issue == nil {
 		i.logger.WithField("request", req).Error("Failed to create an issue: no issue returned")
-		return nil, errors.New("issue creation failed: no issue returned")
+		return nil, false, errors.New("issue creation failed: no issue returned")
 	}
 
 	if updatedIssue {
 		i.logger.WithField("issue_id", issue.ID).Info("Existing issue has been updated")
-		// Reload with associations
-		return i.FindByID(ctx, issue.ID)
+	} else {
+		i.logger.WithField("issue_id", issue.ID).Info("Created new issue")
 	}
 
-	i.logger.WithField("issue_id", issue.ID).Info("Created new issue")
 	// Reload with associations
-	return i.FindByID(ctx, issue.ID)
+	issue, err = i.reloadByID(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	return issue, !updatedIssue, nil
 }
 
 // createNewIssueInTx creates an issue within a database transaction.
@@ -379,21 +1017,19 @@ func (i *issueRepository) Create(ctx context.Context, req dto.IssuePayload) (*mo
 //   - tx: The database transaction to execute within
 //   - req: The issue payload for creating the issue
 //
+// When the unique index of open issues is enforced, an issue rejected by it is rolled
+// back, leaving the transaction usable, and errConcurrentDuplicate is returned.
+//
 // Returns:
 //   - *models.Issue: The created issue, nil if not created
-//   - error: Database error or nil
+//   - error: errConcurrentDuplicate, database error or nil
 func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload) (*models.Issue, error) {
-	now := time.Now()
+	now := i.now()
 	state := req.GetState()
 	if state == "" {
 		state = models.IssueStateActive
 	}
 
-	resourceNamespace := req.GetScope().GetResourceNamespace()
-	if resourceNamespace == "" {
-		resourceNamespace = req.GetNamespace()
-	}
-
 	newIssue := &models.Issue{
 		Title:       req.GetTitle(),
 		Description: req.GetDescription(),
@@ -401,29 +1037,126 @@ func (i *issueRepository) createNewIssueInTx(tx *gorm.DB, req dto.IssuePayload)
 		IssueType:   req.GetIssueType(),
 		State:       state,
 		DetectedAt:  now,
+		LastSeenAt:  now,
+		DueAt:       i.dueAt(req, now),
+		CreatedAt:   now,
+		UpdatedAt:   now,
 		Namespace:   req.GetNamespace(),
+		CommitContext: models.CommitContext{
+			CommitSHA: req.GetCommit().CommitSHA,
+			RepoURL:   req.GetCommit().RepoURL,
+			Branch:    req.GetCommit().Branch,
+		},
+		Fingerprint:  req.GetFingerprint(),
+		ReasonHash:   reason.Hash(req.GetDescription()),
+		Environment:  req.GetEnvironment(),
+		CustomFields: req.GetCustomFields(),
 		Scope: models.IssueScope{
 			ResourceType:      req.GetScope().GetResourceType(),
 			ResourceName:      req.GetScope().GetResourceName(),
-			ResourceNamespace: resourceNamespace,
+			ResourceNamespace: resourceNamespace(req),
+
+			NormalizedResourceName: i.normalizeResourceName(req.GetScope().GetResourceName()),
 		},
 	}
 
+	if state == models.IssueStateActive {
+		if err := i.checkNamespaceIssueLimitInTx(tx, newIssue.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert links
+	if dto.CountPrimaryLinks(req.GetLinks()) > 1 {
+		return nil, ErrMultiplePrimaryLinks
+	}
 	for _, linkReq := range req.GetLinks() {
 		newIssue.Links = append(newIssue.Links, models.Link{
-			Title: linkReq.Title,
-			URL:   linkReq.URL,
+			Title:   linkReq.Title,
+			URL:     linkReq.URL,
+			Order:   linkReq.Order,
+			Primary: linkReq.Primary,
 		})
 	}
 
+	newIssue.DedupKey = i.openDedupKey(*newIssue)
+	if newIssue.DedupKey != nil {
+		if err := tx.SavePoint(createIssueSavePoint).Error; err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+	}
 	if err := tx.Create(&newIssue).Error; err != nil {
+		if isDedupKeyViolation(err) {
+			if err := tx.RollbackTo(createIssueSavePoint).Error; err != nil {
+				return nil, fmt.Errorf("failed to roll back duplicate issue: %w", err)
+			}
+			return nil, errConcurrentDuplicate
+		}
 		return nil, fmt.Errorf("failed to create issue: %w", err)
 	}
 
+	if err := i.autoRelateInTx(tx, newIssue); err != nil {
+		return nil, err
+	}
+
 	return newIssue, nil
 }
 
+// dueAt returns the deadline of a new issue: the one requested, or else the deadline
+// of its severity after it's detected. Returns nil if its severity has no deadline.
+func (i *issueRepository) dueAt(req dto.IssuePayload, detectedAt time.Time) *time.Time {
+	if dueAt := req.GetDueAt(); dueAt != nil {
+		utc := dueAt.UTC()
+		return &utc
+	}
+	deadline, ok := i.resolutionDeadlines[req.GetSeverity()]
+	if !ok {
+		return nil
+	}
+	dueAt := detectedAt.Add(deadline)
+	return &dueAt
+}
+
+// autoRelateInTx relates a new issue to the active issues scoped to the same resource,
+// when enabled. Issues that already reached their relationship limit are skipped.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issue: The issue just created, along with its scope
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) autoRelateInTx(tx *gorm.DB, issue *models.Issue) error {
+	if i.maxAutoRelations <= 0 || issue.State != models.IssueStateActive {
+		return nil
+	}
+
+	var relatedIDs []string
+	err := tx.Model(&models.Issue{}).
+		Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
+		Where("issues.id <> ? AND issues.state = ?", issue.ID, models.IssueStateActive).
+		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ? AND issue_scopes.resource_namespace = ?",
+			issue.Scope.ResourceType, issue.Scope.ResourceName, issue.Scope.ResourceNamespace).
+		Order("issues.last_seen_at DESC").
+		Limit(i.maxAutoRelations).
+		Pluck("issues.id", &relatedIDs).Error
+	if err != nil {
+		return fmt.Errorf("failed to find issues sharing the resource: %w", err)
+	}
+
+	for _, relatedID := range relatedIDs {
+		_, err := i.ensureRelationshipInTx(tx, issue.ID, relatedID, models.RelationshipRelatesTo)
+		if errors.Is(err, ErrRelationshipLimitExceeded) {
+			i.logger.WithField("issue_id", relatedID).Debug("Skipped relating issue sharing the resource: relationship limit reached")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Update performs an update operation on an existing issue record.
 //
 // Parameters:
@@ -445,7 +1178,7 @@ func (i *issueRepository) Update(ctx context.Context, id string, req dto.IssuePa
 	}
 
 	err = i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return i.updateIssueInTx(tx, existingIssue, req)
+		return i.updateIssueInTx(tx, existingIssue, req, false)
 	})
 
 	if err != nil {
@@ -455,19 +1188,104 @@ func (i *issueRepository) Update(ctx context.Context, id string, req dto.IssuePa
 
 	i.logger.WithField("issue_id", id).Info("Updated issue")
 
-	return i.FindByID(ctx, id)
+	return i.reloadByID(ctx, id)
+}
+
+// IssuePatch holds the fields of an issue changed by a patch, nil fields are left unchanged.
+//
+// Unlike update payloads, empty values are applied, so a field can be cleared.
+type IssuePatch struct {
+	Title       *string
+	Description *string
+	Severity    *models.Severity
+	State       *models.IssueState
+	// Hides the issue from the default issue list until its next occurrence
+	SnoozedUntilRecurrence *bool
+}
+
+// Patch applies a patch to an existing issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: ID of the issue
+//   - patch: The fields to change
+//
+// Returns:
+//   - *models.Issue: The patched issue
+//   - error: ErrIssueNotFound, database error or nil
+func (i *issueRepository) Patch(ctx context.Context, id string, patch IssuePatch) (*models.Issue, error) {
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingIssue models.Issue
+		if err := tx.Where("id = ?", id).First(&existingIssue).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIssueNotFound
+			}
+			return fmt.Errorf("failed to find issue: %w", err)
+		}
+
+		updates := map[string]any{"updated_at": i.now()}
+		if patch.Title != nil {
+			updates["title"] = *patch.Title
+		}
+		if patch.Description != nil {
+			updates["description"] = *patch.Description
+			updates["reason_hash"] = reason.Hash(*patch.Description)
+		}
+		if patch.Severity != nil {
+			updates["severity"] = *patch.Severity
+		}
+		if patch.State != nil && *patch.State != existingIssue.State {
+			updates["state"] = *patch.State
+			if *patch.State == models.IssueStateResolved {
+				updates["resolved_at"] = i.now()
+				updates["resolved_by"] = models.ResolutionSourceManual
+			} else {
+				updates["resolved_at"] = nil
+				updates["resolved_by"] = nil
+			}
+		}
+
+		if patch.SnoozedUntilRecurrence != nil {
+			updates["snoozed_until_recurrence"] = *patch.SnoozedUntilRecurrence
+		}
+		if !i.dedup.EnforceUnique {
+			updates["dedup_key"] = nil
+		}
+
+		if err := tx.Model(&existingIssue).Updates(updates).Error; err != nil {
+			if isDedupKeyViolation(err) {
+				return ErrDuplicateOpenIssue
+			}
+			return fmt.Errorf("failed to patch issue: %w", err)
+		}
+		return i.syncDedupKeyInTx(tx, id)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrIssueNotFound) && !errors.Is(err, ErrDuplicateOpenIssue) {
+			i.logger.WithError(err).WithField("issue_id", id).Error("Failed to patch issue")
+		}
+		return nil, err
+	}
+
+	i.logger.WithField("issue_id", id).Info("Patched issue")
+
+	return i.reloadByID(ctx, id)
 }
 
 // updateIssueInTx updates an issue within a database transaction.
 //
+// A recurrence of an issue resolved by a concurrent success webhook reopens it, see
+// reopensConcurrentResolve, so the final state doesn't depend on which one came first.
+//
 // Parameters:
 //   - tx: The database transaction to execute within
 //   - existingIssue: The issue that will be updated
 //   - req: The update payload
+//   - recurrence: Whether the payload is a recurrence of the issue, rather than an edit
 //
 // Returns:
 //   - error: Database error or nil
-func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Issue, req dto.IssuePayload) error {
+func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Issue, req dto.IssuePayload, recurrence bool) error {
 	// Prepare updates
 	updates := make(map[string]any)
 
@@ -476,6 +1294,7 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 	}
 	if desc := req.GetDescription(); desc != "" {
 		updates["description"] = desc
+		updates["reason_hash"] = reason.Hash(desc)
 	}
 	if severity := req.GetSeverity(); severity != "" {
 		updates["severity"] = severity
@@ -483,24 +1302,78 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 	if issueType := req.GetIssueType(); issueType != "" {
 		updates["issue_type"] = issueType
 	}
-	if namespace := req.GetNamespace(); namespace != "" {
+	// Recurrences reported from another namespace, when duplicates are matched across
+	// namespaces, stay tracked in the namespace that first reported them
+	if namespace := req.GetNamespace(); namespace != "" && !recurrence {
 		updates["namespace"] = namespace
 	}
+	if environment := req.GetEnvironment(); environment != "" {
+		updates["environment"] = environment
+	}
 
 	// Always update the timestamp
-	updates["updated_at"] = time.Now()
+	updates["updated_at"] = i.now()
 
 	if req.GetState() != "" {
 		updates["state"] = req.GetState()
 		if req.GetState() == models.IssueStateResolved && existingIssue.State != models.IssueStateResolved {
-			updates["resolved_at"] = time.Now()
+			updates["resolved_at"] = i.now()
 		} else if ra := req.GetResolvedAt(); !ra.IsZero() {
-			updates["resolved_at"] = ra
+			updates["resolved_at"] = ra.UTC()
+		}
+		// Issues are resolved through the API by users, reopened ones are no longer resolved
+		if req.GetState() == models.IssueStateResolved {
+			if existingIssue.State != models.IssueStateResolved {
+				updates["resolved_by"] = models.ResolutionSourceManual
+			}
+		} else {
+			updates["resolved_by"] = nil
+		}
+	}
+	if recurrence && i.reopensConcurrentResolve(existingIssue, req) {
+		updates["state"] = models.IssueStateActive
+		updates["resolved_at"] = nil
+		updates["resolved_by"] = nil
+		i.logger.WithField("issue_id", existingIssue.ID).Info("Reopened issue resolved by a concurrent success")
+	}
+
+	if dueAt := req.GetDueAt(); dueAt != nil {
+		updates["due_at"] = dueAt.UTC()
+	} else if req.GetState() == models.IssueStateActive && existingIssue.State == models.IssueStateResolved {
+		// A reopened issue gets a new deadline, the previous one was for its previous occurrence
+		severity := cmp.Or(req.GetSeverity(), existingIssue.Severity)
+		if deadline, ok := i.resolutionDeadlines[severity]; ok {
+			updates["due_at"] = i.now().Add(deadline)
+		}
+	}
+
+	// A recurrence on another commit replaces the whole commit context
+	if commit := req.GetCommit(); commit.CommitSHA != "" {
+		updates["commit_sha"] = commit.CommitSHA
+		updates["repo_url"] = commit.RepoURL
+		updates["branch"] = commit.Branch
+	}
+
+	// Custom fields are replaced as a whole. Map updates bypass the serializer of the
+	// column, they're encoded like it.
+	if customFields := req.GetCustomFields(); customFields != nil {
+		encoded, err := json.Marshal(customFields)
+		if err != nil {
+			return fmt.Errorf("failed to encode custom fields: %w", err)
 		}
+		updates["custom_fields"] = string(encoded)
+	}
+
+	if !i.dedup.EnforceUnique {
+		// Keys are only kept up to date while enforced, stale ones could reject issues
+		updates["dedup_key"] = nil
 	}
 
 	// Update the issue
 	if err := tx.Model(existingIssue).Updates(updates).Error; err != nil {
+		if isDedupKeyViolation(err) {
+			return ErrDuplicateOpenIssue
+		}
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
 
@@ -524,9 +1397,115 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 		i.logger.WithField("issue_id", existingIssue.ID).Info("Updated scope")
 	}
 
+	return i.syncDedupKeyInTx(tx, existingIssue.ID)
+}
+
+// markSeenInTx records that the condition behind an issue was observed again.
+//
+// This is only meant for the duplicate detection path, manual edits must not
+// change when an issue was last seen. The occurrence is added to the timeline
+// of the issue, keeping only the most recent ones.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue seen
+//   - detail: Details about the occurrence
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) markSeenInTx(tx *gorm.DB, issueID, detail string) error {
+	if err := i.countOccurrenceInTx(tx, issueID); err != nil {
+		return err
+	}
+
+	if i.dedup.MaxOccurrences <= 0 {
+		return nil
+	}
+
+	occurrence := models.Occurrence{
+		IssueID:    issueID,
+		OccurredAt: i.now(),
+		Detail:     detail,
+	}
+	if err := tx.Create(&occurrence).Error; err != nil {
+		return fmt.Errorf("failed to record occurrence: %w", err)
+	}
+	return i.pruneOccurrencesInTx(tx, issueID)
+}
+
+// pruneOccurrencesInTx deletes the oldest occurrences of an issue over DedupOptions.MaxOccurrences.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) pruneOccurrencesInTx(tx *gorm.DB, issueID string) error {
+	recent := tx.Model(&models.Occurrence{}).
+		Select("id").
+		Where("issue_id = ?", issueID).
+		Order("occurred_at DESC").
+		Limit(i.dedup.MaxOccurrences)
+	err := tx.Where("issue_id = ? AND id NOT IN (?)", issueID, recent).
+		Delete(&models.Occurrence{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to prune occurrences: %w", err)
+	}
+	return nil
+}
+
+// countOccurrenceInTx counts an occurrence of an issue and updates when it was last
+// seen, without adding it to its timeline. It's all coalesced duplicates cost.
+// The count is incremented in SQL rather than read and written back, so concurrent
+// duplicates don't lose increments. An issue snoozed until it recurs is resurfaced, and
+// marked updated so clients polling for changes see it again.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue seen
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) countOccurrenceInTx(tx *gorm.DB, issueID string) error {
+	now := i.now()
+	err := tx.Model(&models.Issue{}).
+		Where("id = ?", issueID).
+		UpdateColumns(map[string]any{
+			"last_seen_at":     now,
+			"occurrence_count": gorm.Expr("occurrence_count + 1"),
+			// Only resurfacing changes the issue, counting doesn't
+			"updated_at":               gorm.Expr("CASE WHEN snoozed_until_recurrence THEN ? ELSE updated_at END", now),
+			"snoozed_until_recurrence": false,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to count occurrence: %w", err)
+	}
 	return nil
 }
 
+// FindOccurrences finds the recent occurrences of an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - []models.Occurrence: The occurrences, newest first
+//   - error: Database error or nil
+func (i *issueRepository) FindOccurrences(ctx context.Context, issueID string) ([]models.Occurrence, error) {
+	occurrences := []models.Occurrence{}
+	err := i.db.WithContext(ctx).
+		Where("issue_id = ?", issueID).
+		Order("occurred_at DESC").
+		Find(&occurrences).Error
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_id", issueID).Error("Failed to find occurrences")
+		return nil, fmt.Errorf("failed to find occurrences: %w", err)
+	}
+	return occurrences, nil
+}
+
 // replaceIssueLinks updates the links for an issue within a database transaction.
 //
 // Parameters:
@@ -537,6 +1516,11 @@ func (i *issueRepository) updateIssueInTx(tx *gorm.DB, existingIssue *models.Iss
 // Returns:
 //   - error: Database error or nil
 func (i *issueRepository) replaceIssueLinks(tx *gorm.DB, issueID string, links []dto.CreateLinkRequest) error {
+	// Only one link can be used for the one-click action
+	if dto.CountPrimaryLinks(links) > 1 {
+		return ErrMultiplePrimaryLinks
+	}
+
 	// Delete old links
 	if err := tx.Where("issue_id = ?", issueID).Delete(&models.Link{}).Error; err != nil {
 		return fmt.Errorf("failed to delete old links: %w", err)
@@ -547,6 +1531,8 @@ func (i *issueRepository) replaceIssueLinks(tx *gorm.DB, issueID string, links [
 		link := models.Link{
 			Title:   linkReq.Title,
 			URL:     linkReq.URL,
+			Order:   linkReq.Order,
+			Primary: linkReq.Primary,
 			IssueID: issueID,
 		}
 		if err := tx.Create(&link).Error; err != nil {
@@ -556,7 +1542,8 @@ func (i *issueRepository) replaceIssueLinks(tx *gorm.DB, issueID string, links [
 	return nil
 }
 
-// updateIssueScopeInTx updates the scope for an issue within a database transaction
+// updateIssueScopeInTx updates the scope for an issue within a database transaction,
+// along with the normalized resource name when the name changes
 //
 // Parameters:
 //   - tx: The database transaction to execute within
@@ -566,9 +1553,18 @@ func (i *issueRepository) replaceIssueLinks(tx *gorm.DB, issueID string, links [
 // Returns:
 //   - error: Database error or nil
 func (i *issueRepository) updateIssueScopeInTx(tx *gorm.DB, scopeID string, req dto.ScopeReqBodyOptional) error {
+	scope := models.IssueScope{
+		ResourceType:      req.ResourceType,
+		ResourceName:      req.ResourceName,
+		ResourceNamespace: req.ResourceNamespace,
+	}
+	if req.ResourceName != "" {
+		scope.NormalizedResourceName = i.normalizeResourceName(req.ResourceName)
+	}
+	// Zero fields aren't updated
 	err := tx.Model(&models.IssueScope{}).
 		Where("id = ?", scopeID).
-		Updates(req).Error
+		Updates(scope).Error
 	if err != nil {
 		return fmt.Errorf("failed to update issue scope")
 	}
@@ -595,27 +1591,7 @@ func (i *issueRepository) Delete(ctx context.Context, id string) error {
 
 	// Delete in transaction so we have control of the order
 	err = i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Delete related issue relationships first using issue id
-		if err := tx.Where("source_id = ? OR target_id = ?", id, id).Delete(&models.RelatedIssue{}).Error; err != nil {
-			return fmt.Errorf("failed to delete related issues: %w", err)
-		}
-
-		// Delete links by issue id
-		if err := tx.Where("issue_id = ?", id).Delete(&models.Link{}).Error; err != nil {
-			return fmt.Errorf("failed to delete links: %w", err)
-		}
-
-		// Delete the issue by id
-		if err := tx.Delete(&models.Issue{}, "id = ?", id).Error; err != nil {
-			return fmt.Errorf("failed to delete issue: %w", err)
-		}
-
-		// Delete the issue scope by scope id
-		if err := tx.Delete(&models.IssueScope{}, "id = ?", issue.ScopeID).Error; err != nil {
-			return fmt.Errorf("failed to delete issue scope: %w", err)
-		}
-
-		return nil
+		return i.deleteIssueInTx(tx, issue)
 	})
 
 	if err != nil {
@@ -627,8 +1603,130 @@ func (i *issueRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// deleteIssueInTx deletes an issue along with its relationships, links and scope
+// within a database transaction, leaving a tombstone for clients syncing changes.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issue: The issue to delete
+//
+// Returns:
+//   - error: Database error or nil
+func (i *issueRepository) deleteIssueInTx(tx *gorm.DB, issue *models.Issue) error {
+	// Delete related issue relationships first using issue id
+	if err := tx.Where("source_id = ? OR target_id = ?", issue.ID, issue.ID).Delete(&models.RelatedIssue{}).Error; err != nil {
+		return fmt.Errorf("failed to delete related issues: %w", err)
+	}
+
+	// Delete links by issue id
+	if err := tx.Where("issue_id = ?", issue.ID).Delete(&models.Link{}).Error; err != nil {
+		return fmt.Errorf("failed to delete links: %w", err)
+	}
+
+	// Delete notes by issue id
+	if err := tx.Where("issue_id = ?", issue.ID).Delete(&models.IssueNote{}).Error; err != nil {
+		return fmt.Errorf("failed to delete notes: %w", err)
+	}
+
+	// Delete occurrences by issue id
+	if err := tx.Where("issue_id = ?", issue.ID).Delete(&models.Occurrence{}).Error; err != nil {
+		return fmt.Errorf("failed to delete occurrences: %w", err)
+	}
+
+	// Delete attachments by issue id, the files are left to the lifecycle of the object storage
+	if err := tx.Where("issue_id = ?", issue.ID).Delete(&models.Attachment{}).Error; err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+
+	// Delete the issue by id
+	if err := tx.Delete(&models.Issue{}, "id = ?", issue.ID).Error; err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
+	}
+
+	// Delete the issue scope by scope id
+	if err := tx.Delete(&models.IssueScope{}, "id = ?", issue.ScopeID).Error; err != nil {
+		return fmt.Errorf("failed to delete issue scope: %w", err)
+	}
+
+	tombstone := models.DeletedIssue{IssueID: issue.ID, Namespace: issue.Namespace, DeletedAt: i.now()}
+	if err := tx.Create(&tombstone).Error; err != nil {
+		return fmt.Errorf("failed to record deleted issue: %w", err)
+	}
+
+	return nil
+}
+
+// bulkDeleteBatchSize is the number of issues deleted per transaction in BulkDelete
+const bulkDeleteBatchSize = 25
+
+// BulkDelete deletes many issues, in batched transactions.
+//
+// Each issue is deleted within its own savepoint, so an issue that can't be
+// deleted is reported in its result without aborting the rest of the batch.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: Only delete issues from this namespace, any namespace if empty
+//   - ids: IDs of the issues to delete
+//
+// Returns:
+//   - []dto.BulkIssueResult: The outcome for each ID, in the order received
+//   - error: Database error or nil
+func (i *issueRepository) BulkDelete(ctx context.Context, namespace string, ids []string) ([]dto.BulkIssueResult, error) {
+	results := make([]dto.BulkIssueResult, 0, len(ids))
+
+	for start := 0; start < len(ids); start += bulkDeleteBatchSize {
+		batch := ids[start:min(start+bulkDeleteBatchSize, len(ids))]
+		var batchResults []dto.BulkIssueResult
+
+		err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			batchResults = make([]dto.BulkIssueResult, 0, len(batch))
+			for _, id := range batch {
+				batchResults = append(batchResults, i.bulkDeleteOneInTx(tx, namespace, id))
+			}
+			return nil
+		})
+		if err != nil {
+			i.logger.WithError(err).Error("Failed to bulk delete issues")
+			return nil, fmt.Errorf("failed to bulk delete issues: %w", err)
+		}
+
+		results = append(results, batchResults...)
+	}
+
+	i.logger.WithField("count", len(ids)).Info("Bulk deleted issues")
+	return results, nil
+}
+
+// bulkDeleteOneInTx deletes a single issue of a bulk delete within a savepoint.
+func (i *issueRepository) bulkDeleteOneInTx(tx *gorm.DB, namespace, id string) dto.BulkIssueResult {
+	result := dto.BulkIssueResult{ID: id, Status: dto.BulkIssueDeleted}
+
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		var issue models.Issue
+		if err := tx.First(&issue, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("issue not found")
+			}
+			return fmt.Errorf("failed to find issue: %w", err)
+		}
+		if namespace != "" && issue.Namespace != namespace {
+			return errors.New("access denied to this namespace")
+		}
+		return i.deleteIssueInTx(tx, &issue)
+	})
+
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_id", id).Warn("Failed to delete issue in bulk")
+		result.Status = dto.BulkIssueError
+		result.Error = err.Error()
+	}
+	return result
+}
+
 // ResolveByScope will find an issue found using the specified scope and update
-// that issue's state as resolved.
+// that issue's state as resolved. It's called by success webhooks, so the issues
+// are recorded as resolved by ResolutionSourceSuccessWebhook.
 //
 // The issue is found using it's scope's:
 //   - resourceType: PipelineRun, Component, Application, etc.
@@ -645,15 +1743,98 @@ func (i *issueRepository) Delete(ctx context.Context, id string) error {
 //   - int64: The number of issues resolved in that scope
 //   - error: Database errors or nil
 func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
-	now := time.Now()
+	return i.ResolveByScopes(ctx, []string{resourceType}, resourceName, namespace)
+}
+
+// ResolveByScopes works like ResolveByScope, but matches any of the resource types passed.
+// This allows resolving issues for a resource that is tracked under several resource types
+// (e.g. "mintmaker-error" and "mintmaker-warning") in one call.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - resourceTypes: The types of resource to match
+//   - resourceName: The name of that resource
+//   - namespace: The namespace of that resource
+//
+// Returns:
+//   - int64: The number of issues resolved in those scopes
+//   - error: Database errors or nil
+func (i *issueRepository) ResolveByScopes(ctx context.Context, resourceTypes []string, resourceName, namespace string) (int64, error) {
+	fields := logrus.Fields{
+		"resource_types": resourceTypes,
+		"resource_name":  resourceName,
+		"namespace":      namespace,
+	}
+	return i.resolveScopedIssues(ctx, namespace, fields, func(query *gorm.DB) *gorm.DB {
+		return query.Where("issue_scopes.resource_type IN ? AND issue_scopes.resource_name = ?", resourceTypes, resourceName)
+	})
+}
+
+// ResolveByScopePrefix works like ResolveByScope, but also matches the resources named
+// after the prefix followed by a generated suffix, e.g. "frontend-build-abc123" for the
+// prefix "frontend-build". To avoid matching unrelated resources, the suffix must be a
+// single segment: "frontend-build-test-abc123" and "frontend-builder" don't match.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - resourceType: The type of resource
+//   - namePrefix: The name of the resource, without its generated suffix
+//   - namespace: The namespace of that resource
+//
+// Returns:
+//   - int64: The number of issues resolved in the matching scopes
+//   - error: Database errors or nil
+func (i *issueRepository) ResolveByScopePrefix(ctx context.Context, resourceType, namePrefix, namespace string) (int64, error) {
+	fields := logrus.Fields{
+		"resource_type": resourceType,
+		"name_prefix":   namePrefix,
+		"namespace":     namespace,
+	}
+	// The name starts with the prefix and a dash, compared exactly rather than with
+	// LIKE, which is case-insensitive on SQLite
+	withDash := namePrefix + "-"
+	length := utf8.RuneCountInString(withDash)
+	return i.resolveScopedIssues(ctx, namespace, fields, func(query *gorm.DB) *gorm.DB {
+		return query.
+			Where("issue_scopes.resource_type = ?", resourceType).
+			Where("issue_scopes.resource_name = ? OR "+
+				"(LENGTH(issue_scopes.resource_name) > ? AND SUBSTR(issue_scopes.resource_name, 1, ?) = ? "+
+				"AND issue_scopes.resource_name NOT LIKE ? ESCAPE '\\')",
+				namePrefix, length, length, withDash, escapeLike(withDash)+"%-%")
+	})
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, with backslash as the escape character
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
+// resolveScopedIssues resolves the open issues of a namespace whose scope matches the
+// filter passed. Success webhooks are the only ones resolving issues by scope, so the
+// issues are recorded as resolved by ResolutionSourceSuccessWebhook.
+//
+// Issues last seen within the resolve conflict window stay open: the failure is fresher
+// than the success, so it wins over the concurrent resolution. The window is checked
+// again by the update, so a failure committed in between still wins.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace of the issues
+//   - fields: Fields describing the scopes matched, for logging
+//   - scopeFilter: Filters the issues joined with their scope
+//
+// Returns:
+//   - int64: The number of issues resolved
+//   - error: Database errors or nil
+func (i *issueRepository) resolveScopedIssues(ctx context.Context, namespace string, fields logrus.Fields, scopeFilter func(*gorm.DB) *gorm.DB) (int64, error) {
+	now := i.now()
 
 	// Get the IDs of all issues meeting this criteria
 	var ids []string
 	query := i.db.WithContext(ctx).Model(&models.Issue{}).
 		Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
-		Where("issues.state = ? AND issues.namespace = ?", models.IssueStateActive, namespace).
-		Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ?", resourceType, resourceName).
-		Pluck("issues.id", &ids)
+		Where("issues.state IN ? AND issues.namespace = ?", models.OpenStates, namespace)
+	query = i.notSeenWithinConflictWindow(scopeFilter(query), "issues.", now).Pluck("issues.id", &ids)
 
 	// Check for error in query
 	if query.Error != nil {
@@ -662,22 +1843,20 @@ func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, reso
 
 	// Check if any issues were found
 	if len(ids) == 0 {
-		i.logger.WithFields(logrus.Fields{
-			"resource_type": resourceType,
-			"resource_name": resourceName,
-			"namespace":     namespace,
-		}).Info("No active issues found for scope")
+		i.logger.WithFields(fields).Info("No active issues found for scope")
 		return 0, nil
 	}
 
-	// Update issues by ID
-	result := i.db.
+	// Update issues by ID, unless they were resolved or seen again in the meantime
+	update := i.db.
 		WithContext(ctx).
 		Model(&models.Issue{}).
-		Where("id IN ?", ids).
+		Where("id IN ? AND state IN ?", ids, models.OpenStates)
+	result := i.notSeenWithinConflictWindow(update, "", now).
 		Updates(map[string]any{
 			"state":       models.IssueStateResolved,
 			"resolved_at": &now,
+			"resolved_by": models.ResolutionSourceSuccessWebhook,
 			"updated_at":  now,
 		})
 
@@ -687,17 +1866,399 @@ func (i *issueRepository) ResolveByScope(ctx context.Context, resourceType, reso
 	}
 
 	count := result.RowsAffected
-	i.logger.WithFields(logrus.Fields{
-		"resource_type": resourceType,
-		"resource_name": resourceName,
-		"namespace":     namespace,
-		"count":         count,
-	}).Info("Resolved issues by scope")
+	i.logger.WithFields(fields).WithField("count", count).Info("Resolved issues by scope")
 
 	return count, nil
 }
 
-// AddRelatedIssue creates a relationship between two issues by creating a RelatedIssue record.
+// notSeenWithinConflictWindow filters out the issues last seen within the resolve conflict
+// window before now, the columns of the issues being prefixed with prefix
+func (i *issueRepository) notSeenWithinConflictWindow(query *gorm.DB, prefix string, now time.Time) *gorm.DB {
+	if i.resolveConflictWindow <= 0 {
+		return query
+	}
+	return query.Where(prefix+"last_seen_at < ?", now.Add(-i.resolveConflictWindow))
+}
+
+// AcknowledgeByScope acknowledges the active issues of a namespace scoped to a resource,
+// e.g. during a known incident affecting it. Resolved and already acknowledged issues
+// are left untouched.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - resourceType: The type of resource
+//   - resourceName: The name of that resource
+//   - namespace: The namespace of the issues
+//   - note: Content of the note added to the acknowledged issues, none if empty
+//
+// Returns:
+//   - int64: The number of issues acknowledged
+//   - error: Database error or nil
+func (i *issueRepository) AcknowledgeByScope(ctx context.Context, resourceType, resourceName, namespace, note string) (int64, error) {
+	var acknowledged int64
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		err := tx.Model(&models.Issue{}).
+			Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
+			Where("issues.state = ? AND issues.namespace = ?", models.IssueStateActive, namespace).
+			Where("issue_scopes.resource_type = ? AND issue_scopes.resource_name = ?", resourceType, resourceName).
+			Pluck("issues.id", &ids).Error
+		if err != nil {
+			return fmt.Errorf("failed to query issues by scope: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		result := tx.Model(&models.Issue{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"state":      models.IssueStateAcknowledged,
+				"updated_at": i.now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to acknowledge issues: %w", result.Error)
+		}
+		acknowledged = result.RowsAffected
+
+		if note == "" {
+			return nil
+		}
+		notes := make([]models.IssueNote, 0, len(ids))
+		for _, id := range ids {
+			notes = append(notes, models.IssueNote{IssueID: id, Content: note})
+		}
+		if err := tx.Create(&notes).Error; err != nil {
+			return fmt.Errorf("failed to create notes: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to acknowledge issues by scope")
+		return 0, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"resource_type": resourceType,
+		"resource_name": resourceName,
+		"namespace":     namespace,
+		"count":         acknowledged,
+	}).Info("Acknowledged issues by scope")
+	return acknowledged, nil
+}
+
+// ResolveStale resolves the active issues of a type that haven't been reported since a time.
+// Every issue resolved this way gets a note explaining its resolution.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueType: The type of the issues to resolve
+//   - lastSeenBefore: Only issues last seen before this time are resolved
+//   - note: Content of the note added to the resolved issues
+//
+// Returns:
+//   - int64: The number of issues resolved
+//   - error: Database error or nil
+func (i *issueRepository) ResolveStale(ctx context.Context, issueType models.IssueType, lastSeenBefore time.Time, note string) (int64, error) {
+	var resolved int64
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		err := tx.Model(&models.Issue{}).
+			Where("state IN ? AND issue_type = ? AND last_seen_at < ?", models.OpenStates, issueType, lastSeenBefore).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return fmt.Errorf("failed to query stale issues: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		now := i.now()
+		result := tx.Model(&models.Issue{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"state":       models.IssueStateResolved,
+				"resolved_at": &now,
+				"resolved_by": models.ResolutionSourceAutoResolve,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to resolve stale issues: %w", result.Error)
+		}
+		resolved = result.RowsAffected
+
+		notes := make([]models.IssueNote, 0, len(ids))
+		for _, id := range ids {
+			notes = append(notes, models.IssueNote{IssueID: id, Content: note})
+		}
+		if err := tx.Create(&notes).Error; err != nil {
+			return fmt.Errorf("failed to create notes: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_type", issueType).Error("Failed to resolve stale issues")
+		return 0, err
+	}
+	return resolved, nil
+}
+
+// ResolveNamespace resolves all the open issues of a namespace, e.g. when it's decommissioned
+// along with its resources. Every issue resolved this way gets a note explaining its resolution.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - namespace: The namespace of the issues to resolve
+//   - note: Content of the note added to the resolved issues
+//
+// Returns:
+//   - int64: The number of issues resolved
+//   - error: Database error or nil
+func (i *issueRepository) ResolveNamespace(ctx context.Context, namespace, note string) (int64, error) {
+	var resolved int64
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		err := tx.Model(&models.Issue{}).
+			Where("state IN ? AND namespace = ?", models.OpenStates, namespace).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return fmt.Errorf("failed to query namespace issues: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		now := i.now()
+		result := tx.Model(&models.Issue{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"state":       models.IssueStateResolved,
+				"resolved_at": &now,
+				"resolved_by": models.ResolutionSourceDecommission,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to resolve namespace issues: %w", result.Error)
+		}
+		resolved = result.RowsAffected
+
+		notes := make([]models.IssueNote, 0, len(ids))
+		for _, id := range ids {
+			notes = append(notes, models.IssueNote{IssueID: id, Content: note})
+		}
+		if err := tx.Create(&notes).Error; err != nil {
+			return fmt.Errorf("failed to create notes: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).WithField("namespace", namespace).Error("Failed to resolve namespace issues")
+		return 0, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"namespace": namespace,
+		"count":     resolved,
+	}).Info("Resolved namespace issues")
+	return resolved, nil
+}
+
+// DeescalateQuiet lowers the severity of the active issues that haven't been reported since
+// a time by one level, down to the severity they were first reported with. Issues already
+// de-escalated since that time are skipped, so severities step down once per quiet window.
+// Every issue de-escalated this way gets a note recording the change.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - lastSeenBefore: Only issues last seen, and last de-escalated, before this time are de-escalated
+//   - note: Content of the note added to the de-escalated issues, followed by the change of severity
+//
+// Returns:
+//   - int64: The number of issues de-escalated
+//   - error: Database error or nil
+func (i *issueRepository) DeescalateQuiet(ctx context.Context, lastSeenBefore time.Time, note string) (int64, error) {
+	var deescalated int64
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var issues []models.Issue
+		err := tx.Model(&models.Issue{}).
+			Select("id", "severity", "base_severity").
+			Where("state IN ? AND last_seen_at < ? AND severity <> base_severity", models.OpenStates, lastSeenBefore).
+			Where("deescalated_at IS NULL OR deescalated_at < ?", lastSeenBefore).
+			Find(&issues).Error
+		if err != nil {
+			return fmt.Errorf("failed to query quiet issues: %w", err)
+		}
+
+		// Issues are de-escalated in batches of the same severity
+		idsBySeverity := make(map[models.Severity][]string)
+		for _, issue := range issues {
+			if issue.Severity.Rank() > issue.BaseSeverity.Rank() {
+				idsBySeverity[issue.Severity] = append(idsBySeverity[issue.Severity], issue.ID)
+			}
+		}
+
+		now := i.now()
+		var notes []models.IssueNote
+		for _, severity := range models.Severities {
+			ids := idsBySeverity[severity]
+			if len(ids) == 0 {
+				continue
+			}
+			result := tx.Model(&models.Issue{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{
+					"severity":       severity.Lower(),
+					"deescalated_at": &now,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("failed to de-escalate quiet issues: %w", result.Error)
+			}
+			deescalated += result.RowsAffected
+
+			content := fmt.Sprintf("%s, severity lowered from %s to %s", note, severity, severity.Lower())
+			for _, id := range ids {
+				notes = append(notes, models.IssueNote{IssueID: id, Content: content})
+			}
+		}
+		if len(notes) == 0 {
+			return nil
+		}
+		if err := tx.Create(&notes).Error; err != nil {
+			return fmt.Errorf("failed to create notes: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to de-escalate quiet issues")
+		return 0, err
+	}
+	return deescalated, nil
+}
+
+// maxCascadeDepth caps how far a resolution cascades through CAUSED_BY relationships
+const maxCascadeDepth = 5
+
+// ResolveWithCascade resolves an issue along with the issues it caused.
+//
+// Issues are followed through CAUSED_BY relationships where the resolved issue
+// is the source, up to maxCascadeDepth levels deep, within the namespace of the
+// root issue: access to other namespaces isn't checked. Every issue resolved this
+// way gets a note pointing to the issue that triggered its resolution.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: ID of the root cause issue to resolve
+//
+// Returns:
+//   - []string: IDs of the dependent issues resolved by the cascade
+//   - error: Database error or nil
+func (i *issueRepository) ResolveWithCascade(ctx context.Context, id string) ([]string, error) {
+	var cascaded []string
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var root models.Issue
+		if err := tx.Select("id", "namespace").Where("id = ?", id).First(&root).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIssueNotFound
+			}
+			return fmt.Errorf("failed to find issue: %w", err)
+		}
+
+		now := i.now()
+		if _, err := i.resolveIssueInTx(tx, id, now, models.ResolutionSourceManual); err != nil {
+			return err
+		}
+
+		// Breadth-first walk, tracking visited issues to guard against cycles
+		visited := map[string]bool{id: true}
+		frontier := []string{id}
+		for depth := 0; depth < maxCascadeDepth && len(frontier) > 0; depth++ {
+			var edges []models.RelatedIssue
+			err := tx.Joins("JOIN issues ON issues.id = related_issues.target_id").
+				Where("related_issues.source_id IN ? AND related_issues.kind = ?", frontier, models.RelationshipCausedBy).
+				Where("issues.namespace = ?", root.Namespace).
+				Order("related_issues.id").
+				Find(&edges).Error
+			if err != nil {
+				return fmt.Errorf("failed to find caused issues: %w", err)
+			}
+
+			var next []string
+			for _, edge := range edges {
+				if visited[edge.TargetID] {
+					continue
+				}
+				visited[edge.TargetID] = true
+				next = append(next, edge.TargetID)
+
+				resolved, err := i.resolveIssueInTx(tx, edge.TargetID, now, models.ResolutionSourceCascade)
+				if err != nil {
+					return err
+				}
+				if !resolved {
+					continue
+				}
+
+				note := models.IssueNote{
+					IssueID: edge.TargetID,
+					Content: fmt.Sprintf("Resolved via related issue %s", edge.SourceID),
+				}
+				if err := tx.Create(&note).Error; err != nil {
+					return fmt.Errorf("failed to create note: %w", err)
+				}
+				cascaded = append(cascaded, edge.TargetID)
+			}
+			frontier = next
+		}
+		return nil
+	})
+
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_id", id).Error("Failed to resolve issue with cascade")
+		return nil, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id": id,
+		"cascaded": len(cascaded),
+	}).Info("Resolved issue with cascade")
+	return cascaded, nil
+}
+
+// resolveIssueInTx marks an issue as resolved unless it already is.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - id: ID of the issue to resolve
+//   - now: The resolution time
+//   - source: How the issue was resolved
+//
+// Returns:
+//   - bool: Whether the issue was resolved by this call
+//   - error: Database error or nil
+func (i *issueRepository) resolveIssueInTx(tx *gorm.DB, id string, now time.Time, source models.ResolutionSource) (bool, error) {
+	result := tx.Model(&models.Issue{}).
+		Where("id = ? AND state <> ?", id, models.IssueStateResolved).
+		Updates(map[string]interface{}{
+			"state":       models.IssueStateResolved,
+			"resolved_at": now,
+			"resolved_by": source,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to resolve issue: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AddRelatedIssue creates a relationship between two issues by creating a RelatedIssue record.
 //
 // Parameters:
 //   - ctx: Context for cancellations and timeouts
@@ -720,34 +2281,266 @@ func (i *issueRepository) AddRelatedIssue(ctx context.Context, sourceID, targetI
 		return errors.New("one or both issues not found")
 	}
 
-	// Check if relationship already exists
+	created, err := i.ensureRelationshipInTx(i.db.WithContext(ctx), sourceID, targetID, models.RelationshipRelatesTo)
+	if err != nil {
+		if !errors.Is(err, ErrRelationshipLimitExceeded) {
+			i.logger.WithError(err).Error("Failed to add related issue")
+		}
+		return err
+	}
+	if !created {
+		return errors.New("relationship already exists")
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"source_id": sourceID,
+		"target_id": targetID,
+	}).Info("Added related issue")
+	return nil
+}
+
+// ensureRelationshipInTx creates a relationship between two issues unless the
+// issues are already related, in either direction. Neither issue can go over
+// the maximum number of relationships.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - sourceID: The parent issue
+//   - targetID: The child issue
+//   - kind: The kind of relationship
+//
+// Returns:
+//   - bool: Whether the relationship was created
+//   - error: ErrRelationshipLimitExceeded, database error or nil
+func (i *issueRepository) ensureRelationshipInTx(tx *gorm.DB, sourceID, targetID string, kind models.RelationshipKind) (bool, error) {
+	if err := lockIssuesInTx(tx, sourceID, targetID); err != nil {
+		return false, err
+	}
+
 	var existingRelation models.RelatedIssue
-	err = i.db.WithContext(ctx).Where("(source_id = ? AND target_id = ?) OR (source_id = ? AND target_id = ?)",
+	err := tx.Where("(source_id = ? AND target_id = ?) OR (source_id = ? AND target_id = ?)",
 		sourceID, targetID, targetID, sourceID).First(&existingRelation).Error
 
 	if err == nil {
-		return errors.New("relationship already exists")
+		return false, nil
 	}
 	// Check if we get any other error besides Record Not Found
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("failed to check exiting relationship: %w", err)
+		return false, fmt.Errorf("failed to check exiting relationship: %w", err)
+	}
+
+	for _, id := range []string{sourceID, targetID} {
+		if err := i.checkRelationshipLimitInTx(tx, id); err != nil {
+			return false, err
+		}
 	}
 
-	// Create relationship
 	relation := models.RelatedIssue{
 		SourceID: sourceID,
 		TargetID: targetID,
+		Kind:     kind,
+	}
+	if err := tx.Create(&relation).Error; err != nil {
+		return false, fmt.Errorf("failed to create relationship: %w", err)
 	}
 
-	if err := i.db.WithContext(ctx).Create(&relation).Error; err != nil {
-		i.logger.WithError(err).Error("Failed to add related issue")
-		return fmt.Errorf("failed to create relationship: %w", err)
+	return true, nil
+}
+
+// lockIssues locks the issues selected by a query until the end of its transaction,
+// and only them when tables are joined. SQLite ignores it, it serializes writes already.
+var lockIssues = clause.Locking{Strength: clause.LockingStrengthUpdate, Table: clause.Table{Name: "issues"}}
+
+// lockIssuesInTx locks the rows of issues until the transaction ends, so concurrent
+// relationships of the same issues are counted and created one after the other.
+// Rows are locked in the order of their IDs, so transactions locking the same issues
+// don't deadlock. SQLite serializes writes already, so only Postgres rows are locked.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - ids: The IDs of the issues
+//
+// Returns:
+//   - error: Database error or nil
+func lockIssuesInTx(tx *gorm.DB, ids ...string) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	var locked []string
+	err := tx.Raw("SELECT id FROM issues WHERE id IN ? ORDER BY id FOR UPDATE", slices.Compact(slices.Sorted(slices.Values(ids)))).
+		Scan(&locked).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock issues: %w", err)
 	}
+	return nil
+}
 
-	i.logger.WithFields(logrus.Fields{
-		"source_id": sourceID,
-		"target_id": targetID,
-	}).Info("Added related issue")
+// checkRelationshipLimitInTx makes sure an issue can have one more relationship.
+// The issue must be locked with lockIssuesInTx, so concurrent relationships can't
+// both see it under the limit.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - error: ErrRelationshipLimitExceeded, database error or nil
+func (i *issueRepository) checkRelationshipLimitInTx(tx *gorm.DB, issueID string) error {
+	var count int64
+	err := tx.Model(&models.RelatedIssue{}).
+		Where("source_id = ? OR target_id = ?", issueID, issueID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to count relationships: %w", err)
+	}
+
+	if count >= int64(i.maxRelationships) {
+		return fmt.Errorf("%w: issue %s already has %d relationships", ErrRelationshipLimitExceeded, issueID, count)
+	}
+	return nil
+}
+
+// checkNamespaceIssueLimitInTx checks that a namespace can have another active issue.
+//
+// On Postgres, issue creations in the namespace are serialized until the transaction
+// ends, so concurrent creations can't both see the namespace under the limit.
+//
+// Returns:
+//   - error: ErrNamespaceIssueLimitExceeded, database error or nil
+func (i *issueRepository) checkNamespaceIssueLimitInTx(tx *gorm.DB, namespace string) error {
+	if i.maxActiveIssuesPerNamespace <= 0 {
+		return nil
+	}
+
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "kite-namespace-issues:"+namespace).Error; err != nil {
+			return fmt.Errorf("failed to lock namespace issues: %w", err)
+		}
+	}
+
+	var count int64
+	err := tx.Model(&models.Issue{}).
+		Where("namespace = ? AND state IN ?", namespace, models.OpenStates).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to count active issues: %w", err)
+	}
+
+	if count >= int64(i.maxActiveIssuesPerNamespace) {
+		return fmt.Errorf("%w: namespace %s already has %d active issues", ErrNamespaceIssueLimitExceeded, namespace, count)
+	}
+	return nil
+}
+
+// BatchAddRelatedIssues creates many relationships between issues in a single transaction.
+//
+// All the issues referenced are checked up front, so the whole batch is rejected
+// if any of them doesn't exist. Edges that are invalid, or that relate issues
+// which are already related, are reported in the results without failing the batch.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - edges: The relationships to create
+//
+// Returns:
+//   - []dto.RelationshipEdgeResult: The outcome of each edge, in the order received
+//   - error: ErrIssuesNotFound, database error or nil
+func (i *issueRepository) BatchAddRelatedIssues(ctx context.Context, edges []dto.RelationshipEdgeRequest) ([]dto.RelationshipEdgeResult, error) {
+	results := make([]dto.RelationshipEdgeResult, 0, len(edges))
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := i.checkIssuesExistInTx(tx, edges); err != nil {
+			return err
+		}
+
+		for _, edge := range edges {
+			result := dto.RelationshipEdgeResult{
+				SourceID: edge.SourceID,
+				TargetID: edge.TargetID,
+				Kind:     edge.Kind,
+			}
+			if result.Kind == "" {
+				result.Kind = models.RelationshipRelatesTo
+			}
+
+			switch {
+			case !result.Kind.IsValid():
+				result.Status = dto.RelationshipEdgeError
+				result.Error = fmt.Sprintf("invalid relationship kind: %s", result.Kind)
+			case edge.SourceID == edge.TargetID:
+				result.Status = dto.RelationshipEdgeError
+				result.Error = "an issue cannot be related to itself"
+			default:
+				created, err := i.ensureRelationshipInTx(tx, edge.SourceID, edge.TargetID, result.Kind)
+				if errors.Is(err, ErrRelationshipLimitExceeded) {
+					result.Status = dto.RelationshipEdgeError
+					result.Error = err.Error()
+					break
+				}
+				if err != nil {
+					return err
+				}
+				result.Status = dto.RelationshipEdgeCreated
+				if !created {
+					result.Status = dto.RelationshipEdgeSkipped
+				}
+			}
+
+			results = append(results, result)
+		}
+		return nil
+	})
+
+	if err != nil {
+		if !errors.Is(err, ErrIssuesNotFound) {
+			i.logger.WithError(err).Error("Failed to add related issues in batch")
+		}
+		return nil, err
+	}
+
+	i.logger.WithField("count", len(edges)).Info("Added related issues in batch")
+	return results, nil
+}
+
+// checkIssuesExistInTx makes sure every issue referenced by the edges exists.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - edges: The relationships referencing the issues
+//
+// Returns:
+//   - error: ErrIssuesNotFound listing the missing issues, database error or nil
+func (i *issueRepository) checkIssuesExistInTx(tx *gorm.DB, edges []dto.RelationshipEdgeRequest) error {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		for _, id := range []string{edge.SourceID, edge.TargetID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	var foundIDs []string
+	if err := tx.Model(&models.Issue{}).Where("id IN ?", ids).Pluck("id", &foundIDs).Error; err != nil {
+		return fmt.Errorf("failed to check issues exist: %w", err)
+	}
+
+	found := make(map[string]bool, len(foundIDs))
+	for _, id := range foundIDs {
+		found[id] = true
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrIssuesNotFound, strings.Join(missing, ", "))
+	}
 	return nil
 }
 
@@ -780,3 +2573,448 @@ func (i *issueRepository) RemoveRelatedIssue(ctx context.Context, sourceID, targ
 
 	return nil
 }
+
+// RemoveAllRelated removes every relationship of an issue, in either direction,
+// leaving the related issues themselves untouched.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the issue
+//
+// Returns:
+//   - int64: The number of relationships removed
+//   - error: ErrIssueNotFound, database error or nil
+func (i *issueRepository) RemoveAllRelated(ctx context.Context, id string) (int64, error) {
+	var removed int64
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Issue{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if count == 0 {
+			return ErrIssueNotFound
+		}
+
+		result := tx.Where("source_id = ? OR target_id = ?", id, id).Delete(&models.RelatedIssue{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove relationships: %w", result.Error)
+		}
+		removed = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrIssueNotFound) {
+			i.logger.WithError(err).Error("failed to remove relationships of issue")
+		}
+		return 0, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id": id,
+		"removed":  removed,
+	}).Info("Removed all relationships of issue")
+
+	return removed, nil
+}
+
+// FindRelatedGraph finds the issues connected to an issue through relationships, in either
+// direction, along with the relationships between them.
+//
+// The graph is walked breadth-first, so the closest issues are returned first, and the walk
+// stops expanding once maxSize issues are found. Relationships are capped to maxSize too,
+// and so are the relationships fetched at each step of the walk, so issues with many
+// relationships don't load all of them. Only issues in the namespace of the root issue
+// are walked.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the root issue
+//   - maxSize: Maximum number of issues, and of relationships, returned
+//
+// Returns:
+//   - *dto.IssueGraph: The graph, flagged as truncated if it has more issues or relationships
+//   - error: ErrIssueNotFound, database error or nil
+func (i *issueRepository) FindRelatedGraph(ctx context.Context, id string, maxSize int) (*dto.IssueGraph, error) {
+	db := i.db.WithContext(ctx)
+	graph := &dto.IssueGraph{RootID: id, Nodes: []dto.GraphNode{}, Edges: []dto.GraphEdge{}}
+
+	var root dto.GraphNode
+	err := db.Model(&models.Issue{}).
+		Select("id, title, severity, issue_type, state, namespace").
+		Where("id = ?", id).
+		Take(&root).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrIssueNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue: %w", err)
+	}
+	graph.Nodes = append(graph.Nodes, root)
+
+	// Breadth-first walk, tracking visited issues to guard against cycles
+	visited := map[string]bool{id: true}
+	visitedIDs := []string{id}
+	frontier := []string{id}
+	for len(frontier) > 0 && !graph.Truncated {
+		// Each relationship leads to one issue not visited yet at most, so the issues the
+		// graph can still take bound the relationships fetched. One more is fetched to
+		// know whether there are more.
+		remaining := maxSize - len(graph.Nodes)
+		var edges []models.RelatedIssue
+		err := db.Where("source_id IN ? OR target_id IN ?", frontier, frontier).
+			Where("NOT (source_id IN ? AND target_id IN ?)", visitedIDs, visitedIDs).
+			Order("id").
+			Limit(remaining + 1).
+			Find(&edges).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to find relationships: %w", err)
+		}
+		if len(edges) > remaining {
+			edges = edges[:remaining]
+			graph.Truncated = true
+		}
+
+		var neighbors []string
+		for _, edge := range edges {
+			for _, neighbor := range []string{edge.SourceID, edge.TargetID} {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					visitedIDs = append(visitedIDs, neighbor)
+					neighbors = append(neighbors, neighbor)
+				}
+			}
+		}
+		if len(neighbors) == 0 {
+			break
+		}
+
+		var nodes []dto.GraphNode
+		err = db.Model(&models.Issue{}).
+			Select("id, title, severity, issue_type, state, namespace").
+			Where("id IN ? AND namespace = ?", neighbors, root.Namespace).
+			Order("detected_at DESC, id").
+			Scan(&nodes).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to find related issues: %w", err)
+		}
+
+		frontier = frontier[:0]
+		for _, node := range nodes {
+			if len(graph.Nodes) >= maxSize {
+				graph.Truncated = true
+				break
+			}
+			graph.Nodes = append(graph.Nodes, node)
+			frontier = append(frontier, node.ID)
+		}
+	}
+
+	nodeIDs := make([]string, len(graph.Nodes))
+	for idx, node := range graph.Nodes {
+		nodeIDs[idx] = node.ID
+	}
+	// One more than the maximum is fetched, to know whether there are more
+	err = db.Model(&models.RelatedIssue{}).
+		Select("id, source_id, target_id, kind").
+		Where("source_id IN ? AND target_id IN ?", nodeIDs, nodeIDs).
+		Order("id").
+		Limit(maxSize + 1).
+		Scan(&graph.Edges).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find relationships: %w", err)
+	}
+	if len(graph.Edges) > maxSize {
+		graph.Edges = graph.Edges[:maxSize]
+		graph.Truncated = true
+	}
+
+	return graph, nil
+}
+
+// AddLink appends a link to an issue, leaving its existing links untouched.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - req: Payload for the new link
+//
+// Returns:
+//   - *models.Link: The created link
+//   - error: ErrIssueNotFound, ErrMultiplePrimaryLinks, database error or nil
+func (i *issueRepository) AddLink(ctx context.Context, issueID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	link := models.Link{
+		Title:   req.Title,
+		URL:     req.URL,
+		Order:   req.Order,
+		Primary: req.Primary,
+		IssueID: issueID,
+	}
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Issue{}).Where("id = ?", issueID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if count == 0 {
+			return ErrIssueNotFound
+		}
+
+		if err := i.checkPrimaryLinkInTx(tx, issueID, "", req.Primary); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to create link: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"link_id":  link.ID,
+	}).Info("Added link to issue")
+
+	return &link, nil
+}
+
+// AddAttachment registers the metadata of a file attached to an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - req: Payload for the new attachment
+//
+// Returns:
+//   - *models.Attachment: The created attachment
+//   - error: ErrIssueNotFound, database error or nil
+func (i *issueRepository) AddAttachment(ctx context.Context, issueID string, req dto.CreateAttachmentRequest) (*models.Attachment, error) {
+	attachment := models.Attachment{
+		IssueID:     issueID,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		StorageURL:  req.StorageURL,
+	}
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Issue{}).Where("id = ?", issueID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if count == 0 {
+			return ErrIssueNotFound
+		}
+
+		if err := tx.Create(&attachment).Error; err != nil {
+			return fmt.Errorf("failed to create attachment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id":      issueID,
+		"attachment_id": attachment.ID,
+	}).Info("Added attachment to issue")
+
+	return &attachment, nil
+}
+
+// FindAttachments finds the attachments of an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//
+// Returns:
+//   - []models.Attachment: The attachments, oldest first
+//   - error: Database error or nil
+func (i *issueRepository) FindAttachments(ctx context.Context, issueID string) ([]models.Attachment, error) {
+	attachments := []models.Attachment{}
+	err := i.db.WithContext(ctx).
+		Where("issue_id = ?", issueID).
+		Order("created_at ASC").
+		Find(&attachments).Error
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_id", issueID).Error("Failed to find attachments")
+		return nil, fmt.Errorf("failed to find attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// UpdateLink edits a single link of an issue.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - linkID: The ID of the link
+//   - req: Payload with the new link values
+//
+// Returns:
+//   - *models.Link: The updated link
+//   - error: ErrLinkNotFound, ErrMultiplePrimaryLinks, database error or nil
+func (i *issueRepository) UpdateLink(ctx context.Context, issueID, linkID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	var link models.Link
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND issue_id = ?", linkID, issueID).First(&link).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrLinkNotFound
+			}
+			return fmt.Errorf("failed to find link: %w", err)
+		}
+
+		if err := i.checkPrimaryLinkInTx(tx, issueID, linkID, req.Primary); err != nil {
+			return err
+		}
+
+		// Use a map so that zero values (order 0, primary false) are written too
+		err := tx.Model(&link).Updates(map[string]interface{}{
+			"title":      req.Title,
+			"url":        req.URL,
+			"position":   req.Order,
+			"is_primary": req.Primary,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update link: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link.Title = req.Title
+	link.URL = req.URL
+	link.Order = req.Order
+	link.Primary = req.Primary
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"link_id":  linkID,
+	}).Info("Updated issue link")
+
+	return &link, nil
+}
+
+// DeleteLink removes a single link of an issue, leaving the other links untouched.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - linkID: The ID of the link
+//
+// Returns:
+//   - error: ErrLinkNotFound, database error or nil
+func (i *issueRepository) DeleteLink(ctx context.Context, issueID, linkID string) error {
+	result := i.db.WithContext(ctx).
+		Where("id = ? AND issue_id = ?", linkID, issueID).
+		Delete(&models.Link{})
+	if result.Error != nil {
+		i.logger.WithError(result.Error).Error("failed to delete link")
+		return fmt.Errorf("failed to delete link: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrLinkNotFound
+	}
+
+	i.logger.WithFields(logrus.Fields{
+		"issue_id": issueID,
+		"link_id":  linkID,
+	}).Info("Deleted issue link")
+
+	return nil
+}
+
+// checkPrimaryLinkInTx makes sure an issue keeps at most one primary link
+// when a link is flagged as primary.
+//
+// Parameters:
+//   - tx: The database transaction to execute within
+//   - issueID: The ID of the issue
+//   - excludeLinkID: The link being edited, if any
+//   - primary: Whether the link is flagged as primary
+//
+// Returns:
+//   - error: ErrMultiplePrimaryLinks, database error or nil
+func (i *issueRepository) checkPrimaryLinkInTx(tx *gorm.DB, issueID, excludeLinkID string, primary bool) error {
+	if !primary {
+		return nil
+	}
+
+	query := tx.Model(&models.Link{}).Where("issue_id = ? AND is_primary = ?", issueID, true)
+	if excludeLinkID != "" {
+		query = query.Where("id <> ?", excludeLinkID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check primary links: %w", err)
+	}
+	if count > 0 {
+		return ErrMultiplePrimaryLinks
+	}
+	return nil
+}
+
+// FindNamespaces finds every namespace that contains issues, along with the
+// number of active issues in each of them.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//
+// Returns:
+//   - []dto.NamespaceSummary: The namespaces found, ordered by name
+//   - error: Database error or nil
+func (i *issueRepository) FindNamespaces(ctx context.Context) ([]dto.NamespaceSummary, error) {
+	var namespaces []dto.NamespaceSummary
+
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("namespace, SUM(CASE WHEN state IN ? THEN 1 ELSE 0 END) AS active_count", models.OpenStates).
+		Group("namespace").
+		Order("namespace").
+		Scan(&namespaces).Error
+
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to find namespaces")
+		return nil, fmt.Errorf("failed to find namespaces: %w", err)
+	}
+
+	return namespaces, nil
+}
+
+// RankNamespaces counts the issues in the states passed of every namespace that has some,
+// ranking the namespaces with the most issues first.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - states: The states of the issues counted
+//   - limit: Maximum number of namespaces ranked
+//
+// Returns:
+//   - []dto.NamespaceIssueCount: The namespaces found, by decreasing number of issues then by name
+//   - error: Database error or nil
+func (i *issueRepository) RankNamespaces(ctx context.Context, states []models.IssueState, limit int) ([]dto.NamespaceIssueCount, error) {
+	var ranking []dto.NamespaceIssueCount
+
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("namespace, COUNT(*) AS issue_count").
+		Where("state IN ?", states).
+		Group("namespace").
+		Order("issue_count DESC, namespace").
+		Limit(limit).
+		Scan(&ranking).Error
+
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to rank namespaces")
+		return nil, fmt.Errorf("failed to rank namespaces: %w", err)
+	}
+
+	return ranking, nil
+}
@@ -1,20 +1,32 @@
 package repository
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/reason"
+	"github.com/konflux-ci/kite/internal/pkg/requestid"
 	"github.com/konflux-ci/kite/internal/testhelpers"
 	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 type SetupOptions struct {
-	UseConcurrentDatabase bool // Use a concurrent database setup
+	UseConcurrentDatabase bool     // Use a concurrent database setup
+	RepositoryOptions     []Option // Options passed to the repository
 }
 
 // setupTestScenario sets up a context and repository for test scenarios
@@ -26,7 +38,7 @@ func setupTestScenario(t *testing.T, options SetupOptions) (context.Context, *go
 		db = testhelpers.SetupTestDB(t)
 	}
 	logger := logrus.New()
-	repo := NewIssueRepository(db, logger)
+	repo := NewIssueRepository(db, logger, options.RepositoryOptions...)
 	ctx := context.Background()
 
 	return ctx, db, repo
@@ -62,7 +74,7 @@ func TestIssueRepository_Create(t *testing.T) {
 	req := createTestIssue("Test Issue", "test-namespace")
 
 	// Create it
-	issue, err := repo.Create(ctx, req)
+	issue, _, err := repo.Create(ctx, req)
 
 	// Check
 	if err != nil {
@@ -88,7 +100,7 @@ func TestIssueRepository_FindByID(t *testing.T) {
 
 	// Create a test issue first
 	req := createTestIssue("Find Test Issue", "test-namespace")
-	createdIssue, err := repo.Create(ctx, req)
+	createdIssue, _, err := repo.Create(ctx, req)
 	if err != nil {
 		t.Fatalf("unexpected error, got %v", err)
 	}
@@ -129,6 +141,91 @@ func TestIssueRepository_FindByID_NotFound(t *testing.T) {
 	}
 }
 
+func TestIssueRepository_FindByIDs(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Batch Test Issue", "test-namespace")
+	req.Links = []dto.CreateLinkRequest{{Title: "Logs", URL: "https://konflux.test/logs"}}
+	first, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	second, _, err := repo.Create(ctx, createTestIssue("Other Batch Test Issue", "other-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// A mix of found and missing issues
+	missingID := "00000000-0000-4000-8000-000000000000"
+	issues, err := repo.FindByIDs(ctx, []string{second.ID, missingID, first.ID})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	found := make(map[string]models.Issue)
+	for _, issue := range issues {
+		found[issue.ID] = issue
+	}
+	if _, ok := found[missingID]; ok {
+		t.Errorf("Expected the missing issue not to be found")
+	}
+	if issue, ok := found[first.ID]; !ok || issue.Scope.ResourceName == "" || len(issue.Links) != 1 {
+		t.Errorf("Expected issue %s to be found with its associations, got %+v", first.ID, issue)
+	}
+	if issue, ok := found[second.ID]; !ok || issue.Namespace != "other-namespace" {
+		t.Errorf("Expected issue %s to be found, got %+v", second.ID, issue)
+	}
+
+	// Nothing to find
+	issues, err = repo.FindByIDs(ctx, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(issues))
+	}
+}
+
+func TestIssueRepository_FindStatusByID(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	created, _, err := repo.Create(ctx, createTestIssue("Status Test Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	status, err := repo.FindStatusByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if status == nil {
+		t.Fatal("Expected the status to be found, got nil")
+	}
+	if status.ID != created.ID || status.State != models.IssueStateActive || status.Severity != models.SeverityMajor {
+		t.Errorf("Expected the status of issue %s, got %+v", created.ID, status)
+	}
+	if !status.UpdatedAt.Equal(created.UpdatedAt) || status.UpdatedAt.Location() != time.UTC {
+		t.Errorf("Expected updatedAt %v in UTC, got %v", created.UpdatedAt, status.UpdatedAt)
+	}
+	if status.ResolvedAt != nil {
+		t.Errorf("Expected no resolvedAt, got %v", status.ResolvedAt)
+	}
+	if status.Namespace != "test-namespace" || status.ResourceNamespace != "test-namespace" {
+		t.Errorf("Expected the namespaces of the issue, got %q and %q", status.Namespace, status.ResourceNamespace)
+	}
+
+	missing, err := repo.FindStatusByID(ctx, "00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if missing != nil {
+		t.Errorf("Expected nil for a non-existent issue, got %+v", missing)
+	}
+}
+
 func TestIssueRepository_FindAll_WithFilters(t *testing.T) {
 	// Setup
 	ctx, _, repo := setupTestScenario(t, SetupOptions{})
@@ -153,7 +250,7 @@ func TestIssueRepository_FindAll_WithFilters(t *testing.T) {
 
 	// Write issues to DB
 	for _, req := range issues {
-		_, err := repo.Create(ctx, req)
+		_, _, err := repo.Create(ctx, req)
 		if err != nil {
 			t.Fatalf("Failed to create test issue: %v", err)
 		}
@@ -194,7 +291,7 @@ func TestIssueRepository_CheckDuplicate(t *testing.T) {
 
 	// Create an issue
 	req := createTestIssue("Duplicate Test", "test-namespace")
-	_, err := repo.Create(ctx, req)
+	_, _, err := repo.Create(ctx, req)
 	if err != nil {
 		t.Fatalf("Unexpected error, got %v", err)
 	}
@@ -218,7 +315,7 @@ func TestIssueRepository_Update(t *testing.T) {
 
 	// Create an issue
 	req := createTestIssue("Some Issue", "test-namespace")
-	issue, err := repo.Create(ctx, req)
+	issue, _, err := repo.Create(ctx, req)
 	if err != nil {
 		t.Fatalf("Unexpected error, got %v", err)
 	}
@@ -263,7 +360,7 @@ func TestIssueRepository_Delete(t *testing.T) {
 		},
 	)
 
-	createdIssue, err := repo.Create(ctx, req)
+	createdIssue, _, err := repo.Create(ctx, req)
 	if err != nil {
 		t.Fatalf("Failed to create test issue: %v", err)
 	}
@@ -339,7 +436,7 @@ func TestIssueRepository_CreateOrUpdate_NoDuplicates(t *testing.T) {
 			// This creates three waves of goroutines, each in some delay (0ms, 1ms, 2ms)
 			time.Sleep(time.Millisecond * time.Duration(index%3))
 
-			issue, err := repo.CreateOrUpdate(ctx, req)
+			issue, _, err := repo.CreateOrUpdate(ctx, req)
 			issues[index] = issue
 			errors[index] = err
 		}(i)
@@ -363,3 +460,3391 @@ func TestIssueRepository_CreateOrUpdate_NoDuplicates(t *testing.T) {
 		}
 	}
 }
+
+func TestIssueRepository_CreateOrUpdate_ConcurrentOccurrences(t *testing.T) {
+	const numRequests = 20
+
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "updated duplicates"},
+		{
+			name:    "coalesced duplicates",
+			options: []Option{WithDedupOptions(DedupOptions{MinUpdateInterval: time.Hour})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, repo := setupTestScenario(t, SetupOptions{
+				UseConcurrentDatabase: true,
+				RepositoryOptions:     tt.options,
+			})
+
+			req := createTestIssue("Concurrent occurrences", "test-namespace")
+			first, _, err := repo.CreateOrUpdate(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			// Every duplicate reads the same count, an increment is lost if any of
+			// them writes back the count it read
+			var wg sync.WaitGroup
+			errs := make([]error, numRequests-1)
+			for i := range numRequests - 1 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _, errs[i] = repo.CreateOrUpdate(ctx, req)
+				}()
+			}
+			wg.Wait()
+
+			for _, err := range errs {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			issue, err := repo.FindByID(ctx, first.ID)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if issue.OccurrenceCount != numRequests {
+				t.Errorf("Expected %d occurrences, got %d", numRequests, issue.OccurrenceCount)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_Created(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "updated duplicate"},
+		{
+			name:    "coalesced duplicate",
+			options: []Option{WithDedupOptions(DedupOptions{MinUpdateInterval: time.Hour})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: tt.options})
+
+			req := createTestIssue("Created flag", "test-namespace")
+			first, created, err := repo.CreateOrUpdate(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if !created {
+				t.Errorf("Expected the first report to create the issue")
+			}
+
+			duplicate, created, err := repo.CreateOrUpdate(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if created {
+				t.Errorf("Expected a duplicate report not to create an issue")
+			}
+			if duplicate.ID != first.ID {
+				t.Errorf("Expected duplicate to update issue %s, got %s", first.ID, duplicate.ID)
+			}
+
+			_, created, err = repo.Create(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if created {
+				t.Errorf("Expected Create not to create a duplicate issue")
+			}
+		})
+	}
+}
+
+func TestIssueRepository_EnforceUnique(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+		WithDedupOptions(DedupOptions{EnforceUnique: true}),
+	}})
+
+	first, _, err := repo.Create(ctx, createTestIssue("Unique issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if first.DedupKey == nil {
+		t.Fatal("Expected the issue to have a dedup key")
+	}
+
+	// A direct write of a duplicate, bypassing duplicate detection
+	newDuplicate := func() *models.Issue {
+		now := time.Now().UTC()
+		return &models.Issue{
+			Title:       "Direct write",
+			Description: "Test description",
+			Severity:    models.SeverityMajor,
+			IssueType:   models.IssueTypeBuild,
+			State:       models.IssueStateActive,
+			DetectedAt:  now,
+			LastSeenAt:  now,
+			Namespace:   "test-namespace",
+			DedupKey:    first.DedupKey,
+			Scope: models.IssueScope{
+				ResourceType:      "component",
+				ResourceName:      "test-component",
+				ResourceNamespace: "test-namespace",
+			},
+		}
+	}
+	if err := db.Create(newDuplicate()).Error; err == nil {
+		t.Fatal("Expected the database to reject a duplicate open issue")
+	}
+
+	// Resolved issues aren't part of the index
+	if _, err := repo.Update(ctx, first.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	duplicate := newDuplicate()
+	if err := db.Create(duplicate).Error; err != nil {
+		t.Fatalf("Expected a duplicate of a resolved issue to be stored, got %v", err)
+	}
+
+	// Reopening the resolved issue would make it a duplicate of the open one
+	_, err = repo.Update(ctx, first.ID, dto.UpdateIssueRequest{State: models.IssueStateActive})
+	if !errors.Is(err, ErrDuplicateOpenIssue) {
+		t.Errorf("Expected ErrDuplicateOpenIssue, got %v", err)
+	}
+
+	// Moving an issue to the scope of an open issue too
+	otherReq := createTestIssue("Other issue", "test-namespace")
+	otherReq.Scope.ResourceName = "other-component"
+	other, _, err := repo.Create(ctx, otherReq)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	_, err = repo.Update(ctx, other.ID, dto.UpdateIssueRequest{
+		Scope: dto.ScopeReqBodyOptional{ResourceName: "test-component"},
+	})
+	if !errors.Is(err, ErrDuplicateOpenIssue) {
+		t.Errorf("Expected ErrDuplicateOpenIssue, got %v", err)
+	}
+}
+
+func TestIssueRepository_EnforceUnique_ConcurrentDuplicate(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+		WithDedupOptions(DedupOptions{EnforceUnique: true}),
+	}})
+	req := createTestIssue("Created concurrently", "test-namespace")
+
+	// A concurrent report creates the issue right after the duplicate lookup missed it
+	var concurrent *models.Issue
+	err := db.Callback().Query().After("gorm:query").Register("test:concurrent_duplicate", func(tx *gorm.DB) {
+		if concurrent != nil || tx.Statement.Table != "issues" || !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return
+		}
+		concurrent = &models.Issue{}
+		// Same transaction, so it's seen by the lookup following the rejection
+		concurrentTx := tx.Session(&gorm.Session{NewDB: true})
+		concurrentTx.Error = nil
+		created, err := repo.(*issueRepository).createNewIssueInTx(concurrentTx, req)
+		if err != nil {
+			t.Errorf("Failed to create concurrent issue: %v", err)
+			return
+		}
+		concurrent = created
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	issue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.ID != concurrent.ID {
+		t.Errorf("Expected the concurrent issue %s to be updated, got %s", concurrent.ID, issue.ID)
+	}
+	if issue.OccurrenceCount != 2 {
+		t.Errorf("Expected 2 occurrences, got %d", issue.OccurrenceCount)
+	}
+
+	var count int64
+	if err := db.Model(&models.Issue{}).Where("namespace = ?", "test-namespace").Count(&count).Error; err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected a single issue, got %d", count)
+	}
+}
+
+func TestIssueRepository_EnforceUnique_Disabled(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Unkeyed issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.DedupKey != nil {
+		t.Errorf("Expected no dedup key, got %s", *issue.DedupKey)
+	}
+}
+
+func TestIssueRepository_CustomFields(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Build failed", "test-namespace")
+	req.CustomFields = map[string]any{"buildNumber": float64(42), "pipeline": "on-push"}
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !reflect.DeepEqual(issue.CustomFields, req.CustomFields) {
+		t.Errorf("Expected custom fields %v, got %v", req.CustomFields, issue.CustomFields)
+	}
+
+	// Left unchanged by updates without custom fields
+	updated, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Title: "Build still failing"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !reflect.DeepEqual(updated.CustomFields, req.CustomFields) {
+		t.Errorf("Expected custom fields %v, got %v", req.CustomFields, updated.CustomFields)
+	}
+
+	// Replaced as a whole by a recurrence
+	recurrence := createTestIssue("Build failed", "test-namespace")
+	recurrence.CustomFields = map[string]any{"buildNumber": float64(43)}
+	updated, _, err = repo.Create(ctx, recurrence)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.ID != issue.ID {
+		t.Fatalf("Expected the recurrence to update issue %s, got %s", issue.ID, updated.ID)
+	}
+	if !reflect.DeepEqual(updated.CustomFields, recurrence.CustomFields) {
+		t.Errorf("Expected custom fields %v, got %v", recurrence.CustomFields, updated.CustomFields)
+	}
+}
+
+func TestIssueRepository_Environment(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	created := map[string]*models.Issue{}
+	for _, environment := range []string{"staging", "prod", ""} {
+		req := createTestIssue("Release failed", "test-namespace")
+		req.IssueType = models.IssueTypeRelease
+		req.Scope.ResourceName = "app-" + environment
+		req.Environment = environment
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.Environment != environment {
+			t.Errorf("Expected environment %q, got %q", environment, issue.Environment)
+		}
+		created[environment] = issue
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace", Environment: "prod"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != created["prod"].ID {
+		t.Fatalf("Expected only the prod issue, got %d issues", total)
+	}
+
+	// Left unchanged by updates without an environment
+	updated, err := repo.Update(ctx, created["staging"].ID, dto.UpdateIssueRequest{Title: "Release still failing"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.Environment != "staging" {
+		t.Errorf("Expected environment staging, got %q", updated.Environment)
+	}
+	updated, err = repo.Update(ctx, created["staging"].ID, dto.UpdateIssueRequest{Environment: "prod"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.Environment != "prod" {
+		t.Errorf("Expected environment prod, got %q", updated.Environment)
+	}
+
+	// MTTR is grouped by environment, issues without one in the empty group
+	if err := db.Model(&models.Issue{}).Where("namespace = ?", "test-namespace").Updates(map[string]any{
+		"state":       models.IssueStateResolved,
+		"resolved_at": time.Now(),
+	}).Error; err != nil {
+		t.Fatalf("Failed to resolve issues: %v", err)
+	}
+	groups, err := repo.MTTR(ctx, IssueQueryFilters{Namespace: "test-namespace"}, time.Time{}, "environment")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	counts := map[string]int64{}
+	for _, group := range groups {
+		counts[group.Group] = group.Count
+	}
+	if !reflect.DeepEqual(counts, map[string]int64{"": 1, "prod": 2}) {
+		t.Errorf("Expected 2 prod issues and 1 without environment, got %v", counts)
+	}
+}
+
+func TestIssueRepository_FindDuplicate_CrossNamespaceResource(t *testing.T) {
+	// A cluster-wide resource, reported from the namespaces of the teams using it
+	reportFrom := func(namespace string) dto.CreateIssueRequest {
+		req := createTestIssue("Shared registry unreachable", namespace)
+		req.Scope.ResourceType = "registry"
+		req.Scope.ResourceName = "quay-mirror"
+		req.Scope.ResourceNamespace = "shared-infra"
+		return req
+	}
+
+	allowAlpha := func(namespace string) bool { return namespace == "team-alpha" }
+	denyAll := func(namespace string) bool { return false }
+
+	tests := []struct {
+		name             string
+		acrossNamespaces bool
+		authorize        NamespaceAuthorizer
+		expectDuplicate  bool
+	}{
+		{name: "deduplicated per namespace", acrossNamespaces: false, authorize: allowAlpha, expectDuplicate: false},
+		{name: "deduplicated across namespaces", acrossNamespaces: true, authorize: allowAlpha, expectDuplicate: true},
+		// Reports can't update the issues of namespaces they aren't authorized for
+		{name: "unauthorized namespace", acrossNamespaces: true, authorize: denyAll, expectDuplicate: false},
+		{name: "no authorizer", acrossNamespaces: true, expectDuplicate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+				WithDedupOptions(DedupOptions{AcrossNamespaces: tt.acrossNamespaces, EnforceUnique: true}),
+			}})
+			if tt.authorize != nil {
+				ctx = WithNamespaceAuthorizer(ctx, tt.authorize)
+			}
+
+			issue, _, err := repo.CreateOrUpdate(ctx, reportFrom("team-alpha"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if issue.Scope.ResourceNamespace != "shared-infra" {
+				t.Errorf("Expected the resource namespace to be kept, got %q", issue.Scope.ResourceNamespace)
+			}
+
+			// Reported again from the same namespace, the resource namespace is part of the match
+			recurrence, _, err := repo.CreateOrUpdate(ctx, reportFrom("team-alpha"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if recurrence.ID != issue.ID {
+				t.Errorf("Expected recurrence to update issue %s, got %s", issue.ID, recurrence.ID)
+			}
+
+			// Reported from another namespace
+			duplicate, err := repo.FindDuplicate(ctx, reportFrom("team-beta"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if tt.expectDuplicate && (duplicate == nil || duplicate.ID != issue.ID) {
+				t.Errorf("Expected issue %s to be a duplicate, got %v", issue.ID, duplicate)
+			}
+			if !tt.expectDuplicate && duplicate != nil {
+				t.Errorf("Expected no duplicate, got issue %s", duplicate.ID)
+			}
+
+			reported, created, err := repo.CreateOrUpdate(ctx, reportFrom("team-beta"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if created == tt.expectDuplicate {
+				t.Errorf("Expected the report from another namespace to create an issue: %t, got %t", !tt.expectDuplicate, created)
+			}
+			if created && reported.Namespace != "team-beta" {
+				t.Errorf("Expected the issue to be created in team-beta, got %s", reported.Namespace)
+			}
+			original, err := repo.FindByID(ctx, issue.ID)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			expected := 2
+			if tt.expectDuplicate {
+				expected = 3
+			}
+			if original.OccurrenceCount != expected {
+				t.Errorf("Expected %d occurrences of the original issue, got %d", expected, original.OccurrenceCount)
+			}
+			if original.Namespace != "team-alpha" {
+				t.Errorf("Expected the original issue to stay in team-alpha, got %s", original.Namespace)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindDuplicate_ResourceNameStrip(t *testing.T) {
+	// Failures of two runs of the same pipeline, named with a random suffix
+	reportFor := func(runName string) dto.CreateIssueRequest {
+		req := createTestIssue("Pipeline run failed", "team-alpha")
+		req.IssueType = models.IssueTypePipeline
+		req.Scope.ResourceType = "pipelinerun"
+		req.Scope.ResourceName = runName
+		return req
+	}
+
+	tests := []struct {
+		name            string
+		strip           *regexp.Regexp
+		expectDuplicate bool
+	}{
+		{name: "names matched as is", strip: nil, expectDuplicate: false},
+		{name: "suffixes stripped", strip: regexp.MustCompile(`-[a-z0-9]{6}$`), expectDuplicate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, db, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+				WithDedupOptions(DedupOptions{ResourceNameStrip: tt.strip}),
+			}})
+
+			issue, _, err := repo.CreateOrUpdate(ctx, reportFor("build-xyz-a1b2c3"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			recurrence, _, err := repo.CreateOrUpdate(ctx, reportFor("build-xyz-d4e5f6"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if tt.expectDuplicate && recurrence.ID != issue.ID {
+				t.Errorf("Expected recurrence to update issue %s, got %s", issue.ID, recurrence.ID)
+			}
+			if !tt.expectDuplicate && recurrence.ID == issue.ID {
+				t.Errorf("Expected a new issue, got issue %s updated", issue.ID)
+			}
+
+			// Both the raw and normalized names are stored, the raw one of the latest run
+			var scope models.IssueScope
+			if err := db.Where("id = ?", recurrence.ScopeID).First(&scope).Error; err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if scope.ResourceName != "build-xyz-d4e5f6" {
+				t.Errorf("Expected resource name build-xyz-d4e5f6, got %q", scope.ResourceName)
+			}
+			expectedNormalized := "build-xyz-d4e5f6"
+			if tt.strip != nil {
+				expectedNormalized = "build-xyz"
+			}
+			if scope.NormalizedResourceName != expectedNormalized {
+				t.Errorf("Expected normalized resource name %s, got %q", expectedNormalized, scope.NormalizedResourceName)
+			}
+		})
+	}
+
+	t.Run("names stripped entirely are kept", func(t *testing.T) {
+		ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+			WithDedupOptions(DedupOptions{ResourceNameStrip: regexp.MustCompile(`.*`)}),
+		}})
+
+		issue, _, err := repo.CreateOrUpdate(ctx, reportFor("build-xyz-a1b2c3"))
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.Scope.NormalizedResourceName != "build-xyz-a1b2c3" {
+			t.Errorf("Expected normalized resource name build-xyz-a1b2c3, got %q", issue.Scope.NormalizedResourceName)
+		}
+		other, _, err := repo.CreateOrUpdate(ctx, reportFor("deploy-abc-d4e5f6"))
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if other.ID == issue.ID {
+			t.Errorf("Expected a new issue, got issue %s updated", issue.ID)
+		}
+	})
+}
+
+func TestFindDuplicateInTx_LocksDuplicate(t *testing.T) {
+	// The statements are only built, no database is needed
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	var queries []string
+	err = db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	repo := NewIssueRepository(db, logrus.New()).(*issueRepository)
+	if _, err := repo.findDuplicateInTx(db, createTestIssue("Build failed", "team-alpha")); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if len(queries) == 0 || !strings.HasSuffix(queries[0], `FOR UPDATE OF "issues"`) {
+		t.Errorf("Expected the duplicate to be selected for update, got %q", queries)
+	}
+}
+
+func TestIssueRepository_NormalizeResourceNames(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+	logger := logrus.New()
+
+	req := createTestIssue("Pipeline run failed", "team-alpha")
+	req.IssueType = models.IssueTypePipeline
+	req.Scope.ResourceType = "pipelinerun"
+	req.Scope.ResourceName = "build-xyz-a1b2c3"
+	issue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	normalizedName := func() string {
+		t.Helper()
+		var scope models.IssueScope
+		if err := db.Where("id = ?", issue.ScopeID).First(&scope).Error; err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return scope.NormalizedResourceName
+	}
+
+	tests := []struct {
+		name               string
+		strip              *regexp.Regexp
+		expectedUpdated    int64
+		expectedNormalized string
+	}{
+		{name: "pattern enabled", strip: regexp.MustCompile(`-[a-z0-9]{6}$`), expectedUpdated: 1, expectedNormalized: "build-xyz"},
+		{name: "pattern unchanged", strip: regexp.MustCompile(`-[a-z0-9]{6}$`), expectedUpdated: 0, expectedNormalized: "build-xyz"},
+		{name: "pattern changed", strip: regexp.MustCompile(`[a-z0-9]{3}$`), expectedUpdated: 1, expectedNormalized: "build-xyz-a1b"},
+		{name: "pattern disabled", strip: nil, expectedUpdated: 1, expectedNormalized: "build-xyz-a1b2c3"},
+	}
+
+	// Each case restarts with the pattern, on the scopes normalized by the previous one
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restarted := NewIssueRepository(db, logger, WithDedupOptions(DedupOptions{ResourceNameStrip: tt.strip}))
+			updated, err := restarted.NormalizeResourceNames(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if updated != tt.expectedUpdated {
+				t.Errorf("Expected %d scopes updated, got %d", tt.expectedUpdated, updated)
+			}
+			if got := normalizedName(); got != tt.expectedNormalized {
+				t.Errorf("Expected normalized resource name %s, got %q", tt.expectedNormalized, got)
+			}
+		})
+	}
+
+	// A new run matches the existing issue once its scope is normalized
+	restarted := NewIssueRepository(db, logger, WithDedupOptions(DedupOptions{ResourceNameStrip: regexp.MustCompile(`-[a-z0-9]{6}$`)}))
+	if _, err := restarted.NormalizeResourceNames(ctx); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	req.Scope.ResourceName = "build-xyz-d4e5f6"
+	recurrence, created, err := restarted.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if created || recurrence.ID != issue.ID {
+		t.Errorf("Expected recurrence to update issue %s, got %s", issue.ID, recurrence.ID)
+	}
+}
+
+func TestIssueRepository_FindDuplicate_Fingerprint(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// The same flaky test, reported by the pipelines of two components
+	reportFor := func(component, fingerprint string) dto.CreateIssueRequest {
+		req := createTestIssue("Flaky test: TestCheckout", "team-alpha")
+		req.IssueType = models.IssueTypeTest
+		req.Scope.ResourceName = component
+		req.Fingerprint = fingerprint
+		return req
+	}
+
+	issue, _, err := repo.CreateOrUpdate(ctx, reportFor("frontend", "flaky/TestCheckout"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.Fingerprint != "flaky/TestCheckout" {
+		t.Errorf("Expected the fingerprint to be stored, got %q", issue.Fingerprint)
+	}
+
+	// Same fingerprint on another scope, grouped with the first report
+	recurrence, _, err := repo.CreateOrUpdate(ctx, reportFor("backend", "flaky/TestCheckout"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if recurrence.ID != issue.ID {
+		t.Errorf("Expected recurrence to update issue %s, got %s", issue.ID, recurrence.ID)
+	}
+
+	// Another fingerprint on the same scope, a separate issue
+	other, _, err := repo.CreateOrUpdate(ctx, reportFor("backend", "flaky/TestPayment"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if other.ID == issue.ID {
+		t.Errorf("Expected a new issue for another fingerprint, got issue %s", other.ID)
+	}
+
+	// The fingerprint is only matched in the same namespace
+	elsewhere := reportFor("frontend", "flaky/TestCheckout")
+	elsewhere.Namespace = "team-beta"
+	duplicate, err := repo.FindDuplicate(ctx, elsewhere)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if duplicate != nil {
+		t.Errorf("Expected no duplicate in another namespace, got issue %s", duplicate.ID)
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_DedupByReason(t *testing.T) {
+	// Failures of the same pipeline, the first two for the same reason
+	reportFor := func(reason string) dto.CreateIssueRequest {
+		req := createTestIssue("Build pipeline failed", "team-alpha")
+		req.Description = reason
+		return req
+	}
+	reports := []dto.CreateIssueRequest{
+		reportFor("PipelineRun build-x7k2p failed at 2026-10-17T08:00:00Z: missing dependency"),
+		reportFor("PipelineRun build-b4zq9 failed at 2026-10-17T09:30:00Z:\n  missing dependency"),
+		reportFor("PipelineRun build-m8wq2 failed at 2026-10-17T10:00:00Z: out of memory"),
+	}
+
+	tests := []struct {
+		name           string
+		strategy       DedupStrategy
+		expectedIssues int
+	}{
+		{name: "scope", strategy: DedupByScope, expectedIssues: 1},
+		{name: "default strategy", expectedIssues: 1},
+		{name: "reason", strategy: DedupByReason, expectedIssues: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, db, repo := setupTestScenario(t, SetupOptions{
+				RepositoryOptions: []Option{WithDedupOptions(DedupOptions{IncludeResolved: true, Strategy: tt.strategy})},
+			})
+
+			var issues []*models.Issue
+			for _, req := range reports {
+				issue, _, err := repo.CreateOrUpdate(ctx, req)
+				if err != nil {
+					t.Fatalf("Unexpected error, got %v", err)
+				}
+				issues = append(issues, issue)
+			}
+
+			var count int64
+			db.Model(&models.Issue{}).Count(&count)
+			if count != int64(tt.expectedIssues) {
+				t.Errorf("Expected %d issues, got %d", tt.expectedIssues, count)
+			}
+			// Reports of the same failure are always grouped
+			if issues[1].ID != issues[0].ID || issues[1].OccurrenceCount != 2 {
+				t.Errorf("Expected the same failure to update issue %s, got issue %s seen %d times",
+					issues[0].ID, issues[1].ID, issues[1].OccurrenceCount)
+			}
+			if separate := issues[2].ID != issues[0].ID; separate != (tt.strategy == DedupByReason) {
+				t.Errorf("Expected another failure to create a separate issue only with the reason strategy, got issue %s", issues[2].ID)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_DedupKeyLogging(t *testing.T) {
+	tests := []struct {
+		name         string
+		level        logrus.Level
+		options      []Option
+		expectLogged bool
+	}{
+		{"enabled at debug level", logrus.DebugLevel, []Option{WithDedupKeyLogging()}, true},
+		{"enabled at info level", logrus.InfoLevel, []Option{WithDedupKeyLogging()}, false},
+		{"disabled", logrus.DebugLevel, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&out)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+			logger.SetLevel(tt.level)
+			repo := NewIssueRepository(testhelpers.SetupTestDB(t), logger, tt.options...)
+			ctx := requestid.NewContext(context.Background(), "req-123")
+
+			first, _, err := repo.CreateOrUpdate(ctx, createTestIssue("Build failed", "team-alpha"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if _, _, err := repo.CreateOrUpdate(ctx, createTestIssue("Build failed", "team-alpha")); err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			var lookups []map[string]any
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(line), &entry); err == nil && entry["msg"] == "Looked up duplicate issue" {
+					lookups = append(lookups, entry)
+				}
+			}
+			if !tt.expectLogged {
+				if len(lookups) != 0 {
+					t.Errorf("Expected no dedup key logged, got %v", lookups)
+				}
+				return
+			}
+			if len(lookups) != 2 {
+				t.Fatalf("Expected 2 dedup lookups logged, got %d", len(lookups))
+			}
+
+			expected := map[string]any{
+				"request_id":         "req-123",
+				"namespace":          "team-alpha",
+				"issue_type":         "build",
+				"resource_type":      "component",
+				"resource_name":      "test-component",
+				"resource_namespace": "team-alpha",
+				"level":              "debug",
+			}
+			for _, lookup := range lookups {
+				for field, value := range expected {
+					if lookup[field] != value {
+						t.Errorf("Expected %s %v, got %v", field, value, lookup[field])
+					}
+				}
+			}
+			if lookups[0]["matched"] != false {
+				t.Errorf("Expected the first lookup not to match, got %v", lookups[0]["matched"])
+			}
+			if lookups[1]["matched"] != true || lookups[1]["existing_issue_id"] != first.ID {
+				t.Errorf("Expected the second lookup to match %s, got %v %v", first.ID, lookups[1]["matched"], lookups[1]["existing_issue_id"])
+			}
+		})
+	}
+}
+
+func TestIssueRepository_DedupByReason_EditedDescription(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithDedupOptions(DedupOptions{Strategy: DedupByReason})},
+	})
+
+	req := createTestIssue("Build pipeline failed", "team-alpha")
+	req.Description = "Build failed: missing dependency"
+	issue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// The reason follows the description when it's edited
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Description: "Build failed: registry unreachable"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	req.Description = "Build failed:  Registry unreachable"
+	duplicate, err := repo.FindDuplicate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if duplicate == nil || duplicate.ID != issue.ID {
+		t.Errorf("Expected issue %s to match its edited reason, got %+v", issue.ID, duplicate)
+	}
+
+	req.Description = "Build failed: missing dependency"
+	duplicate, err = repo.FindDuplicate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if duplicate != nil {
+		t.Errorf("Expected no duplicate for the former reason, got issue %s", duplicate.ID)
+	}
+}
+
+func TestIssueRepository_FindDuplicate_ResolvedIssues(t *testing.T) {
+	tests := []struct {
+		name            string
+		includeResolved bool
+		expectDuplicate bool
+	}{
+		{name: "resolved issues included", includeResolved: true, expectDuplicate: true},
+		{name: "resolved issues excluded", includeResolved: false, expectDuplicate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			db := testhelpers.SetupTestDB(t)
+			repo := NewIssueRepository(db, logrus.New(), WithDedupOptions(DedupOptions{
+				IncludeResolved: tt.includeResolved,
+			}))
+			ctx := context.Background()
+
+			// Create an issue and resolve it
+			req := createTestIssue("Resolved Duplicate Test", "test-namespace")
+			issue, _, err := repo.Create(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			_, err = repo.ResolveByScope(ctx, req.Scope.ResourceType, req.Scope.ResourceName, req.Namespace)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			// Check for duplicates with the same properties
+			foundIssue, err := repo.FindDuplicate(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			if tt.expectDuplicate && (foundIssue == nil || foundIssue.ID != issue.ID) {
+				t.Errorf("Expected resolved issue %s to be treated as a duplicate, got %v", issue.ID, foundIssue)
+			}
+			if !tt.expectDuplicate && foundIssue != nil {
+				t.Errorf("Expected no duplicate, got issue %s", foundIssue.ID)
+			}
+
+			// A recurrence should only create a new issue when resolved issues are excluded
+			recurrence, _, err := repo.CreateOrUpdate(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if tt.expectDuplicate && recurrence.ID != issue.ID {
+				t.Errorf("Expected recurrence to update issue %s, got %s", issue.ID, recurrence.ID)
+			}
+			if !tt.expectDuplicate && recurrence.ID == issue.ID {
+				t.Errorf("Expected recurrence to create a new issue, got existing issue %s", issue.ID)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_FindDuplicate_DeletedIssues(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// Create an issue and delete it
+	req := createTestIssue("Deleted Duplicate Test", "test-namespace")
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := repo.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Deleted issues are never treated as duplicates
+	foundIssue, err := repo.FindDuplicate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if foundIssue != nil {
+		t.Errorf("Expected no duplicate for a deleted issue, got issue %s", foundIssue.ID)
+	}
+}
+
+func TestIssueRepository_FindNamespaces(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	issues := []dto.CreateIssueRequest{
+		createTestIssue("Alpha Issue 1", "team-alpha"),
+		createTestIssue("Alpha Issue 2", "team-alpha"),
+		createTestIssue("Beta Issue", "team-beta"),
+	}
+	issues[1].Scope.ResourceName = "other-component"
+
+	var created []*models.Issue
+	for _, req := range issues {
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		created = append(created, issue)
+	}
+
+	// Resolve the only issue in team-beta, it should still be listed
+	err := db.Model(&models.Issue{}).Where("id = ?", created[2].ID).Update("state", models.IssueStateResolved).Error
+	if err != nil {
+		t.Fatalf("Failed to resolve test issue: %v", err)
+	}
+
+	namespaces, err := repo.FindNamespaces(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []dto.NamespaceSummary{
+		{Namespace: "team-alpha", ActiveCount: 2},
+		{Namespace: "team-beta", ActiveCount: 0},
+	}
+	if len(namespaces) != len(expected) {
+		t.Fatalf("Expected %d namespaces, got %d", len(expected), len(namespaces))
+	}
+	for i, namespace := range namespaces {
+		if namespace != expected[i] {
+			t.Errorf("Expected namespace %+v, got %+v", expected[i], namespace)
+		}
+	}
+}
+
+func TestIssueRepository_Update_LinkOrderAndPrimary(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Linked Issue", "test-namespace")
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Replace the links, out of order, with a single primary link
+	updateReq := dto.UpdateIssueRequest{
+		Links: []dto.CreateLinkRequest{
+			{Title: "Docs", URL: "konflux.test/docs", Order: 2},
+			{Title: "Logs", URL: "konflux.test/logs", Order: 0, Primary: true},
+			{Title: "Dashboard", URL: "konflux.test/dashboard", Order: 1},
+		},
+	}
+	if _, err := repo.Update(ctx, issue.ID, updateReq); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	updatedIssue, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	expectedTitles := []string{"Logs", "Dashboard", "Docs"}
+	if len(updatedIssue.Links) != len(expectedTitles) {
+		t.Fatalf("Expected %d links, got %d", len(expectedTitles), len(updatedIssue.Links))
+	}
+	for idx, link := range updatedIssue.Links {
+		if link.Title != expectedTitles[idx] {
+			t.Errorf("Expected link %d to be '%s', got '%s'", idx, expectedTitles[idx], link.Title)
+		}
+		if link.Primary != (link.Title == "Logs") {
+			t.Errorf("Unexpected primary flag %t for link '%s'", link.Primary, link.Title)
+		}
+	}
+
+	// Flagging two links as primary must be rejected and leave links untouched
+	invalidReq := dto.UpdateIssueRequest{
+		Links: []dto.CreateLinkRequest{
+			{Title: "Logs", URL: "konflux.test/logs", Primary: true},
+			{Title: "Docs", URL: "konflux.test/docs", Primary: true},
+		},
+	}
+	_, err = repo.Update(ctx, issue.ID, invalidReq)
+	if !errors.Is(err, ErrMultiplePrimaryLinks) {
+		t.Fatalf("Expected ErrMultiplePrimaryLinks, got %v", err)
+	}
+
+	unchangedIssue, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(unchangedIssue.Links) != len(expectedTitles) {
+		t.Errorf("Expected links to be unchanged, got %d links", len(unchangedIssue.Links))
+	}
+}
+
+func TestIssueRepository_Create_MultiplePrimaryLinks(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Two Primary Links", "test-namespace")
+	req.Links = []dto.CreateLinkRequest{
+		{Title: "Logs", URL: "konflux.test/logs", Primary: true},
+		{Title: "Docs", URL: "konflux.test/docs", Primary: true},
+	}
+
+	_, _, err := repo.Create(ctx, req)
+	if !errors.Is(err, ErrMultiplePrimaryLinks) {
+		t.Fatalf("Expected ErrMultiplePrimaryLinks, got %v", err)
+	}
+}
+
+func TestIssueRepository_BatchAddRelatedIssues(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	var ids []string
+	for _, name := range []string{"component-a", "component-b", "component-c"} {
+		req := createTestIssue("Batch Issue "+name, "test-namespace")
+		req.Scope.ResourceName = name
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// An existing relationship, which should be skipped
+	if err := repo.AddRelatedIssue(ctx, ids[0], ids[1]); err != nil {
+		t.Fatalf("Failed to add related issue: %v", err)
+	}
+
+	t.Run("mixed batch", func(t *testing.T) {
+		edges := []dto.RelationshipEdgeRequest{
+			{SourceID: ids[1], TargetID: ids[2], Kind: models.RelationshipCausedBy},
+			{SourceID: ids[1], TargetID: ids[0]},
+			{SourceID: ids[0], TargetID: ids[2], Kind: "UNKNOWN"},
+			{SourceID: ids[2], TargetID: ids[2]},
+			{SourceID: ids[2], TargetID: ids[1]},
+		}
+		expectedStatuses := []string{
+			dto.RelationshipEdgeCreated,
+			dto.RelationshipEdgeSkipped,
+			dto.RelationshipEdgeError,
+			dto.RelationshipEdgeError,
+			dto.RelationshipEdgeSkipped,
+		}
+
+		results, err := repo.BatchAddRelatedIssues(ctx, edges)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(results) != len(expectedStatuses) {
+			t.Fatalf("Expected %d results, got %d", len(expectedStatuses), len(results))
+		}
+		for idx, result := range results {
+			if result.Status != expectedStatuses[idx] {
+				t.Errorf("Expected edge %d to be '%s', got '%s' (%s)", idx, expectedStatuses[idx], result.Status, result.Error)
+			}
+		}
+
+		var relation models.RelatedIssue
+		if err := db.Where("source_id = ? AND target_id = ?", ids[1], ids[2]).First(&relation).Error; err != nil {
+			t.Fatalf("Expected relationship to be created, got %v", err)
+		}
+		if relation.Kind != models.RelationshipCausedBy {
+			t.Errorf("Expected kind '%s', got '%s'", models.RelationshipCausedBy, relation.Kind)
+		}
+	})
+
+	t.Run("unknown issue rejects the batch", func(t *testing.T) {
+		edges := []dto.RelationshipEdgeRequest{
+			{SourceID: ids[0], TargetID: ids[2]},
+			{SourceID: ids[0], TargetID: "a4a9f17e-5a5e-4f0a-8f8c-000000000000"},
+		}
+
+		_, err := repo.BatchAddRelatedIssues(ctx, edges)
+		if !errors.Is(err, ErrIssuesNotFound) {
+			t.Fatalf("Expected ErrIssuesNotFound, got %v", err)
+		}
+
+		var count int64
+		db.Model(&models.RelatedIssue{}).Where("source_id = ? AND target_id = ?", ids[0], ids[2]).Count(&count)
+		if count != 0 {
+			t.Errorf("Expected no relationship to be created, got %d", count)
+		}
+	})
+}
+
+func TestIssueRepository_LastSeenAt(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Last Seen Issue", "test-namespace")
+	issue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if issue.LastSeenAt.IsZero() {
+		t.Fatal("Expected LastSeenAt to be set on creation")
+	}
+
+	// Move the last sighting back in time to make changes obvious
+	lastSeen := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := db.Model(&models.Issue{}).Where("id = ?", issue.ID).Update("last_seen_at", lastSeen).Error; err != nil {
+		t.Fatalf("Failed to set last seen time: %v", err)
+	}
+
+	// A pure title edit must not change when the issue was last seen
+	editedIssue, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{Title: "Edited title"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !editedIssue.LastSeenAt.Equal(lastSeen) {
+		t.Errorf("Expected LastSeenAt to stay at %v after an edit, got %v", lastSeen, editedIssue.LastSeenAt)
+	}
+
+	// The same problem being reported again is a new sighting
+	duplicateIssue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if duplicateIssue.ID != issue.ID {
+		t.Fatalf("Expected duplicate to update issue %s, got %s", issue.ID, duplicateIssue.ID)
+	}
+	if !duplicateIssue.LastSeenAt.After(lastSeen) {
+		t.Errorf("Expected LastSeenAt to advance past %v on a duplicate, got %v", lastSeen, duplicateIssue.LastSeenAt)
+	}
+
+	// Filter by the last sighting
+	filters := IssueQueryFilters{LastSeenAfter: &lastSeen, SortBy: "lastSeenAt", Limit: 10}
+	issues, total, err := repo.FindAll(ctx, filters)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != issue.ID {
+		t.Errorf("Expected only issue %s to be seen after %v, got %d issues", issue.ID, lastSeen, total)
+	}
+}
+
+func TestIssueRepository_BulkDelete(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	var ids []string
+	for _, name := range []string{"component-a", "component-b", "component-c"} {
+		req := createTestIssue("Bulk Delete "+name, "test-namespace")
+		req.Scope.ResourceName = name
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := repo.AddRelatedIssue(ctx, ids[0], ids[1]); err != nil {
+		t.Fatalf("Failed to add related issue: %v", err)
+	}
+
+	// Delete one of the issues beforehand
+	if err := repo.Delete(ctx, ids[2]); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+
+	unknownID := "a4a9f17e-5a5e-4f0a-8f8c-000000000000"
+	results, err := repo.BulkDelete(ctx, "", []string{ids[0], ids[2], unknownID, ids[1]})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedStatuses := map[string]string{
+		ids[0]:    dto.BulkIssueDeleted,
+		ids[2]:    dto.BulkIssueError,
+		unknownID: dto.BulkIssueError,
+		ids[1]:    dto.BulkIssueDeleted,
+	}
+	if len(results) != len(expectedStatuses) {
+		t.Fatalf("Expected %d results, got %d", len(expectedStatuses), len(results))
+	}
+	for _, result := range results {
+		if result.Status != expectedStatuses[result.ID] {
+			t.Errorf("Expected issue %s to be '%s', got '%s' (%s)", result.ID, expectedStatuses[result.ID], result.Status, result.Error)
+		}
+	}
+
+	// Everything belonging to the issues should be gone
+	for _, model := range []interface{}{&models.Issue{}, &models.IssueScope{}, &models.Link{}, &models.RelatedIssue{}} {
+		var count int64
+		db.Model(model).Count(&count)
+		if count != 0 {
+			t.Errorf("Expected no %T records left, got %d", model, count)
+		}
+	}
+}
+
+func TestIssueRepository_BulkDelete_OtherNamespace(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Other Namespace", "team-other"))
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	results, err := repo.BulkDelete(ctx, "test-namespace", []string{issue.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Status != dto.BulkIssueError {
+		t.Fatalf("Expected deletion to fail for another namespace, got %+v", results)
+	}
+
+	existing, err := repo.FindByID(ctx, issue.ID)
+	if err != nil || existing == nil {
+		t.Errorf("Expected issue to still exist, got %v (%v)", existing, err)
+	}
+}
+
+func TestIssueRepository_ResolveWithCascade(t *testing.T) {
+	// Setup
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{WithClock(clock)}})
+
+	issues := map[string]string{}
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		namespace := "test-namespace"
+		if name == "E" {
+			namespace = "other-namespace"
+		}
+		req := createTestIssue("Cascade "+name, namespace)
+		req.Scope.ResourceName = "component-" + name
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+		issues[name] = issue.ID
+	}
+
+	// A caused B, B caused C and C caused A (a cycle). D only relates to A. A caused E,
+	// in a namespace the cascade doesn't reach.
+	edges := []dto.RelationshipEdgeRequest{
+		{SourceID: issues["A"], TargetID: issues["B"], Kind: models.RelationshipCausedBy},
+		{SourceID: issues["B"], TargetID: issues["C"], Kind: models.RelationshipCausedBy},
+		{SourceID: issues["C"], TargetID: issues["A"], Kind: models.RelationshipCausedBy},
+		{SourceID: issues["A"], TargetID: issues["D"], Kind: models.RelationshipRelatesTo},
+		{SourceID: issues["A"], TargetID: issues["E"], Kind: models.RelationshipCausedBy},
+	}
+	if _, err := repo.BatchAddRelatedIssues(ctx, edges); err != nil {
+		t.Fatalf("Failed to add related issues: %v", err)
+	}
+
+	clock.Advance(time.Hour)
+	cascaded, err := repo.ResolveWithCascade(ctx, issues["A"])
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cascaded) != 2 {
+		t.Errorf("Expected 2 issues resolved by the cascade, got %d", len(cascaded))
+	}
+
+	expectedStates := map[string]models.IssueState{
+		"A": models.IssueStateResolved,
+		"B": models.IssueStateResolved,
+		"C": models.IssueStateResolved,
+		"D": models.IssueStateActive,
+		"E": models.IssueStateActive,
+	}
+	for name, expectedState := range expectedStates {
+		issue, err := repo.FindByID(ctx, issues[name])
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.State != expectedState {
+			t.Errorf("Expected issue %s to be %s, got %s", name, expectedState, issue.State)
+		}
+	}
+
+	issueB, err := repo.FindByID(ctx, issues["B"])
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedNote := "Resolved via related issue " + issues["A"]
+	if len(issueB.Notes) != 1 || issueB.Notes[0].Content != expectedNote {
+		t.Errorf("Expected B to have the note '%s', got %+v", expectedNote, issueB.Notes)
+	}
+
+	issueA, err := repo.FindByID(ctx, issues["A"])
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issueA.Notes) != 0 {
+		t.Errorf("Expected no notes on the root issue, got %+v", issueA.Notes)
+	}
+	if issueA.ResolvedBy != models.ResolutionSourceManual {
+		t.Errorf("Expected the root issue to be resolved by %s, got '%s'", models.ResolutionSourceManual, issueA.ResolvedBy)
+	}
+	if issueB.ResolvedBy != models.ResolutionSourceCascade {
+		t.Errorf("Expected B to be resolved by %s, got '%s'", models.ResolutionSourceCascade, issueB.ResolvedBy)
+	}
+	// Resolutions are changes, timestamped with the clock of the repository
+	if !issueB.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("Expected B to be updated at %s, got %s", clock.Now(), issueB.UpdatedAt)
+	}
+}
+
+func TestIssueRepository_FindAll_MultipleNamespaces(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for _, namespace := range []string{"team-a", "team-b", "team-c"} {
+		if _, _, err := repo.Create(ctx, createTestIssue("Issue in "+namespace, namespace)); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespaces: []string{"team-a", "team-c"}, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if total != 2 || len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", total)
+	}
+	for _, issue := range issues {
+		if issue.Namespace != "team-a" && issue.Namespace != "team-c" {
+			t.Errorf("Unexpected issue from namespace '%s'", issue.Namespace)
+		}
+	}
+}
+
+func TestIssueRepository_FindAll_States(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for _, state := range []models.IssueState{models.IssueStateActive, models.IssueStateAcknowledged, models.IssueStateResolved} {
+		req := createTestIssue("Issue "+string(state), "team-states")
+		req.Scope.ResourceName = "component-" + string(state)
+		req.State = state
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		group    models.IssueStateGroup
+		expected int64
+	}{
+		{models.IssueStateGroupOpen, 2},
+		{models.IssueStateGroupClosed, 1},
+		{models.IssueStateGroupAll, 3},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.group), func(t *testing.T) {
+			states, ok := tt.group.States()
+			if !ok {
+				t.Fatalf("Expected %s to be a known group", tt.group)
+			}
+
+			issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-states", States: states, Limit: 10})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if total != tt.expected || len(issues) != int(tt.expected) {
+				t.Fatalf("Expected %d issues, got %d", tt.expected, total)
+			}
+			for _, issue := range issues {
+				if tt.group == models.IssueStateGroupOpen && issue.State == models.IssueStateResolved {
+					t.Errorf("Unexpected resolved issue '%s' among open issues", issue.Title)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueRepository_GroupByResource(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	newIssue := func(resourceType, resourceName string, issueType models.IssueType, severity models.Severity) {
+		req := createTestIssue(string(issueType)+" failed", "team-grouped")
+		req.Scope.ResourceType = resourceType
+		req.Scope.ResourceName = resourceName
+		req.IssueType = issueType
+		req.Severity = severity
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+	newIssue("component", "frontend", models.IssueTypeBuild, models.SeverityMinor)
+	newIssue("component", "frontend", models.IssueTypeTest, models.SeverityMajor)
+	newIssue("component", "frontend", models.IssueTypeRelease, models.SeverityMinor)
+	newIssue("component", "backend", models.IssueTypeBuild, models.SeverityCritical)
+	newIssue("pipelinerun", "deploy", models.IssueTypePipeline, models.SeverityInfo)
+
+	// The issues listed are capped, not the counts
+	groups, err := repo.GroupByResource(ctx, IssueQueryFilters{Namespace: "team-grouped"}, 2, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].Resource.Name != "backend" || groups[0].MaxSeverity != models.SeverityCritical {
+		t.Errorf("Expected the critical backend group first, got %+v", groups[0].Resource)
+	}
+	frontend := groups[1]
+	if frontend.Resource.Name != "frontend" || frontend.MaxSeverity != models.SeverityMajor {
+		t.Errorf("Expected the major frontend group second, got %+v", frontend.Resource)
+	}
+	if frontend.IssueCount != 3 || frontend.ActiveCount != 3 {
+		t.Errorf("Expected 3 active issues in the frontend group, got %d with %d active", frontend.IssueCount, frontend.ActiveCount)
+	}
+	if len(frontend.Issues) != 2 {
+		t.Errorf("Expected 2 issues listed in the frontend group, got %d", len(frontend.Issues))
+	}
+
+	// Scope filters join the scopes too
+	groups, err = repo.GroupByResource(ctx, IssueQueryFilters{Namespace: "team-grouped", ResourceType: "pipelinerun"}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(groups) != 1 || groups[0].Resource.Name != "deploy" || len(groups[0].Issues) != 1 {
+		t.Errorf("Expected only the deploy group, got %+v", groups)
+	}
+}
+
+func TestIssueRepository_ResolvedBy(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	webhookReq := createTestIssue("Resolved by a webhook", "team-resolution")
+	webhookReq.Scope.ResourceName = "component-webhook"
+	webhookIssue, _, err := repo.Create(ctx, webhookReq)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+	manualReq := createTestIssue("Resolved by a user", "team-resolution")
+	manualReq.Scope.ResourceName = "component-manual"
+	manualIssue, _, err := repo.Create(ctx, manualReq)
+	if err != nil {
+		t.Fatalf("Failed to create test issue: %v", err)
+	}
+
+	if _, err := repo.ResolveByScope(ctx, "component", "component-webhook", "team-resolution"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.Update(ctx, manualIssue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	expected := map[string]models.ResolutionSource{
+		webhookIssue.ID: models.ResolutionSourceSuccessWebhook,
+		manualIssue.ID:  models.ResolutionSourceManual,
+	}
+	for id, source := range expected {
+		issue, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.ResolvedBy != source {
+			t.Errorf("Expected '%s' to be resolved by %s, got '%s'", issue.Title, source, issue.ResolvedBy)
+		}
+	}
+
+	source := models.ResolutionSourceSuccessWebhook
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-resolution", ResolvedBy: &source})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || issues[0].ID != webhookIssue.ID {
+		t.Errorf("Expected only the issue resolved by a webhook, got %d issues", total)
+	}
+
+	// A reopened issue is no longer resolved by anything
+	reopened, err := repo.Update(ctx, manualIssue.ID, dto.UpdateIssueRequest{State: models.IssueStateActive})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if reopened.ResolvedBy != "" {
+		t.Errorf("Expected the reopened issue to have no resolution source, got '%s'", reopened.ResolvedBy)
+	}
+}
+
+func TestIssueRepository_FindAll_ResourceNamespaces(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for _, resourceNamespace := range []string{"team-a", "shared-infra"} {
+		req := createTestIssue("Issue on "+resourceNamespace, "team-a")
+		req.Scope.ResourceName = "component-" + resourceNamespace
+		req.Scope.ResourceNamespace = resourceNamespace
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Failed to create test issue: %v", err)
+		}
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-a", ResourceNamespaces: []string{"team-a"}, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if total != 1 || len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", total)
+	}
+	if issues[0].Scope.ResourceNamespace != "team-a" {
+		t.Errorf("Unexpected issue scoped to namespace '%s'", issues[0].Scope.ResourceNamespace)
+	}
+}
+
+func TestIssueRepository_AddLink(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Linked Issue", "test-namespace")
+	req.Links = []dto.CreateLinkRequest{
+		{Title: "Logs", URL: "https://konflux.test/logs", Primary: true},
+	}
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	link, err := repo.AddLink(ctx, issue.ID, dto.CreateLinkRequest{
+		Title: "Docs",
+		URL:   "https://konflux.test/docs",
+		Order: 1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if link.ID == "" || link.IssueID != issue.ID {
+		t.Errorf("Expected link to be created for issue %s, got %+v", issue.ID, link)
+	}
+
+	// Appending must preserve the existing links
+	updatedIssue, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedTitles := []string{"Logs", "Docs"}
+	if len(updatedIssue.Links) != len(expectedTitles) {
+		t.Fatalf("Expected %d links, got %d", len(expectedTitles), len(updatedIssue.Links))
+	}
+	for idx, l := range updatedIssue.Links {
+		if l.Title != expectedTitles[idx] {
+			t.Errorf("Expected link %d to be '%s', got '%s'", idx, expectedTitles[idx], l.Title)
+		}
+	}
+
+	// A second primary link must be rejected
+	_, err = repo.AddLink(ctx, issue.ID, dto.CreateLinkRequest{
+		Title:   "Dashboard",
+		URL:     "https://konflux.test/dashboard",
+		Primary: true,
+	})
+	if !errors.Is(err, ErrMultiplePrimaryLinks) {
+		t.Errorf("Expected ErrMultiplePrimaryLinks, got %v", err)
+	}
+
+	// Unknown issues are reported
+	_, err = repo.AddLink(ctx, "non-existent-id", dto.CreateLinkRequest{Title: "Docs", URL: "https://konflux.test/docs"})
+	if !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func TestIssueRepository_Attachments(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Issue with attachments", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	names := []string{"build.log", "screenshot.png"}
+	for idx, name := range names {
+		attachment, err := repo.AddAttachment(ctx, issue.ID, dto.CreateAttachmentRequest{
+			Name:        name,
+			ContentType: "text/plain",
+			Size:        int64(1024 * (idx + 1)),
+			StorageURL:  "s3://kite-attachments/" + issue.ID + "/" + name,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if attachment.ID == "" || attachment.IssueID != issue.ID {
+			t.Errorf("Expected attachment to be registered for issue %s, got %+v", issue.ID, attachment)
+		}
+	}
+
+	attachments, err := repo.FindAttachments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(attachments) != len(names) {
+		t.Fatalf("Expected %d attachments, got %d", len(names), len(attachments))
+	}
+	for idx, attachment := range attachments {
+		if attachment.Name != names[idx] {
+			t.Errorf("Expected attachment %d to be '%s', got '%s'", idx, names[idx], attachment.Name)
+		}
+	}
+	if attachments[1].Size != 2048 || attachments[1].StorageURL != "s3://kite-attachments/"+issue.ID+"/screenshot.png" {
+		t.Errorf("Expected the metadata of the attachment to be stored, got %+v", attachments[1])
+	}
+
+	// Unknown issues are reported
+	_, err = repo.AddAttachment(ctx, "non-existent-id", dto.CreateAttachmentRequest{
+		Name:        "build.log",
+		ContentType: "text/plain",
+		Size:        1024,
+		StorageURL:  "s3://kite-attachments/build.log",
+	})
+	if !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+
+	// Attachments are deleted with their issue
+	if err := repo.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	var count int64
+	db.Model(&models.Attachment{}).Where("issue_id = ?", issue.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the attachments to be deleted with the issue, got %d", count)
+	}
+}
+
+func TestIssueRepository_UpdateLink(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Linked Issue", "test-namespace")
+	req.Links = []dto.CreateLinkRequest{
+		{Title: "Logs", URL: "https://konflux.test/logs", Order: 0, Primary: true},
+		{Title: "Docs", URL: "https://konflux.test/docs", Order: 1},
+	}
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	logsID, docsID := issue.Links[0].ID, issue.Links[1].ID
+
+	// The primary flag can't be moved while another link holds it
+	_, err = repo.UpdateLink(ctx, issue.ID, docsID, dto.CreateLinkRequest{
+		Title: "Docs", URL: "https://konflux.test/docs", Order: 1, Primary: true,
+	})
+	if !errors.Is(err, ErrMultiplePrimaryLinks) {
+		t.Errorf("Expected ErrMultiplePrimaryLinks, got %v", err)
+	}
+
+	// Clearing fields must be persisted too
+	updated, err := repo.UpdateLink(ctx, issue.ID, logsID, dto.CreateLinkRequest{
+		Title: "Build logs", URL: "https://konflux.test/build-logs", Order: 2,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.Title != "Build logs" || updated.Primary {
+		t.Errorf("Unexpected updated link %+v", updated)
+	}
+
+	updatedIssue, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedTitles := []string{"Docs", "Build logs"}
+	if len(updatedIssue.Links) != len(expectedTitles) {
+		t.Fatalf("Expected %d links, got %d", len(expectedTitles), len(updatedIssue.Links))
+	}
+	for idx, l := range updatedIssue.Links {
+		if l.Title != expectedTitles[idx] {
+			t.Errorf("Expected link %d to be '%s', got '%s'", idx, expectedTitles[idx], l.Title)
+		}
+		if l.Primary {
+			t.Errorf("Expected no primary link, got '%s'", l.Title)
+		}
+	}
+
+	// Links can't be edited through another issue
+	_, err = repo.UpdateLink(ctx, "non-existent-id", docsID, dto.CreateLinkRequest{Title: "Docs", URL: "https://konflux.test/docs"})
+	if !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("Expected ErrLinkNotFound, got %v", err)
+	}
+}
+
+func TestIssueRepository_DeleteLink(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	req := createTestIssue("Linked Issue", "test-namespace")
+	req.Links = []dto.CreateLinkRequest{
+		{Title: "Logs", URL: "https://konflux.test/logs", Order: 0},
+		{Title: "Docs", URL: "https://konflux.test/docs", Order: 1},
+		{Title: "Dashboard", URL: "https://konflux.test/dashboard", Order: 2},
+	}
+	issue, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	if err := repo.DeleteLink(ctx, issue.ID, issue.Links[1].ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Deleting one link must leave the others intact
+	updatedIssue, err := repo.FindByID(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedTitles := []string{"Logs", "Dashboard"}
+	if len(updatedIssue.Links) != len(expectedTitles) {
+		t.Fatalf("Expected %d links, got %d", len(expectedTitles), len(updatedIssue.Links))
+	}
+	for idx, l := range updatedIssue.Links {
+		if l.Title != expectedTitles[idx] {
+			t.Errorf("Expected link %d to be '%s', got '%s'", idx, expectedTitles[idx], l.Title)
+		}
+	}
+
+	// Deleting it again reports it's gone
+	err = repo.DeleteLink(ctx, issue.ID, issue.Links[1].ID)
+	if !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("Expected ErrLinkNotFound, got %v", err)
+	}
+}
+
+func TestIssueRepository_AddRelatedIssue_Limit(t *testing.T) {
+	// Setup
+	const limit = 3
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithMaxRelationshipsPerIssue(limit)},
+	})
+
+	hub, _, err := repo.Create(ctx, createTestIssue("Hub", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	spokes := make([]*models.Issue, limit+1)
+	for idx := range spokes {
+		req := createTestIssue(fmt.Sprintf("Spoke %d", idx), "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("spoke-%d", idx)
+		spokes[idx], _, err = repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	// Relationships in both directions count towards the limit
+	for idx := 0; idx < limit; idx++ {
+		sourceID, targetID := hub.ID, spokes[idx].ID
+		if idx%2 == 1 {
+			sourceID, targetID = targetID, sourceID
+		}
+		if err := repo.AddRelatedIssue(ctx, sourceID, targetID); err != nil {
+			t.Fatalf("Unexpected error adding relationship %d, got %v", idx, err)
+		}
+	}
+
+	err = repo.AddRelatedIssue(ctx, spokes[limit].ID, hub.ID)
+	if !errors.Is(err, ErrRelationshipLimitExceeded) {
+		t.Fatalf("Expected ErrRelationshipLimitExceeded, got %v", err)
+	}
+
+	// The batch reports the edge over the limit without failing the others
+	results, err := repo.BatchAddRelatedIssues(ctx, []dto.RelationshipEdgeRequest{
+		{SourceID: hub.ID, TargetID: spokes[limit].ID},
+		{SourceID: spokes[0].ID, TargetID: spokes[limit].ID},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if results[0].Status != dto.RelationshipEdgeError {
+		t.Errorf("Expected the edge over the limit to fail, got %s", results[0].Status)
+	}
+	if results[1].Status != dto.RelationshipEdgeCreated {
+		t.Errorf("Expected the edge under the limit to be created, got %s", results[1].Status)
+	}
+}
+
+func TestIssueRepository_RemoveAllRelated(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issues := make([]*models.Issue, 4)
+	for idx := range issues {
+		req := createTestIssue(fmt.Sprintf("Issue %d", idx), "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", idx)
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		issues[idx] = issue
+	}
+
+	// The issue is related to others in both directions, and the others to each other
+	edges := [][2]int{{0, 1}, {2, 0}, {0, 3}, {1, 2}}
+	for _, edge := range edges {
+		if err := repo.AddRelatedIssue(ctx, issues[edge[0]].ID, issues[edge[1]].ID); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	removed, err := repo.RemoveAllRelated(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected 3 relationships removed, got %d", removed)
+	}
+
+	// The issues remain, and only the relationships of the issue are removed
+	for _, issue := range issues {
+		found, err := repo.FindByID(ctx, issue.ID)
+		if err != nil || found == nil {
+			t.Fatalf("Expected issue %s to remain, got %v", issue.ID, err)
+		}
+		related := len(found.RelatedFrom) + len(found.RelatedTo)
+		expected := 0
+		if issue.ID == issues[1].ID || issue.ID == issues[2].ID {
+			expected = 1
+		}
+		if related != expected {
+			t.Errorf("Expected issue %s to have %d relationships, got %d", issue.Title, expected, related)
+		}
+	}
+
+	// Nothing is left to remove
+	if removed, err := repo.RemoveAllRelated(ctx, issues[0].ID); err != nil || removed != 0 {
+		t.Errorf("Expected no relationships left to remove, got %d and %v", removed, err)
+	}
+
+	if _, err := repo.RemoveAllRelated(ctx, "00000000-0000-0000-0000-000000000000"); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func TestIssueRepository_FindRelatedGraph(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	// A chain of issues, each related to the next, with a branch off the root
+	// and an issue of another namespace related to the root
+	issues := make([]*models.Issue, 8)
+	for idx := range issues {
+		req := createTestIssue(fmt.Sprintf("Issue %d", idx), "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", idx)
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		issues[idx] = issue
+	}
+	for idx := range issues[:6] {
+		if err := repo.AddRelatedIssue(ctx, issues[idx].ID, issues[idx+1].ID); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+	if err := repo.AddRelatedIssue(ctx, issues[7].ID, issues[0].ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	otherReq := createTestIssue("Other namespace", "other-namespace")
+	other, _, err := repo.Create(ctx, otherReq)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := repo.AddRelatedIssue(ctx, issues[0].ID, other.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	nodeIDs := func(graph *dto.IssueGraph) map[string]bool {
+		ids := make(map[string]bool)
+		for _, node := range graph.Nodes {
+			ids[node.ID] = true
+		}
+		return ids
+	}
+
+	// Below the cap, the whole graph of the namespace is returned
+	graph, err := repo.FindRelatedGraph(ctx, issues[0].ID, 200)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if graph.Truncated || len(graph.Nodes) != 8 || len(graph.Edges) != 7 {
+		t.Errorf("Expected the 8 issues and 7 relationships, got %d issues, %d relationships and truncated %v",
+			len(graph.Nodes), len(graph.Edges), graph.Truncated)
+	}
+	if nodeIDs(graph)[other.ID] {
+		t.Error("Expected the issue of another namespace to be left out")
+	}
+
+	// Over the cap, the closest issues are returned and the graph is flagged as truncated
+	graph, err = repo.FindRelatedGraph(ctx, issues[0].ID, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !graph.Truncated {
+		t.Error("Expected the graph to be truncated")
+	}
+	if len(graph.Nodes) != 4 || len(graph.Edges) > 4 {
+		t.Fatalf("Expected 4 issues and at most 4 relationships, got %d and %d", len(graph.Nodes), len(graph.Edges))
+	}
+	ids := nodeIDs(graph)
+	for _, closest := range []*models.Issue{issues[0], issues[1], issues[7], issues[2]} {
+		if !ids[closest.ID] {
+			t.Errorf("Expected %s, among the closest issues, to be returned", closest.Title)
+		}
+	}
+	for _, edge := range graph.Edges {
+		if !ids[edge.SourceID] || !ids[edge.TargetID] {
+			t.Errorf("Expected only relationships between returned issues, got %+v", edge)
+		}
+	}
+
+	// Relationships are capped too, e.g. for 4 issues all related to each other
+	clique := make([]*models.Issue, 4)
+	for idx := range clique {
+		req := createTestIssue(fmt.Sprintf("Clique %d", idx), "clique-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", idx)
+		if clique[idx], _, err = repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		for _, previous := range clique[:idx] {
+			if err := repo.AddRelatedIssue(ctx, previous.ID, clique[idx].ID); err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+		}
+	}
+	graph, err = repo.FindRelatedGraph(ctx, clique[0].ID, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !graph.Truncated || len(graph.Nodes) != 4 || len(graph.Edges) != 4 {
+		t.Errorf("Expected 4 issues, 4 of the 6 relationships and truncated, got %d, %d and %v",
+			len(graph.Nodes), len(graph.Edges), graph.Truncated)
+	}
+
+	if _, err := repo.FindRelatedGraph(ctx, "00000000-0000-0000-0000-000000000000", 200); !errors.Is(err, ErrIssueNotFound) {
+		t.Errorf("Expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_Occurrences(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithDedupOptions(DedupOptions{
+			IncludeResolved: true,
+			MaxOccurrences:  2,
+		})},
+	})
+
+	var issue *models.Issue
+	for idx := 0; idx < 4; idx++ {
+		req := createTestIssue("Recurring Issue", "test-namespace")
+		req.Description = fmt.Sprintf("Failure %d", idx)
+
+		var err error
+		issue, _, err = repo.CreateOrUpdate(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	// Only the most recent recurrences are kept
+	occurrences, err := repo.FindOccurrences(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedDetails := []string{"Failure 3", "Failure 2"}
+	if len(occurrences) != len(expectedDetails) {
+		t.Fatalf("Expected %d occurrences, got %d", len(expectedDetails), len(occurrences))
+	}
+	for idx, occurrence := range occurrences {
+		if occurrence.Detail != expectedDetails[idx] {
+			t.Errorf("Expected occurrence %d to be '%s', got '%s'", idx, expectedDetails[idx], occurrence.Detail)
+		}
+	}
+
+	// Occurrences are removed along with the issue
+	if err := repo.Delete(ctx, issue.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	occurrences, err = repo.FindOccurrences(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(occurrences) != 0 {
+		t.Errorf("Expected occurrences to be deleted, got %d", len(occurrences))
+	}
+}
+
+func TestIssueRepository_OccurrenceHistogram(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Recurring Issue", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	otherReq := createTestIssue("Other Issue", "test-namespace")
+	otherReq.Scope.ResourceName = "other-component"
+	other, _, err := repo.Create(ctx, otherReq)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := db.Where("issue_id IN ?", []string{issue.ID, other.ID}).Delete(&models.Occurrence{}).Error; err != nil {
+		t.Fatalf("Failed to clear occurrences: %v", err)
+	}
+
+	// 2026-10-12 is a Monday
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	seeded := []time.Time{
+		monday.Add(-30 * time.Minute),
+		monday.Add(8*time.Hour + 5*time.Minute),
+		monday.Add(8*time.Hour + 40*time.Minute),
+		monday.Add(9*time.Hour + 10*time.Minute),
+		monday.Add(11*time.Hour + 59*time.Minute),
+	}
+	for _, at := range seeded {
+		if err := db.Create(&models.Occurrence{IssueID: issue.ID, OccurredAt: at}).Error; err != nil {
+			t.Fatalf("Failed to create occurrence: %v", err)
+		}
+	}
+	// Occurrences of other issues aren't counted
+	if err := db.Create(&models.Occurrence{IssueID: other.ID, OccurredAt: seeded[1]}).Error; err != nil {
+		t.Fatalf("Failed to create occurrence: %v", err)
+	}
+
+	tests := []struct {
+		unit     string
+		since    time.Time
+		expected []dto.OccurrenceBucket
+	}{
+		{"hour", monday, []dto.OccurrenceBucket{
+			{Start: monday.Add(8 * time.Hour), Count: 2},
+			{Start: monday.Add(9 * time.Hour), Count: 1},
+			{Start: monday.Add(11 * time.Hour), Count: 1},
+		}},
+		{"minute", monday.Add(11 * time.Hour), []dto.OccurrenceBucket{
+			{Start: monday.Add(11*time.Hour + 59*time.Minute), Count: 1},
+		}},
+		{"day", time.Time{}, []dto.OccurrenceBucket{
+			{Start: monday.AddDate(0, 0, -1), Count: 1},
+			{Start: monday, Count: 4},
+		}},
+		{"week", time.Time{}, []dto.OccurrenceBucket{
+			{Start: monday.AddDate(0, 0, -7), Count: 1},
+			{Start: monday, Count: 4},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			buckets, err := repo.OccurrenceHistogram(ctx, issue.ID, tt.unit, tt.since)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if len(buckets) != len(tt.expected) {
+				t.Fatalf("Expected %d buckets, got %+v", len(tt.expected), buckets)
+			}
+			for idx, want := range tt.expected {
+				if !buckets[idx].Start.Equal(want.Start) || buckets[idx].Count != want.Count {
+					t.Errorf("Expected bucket %+v, got %+v", want, buckets[idx])
+				}
+			}
+		})
+	}
+
+	if _, err := repo.OccurrenceHistogram(ctx, issue.ID, "second", time.Time{}); err == nil {
+		t.Error("Expected an error for an invalid bucket")
+	}
+}
+
+func TestIssueRepository_CreateOrUpdate_MinUpdateInterval(t *testing.T) {
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{
+			WithClock(clock),
+			WithDedupOptions(DedupOptions{
+				IncludeResolved:   true,
+				MaxOccurrences:    50,
+				MinUpdateInterval: time.Second,
+			}),
+		},
+	})
+
+	// A noisy producer reports the same failure every 100ms for 2.5s
+	var issue *models.Issue
+	for idx := range 26 {
+		req := createTestIssue("Noisy Issue", "team-noisy")
+		req.Description = fmt.Sprintf("Failure %d", idx)
+
+		var err error
+		issue, _, err = repo.CreateOrUpdate(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	// Every report is counted
+	if issue.OccurrenceCount != 26 {
+		t.Errorf("Expected 26 occurrences counted, got %d", issue.OccurrenceCount)
+	}
+	if expected := clock.Now().Add(-100 * time.Millisecond); !issue.LastSeenAt.Equal(expected) {
+		t.Errorf("Expected the issue to be last seen at %s, got %s", expected, issue.LastSeenAt)
+	}
+
+	// But the issue is only updated once a second, by the reports at 1s and 2s
+	occurrences, err := repo.FindOccurrences(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expectedDetails := []string{"Failure 20", "Failure 10"}
+	if len(occurrences) != len(expectedDetails) {
+		t.Fatalf("Expected %d full updates, got %d", len(expectedDetails), len(occurrences))
+	}
+	for idx, occurrence := range occurrences {
+		if occurrence.Detail != expectedDetails[idx] {
+			t.Errorf("Expected update %d to be '%s', got '%s'", idx, expectedDetails[idx], occurrence.Detail)
+		}
+	}
+	if issue.Description != "Failure 20" {
+		t.Errorf("Expected the description of the last full update, got '%s'", issue.Description)
+	}
+
+	// Reports changing the state of the issue are never coalesced
+	req := createTestIssue("Noisy Issue", "team-noisy")
+	req.State = models.IssueStateResolved
+	resolved, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.State != models.IssueStateResolved {
+		t.Errorf("Expected the issue to be resolved, got %s", resolved.State)
+	}
+	if resolved.OccurrenceCount != 27 {
+		t.Errorf("Expected 27 occurrences counted, got %d", resolved.OccurrenceCount)
+	}
+}
+
+func TestIssueRepository_SnoozedUntilRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{"updated duplicate", nil},
+		// Duplicates that are only counted are recurrences too
+		{"coalesced duplicate", []Option{WithDedupOptions(DedupOptions{MinUpdateInterval: time.Hour})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: tt.options})
+
+			issue, _, err := repo.CreateOrUpdate(ctx, createTestIssue("Flaky Issue", "team-snooze"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			other, _, err := repo.Create(ctx, createTestIssue("Other Issue", "team-other"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+
+			snoozed := true
+			issue, err = repo.Patch(ctx, issue.ID, IssuePatch{SnoozedUntilRecurrence: &snoozed})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if !issue.SnoozedUntilRecurrence {
+				t.Fatal("Expected the issue to be snoozed")
+			}
+
+			// Snoozed issues are only left out when asked to
+			found, total, err := repo.FindAll(ctx, IssueQueryFilters{ExcludeSnoozed: true})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if total != 1 || len(found) != 1 || found[0].ID != other.ID {
+				t.Errorf("Expected only the issue that isn't snoozed, got %d issues", total)
+			}
+			if _, total, _ := repo.FindAll(ctx, IssueQueryFilters{}); total != 2 {
+				t.Errorf("Expected 2 issues including the snoozed one, got %d", total)
+			}
+
+			// The next occurrence resurfaces the issue
+			time.Sleep(time.Millisecond)
+			recurred, _, err := repo.CreateOrUpdate(ctx, createTestIssue("Flaky Issue", "team-snooze"))
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if recurred.ID != issue.ID {
+				t.Fatalf("Expected the duplicate to update issue %s, got %s", issue.ID, recurred.ID)
+			}
+			if recurred.SnoozedUntilRecurrence {
+				t.Error("Expected the recurrence to clear the snooze")
+			}
+			// Clients polling for changes see the resurfaced issue
+			changed, _, err := repo.FindAll(ctx, IssueQueryFilters{ChangedSince: &issue.UpdatedAt})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if len(changed) != 1 || changed[0].ID != issue.ID {
+				t.Errorf("Expected the resurfaced issue to be changed, got %d issues", len(changed))
+			}
+			if _, total, _ := repo.FindAll(ctx, IssueQueryFilters{ExcludeSnoozed: true}); total != 2 {
+				t.Errorf("Expected the resurfaced issue to be found, got %d issues", total)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_Search(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	issues := []struct {
+		title       string
+		description string
+	}{
+		{"Unrelated failure", "The build failed because of a missing dependency"},
+		{"Task run failed", "The pipeline run failed after a Timeout in the test step"},
+		{"Timeout in build", "The build task hit a timeout, a second timeout followed"},
+	}
+	for idx, issue := range issues {
+		req := createTestIssue(issue.title, "test-namespace")
+		req.Description = issue.description
+		req.Scope.ResourceName = fmt.Sprintf("search-component-%d", idx)
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	results, err := repo.Search(ctx, IssueQueryFilters{Search: "timeout", Namespace: "test-namespace"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Matches in the title rank higher
+	expectedTitles := []string{"Timeout in build", "Task run failed"}
+	if len(results) != len(expectedTitles) {
+		t.Fatalf("Expected %d results, got %d", len(expectedTitles), len(results))
+	}
+	for idx, result := range results {
+		if result.Issue.Title != expectedTitles[idx] {
+			t.Errorf("Expected result %d to be '%s', got '%s'", idx, expectedTitles[idx], result.Issue.Title)
+		}
+	}
+	if results[0].Score <= results[1].Score {
+		t.Errorf("Expected scores to decrease, got %f then %f", results[0].Score, results[1].Score)
+	}
+
+	// Matched terms are highlighted, keeping their case
+	if len(results[0].Highlights) != 2 {
+		t.Fatalf("Expected title and description highlights, got %v", results[0].Highlights)
+	}
+	if results[0].Highlights[0] != HighlightStart+"Timeout"+HighlightStop+" in build" {
+		t.Errorf("Unexpected title highlight '%s'", results[0].Highlights[0])
+	}
+	if len(results[1].Highlights) != 1 || !strings.Contains(results[1].Highlights[0], HighlightStart+"Timeout"+HighlightStop) {
+		t.Errorf("Expected the description to be highlighted, got %v", results[1].Highlights)
+	}
+
+	// Every term must match
+	results, err = repo.Search(ctx, IssueQueryFilters{Search: "timeout dependency"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got %d", len(results))
+	}
+}
+
+func TestIssueRepository_Search_Limit(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for idx := range DefaultSearchLimit + 1 {
+		req := createTestIssue(fmt.Sprintf("Timeout %d", idx), "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("limit-component-%d", idx)
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "default", limit: 0, expected: DefaultSearchLimit},
+		{name: "requested", limit: 5, expected: 5},
+		{name: "above the results", limit: MaxSearchLimit + 1, expected: DefaultSearchLimit + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.Search(ctx, IssueQueryFilters{Search: "timeout", Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if len(results) != tt.expected {
+				t.Errorf("Expected %d results, got %d", tt.expected, len(results))
+			}
+		})
+	}
+}
+
+func TestIssueRepository_Search_Escaping(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for idx, title := range []string{"Disk <b>100%</b> full", "Disk 1000 blocks full"} {
+		req := createTestIssue(title, "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("escape-component-%d", idx)
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	// Wildcards are matched literally
+	results, err := repo.Search(ctx, IssueQueryFilters{Search: "100%", Namespace: "test-namespace"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+
+	// Only the highlight markers are HTML
+	expected := "Disk &lt;b&gt;" + HighlightStart + "100%" + HighlightStop + "&lt;/b&gt; full"
+	if len(results[0].Highlights) == 0 || results[0].Highlights[0] != expected {
+		t.Errorf("Expected title highlight '%s', got %v", expected, results[0].Highlights)
+	}
+}
+
+func TestIssueRepository_FindAll_NoLimit(t *testing.T) {
+	// Setup
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	for idx := 0; idx < 3; idx++ {
+		req := createTestIssue(fmt.Sprintf("Issue %d", idx), "test-namespace")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", idx)
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	// The repository doesn't pick a page size on its own
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 3 || total != 3 {
+		t.Errorf("Expected all 3 issues, got %d (total %d)", len(issues), total)
+	}
+
+	issues, total, err = repo.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace", Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(issues) != 2 || total != 3 {
+		t.Errorf("Expected 2 of 3 issues, got %d (total %d)", len(issues), total)
+	}
+}
+
+func TestIssueRepository_ChangedSince(t *testing.T) {
+	// Setup
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	ids := make(map[string]string)
+	for _, name := range []string{"unchanged", "updated", "resolved", "deleted"} {
+		req := createTestIssue("Issue "+name, "team-sync")
+		req.Scope.ResourceName = name
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		ids[name] = issue.ID
+	}
+
+	// Every issue was last changed before the client's previous sync
+	lastSync := time.Now().Add(-time.Hour)
+	if err := db.Model(&models.Issue{}).Where("namespace = ?", "team-sync").
+		UpdateColumn("updated_at", lastSync.Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("Failed to age issues: %v", err)
+	}
+
+	if _, err := repo.Update(ctx, ids["updated"], dto.UpdateIssueRequest{Description: "Updated description"}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, err := repo.ResolveByScope(ctx, "component", "resolved", "team-sync"); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if err := repo.Delete(ctx, ids["deleted"]); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-sync", ChangedSince: &lastSync})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 changed issues, got %d", total)
+	}
+	for _, issue := range issues {
+		if issue.ID != ids["updated"] && issue.ID != ids["resolved"] {
+			t.Errorf("Expected only the updated and resolved issues, got %q", issue.Title)
+		}
+	}
+
+	deleted, err := repo.FindDeletedSince(ctx, IssueQueryFilters{Namespace: "team-sync"}, lastSync)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].IssueID != ids["deleted"] || deleted[0].Namespace != "team-sync" {
+		t.Errorf("Expected a tombstone for the deleted issue, got %+v", deleted)
+	}
+
+	// Tombstones are scoped to namespaces like issues
+	deleted, err = repo.FindDeletedSince(ctx, IssueQueryFilters{Namespace: "team-other"}, lastSync)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no tombstones for another namespace, got %+v", deleted)
+	}
+}
+
+func TestIssueRepository_AutoRelateSameResource(t *testing.T) {
+	// Issues of different types on the same resource aren't duplicates
+	buildFailure := createTestIssue("Build failed", "team-auto-relate")
+	testFailure := createTestIssue("Tests failed", "team-auto-relate")
+	testFailure.IssueType = models.IssueTypeTest
+	otherResource := createTestIssue("Release failed", "team-auto-relate")
+	otherResource.IssueType = models.IssueTypeRelease
+	otherResource.Scope.ResourceName = "other-component"
+
+	tests := []struct {
+		name            string
+		options         []Option
+		expectedRelated bool
+	}{
+		{"enabled", []Option{WithAutoRelateSameResource(DefaultMaxAutoRelations)}, true},
+		{"disabled", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: tt.options})
+
+			first, _, err := repo.Create(ctx, buildFailure)
+			if err != nil {
+				t.Fatalf("Failed to create issue: %v", err)
+			}
+			other, _, err := repo.Create(ctx, otherResource)
+			if err != nil {
+				t.Fatalf("Failed to create issue: %v", err)
+			}
+			second, _, err := repo.Create(ctx, testFailure)
+			if err != nil {
+				t.Fatalf("Failed to create issue: %v", err)
+			}
+
+			related := second.RelatedFrom
+			if tt.expectedRelated {
+				if len(related) != 1 || related[0].TargetID != first.ID || related[0].Kind != models.RelationshipRelatesTo {
+					t.Fatalf("Expected the issue to relate to %s, got %+v", first.ID, related)
+				}
+			} else if len(related) != 0 {
+				t.Fatalf("Expected no relationships, got %+v", related)
+			}
+
+			other, err = repo.FindByID(ctx, other.ID)
+			if err != nil {
+				t.Fatalf("Failed to find issue: %v", err)
+			}
+			if len(other.RelatedFrom)+len(other.RelatedTo) != 0 {
+				t.Errorf("Expected the issue on another resource not to be related")
+			}
+		})
+	}
+}
+
+func TestIssueRepository_AutoRelateSameResource_Capped(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithAutoRelateSameResource(2)},
+	})
+
+	issueTypes := []models.IssueType{
+		models.IssueTypeBuild,
+		models.IssueTypeTest,
+		models.IssueTypeRelease,
+		models.IssueTypeDependency,
+	}
+	var last *models.Issue
+	for _, issueType := range issueTypes {
+		req := createTestIssue(fmt.Sprintf("%s failed", issueType), "team-auto-relate")
+		req.IssueType = issueType
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		last = issue
+	}
+
+	if len(last.RelatedFrom) != 2 {
+		t.Errorf("Expected the last issue to be related to 2 issues, got %d", len(last.RelatedFrom))
+	}
+}
+
+func TestIssueRepository_MTTR(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	seeded := []struct {
+		issueType    models.IssueType
+		resourceName string
+		resolvedAgo  time.Duration
+		duration     time.Duration
+	}{
+		{models.IssueTypeBuild, "build-a", time.Hour, 10 * time.Minute},
+		{models.IssueTypeBuild, "build-b", time.Hour, 20 * time.Minute},
+		{models.IssueTypeBuild, "build-c", time.Hour, 60 * time.Minute},
+		{models.IssueTypeTest, "test-a", time.Hour, 2 * time.Hour},
+		// Resolved before the window
+		{models.IssueTypeTest, "test-b", 72 * time.Hour, 10 * time.Hour},
+		// Still active
+		{models.IssueTypeTest, "test-c", 0, 0},
+	}
+	for _, s := range seeded {
+		req := createTestIssue(s.resourceName, "team-mttr")
+		req.IssueType = s.issueType
+		req.Scope.ResourceName = s.resourceName
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		if s.resolvedAgo == 0 {
+			continue
+		}
+
+		resolvedAt := now.Add(-s.resolvedAgo)
+		err = db.Model(&models.Issue{}).Where("id = ?", issue.ID).Updates(map[string]any{
+			"state":       models.IssueStateResolved,
+			"detected_at": resolvedAt.Add(-s.duration),
+			"resolved_at": resolvedAt,
+		}).Error
+		if err != nil {
+			t.Fatalf("Failed to resolve issue: %v", err)
+		}
+	}
+	// Issues of other namespaces aren't included
+	other, _, err := repo.Create(ctx, createTestIssue("Other team", "team-other"))
+	if err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := db.Model(&models.Issue{}).Where("id = ?", other.ID).Updates(map[string]any{
+		"state":       models.IssueStateResolved,
+		"resolved_at": now,
+	}).Error; err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+
+	since := now.Add(-24 * time.Hour)
+	groups, err := repo.MTTR(ctx, IssueQueryFilters{Namespace: "team-mttr"}, since, "issueType")
+	if err != nil {
+		t.Fatalf("Failed to compute MTTR: %v", err)
+	}
+
+	expected := []dto.MTTRGroup{
+		{Group: "build", Count: 3, MeanSeconds: 30 * 60, MedianSeconds: 20 * 60},
+		{Group: "test", Count: 1, MeanSeconds: 2 * 60 * 60, MedianSeconds: 2 * 60 * 60},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d groups, got %+v", len(expected), groups)
+	}
+	for idx, want := range expected {
+		if groups[idx] != want {
+			t.Errorf("Expected %+v, got %+v", want, groups[idx])
+		}
+	}
+
+	// Without grouping nor window, all resolved issues are in a single group
+	groups, err = repo.MTTR(ctx, IssueQueryFilters{Namespace: "team-mttr"}, time.Time{}, "")
+	if err != nil {
+		t.Fatalf("Failed to compute MTTR: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Count != 5 || groups[0].MedianSeconds != 60*60 {
+		t.Errorf("Expected a single group of 5 issues with a median of 1h, got %+v", groups)
+	}
+}
+
+func TestIssueRepository_MaxActiveIssuesPerNamespace(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+		WithMaxActiveIssuesPerNamespace(2),
+	}})
+
+	newIssue := func(name, namespace string) dto.CreateIssueRequest {
+		req := createTestIssue("Issue on "+name, namespace)
+		req.Scope.ResourceName = name
+		return req
+	}
+
+	// Issues can be created up to the limit
+	var created []*models.Issue
+	for _, name := range []string{"component-a", "component-b"} {
+		issue, _, err := repo.Create(ctx, newIssue(name, "team-runaway"))
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		created = append(created, issue)
+	}
+
+	// The next one is rejected, whether created directly or by a webhook
+	if _, _, err := repo.Create(ctx, newIssue("component-c", "team-runaway")); !errors.Is(err, ErrNamespaceIssueLimitExceeded) {
+		t.Errorf("Expected ErrNamespaceIssueLimitExceeded, got %v", err)
+	}
+	if _, _, err := repo.CreateOrUpdate(ctx, newIssue("component-c", "team-runaway")); !errors.Is(err, ErrNamespaceIssueLimitExceeded) {
+		t.Errorf("Expected ErrNamespaceIssueLimitExceeded, got %v", err)
+	}
+
+	// Duplicates still update their issue, and other namespaces aren't limited
+	if _, _, err := repo.CreateOrUpdate(ctx, newIssue("component-a", "team-runaway")); err != nil {
+		t.Errorf("Expected the duplicate to be updated, got %v", err)
+	}
+	if _, _, err := repo.Create(ctx, newIssue("component-c", "team-quiet")); err != nil {
+		t.Errorf("Expected another namespace to be unaffected, got %v", err)
+	}
+
+	// Resolving an issue frees up room
+	if _, err := repo.ResolveWithCascade(ctx, created[0].ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if _, _, err := repo.Create(ctx, newIssue("component-c", "team-runaway")); err != nil {
+		t.Errorf("Expected the issue to be created once below the limit, got %v", err)
+	}
+}
+
+func TestIssueRepository_ResolutionDeadlines(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{
+		WithResolutionDeadlines(map[models.Severity]time.Duration{
+			models.SeverityCritical: 4 * time.Hour,
+			models.SeverityMajor:    24 * time.Hour,
+		}),
+	}})
+
+	newIssue := func(name string, severity models.Severity) dto.CreateIssueRequest {
+		req := createTestIssue("Issue on "+name, "team-deadlines")
+		req.Scope.ResourceName = name
+		req.Severity = severity
+		return req
+	}
+
+	// The deadline is derived from the severity by default
+	critical, _, err := repo.Create(ctx, newIssue("component-critical", models.SeverityCritical))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if critical.DueAt == nil || !critical.DueAt.Equal(critical.DetectedAt.Add(4*time.Hour)) {
+		t.Errorf("Expected the issue to be due 4h after its detection at %s, got %v", critical.DetectedAt, critical.DueAt)
+	}
+
+	// Severities without a deadline have none
+	info, _, err := repo.Create(ctx, newIssue("component-info", models.SeverityInfo))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if info.DueAt != nil {
+		t.Errorf("Expected no deadline for info issues, got %s", info.DueAt)
+	}
+
+	// An explicit deadline overrides the default one, on create and update
+	dueAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	req := newIssue("component-major", models.SeverityMajor)
+	req.DueAt = &dueAt
+	major, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if major.DueAt == nil || !major.DueAt.Equal(dueAt) {
+		t.Errorf("Expected the issue to be due at %s, got %v", dueAt, major.DueAt)
+	}
+
+	pastDue := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	updated, err := repo.Update(ctx, major.ID, dto.UpdateIssueRequest{DueAt: &pastDue})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if updated.DueAt == nil || !updated.DueAt.Equal(pastDue) {
+		t.Errorf("Expected the issue to be due at %s, got %v", pastDue, updated.DueAt)
+	}
+}
+
+func TestIssueRepository_Overdue(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	newIssue := func(name string, dueAt time.Time) *models.Issue {
+		t.Helper()
+		req := createTestIssue("Issue on "+name, "team-overdue")
+		req.Scope.ResourceName = name
+		req.DueAt = &dueAt
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+
+	now := time.Now()
+	overdue := newIssue("component-overdue", now.Add(-time.Hour))
+	resolved := newIssue("component-resolved", now.Add(-time.Hour))
+	newIssue("component-due", now.Add(time.Hour))
+	if _, err := repo.ResolveWithCascade(ctx, resolved.ID); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Only active issues past their deadline are overdue
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-overdue", OverdueAt: &now})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != overdue.ID {
+		t.Errorf("Expected only issue %s to be overdue, got %d issues", overdue.ID, total)
+	}
+
+	counts, err := repo.CountOverdue(ctx, now)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	expected := []dto.OverdueIssueCount{{Namespace: "team-overdue", Severity: models.SeverityMajor, Count: 1}}
+	if len(counts) != 1 || counts[0] != expected[0] {
+		t.Errorf("Expected %+v, got %+v", expected, counts)
+	}
+}
+
+func TestIssueRepository_UTCTimestamps(t *testing.T) {
+	// A clock in a non-UTC timezone, like a server running in local time
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 17, 9, 30, 0, 0, tokyo)
+	clock := testhelpers.NewFakeClock(now)
+	ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{WithClock(clock)}})
+
+	req := createTestIssue("Issue on a local time server", "team-utc")
+	created, _, err := repo.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !created.DetectedAt.Equal(now) {
+		t.Errorf("Expected the issue to be detected at %s, got %s", now, created.DetectedAt)
+	}
+	if created.DetectedAt.Location() != time.UTC {
+		t.Errorf("Expected detectedAt in UTC, got %s", created.DetectedAt.Location())
+	}
+
+	// Issues resolved by scope are resolved at the time of the clock
+	clock.Advance(time.Hour)
+	now = clock.Now()
+	resolved, err := repo.ResolveByScope(ctx, req.Scope.ResourceType, req.Scope.ResourceName, req.Namespace)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved != 1 {
+		t.Fatalf("Expected 1 resolved issue, got %d", resolved)
+	}
+
+	found, err := repo.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found.ResolvedAt == nil || !found.ResolvedAt.Equal(now) {
+		t.Errorf("Expected the issue to be resolved at %s, got %v", now, found.ResolvedAt)
+	}
+	for name, ts := range map[string]time.Time{
+		"detectedAt": found.DetectedAt,
+		"lastSeenAt": found.LastSeenAt,
+		"resolvedAt": *found.ResolvedAt,
+	} {
+		if ts.Location() != time.UTC {
+			t.Errorf("Expected stored %s in UTC, got %s", name, ts.Location())
+		}
+	}
+
+	// Timestamps are serialized in RFC 3339 with a Z suffix
+	body, err := json.Marshal(found)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if want := `"detectedAt":"2026-10-17T00:30:00Z"`; !strings.Contains(string(body), want) {
+		t.Errorf("Expected %s in %s", want, body)
+	}
+	if want := `"resolvedAt":"2026-10-17T01:30:00Z"`; !strings.Contains(string(body), want) {
+		t.Errorf("Expected %s in %s", want, body)
+	}
+}
+
+func TestIssueRepository_Clock(t *testing.T) {
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	ctx, _, repo := setupTestScenario(t, SetupOptions{RepositoryOptions: []Option{WithClock(clock)}})
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Issue resolved after 90 minutes", "team-clock"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if !issue.DetectedAt.Equal(clock.Now()) {
+		t.Errorf("Expected the issue to be detected at %s, got %s", clock.Now(), issue.DetectedAt)
+	}
+
+	clock.Advance(90 * time.Minute)
+	resolved, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved.ResolvedAt == nil {
+		t.Fatal("Expected the issue to be resolved")
+	}
+	if got := resolved.ResolvedAt.Sub(resolved.DetectedAt); got != 90*time.Minute {
+		t.Errorf("Expected the issue to be resolved in 1h30m, got %s", got)
+	}
+}
+
+func TestIssueRepository_AcknowledgeByScope(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{})
+
+	newIssue := func(issueType models.IssueType, resourceName, namespace string) *models.Issue {
+		t.Helper()
+		req := createTestIssue("Incident on "+resourceName, namespace)
+		req.IssueType = issueType
+		req.Scope.ResourceName = resourceName
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		return issue
+	}
+
+	build := newIssue(models.IssueTypeBuild, "registry", "team-incident")
+	test := newIssue(models.IssueTypeTest, "registry", "team-incident")
+	resolved := newIssue(models.IssueTypeRelease, "registry", "team-incident")
+	if _, err := repo.Update(ctx, resolved.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	otherResource := newIssue(models.IssueTypeBuild, "frontend", "team-incident")
+	otherNamespace := newIssue(models.IssueTypeBuild, "registry", "team-bystander")
+
+	acknowledged, err := repo.AcknowledgeByScope(ctx, "component", "registry", "team-incident", "Acknowledged by jane")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if acknowledged != 2 {
+		t.Errorf("Expected 2 issues acknowledged, got %d", acknowledged)
+	}
+
+	expected := map[string]models.IssueState{
+		build.ID:          models.IssueStateAcknowledged,
+		test.ID:           models.IssueStateAcknowledged,
+		resolved.ID:       models.IssueStateResolved,
+		otherResource.ID:  models.IssueStateActive,
+		otherNamespace.ID: models.IssueStateActive,
+	}
+	for id, state := range expected {
+		issue, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.State != state {
+			t.Errorf("Expected issue %s to be %s, got %s", issue.Title, state, issue.State)
+		}
+		if state == models.IssueStateAcknowledged && (len(issue.Notes) != 1 || issue.Notes[0].Content != "Acknowledged by jane") {
+			t.Errorf("Expected a note recording the acknowledgement, got %+v", issue.Notes)
+		}
+	}
+
+	// A recurrence of an acknowledged issue doesn't undo its triage
+	req := createTestIssue("Incident on registry", "team-incident")
+	req.Scope.ResourceName = "registry"
+	req.State = models.IssueStateActive
+	recurred, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if recurred.ID != build.ID || recurred.State != models.IssueStateAcknowledged {
+		t.Errorf("Expected the acknowledged issue %s to stay acknowledged, got %s in %s", build.ID, recurred.ID, recurred.State)
+	}
+}
+
+// seedIssue inserts an issue directly, bypassing duplicate detection, like the
+// duplicates created before it was tuned
+func seedIssue(t *testing.T, db *gorm.DB, issue models.Issue) models.Issue {
+	t.Helper()
+	if issue.Severity == "" {
+		issue.Severity = models.SeverityMajor
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = models.IssueTypeBuild
+	}
+	if issue.State == "" {
+		issue.State = models.IssueStateActive
+	}
+	if issue.OccurrenceCount == 0 {
+		issue.OccurrenceCount = 1
+	}
+	issue.Description = "Seeded issue"
+	issue.Scope.ResourceType = cmp.Or(issue.Scope.ResourceType, "component")
+	issue.Scope.ResourceNamespace = cmp.Or(issue.Scope.ResourceNamespace, issue.Namespace)
+	if err := db.Create(&issue).Error; err != nil {
+		t.Fatalf("Failed to seed issue: %v", err)
+	}
+	return issue
+}
+
+func TestIssueRepository_MergeDuplicates(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	detected := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	scope := models.IssueScope{ResourceName: "component-a"}
+	oldest := seedIssue(t, db, models.Issue{Title: "Build failed", Namespace: "team-dedup", Scope: scope, DetectedAt: detected})
+	second := seedIssue(t, db, models.Issue{
+		Title:           "Build failed again",
+		Namespace:       "team-dedup",
+		Scope:           scope,
+		DetectedAt:      detected.Add(time.Hour),
+		LastSeenAt:      detected.Add(3 * time.Hour),
+		OccurrenceCount: 3,
+		Notes:           []models.IssueNote{{Content: "Looking into it"}},
+	})
+	third := seedIssue(t, db, models.Issue{Title: "Build failed once more", Namespace: "team-dedup", Scope: scope, DetectedAt: detected.Add(2 * time.Hour)})
+
+	// Same fingerprint on different resources
+	fingerprinted := seedIssue(t, db, models.Issue{
+		Title: "Flaky test", Namespace: "team-dedup", Fingerprint: "flaky-test",
+		Scope: models.IssueScope{ResourceName: "component-b"}, DetectedAt: detected,
+	})
+	fingerprintedDuplicate := seedIssue(t, db, models.Issue{
+		Title: "Flaky test", Namespace: "team-dedup", Fingerprint: "flaky-test",
+		Scope: models.IssueScope{ResourceName: "component-c"}, DetectedAt: detected.Add(time.Hour),
+	})
+
+	// Not duplicates: another type, a resolved issue and another namespace
+	seedIssue(t, db, models.Issue{Title: "Tests failed", Namespace: "team-dedup", IssueType: models.IssueTypeTest, Scope: scope, DetectedAt: detected})
+	seedIssue(t, db, models.Issue{Title: "Resolved build failure", Namespace: "team-dedup", State: models.IssueStateResolved, Scope: scope, DetectedAt: detected})
+	seedIssue(t, db, models.Issue{Title: "Build failed elsewhere", Namespace: "team-other", Scope: scope, DetectedAt: detected})
+
+	expected := []dto.DuplicateMerge{
+		{IssueID: oldest.ID, MergedIDs: []string{second.ID, third.ID}},
+		{IssueID: fingerprinted.ID, MergedIDs: []string{fingerprintedDuplicate.ID}},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		merges, err := repo.MergeDuplicates(ctx, "team-dedup", true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(merges, expected) {
+			t.Errorf("Expected merges %+v, got %+v", expected, merges)
+		}
+
+		issue, err := repo.FindByID(ctx, second.ID)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue == nil {
+			t.Error("Expected a dry run not to delete duplicates")
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		merges, err := repo.MergeDuplicates(ctx, "team-dedup", false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(merges, expected) {
+			t.Errorf("Expected merges %+v, got %+v", expected, merges)
+		}
+
+		for _, id := range []string{second.ID, third.ID, fingerprintedDuplicate.ID} {
+			issue, err := repo.FindByID(ctx, id)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if issue != nil {
+				t.Errorf("Expected duplicate '%s' to be deleted", issue.Title)
+			}
+		}
+
+		merged, err := repo.FindByID(ctx, oldest.ID)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if merged.OccurrenceCount != 5 {
+			t.Errorf("Expected 5 occurrences, got %d", merged.OccurrenceCount)
+		}
+		if !merged.LastSeenAt.Equal(detected.Add(3 * time.Hour)) {
+			t.Errorf("Expected the issue to be last seen when its duplicate was, got %s", merged.LastSeenAt)
+		}
+		if len(merged.Notes) != 2 {
+			t.Fatalf("Expected the note of the duplicate and a merge note, got %+v", merged.Notes)
+		}
+		if expectedNote := "Merged duplicate issues " + second.ID + ", " + third.ID; merged.Notes[1].Content != expectedNote {
+			t.Errorf("Expected the note '%s', got '%s'", expectedNote, merged.Notes[1].Content)
+		}
+
+		// Nothing is left to merge
+		merges, err = repo.MergeDuplicates(ctx, "team-dedup", false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(merges) != 0 {
+			t.Errorf("Expected no duplicates left, got %+v", merges)
+		}
+
+		_, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "team-other"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if total != 1 {
+			t.Errorf("Expected the issue of another namespace to be left untouched, got %d issues", total)
+		}
+	})
+}
+
+func TestIssueRepository_MergeDuplicates_DedupByReason(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithDedupOptions(DedupOptions{Strategy: DedupByReason})},
+	})
+
+	detected := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	scope := models.IssueScope{ResourceName: "component-a"}
+	missingDependency := reason.Hash("Build failed: missing dependency")
+	oldest := seedIssue(t, db, models.Issue{Title: "Build failed", Namespace: "team-dedup", Scope: scope, DetectedAt: detected, ReasonHash: missingDependency})
+	duplicate := seedIssue(t, db, models.Issue{Title: "Build failed", Namespace: "team-dedup", Scope: scope, DetectedAt: detected.Add(time.Hour), ReasonHash: missingDependency})
+	// Same scope, another failure
+	seedIssue(t, db, models.Issue{
+		Title: "Build failed", Namespace: "team-dedup", Scope: scope, DetectedAt: detected.Add(2 * time.Hour),
+		ReasonHash: reason.Hash("Build failed: out of memory"),
+	})
+
+	merges, err := repo.MergeDuplicates(ctx, "team-dedup", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []dto.DuplicateMerge{{IssueID: oldest.ID, MergedIDs: []string{duplicate.ID}}}
+	if !reflect.DeepEqual(merges, expected) {
+		t.Errorf("Expected merges %+v, got %+v", expected, merges)
+	}
+}
+
+func TestIssueRepository_ResolveByScopePrefix(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	pipelineIssue := func(name, namespace string) models.Issue {
+		return seedIssue(t, db, models.Issue{
+			Title:     "Pipeline run failed: " + name,
+			Namespace: namespace,
+			Scope:     models.IssueScope{ResourceType: "pipelinerun", ResourceName: name},
+		})
+	}
+	pipeline := pipelineIssue("frontend-build", "team-prefix")
+	run := pipelineIssue("frontend-build-abc123", "team-prefix")
+	otherPipeline := pipelineIssue("frontend-builder", "team-prefix")
+	otherRun := pipelineIssue("frontend-build-test-abc123", "team-prefix")
+	otherNamespace := pipelineIssue("frontend-build-abc123", "team-other")
+	component := seedIssue(t, db, models.Issue{
+		Title:     "Build failed: frontend-build-abc123",
+		Namespace: "team-prefix",
+		Scope:     models.IssueScope{ResourceType: "component", ResourceName: "frontend-build-abc123"},
+	})
+
+	// An exact match leaves the runs alone
+	resolved, err := repo.ResolveByScope(ctx, "pipelinerun", "frontend-build", "team-prefix")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved != 1 {
+		t.Errorf("Expected 1 issue resolved by the exact match, got %d", resolved)
+	}
+	if err := db.Model(&models.Issue{}).Where("id = ?", pipeline.ID).Updates(map[string]interface{}{
+		"state": models.IssueStateActive, "resolved_at": nil, "resolved_by": nil,
+	}).Error; err != nil {
+		t.Fatalf("Failed to reopen issue: %v", err)
+	}
+
+	resolved, err = repo.ResolveByScopePrefix(ctx, "pipelinerun", "frontend-build", "team-prefix")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved != 2 {
+		t.Errorf("Expected 2 issues resolved by the prefix match, got %d", resolved)
+	}
+
+	expected := map[string]models.IssueState{
+		pipeline.ID:       models.IssueStateResolved,
+		run.ID:            models.IssueStateResolved,
+		otherPipeline.ID:  models.IssueStateActive,
+		otherRun.ID:       models.IssueStateActive,
+		otherNamespace.ID: models.IssueStateActive,
+		component.ID:      models.IssueStateActive,
+	}
+	for id, state := range expected {
+		issue, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.State != state {
+			t.Errorf("Expected '%s' in %s to be %s, got %s", issue.Scope.ResourceName, issue.Namespace, state, issue.State)
+		}
+		if state == models.IssueStateResolved && issue.ResolvedBy != models.ResolutionSourceSuccessWebhook {
+			t.Errorf("Expected '%s' to be resolved by %s, got '%s'", issue.Scope.ResourceName, models.ResolutionSourceSuccessWebhook, issue.ResolvedBy)
+		}
+	}
+
+	// Wildcards in the prefix are matched literally
+	pipelineIssue("frontend_build-abc123", "team-prefix")
+	resolved, err = repo.ResolveByScopePrefix(ctx, "pipelinerun", "frontend%", "team-prefix")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved != 0 {
+		t.Errorf("Expected no issue resolved by a wildcard prefix, got %d", resolved)
+	}
+}
+
+func TestIssueRepository_ResolveNamespace(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	active := seedIssue(t, db, models.Issue{Title: "Active", Namespace: "team-offboarded"})
+	acknowledged := seedIssue(t, db, models.Issue{Title: "Acknowledged", Namespace: "team-offboarded", State: models.IssueStateAcknowledged})
+	alreadyResolved := seedIssue(t, db, models.Issue{Title: "Resolved", Namespace: "team-offboarded", State: models.IssueStateResolved})
+	otherNamespace := seedIssue(t, db, models.Issue{Title: "Other namespace", Namespace: "team-alpha"})
+
+	resolved, err := repo.ResolveNamespace(ctx, "team-offboarded", "namespace decommissioned")
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if resolved != 2 {
+		t.Errorf("Expected 2 issues resolved, got %d", resolved)
+	}
+
+	for _, id := range []string{active.ID, acknowledged.ID} {
+		issue, err := repo.FindByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if issue.State != models.IssueStateResolved || issue.ResolvedAt == nil {
+			t.Errorf("Expected '%s' to be resolved, got %s", issue.Title, issue.State)
+		}
+		if issue.ResolvedBy != models.ResolutionSourceDecommission {
+			t.Errorf("Expected '%s' to be resolved by %s, got '%s'", issue.Title, models.ResolutionSourceDecommission, issue.ResolvedBy)
+		}
+
+		var notes []models.IssueNote
+		if err := db.Where("issue_id = ?", id).Find(&notes).Error; err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if len(notes) != 1 || notes[0].Content != "namespace decommissioned" {
+			t.Errorf("Expected a note explaining the resolution of '%s', got %+v", issue.Title, notes)
+		}
+	}
+
+	// Issues already resolved, and the issues of other namespaces, are left untouched
+	var notes int64
+	if err := db.Model(&models.IssueNote{}).Where("issue_id IN ?", []string{alreadyResolved.ID, otherNamespace.ID}).Count(&notes).Error; err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if notes != 0 {
+		t.Errorf("Expected no notes on the issues left untouched, got %d", notes)
+	}
+	other, err := repo.FindByID(ctx, otherNamespace.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if other.State != models.IssueStateActive {
+		t.Errorf("Expected the issue of another namespace to stay active, got %s", other.State)
+	}
+}
+
+func TestIssueRepository_RankNamespaces(t *testing.T) {
+	ctx, db, repo := setupTestScenario(t, SetupOptions{})
+
+	seed := map[string][]models.IssueState{
+		"team-alpha": {models.IssueStateActive, models.IssueStateActive, models.IssueStateResolved},
+		"team-beta":  {models.IssueStateActive, models.IssueStateAcknowledged, models.IssueStateAcknowledged},
+		"team-gamma": {models.IssueStateActive, models.IssueStateActive},
+		"team-delta": {models.IssueStateResolved},
+	}
+	for namespace, states := range seed {
+		for idx, state := range states {
+			seedIssue(t, db, models.Issue{
+				Title:     fmt.Sprintf("Issue %d", idx),
+				Namespace: namespace,
+				State:     state,
+				Scope:     models.IssueScope{ResourceName: fmt.Sprintf("component-%d", idx)},
+			})
+		}
+	}
+
+	tests := []struct {
+		name     string
+		states   []models.IssueState
+		limit    int
+		expected []dto.NamespaceIssueCount
+	}{
+		{
+			name:   "open issues",
+			states: models.OpenStates,
+			limit:  10,
+			expected: []dto.NamespaceIssueCount{
+				{Namespace: "team-beta", IssueCount: 3},
+				{Namespace: "team-alpha", IssueCount: 2},
+				{Namespace: "team-gamma", IssueCount: 2},
+			},
+		},
+		{
+			name:   "active issues",
+			states: []models.IssueState{models.IssueStateActive},
+			limit:  10,
+			expected: []dto.NamespaceIssueCount{
+				{Namespace: "team-alpha", IssueCount: 2},
+				{Namespace: "team-gamma", IssueCount: 2},
+				{Namespace: "team-beta", IssueCount: 1},
+			},
+		},
+		{
+			name:   "limit",
+			states: models.OpenStates,
+			limit:  2,
+			expected: []dto.NamespaceIssueCount{
+				{Namespace: "team-beta", IssueCount: 3},
+				{Namespace: "team-alpha", IssueCount: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranking, err := repo.RankNamespaces(ctx, tt.states, tt.limit)
+			if err != nil {
+				t.Fatalf("Unexpected error, got %v", err)
+			}
+			if !reflect.DeepEqual(ranking, tt.expected) {
+				t.Errorf("Expected ranking %v, got %v", tt.expected, ranking)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_ResolveConflictWindow(t *testing.T) {
+	for _, includeResolved := range []bool{true, false} {
+		t.Run(fmt.Sprintf("include resolved %t", includeResolved), func(t *testing.T) {
+			clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+			ctx, _, repo := setupTestScenario(t, SetupOptions{
+				RepositoryOptions: []Option{
+					WithClock(clock),
+					WithDedupOptions(DedupOptions{IncludeResolved: includeResolved}),
+					WithResolveConflictWindow(10 * time.Second),
+				},
+			})
+			req := createTestIssue("Conflicting reports", "team-conflict")
+			resolve := func() int64 {
+				t.Helper()
+				resolved, err := repo.ResolveByScope(ctx, req.Scope.ResourceType, req.Scope.ResourceName, req.Namespace)
+				if err != nil {
+					t.Fatalf("Unexpected error, got %v", err)
+				}
+				return resolved
+			}
+			report := func() *models.Issue {
+				t.Helper()
+				issue, _, err := repo.CreateOrUpdate(ctx, req)
+				if err != nil {
+					t.Fatalf("Unexpected error, got %v", err)
+				}
+				return issue
+			}
+
+			// A success right after a failure doesn't resolve the issue
+			issue := report()
+			clock.Advance(2 * time.Second)
+			if resolved := resolve(); resolved != 0 {
+				t.Errorf("Expected the fresh failure to win over the success, got %d issues resolved", resolved)
+			}
+			// Once the window is over, it does
+			clock.Advance(10 * time.Second)
+			if resolved := resolve(); resolved != 1 {
+				t.Errorf("Expected the success to resolve the issue, got %d issues resolved", resolved)
+			}
+
+			// A failure right after the success reopens the issue
+			clock.Advance(2 * time.Second)
+			reopened := report()
+			if reopened.ID != issue.ID {
+				t.Errorf("Expected the failure to reopen issue %s, got issue %s", issue.ID, reopened.ID)
+			}
+			if reopened.State != models.IssueStateActive || reopened.ResolvedAt != nil || reopened.ResolvedBy != "" {
+				t.Errorf("Expected the issue to be reopened, got state %s resolved by '%s'", reopened.State, reopened.ResolvedBy)
+			}
+
+			// Once the window is over, a failure is handled like any recurrence
+			clock.Advance(20 * time.Second)
+			if resolved := resolve(); resolved != 1 {
+				t.Errorf("Expected the success to resolve the issue, got %d issues resolved", resolved)
+			}
+			clock.Advance(20 * time.Second)
+			recurrence := report()
+			if includeResolved && (recurrence.ID != issue.ID || recurrence.State != models.IssueStateResolved) {
+				t.Errorf("Expected the recurrence to update the resolved issue, got issue %s in state %s", recurrence.ID, recurrence.State)
+			}
+			if !includeResolved && recurrence.ID == issue.ID {
+				t.Errorf("Expected the recurrence to create a new issue, got issue %s", recurrence.ID)
+			}
+		})
+	}
+}
+
+func TestIssueRepository_ResolveConflictWindow_ManualResolution(t *testing.T) {
+	ctx, _, repo := setupTestScenario(t, SetupOptions{
+		RepositoryOptions: []Option{WithResolveConflictWindow(time.Minute)},
+	})
+
+	req := createTestIssue("Resolved by a user", "team-conflict")
+	issue, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	// Users aren't subject to the window
+	if _, err := repo.Update(ctx, issue.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	recurrence, _, err := repo.CreateOrUpdate(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if recurrence.State != models.IssueStateResolved {
+		t.Errorf("Expected the issue resolved by a user to stay resolved, got %s", recurrence.State)
+	}
+}
+
+func TestIssueRepository_ResolveConflictWindow_Concurrent(t *testing.T) {
+	const rounds = 10
+
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	ctx, db, repo := setupTestScenario(t, SetupOptions{
+		UseConcurrentDatabase: true,
+		RepositoryOptions: []Option{
+			WithClock(clock),
+			WithDedupOptions(DedupOptions{IncludeResolved: false}),
+			WithResolveConflictWindow(10 * time.Second),
+		},
+	})
+
+	for round := range rounds {
+		req := createTestIssue("Concurrent resolve and failure", "team-conflict")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", round)
+		if _, _, err := repo.CreateOrUpdate(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		clock.Advance(time.Minute)
+
+		// A success and a failure for the same resource, in no particular order
+		var wg sync.WaitGroup
+		var resolveErr, reportErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, resolveErr = repo.ResolveByScope(ctx, req.Scope.ResourceType, req.Scope.ResourceName, req.Namespace)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, reportErr = repo.CreateOrUpdate(ctx, req)
+		}()
+		wg.Wait()
+		if resolveErr != nil || reportErr != nil {
+			t.Fatalf("Unexpected errors, got %v and %v", resolveErr, reportErr)
+		}
+
+		// Whichever came first, the failure wins and the issue isn't duplicated
+		var issues []models.Issue
+		err := db.Joins("JOIN issue_scopes ON issues.scope_id = issue_scopes.id").
+			Where("issue_scopes.resource_name = ?", req.Scope.ResourceName).
+			Find(&issues).Error
+		if err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+		if len(issues) != 1 {
+			t.Fatalf("Expected a single issue in round %d, got %d", round, len(issues))
+		}
+		if issues[0].State != models.IssueStateActive {
+			t.Errorf("Expected the issue to be active in round %d, got %s", round, issues[0].State)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"gorm.io/gorm"
+)
+
+// mttrGroupColumns maps the dimensions MTTR can be grouped by to their column
+var mttrGroupColumns = map[string]string{
+	"issueType": "issue_type",
+	"severity":  "severity",
+	"namespace": "namespace",
+	// Issues without an environment, including those reported before it was recorded
+	"environment": "COALESCE(environment, '')",
+	// Issues resolved before their resolution source was recorded have none
+	"resolvedBy": "COALESCE(resolved_by, '')",
+}
+
+// IsValidMTTRGroup reports whether MTTR can be grouped by the dimension passed
+func IsValidMTTRGroup(groupBy string) bool {
+	_, ok := mttrGroupColumns[groupBy]
+	return ok
+}
+
+// resolutionSeconds is the time taken to resolve an issue, in seconds, on Postgres
+const resolutionSeconds = "EXTRACT(EPOCH FROM (resolved_at - detected_at))"
+
+// resolvedIssue is the resolution of an issue, used when durations are computed in memory
+type resolvedIssue struct {
+	Grp        string
+	DetectedAt time.Time
+	ResolvedAt time.Time
+}
+
+// MTTR computes the mean and median time to resolve the issues resolved since a given time.
+//
+// On Postgres this is a single aggregate query. Databases lacking interval math
+// fall back to computing the durations in memory.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: IssueQueryFilters restricting the issues, the state filter is ignored
+//   - since: Only issues resolved at or after this time are included, ignored when zero
+//   - groupBy: Dimension to group by, see IsValidMTTRGroup. All issues are in a single group when empty
+//
+// Returns:
+//   - []dto.MTTRGroup: The time to resolve for each group, ordered by group
+//   - error: Database error or nil
+func (i *issueRepository) MTTR(ctx context.Context, filters IssueQueryFilters, since time.Time, groupBy string) ([]dto.MTTRGroup, error) {
+	groupColumn := "''"
+	if groupBy != "" {
+		column, ok := mttrGroupColumns[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("invalid MTTR grouping: %s", groupBy)
+		}
+		groupColumn = column
+	}
+
+	filters.State = nil
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters).
+		Where("state = ? AND resolved_at IS NOT NULL", models.IssueStateResolved)
+	if !since.IsZero() {
+		query = query.Where("resolved_at >= ?", since)
+	}
+
+	var groups []dto.MTTRGroup
+	var err error
+	if i.db.Dialector.Name() == "postgres" {
+		groups, err = mttrAggregate(query, groupColumn)
+	} else {
+		groups, err = mttrFallback(query, groupColumn)
+	}
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to compute MTTR")
+		return nil, fmt.Errorf("failed to compute MTTR: %w", err)
+	}
+	return groups, nil
+}
+
+// mttrAggregate computes MTTR with a SQL aggregate
+func mttrAggregate(query *gorm.DB, groupColumn string) ([]dto.MTTRGroup, error) {
+	groups := []dto.MTTRGroup{}
+	err := query.
+		Select(groupColumn + " AS grp, COUNT(*) AS count, " +
+			"AVG(" + resolutionSeconds + ") AS mean_seconds, " +
+			"percentile_cont(0.5) WITHIN GROUP (ORDER BY " + resolutionSeconds + ") AS median_seconds").
+		Group("grp").
+		Order("grp").
+		Scan(&groups).Error
+	return groups, err
+}
+
+// mttrFallback computes MTTR in memory from the resolution of each issue
+func mttrFallback(query *gorm.DB, groupColumn string) ([]dto.MTTRGroup, error) {
+	var resolved []resolvedIssue
+	err := query.
+		Select(groupColumn + " AS grp, detected_at, resolved_at").
+		Scan(&resolved).Error
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string][]float64)
+	for _, issue := range resolved {
+		durations[issue.Grp] = append(durations[issue.Grp], issue.ResolvedAt.Sub(issue.DetectedAt).Seconds())
+	}
+
+	groups := make([]dto.MTTRGroup, 0, len(durations))
+	for grp, seconds := range durations {
+		sort.Float64s(seconds)
+
+		total := 0.0
+		for _, s := range seconds {
+			total += s
+		}
+		median := seconds[len(seconds)/2]
+		if len(seconds)%2 == 0 {
+			median = (seconds[len(seconds)/2-1] + median) / 2
+		}
+
+		groups = append(groups, dto.MTTRGroup{
+			Group:         grp,
+			Count:         int64(len(seconds)),
+			MeanSeconds:   total / float64(len(seconds)),
+			MedianSeconds: median,
+		})
+	}
+	sort.Slice(groups, func(a, b int) bool {
+		return groups[a].Group < groups[b].Group
+	})
+	return groups, nil
+}
+
+// CountOpen counts the active issues, per namespace, issue type and severity.
+//
+// Returns:
+//   - []dto.OpenIssueCount: The number of active issues of each combination that has any
+//   - error: Database error or nil
+func (i *issueRepository) CountOpen(ctx context.Context) ([]dto.OpenIssueCount, error) {
+	counts := []dto.OpenIssueCount{}
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("namespace, issue_type, severity, COUNT(*) AS count").
+		Where("state IN ?", models.OpenStates).
+		Group("namespace, issue_type, severity").
+		Order("namespace, issue_type, severity").
+		Scan(&counts).Error
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to count open issues")
+		return nil, fmt.Errorf("failed to count open issues: %w", err)
+	}
+	return counts, nil
+}
+
+// CountOverdue counts the active issues past their deadline, per namespace and severity.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - now: Issues due before this time are overdue
+//
+// Returns:
+//   - []dto.OverdueIssueCount: The number of overdue issues of each combination that has any
+//   - error: Database error or nil
+func (i *issueRepository) CountOverdue(ctx context.Context, now time.Time) ([]dto.OverdueIssueCount, error) {
+	counts := []dto.OverdueIssueCount{}
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("namespace, severity, COUNT(*) AS count").
+		Where("state IN ? AND due_at < ?", models.OpenStates, now).
+		Group("namespace, severity").
+		Order("namespace, severity").
+		Scan(&counts).Error
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to count overdue issues")
+		return nil, fmt.Errorf("failed to count overdue issues: %w", err)
+	}
+	return counts, nil
+}
+
+// FindResolutions finds the issues resolved within a time window.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - after: Only issues resolved after this time are included
+//   - until: Only issues resolved at or before this time are included
+//
+// Returns:
+//   - []dto.IssueResolution: When each issue was detected and resolved, oldest resolution first
+//   - error: Database error or nil
+func (i *issueRepository) FindResolutions(ctx context.Context, after, until time.Time) ([]dto.IssueResolution, error) {
+	resolutions := []dto.IssueResolution{}
+	err := i.db.WithContext(ctx).Model(&models.Issue{}).
+		Select("issue_type, detected_at, resolved_at").
+		Where("state = ? AND resolved_at > ? AND resolved_at <= ?", models.IssueStateResolved, after, until).
+		Order("resolved_at").
+		Scan(&resolutions).Error
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to find issue resolutions")
+		return nil, fmt.Errorf("failed to find issue resolutions: %w", err)
+	}
+	return resolutions, nil
+}
+
+// occurrenceBucketUnits are the units occurrences can be bucketed by, as understood by date_trunc
+var occurrenceBucketUnits = map[string]bool{
+	"minute": true,
+	"hour":   true,
+	"day":    true,
+	"week":   true,
+}
+
+// IsValidOccurrenceBucket reports whether occurrences can be bucketed by the unit passed
+func IsValidOccurrenceBucket(unit string) bool {
+	return occurrenceBucketUnits[unit]
+}
+
+// OccurrenceHistogram counts the occurrences of an issue in buckets of a unit of time.
+//
+// On Postgres buckets are computed with date_trunc. Other databases fall back to
+// bucketing the occurrences in memory. Buckets start in UTC, weeks on Monday.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - issueID: The ID of the issue
+//   - unit: Unit of time of the buckets, see IsValidOccurrenceBucket
+//   - since: Only occurrences at or after this time are counted, ignored when zero
+//
+// Returns:
+//   - []dto.OccurrenceBucket: The buckets having occurrences, oldest first
+//   - error: Database error or nil
+func (i *issueRepository) OccurrenceHistogram(ctx context.Context, issueID, unit string, since time.Time) ([]dto.OccurrenceBucket, error) {
+	if !IsValidOccurrenceBucket(unit) {
+		return nil, fmt.Errorf("invalid occurrence bucket: %s", unit)
+	}
+
+	query := i.db.WithContext(ctx).Model(&models.Occurrence{}).Where("issue_id = ?", issueID)
+	if !since.IsZero() {
+		query = query.Where("occurred_at >= ?", since)
+	}
+
+	var buckets []dto.OccurrenceBucket
+	var err error
+	if i.db.Dialector.Name() == "postgres" {
+		buckets, err = occurrenceHistogramAggregate(query, unit)
+	} else {
+		buckets, err = occurrenceHistogramFallback(query, unit)
+	}
+	if err != nil {
+		i.logger.WithError(err).WithField("issue_id", issueID).Error("Failed to compute occurrence histogram")
+		return nil, fmt.Errorf("failed to compute occurrence histogram: %w", err)
+	}
+	return buckets, nil
+}
+
+// occurrenceHistogramAggregate buckets occurrences with a SQL aggregate
+func occurrenceHistogramAggregate(query *gorm.DB, unit string) ([]dto.OccurrenceBucket, error) {
+	buckets := []dto.OccurrenceBucket{}
+	err := query.
+		Select("date_trunc(?, occurred_at AT TIME ZONE 'UTC') AS start, COUNT(*) AS count", unit).
+		Group("start").
+		Order("start").
+		Scan(&buckets).Error
+	for idx := range buckets {
+		// date_trunc drops the time zone, the buckets start in UTC
+		start := buckets[idx].Start
+		buckets[idx].Start = time.Date(start.Year(), start.Month(), start.Day(),
+			start.Hour(), start.Minute(), 0, 0, time.UTC)
+	}
+	return buckets, err
+}
+
+// occurrenceHistogramFallback buckets occurrences in memory
+func occurrenceHistogramFallback(query *gorm.DB, unit string) ([]dto.OccurrenceBucket, error) {
+	var occurredAt []time.Time
+	if err := query.Pluck("occurred_at", &occurredAt).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[time.Time]int64)
+	for _, at := range occurredAt {
+		counts[truncateToBucket(at.UTC(), unit)]++
+	}
+
+	buckets := make([]dto.OccurrenceBucket, 0, len(counts))
+	for start, count := range counts {
+		buckets = append(buckets, dto.OccurrenceBucket{Start: start, Count: count})
+	}
+	sort.Slice(buckets, func(a, b int) bool {
+		return buckets[a].Start.Before(buckets[b].Start)
+	})
+	return buckets, nil
+}
+
+// truncateToBucket returns the start of the bucket of a UTC time, like date_trunc
+func truncateToBucket(at time.Time, unit string) time.Time {
+	switch unit {
+	case "minute":
+		return at.Truncate(time.Minute)
+	case "hour":
+		return at.Truncate(time.Hour)
+	}
+
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	if unit == "week" {
+		// ISO weeks start on Monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
@@ -0,0 +1,78 @@
+//go:build postgres
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMTTR_PostgresAggregate(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	repo := NewIssueRepository(db, logrus.New())
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for idx, duration := range []time.Duration{10 * time.Minute, 20 * time.Minute, 60 * time.Minute} {
+		req := createTestIssue("Build failed", "team-mttr")
+		req.Scope.ResourceName = []string{"a", "b", "c"}[idx]
+		issue, _, err := repo.Create(ctx, req)
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		if err := db.Model(&models.Issue{}).Where("id = ?", issue.ID).Updates(map[string]any{
+			"state":       models.IssueStateResolved,
+			"detected_at": now.Add(-duration),
+			"resolved_at": now,
+		}).Error; err != nil {
+			t.Fatalf("Failed to resolve issue: %v", err)
+		}
+	}
+
+	groups, err := repo.MTTR(ctx, IssueQueryFilters{Namespace: "team-mttr"}, now.Add(-time.Hour), "issueType")
+	if err != nil {
+		t.Fatalf("Failed to compute MTTR: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("Expected a single group, got %+v", groups)
+	}
+	if groups[0].Group != "build" || groups[0].Count != 3 ||
+		groups[0].MeanSeconds != 30*60 || groups[0].MedianSeconds != 20*60 {
+		t.Errorf("Unexpected MTTR: %+v", groups[0])
+	}
+}
+
+func TestOccurrenceHistogram_PostgresAggregate(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	repo := NewIssueRepository(db, logrus.New())
+	ctx := context.Background()
+
+	issue, _, err := repo.Create(ctx, createTestIssue("Build failed", "team-histogram"))
+	if err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := db.Where("issue_id = ?", issue.ID).Delete(&models.Occurrence{}).Error; err != nil {
+		t.Fatalf("Failed to clear occurrences: %v", err)
+	}
+
+	start := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	for _, offset := range []time.Duration{5 * time.Minute, 40 * time.Minute, 70 * time.Minute} {
+		if err := db.Create(&models.Occurrence{IssueID: issue.ID, OccurredAt: start.Add(offset)}).Error; err != nil {
+			t.Fatalf("Failed to create occurrence: %v", err)
+		}
+	}
+
+	buckets, err := repo.OccurrenceHistogram(ctx, issue.ID, "hour", time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to compute occurrence histogram: %v", err)
+	}
+	if len(buckets) != 2 ||
+		!buckets[0].Start.Equal(start) || buckets[0].Count != 2 ||
+		!buckets[1].Start.Equal(start.Add(time.Hour)) || buckets[1].Count != 1 {
+		t.Errorf("Unexpected histogram: %+v", buckets)
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "context"
+
+// NamespaceAuthorizer reports whether the caller reporting an issue may update the
+// issues of a namespace, e.g. by checking its access with Kubernetes.
+type NamespaceAuthorizer func(namespace string) bool
+
+type namespaceAuthorizerKey struct{}
+
+// WithNamespaceAuthorizer returns a context whose reports can update duplicates tracked in
+// the namespaces the authorizer allows, when duplicates are matched across namespaces.
+// Without an authorizer, reports only update the duplicates of their own namespace.
+func WithNamespaceAuthorizer(ctx context.Context, authorize NamespaceAuthorizer) context.Context {
+	return context.WithValue(ctx, namespaceAuthorizerKey{}, authorize)
+}
+
+// namespaceAuthorizerFrom returns the NamespaceAuthorizer of a context, nil if it has none
+func namespaceAuthorizerFrom(ctx context.Context) NamespaceAuthorizer {
+	if ctx == nil {
+		return nil
+	}
+	authorize, _ := ctx.Value(namespaceAuthorizerKey{}).(NamespaceAuthorizer)
+	return authorize
+}
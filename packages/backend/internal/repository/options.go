@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+)
+
+// Option configures optional behavior of the issue repository
+type Option func(*issueRepository)
+
+// DedupStrategy selects what duplicates are matched on, besides their type and scope
+type DedupStrategy string
+
+const (
+	// DedupByScope matches duplicates on their type and scope only
+	DedupByScope DedupStrategy = "scope"
+	// DedupByReason also matches duplicates on their failure reason, i.e. their description
+	// without the tokens changing from one report to the next, so different failures of
+	// the same resource are tracked as distinct issues
+	DedupByReason DedupStrategy = "reason"
+)
+
+// DedupOptions controls which issues are considered when looking for duplicates
+type DedupOptions struct {
+	// IncludeResolved considers resolved issues as duplicates, so a recurring
+	// problem re-uses the resolved issue instead of creating a new one.
+	IncludeResolved bool
+	// MaxOccurrences is the number of recent occurrences kept for each issue
+	// when it recurs, older ones are pruned. 0 disables the timeline.
+	MaxOccurrences int
+	// AcrossNamespaces matches duplicates on their resource scope only, whatever
+	// namespace they're tracked in. Meant for cluster-scoped resources, or resources
+	// reported from several namespaces. Duplicates are only matched in the namespaces
+	// the NamespaceAuthorizer of the context allows, besides the namespace reported to.
+	AcrossNamespaces bool
+	// MinUpdateInterval coalesces the duplicates reported within this interval of the
+	// last update of their issue: they're counted, but the issue isn't updated with
+	// them. 0 updates the issue with every duplicate.
+	MinUpdateInterval time.Duration
+	// Strategy selects what duplicates are matched on, DedupByScope when empty.
+	// Fingerprinted payloads are matched on their fingerprint whatever the strategy.
+	Strategy DedupStrategy
+	// EnforceUnique stores the key duplicates are matched on with open issues, in a
+	// unique index, so the database rejects duplicates missed by the application, e.g.
+	// created by concurrent reports. A new issue rejected by the index updates its
+	// duplicate instead. Issues get their key when created or updated.
+	EnforceUnique bool
+	// ResourceNameStrip matches the parts of resource names that change from one report
+	// to the next, e.g. the random suffix of pipeline runs. They're stripped from the
+	// resource name duplicates are matched on, so build-xyz-a1b2c3 and build-xyz-d4e5f6
+	// are both matched as build-xyz. Resource names are matched as is when nil.
+	ResourceNameStrip *regexp.Regexp
+}
+
+// DefaultDedupOptions returns the default deduplication options
+func DefaultDedupOptions() DedupOptions {
+	return DedupOptions{
+		IncludeResolved: true,
+		MaxOccurrences:  20,
+		Strategy:        DedupByScope,
+	}
+}
+
+// WithDedupOptions sets the deduplication options used by the repository
+func WithDedupOptions(opts DedupOptions) Option {
+	return func(i *issueRepository) {
+		i.dedup = opts
+	}
+}
+
+// DefaultMaxRelationshipsPerIssue is the default maximum number of relationships an issue can have
+const DefaultMaxRelationshipsPerIssue = 50
+
+// WithMaxRelationshipsPerIssue sets the maximum number of relationships an
+// issue can have, counting both directions.
+func WithMaxRelationshipsPerIssue(limit int) Option {
+	return func(i *issueRepository) {
+		i.maxRelationships = limit
+	}
+}
+
+// DefaultMaxAutoRelations is the default number of issues a new issue is automatically related to
+const DefaultMaxAutoRelations = 10
+
+// WithAutoRelateSameResource relates new issues to the active issues scoped to
+// the same resource, so correlated failures surface together. At most
+// maxRelations issues are related, the most recently seen first.
+func WithAutoRelateSameResource(maxRelations int) Option {
+	return func(i *issueRepository) {
+		i.maxAutoRelations = maxRelations
+	}
+}
+
+// WithResolutionDeadlines sets how long after their detection issues of each severity
+// are expected to be resolved by, when they're created without a deadline. Issues of
+// severities without a deadline have none.
+func WithResolutionDeadlines(deadlines map[models.Severity]time.Duration) Option {
+	return func(i *issueRepository) {
+		i.resolutionDeadlines = deadlines
+	}
+}
+
+// WithMaxActiveIssuesPerNamespace sets the maximum number of active issues a namespace
+// can have, so a runaway producer can't fill the database. 0 disables the limit.
+func WithMaxActiveIssuesPerNamespace(limit int) Option {
+	return func(i *issueRepository) {
+		i.maxActiveIssuesPerNamespace = limit
+	}
+}
+
+// WithResolveConflictWindow sets the window within which a failure wins over a concurrent
+// resolution by a success webhook for the same resource, whichever came first: issues
+// last seen within the window aren't resolved by success webhooks, and the recurrences
+// of issues resolved by one within the window reopen them. 0 disables it, the last
+// one to be processed wins.
+func WithResolveConflictWindow(window time.Duration) Option {
+	return func(i *issueRepository) {
+		i.resolveConflictWindow = window
+	}
+}
+
+// WithDedupKeyLogging logs the key every duplicate lookup is made with at debug level,
+// along with the issue matched if any, to diagnose why reports were or weren't
+// deduplicated. It's noisy, so meant for debugging only.
+func WithDedupKeyLogging() Option {
+	return func(i *issueRepository) {
+		i.logDedupKeys = true
+	}
+}
+
+// WithClock sets the clock the repository takes timestamps from, e.g. a fake clock
+// in tests. Timestamps are converted to UTC whatever the clock's location.
+func WithClock(c clock.Clock) Option {
+	return func(i *issueRepository) {
+		i.clock = c
+	}
+}
@@ -0,0 +1,70 @@
+//go:build postgres
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestIssueRepository_AddRelatedIssue_ConcurrentLimit_Postgres(t *testing.T) {
+	const maxRelationships = 3
+	const numRequests = 10
+
+	db := setupPostgresTestDB(t)
+	repo := NewIssueRepository(db, logrus.New(), WithMaxRelationshipsPerIssue(maxRelationships))
+	ctx := context.Background()
+
+	source, _, err := repo.Create(ctx, createTestIssue("Source", "team-relations"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	targets := make([]*models.Issue, numRequests)
+	for idx := range targets {
+		req := createTestIssue(fmt.Sprintf("Target %d", idx), "team-relations")
+		req.Scope.ResourceName = fmt.Sprintf("component-%d", idx)
+		if targets[idx], _, err = repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	// Every relationship counts the relationships of the source, they'd all see it under
+	// the limit if they weren't serialized
+	var wg sync.WaitGroup
+	errs := make([]error, numRequests)
+	for idx := range numRequests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[idx] = repo.AddRelatedIssue(ctx, source.ID, targets[idx].ID)
+		}()
+	}
+	wg.Wait()
+
+	added := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			added++
+		case !errors.Is(err, ErrRelationshipLimitExceeded):
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if added != maxRelationships {
+		t.Errorf("Expected %d relationships to be added, got %d", maxRelationships, added)
+	}
+
+	var count int64
+	if err := db.Model(&models.RelatedIssue{}).Where("source_id = ?", source.ID).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count relationships: %v", err)
+	}
+	if count != maxRelationships {
+		t.Errorf("Expected %d relationships, got %d", maxRelationships, count)
+	}
+}
@@ -0,0 +1,119 @@
+//go:build postgres
+
+package repository
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/config"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// replicaTestSchema holds the tables standing for the read replica
+const replicaTestSchema = "kite_replica_test"
+
+// withSearchPath sets the schema the sessions opened with the DSN use
+func withSearchPath(dsn, schema string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if parsed, err := url.Parse(dsn); err == nil {
+			query := parsed.Query()
+			query.Set("search_path", schema)
+			parsed.RawQuery = query.Encode()
+			return parsed.String()
+		}
+	}
+	return dsn + " search_path=" + schema
+}
+
+// setupPostgresReplica routes the reads of the primary database to a "replica", tables
+// of the same database in another schema which don't receive the writes. This way, the
+// database a query ran on can be told from its result. The replica is returned so
+// rows can be written to it directly.
+func setupPostgresReplica(t *testing.T, primary *gorm.DB) *gorm.DB {
+	t.Helper()
+
+	if err := primary.Exec("CREATE SCHEMA IF NOT EXISTS " + replicaTestSchema).Error; err != nil {
+		t.Fatalf("Failed to create the replica schema: %v", err)
+	}
+	replicaDSN := withSearchPath(os.Getenv("KITE_TEST_POSTGRES_DSN"), replicaTestSchema)
+	replica, err := gorm.Open(postgres.Open(replicaDSN), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to the replica: %v", err)
+	}
+	if err := replica.AutoMigrate(models.All()...); err != nil {
+		t.Fatalf("Failed to migrate the replica: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := primary.Exec("DROP SCHEMA " + replicaTestSchema + " CASCADE").Error; err != nil {
+			t.Errorf("Failed to drop the replica schema: %v", err)
+		}
+		if sqlDB, err := replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if _, err := config.UseReadReplica(primary, replicaDSN); err != nil {
+		t.Fatalf("Failed to set up the read replica: %v", err)
+	}
+	return replica
+}
+
+func TestIssueRepository_ReadReplica_Postgres(t *testing.T) {
+	primary := setupPostgresTestDB(t)
+	replica := setupPostgresReplica(t, primary)
+	repo := NewIssueRepository(primary, logrus.New())
+	ctx := context.Background()
+
+	// Writes go to the primary, and the created issue is reloaded from it
+	created, _, err := repo.CreateOrUpdate(ctx, createTestIssue("Written to the primary", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if created == nil || created.Title != "Written to the primary" {
+		t.Fatalf("Expected the created issue to be read from the primary, got %+v", created)
+	}
+	var primaryCount, replicaCount int64
+	primary.Raw("SELECT COUNT(*) FROM public.issues").Scan(&primaryCount)
+	replica.Model(&models.Issue{}).Count(&replicaCount)
+	if primaryCount != 1 || replicaCount != 0 {
+		t.Errorf("Expected the issue to be written to the primary only, got %d and %d issues", primaryCount, replicaCount)
+	}
+
+	// Reads go to the replica, which didn't receive the write
+	found, err := repo.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found != nil {
+		t.Errorf("Expected the issue to be read from the replica, got issue %s", found.ID)
+	}
+
+	replicated, _, err := NewIssueRepository(replica, logrus.New()).Create(ctx, createTestIssue("Only on the replica", "test-namespace"))
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	found, err = repo.FindByID(ctx, replicated.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if found == nil || found.Title != "Only on the replica" {
+		t.Errorf("Expected the issue of the replica, got %+v", found)
+	}
+	issues, total, err := repo.FindAll(ctx, IssueQueryFilters{Namespace: "test-namespace"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+	if total != 1 || len(issues) != 1 || issues[0].ID != replicated.ID {
+		t.Errorf("Expected the issues of the replica to be listed, got %d issues", total)
+	}
+}
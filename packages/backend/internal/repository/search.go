@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"gorm.io/gorm"
+)
+
+// Markers wrapping the matched terms in search highlights
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// maxSearchCandidates bounds the issues ranked in memory when full-text search isn't available
+const maxSearchCandidates = 1000
+
+// Number of search results returned by default, and at most
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// snippetContext is the number of characters kept around a match in description highlights
+const snippetContext = 60
+
+// searchDocument weighs matches in the title over matches in the description
+const searchDocument = "setweight(to_tsvector('english', issues.title), 'A') || " +
+	"setweight(to_tsvector('english', issues.description), 'B')"
+
+// Markers wrapping the matched terms in the highlights built by Postgres, replaced with
+// the highlight markers once the rest of the highlight is HTML-escaped
+const (
+	headlineStart = "\x1e"
+	headlineStop  = "\x1f"
+)
+
+// headlineOptions configures how Postgres builds highlights
+const headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxFragments=2"
+
+// headlineMarkers replaces the markers of Postgres highlights with the highlight markers
+var headlineMarkers = strings.NewReplacer(headlineStart, HighlightStart, headlineStop, HighlightStop)
+
+// searchHit is an issue matching a search, before its associations are loaded
+type searchHit struct {
+	ID                   string
+	Score                float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// Search finds the issues matching a text query in their title or description,
+// ranked by relevance.
+//
+// Postgres full-text search is used when available, matching every term of the
+// query. Other databases fall back to matching the terms with LIKE and ranking
+// the matches in memory.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - filters: IssueQueryFilters holding the query in Search, and optional filters.
+//     DefaultSearchLimit results are returned when no limit is set, MaxSearchLimit at most.
+//
+// Returns:
+//   - []dto.SearchResult: The matching issues, most relevant first
+//   - error: Database error or nil
+func (i *issueRepository) Search(ctx context.Context, filters IssueQueryFilters) ([]dto.SearchResult, error) {
+	text := filters.Search
+	terms := strings.Fields(text)
+	if len(terms) == 0 {
+		return []dto.SearchResult{}, nil
+	}
+	// The query is matched by the search itself rather than the LIKE filter
+	filters.Search = ""
+	if filters.Limit <= 0 {
+		filters.Limit = DefaultSearchLimit
+	}
+	filters.Limit = min(filters.Limit, MaxSearchLimit)
+	query := applyIssueFilters(i.db.WithContext(ctx).Model(&models.Issue{}), filters)
+
+	var hits []searchHit
+	var err error
+	if i.db.Dialector.Name() == "postgres" {
+		hits, err = searchFullText(query, text, filters.Limit)
+	} else {
+		hits, err = searchFallback(query, terms, filters.Limit)
+	}
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to search issues")
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	results, err := i.loadSearchResults(ctx, hits)
+	if err != nil {
+		i.logger.WithError(err).Error("Failed to load search results")
+		return nil, fmt.Errorf("failed to load search results: %w", err)
+	}
+	return results, nil
+}
+
+// searchFullText ranks the issues with Postgres full-text search.
+func searchFullText(query *gorm.DB, text string, limit int) ([]searchHit, error) {
+	var hits []searchHit
+	err := query.
+		Select("issues.id AS id, "+
+			"ts_rank("+searchDocument+", plainto_tsquery('english', ?)) AS score, "+
+			"ts_headline('english', issues.title, plainto_tsquery('english', ?), ?) AS title_highlight, "+
+			"ts_headline('english', issues.description, plainto_tsquery('english', ?), ?) AS description_highlight",
+			text, text, headlineOptions, text, headlineOptions).
+		Where(searchDocument+" @@ plainto_tsquery('english', ?)", text).
+		Order("score DESC, issues.detected_at DESC").
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Highlights are rendered as HTML, the text around the markers must not be
+	for idx := range hits {
+		hits[idx].TitleHighlight = headlineMarkers.Replace(html.EscapeString(hits[idx].TitleHighlight))
+		hits[idx].DescriptionHighlight = headlineMarkers.Replace(html.EscapeString(hits[idx].DescriptionHighlight))
+	}
+	return hits, nil
+}
+
+// searchFallback ranks the issues containing every term in memory. Each match
+// in the title counts twice as much as a match in the description.
+func searchFallback(query *gorm.DB, terms []string, limit int) ([]searchHit, error) {
+	for _, term := range terms {
+		// Terms are matched literally, wildcards included
+		pattern := "%" + escapeLike(strings.ToLower(term)) + "%"
+		query = query.Where("(LOWER(title) LIKE ? ESCAPE '\\' OR LOWER(description) LIKE ? ESCAPE '\\')", pattern, pattern)
+	}
+
+	var candidates []models.Issue
+	err := query.
+		Select("id", "title", "description", "detected_at").
+		Order("detected_at DESC").
+		Limit(maxSearchCandidates).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	matcher := termsMatcher(terms)
+	hits := make([]searchHit, 0, len(candidates))
+	for _, issue := range candidates {
+		titleMatches := len(matcher.FindAllStringIndex(issue.Title, -1))
+		descriptionMatches := len(matcher.FindAllStringIndex(issue.Description, -1))
+		hits = append(hits, searchHit{
+			ID:                   issue.ID,
+			Score:                float64(2*titleMatches + descriptionMatches),
+			TitleHighlight:       highlight(matcher, issue.Title),
+			DescriptionHighlight: highlight(matcher, snippet(matcher, issue.Description)),
+		})
+	}
+
+	// Candidates are sorted newest first, which breaks ties
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Score > hits[b].Score
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// termsMatcher builds a case-insensitive pattern matching any of the terms,
+// preferring the longest ones.
+func termsMatcher(terms []string) *regexp.Regexp {
+	quoted := make([]string, len(terms))
+	for idx, term := range terms {
+		quoted[idx] = regexp.QuoteMeta(term)
+	}
+	sort.Slice(quoted, func(a, b int) bool {
+		return len(quoted[a]) > len(quoted[b])
+	})
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// highlight wraps every match in the text with the highlight markers. Highlights are
+// rendered as HTML, so the text is escaped.
+func highlight(matcher *regexp.Regexp, text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range matcher.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(HighlightStart + html.EscapeString(text[loc[0]:loc[1]]) + HighlightStop)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// snippet returns the words surrounding the first match in the text
+func snippet(matcher *regexp.Regexp, text string) string {
+	loc := matcher.FindStringIndex(text)
+	if loc == nil {
+		return text
+	}
+
+	start, end := loc[0]-snippetContext, loc[1]+snippetContext
+	prefix, suffix := "", ""
+	if start > 0 {
+		// Don't cut a word in half
+		if space := strings.IndexByte(text[start:loc[0]], ' '); space >= 0 {
+			start += space + 1
+		} else {
+			start = loc[0]
+		}
+		prefix = "... "
+	} else {
+		start = 0
+	}
+	if end < len(text) {
+		if space := strings.LastIndexByte(text[loc[1]:end], ' '); space >= 0 {
+			end = loc[1] + space
+		} else {
+			end = loc[1]
+		}
+		suffix = " ..."
+	} else {
+		end = len(text)
+	}
+	return prefix + text[start:end] + suffix
+}
+
+// loadSearchResults loads the issues of the hits, keeping their order.
+func (i *issueRepository) loadSearchResults(ctx context.Context, hits []searchHit) ([]dto.SearchResult, error) {
+	results := make([]dto.SearchResult, 0, len(hits))
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	ids := make([]string, len(hits))
+	for idx, hit := range hits {
+		ids[idx] = hit.ID
+	}
+
+	var issues []models.Issue
+	err := i.db.WithContext(ctx).
+		Preload("Scope").
+		Preload("Links", orderedLinks).
+		Where("id IN ?", ids).
+		Find(&issues).Error
+	if err != nil {
+		return nil, err
+	}
+
+	issuesByID := make(map[string]models.Issue, len(issues))
+	for _, issue := range issues {
+		issuesByID[issue.ID] = issue
+	}
+
+	for _, hit := range hits {
+		issue, ok := issuesByID[hit.ID]
+		if !ok {
+			// Deleted since the search ran
+			continue
+		}
+
+		highlights := []string{}
+		for _, h := range []string{hit.TitleHighlight, hit.DescriptionHighlight} {
+			if strings.Contains(h, HighlightStart) {
+				highlights = append(highlights, h)
+			}
+		}
+
+		results = append(results, dto.SearchResult{
+			Issue:      issue,
+			Score:      hit.Score,
+			Highlights: highlights,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,98 @@
+//go:build postgres
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupPostgresTestDB connects to the database at KITE_TEST_POSTGRES_DSN,
+// skipping the test when it isn't set. Tables are dropped afterwards.
+func setupPostgresTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("KITE_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("KITE_TEST_POSTGRES_DSN not set, skipping Postgres test")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to Postgres: %v", err)
+	}
+
+	tables := models.All()
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.Migrator().DropTable(tables...); err != nil {
+			t.Errorf("Failed to drop tables: %v", err)
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
+
+func TestIssueRepository_Search_Postgres(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	repo := NewIssueRepository(db, logrus.New())
+	ctx := context.Background()
+
+	issues := []struct {
+		title       string
+		description string
+	}{
+		{"Unrelated failure", "The build failed because of a missing dependency"},
+		{"Task run failed", "The pipeline run failed after a timeout in the test step"},
+		{"Timeout in build", "The build task hit a timeout"},
+	}
+	for idx, issue := range issues {
+		req := createTestIssue(issue.title, "test-namespace")
+		req.Description = issue.description
+		req.Scope.ResourceName = fmt.Sprintf("search-component-%d", idx)
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Unexpected error, got %v", err)
+		}
+	}
+
+	results, err := repo.Search(ctx, IssueQueryFilters{Search: "timeouts", Namespace: "test-namespace"})
+	if err != nil {
+		t.Fatalf("Unexpected error, got %v", err)
+	}
+
+	// Terms are stemmed, and matches in the title rank higher
+	expectedTitles := []string{"Timeout in build", "Task run failed"}
+	if len(results) != len(expectedTitles) {
+		t.Fatalf("Expected %d results, got %d", len(expectedTitles), len(results))
+	}
+	for idx, result := range results {
+		if result.Issue.Title != expectedTitles[idx] {
+			t.Errorf("Expected result %d to be '%s', got '%s'", idx, expectedTitles[idx], result.Issue.Title)
+		}
+		if len(result.Highlights) == 0 {
+			t.Errorf("Expected highlights for '%s'", result.Issue.Title)
+		}
+		for _, h := range result.Highlights {
+			if !strings.Contains(strings.ToLower(h), HighlightStart+"timeout"+HighlightStop) {
+				t.Errorf("Expected the term to be highlighted, got '%s'", h)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type webhookDeliveryRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+//
+// Parameters:
+//   - db: Pointer to a database (gorm.DB)
+//   - logger: Pointer to a logger (logrus.Logger)
+//
+// Returns:
+//   - WebhookDeliveryRepository
+func NewWebhookDeliveryRepository(db *gorm.DB, logger *logrus.Logger) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create records a webhook delivery.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - delivery: The delivery to record, its ID is kept if set
+//
+// Returns:
+//   - error: Database error or nil
+func (w *webhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if err := w.db.WithContext(ctx).Create(delivery).Error; err != nil {
+		w.logger.WithError(err).Error("Failed to record webhook delivery")
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a webhook delivery using its ID.
+//
+// Parameters:
+//   - ctx: Context for cancellations and timeouts
+//   - id: The ID of the delivery
+//
+// Returns:
+//   - *models.WebhookDelivery: The delivery if found, nil if not
+//   - error: Database error or nil
+func (w *webhookDeliveryRepository) FindByID(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		w.logger.WithError(err).Error("Failed to find webhook delivery")
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
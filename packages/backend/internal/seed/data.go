@@ -110,6 +110,11 @@ func seedIssueScopes(tx *gorm.DB) (map[string]string, error) {
 		},
 	}
 
+	// Matched on as is, like the scopes backfilled by migrations
+	for idx := range scopes {
+		scopes[idx].NormalizedResourceName = scopes[idx].ResourceName
+	}
+
 	// Create scopes and let GORM generate UUIDs
 	if err := tx.Create(&scopes).Error; err != nil {
 		return nil, err
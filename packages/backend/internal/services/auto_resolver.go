@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// AutoResolver periodically resolves the active issues that stopped being reported,
+// e.g. pipeline failures whose pipeline was retried without a success webhook.
+type AutoResolver struct {
+	repo     repository.IssueRepository
+	logger   *logrus.Logger
+	ttls     map[models.IssueType]time.Duration // How long issues of each type can go unreported
+	interval time.Duration                      // How often stale issues are looked for
+	clock    clock.Clock
+	// How long an issue can go unreported before its severity is lowered, 0 disables it
+	deescalateAfter time.Duration
+}
+
+// AutoResolverOption configures optional behavior of the auto-resolver
+type AutoResolverOption func(*AutoResolver)
+
+// WithDeescalation lowers the severity of active issues not reported for the window by
+// one level, once per window, down to the severity they were first reported with.
+func WithDeescalation(after time.Duration) AutoResolverOption {
+	return func(a *AutoResolver) {
+		a.deescalateAfter = after
+	}
+}
+
+// WithAutoResolverClock sets the clock stale and quiet issues are found with, e.g. a fake clock in tests
+func WithAutoResolverClock(c clock.Clock) AutoResolverOption {
+	return func(a *AutoResolver) {
+		a.clock = c
+	}
+}
+
+// NewAutoResolver creates a resolver for the issue types with a TTL
+func NewAutoResolver(repo repository.IssueRepository, logger *logrus.Logger, ttls map[models.IssueType]time.Duration, interval time.Duration, opts ...AutoResolverOption) *AutoResolver {
+	a := &AutoResolver{
+		repo:     repo,
+		logger:   logger,
+		ttls:     ttls,
+		interval: interval,
+		clock:    clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Enabled reports whether any issue type is resolved, or de-escalated, automatically
+func (a *AutoResolver) Enabled() bool {
+	return len(a.ttls) > 0 || a.deescalateAfter > 0
+}
+
+// Run sweeps stale issues, and de-escalates quiet ones, every interval until the context is done
+func (a *AutoResolver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.Sweep(ctx); err != nil && ctx.Err() == nil {
+				a.logger.WithError(err).Error("Failed to auto-resolve stale issues")
+			}
+			if _, err := a.Deescalate(ctx); err != nil && ctx.Err() == nil {
+				a.logger.WithError(err).Error("Failed to de-escalate quiet issues")
+			}
+		}
+	}
+}
+
+// Sweep resolves the active issues that weren't reported within the TTL of their type.
+//
+// Returns:
+//   - int64: The number of issues resolved
+//   - error: Database error or nil
+func (a *AutoResolver) Sweep(ctx context.Context) (int64, error) {
+	// Sweep the types in a stable order, to keep logs readable
+	issueTypes := make([]models.IssueType, 0, len(a.ttls))
+	for issueType := range a.ttls {
+		issueTypes = append(issueTypes, issueType)
+	}
+	slices.Sort(issueTypes)
+
+	var total int64
+	for _, issueType := range issueTypes {
+		ttl := a.ttls[issueType]
+		note := fmt.Sprintf("Resolved automatically, not reported for %s", ttl)
+		resolved, err := a.repo.ResolveStale(ctx, issueType, a.clock.Now().Add(-ttl), note)
+		if err != nil {
+			return total, err
+		}
+		if resolved > 0 {
+			a.logger.WithFields(logrus.Fields{
+				"issue_type": issueType,
+				"ttl":        ttl,
+				"resolved":   resolved,
+			}).Info("Auto-resolved stale issues")
+		}
+		total += resolved
+	}
+	return total, nil
+}
+
+// Deescalate lowers the severity of the active issues that weren't reported within the
+// de-escalation window by one level, when enabled.
+//
+// Returns:
+//   - int64: The number of issues de-escalated
+//   - error: Database error or nil
+func (a *AutoResolver) Deescalate(ctx context.Context) (int64, error) {
+	if a.deescalateAfter <= 0 {
+		return 0, nil
+	}
+
+	note := fmt.Sprintf("De-escalated automatically, not reported for %s", a.deescalateAfter)
+	deescalated, err := a.repo.DeescalateQuiet(ctx, a.clock.Now().Add(-a.deescalateAfter), note)
+	if err != nil {
+		return 0, err
+	}
+	if deescalated > 0 {
+		a.logger.WithFields(logrus.Fields{
+			"window":      a.deescalateAfter,
+			"deescalated": deescalated,
+		}).Info("De-escalated quiet issues")
+	}
+	return deescalated, nil
+}
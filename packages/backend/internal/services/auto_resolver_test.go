@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAutoResolver_Sweep(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	logger.SetLevel(logrus.ErrorLevel)
+
+	issues := make(map[models.IssueType]*models.Issue)
+	for _, issueType := range []models.IssueType{models.IssueTypePipeline, models.IssueTypeBuild} {
+		issue, _, err := repo.Create(ctx, dto.CreateIssueRequest{
+			Title:       "Failure of type " + string(issueType),
+			Description: "No success reported yet",
+			Severity:    models.SeverityMajor,
+			IssueType:   issueType,
+			Namespace:   "team-retries",
+			Scope: dto.ScopeReqBody{
+				ResourceType:      "pipelinerun",
+				ResourceName:      "build-" + string(issueType),
+				ResourceNamespace: "team-retries",
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		issues[issueType] = issue
+	}
+
+	// Within the window, the failure may still be followed by a success webhook
+	clock := testhelpers.NewFakeClock(time.Now().Add(30 * time.Minute))
+
+	// Only pipeline failures are resolved automatically
+	resolver := NewAutoResolver(repo, logger, map[models.IssueType]time.Duration{
+		models.IssueTypePipeline: time.Hour,
+	}, time.Minute, WithAutoResolverClock(clock))
+
+	resolved, err := resolver.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resolved != 0 {
+		t.Errorf("Expected no issue resolved within the window, got %d", resolved)
+	}
+
+	// After the window, the stale pipeline failure is resolved
+	clock.Advance(90 * time.Minute)
+	resolved, err = resolver.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resolved != 1 {
+		t.Fatalf("Expected 1 issue resolved after the window, got %d", resolved)
+	}
+
+	pipelineIssue, err := repo.FindByID(ctx, issues[models.IssueTypePipeline].ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if pipelineIssue.State != models.IssueStateResolved || pipelineIssue.ResolvedAt == nil {
+		t.Errorf("Expected the pipeline failure to be resolved, got state %s", pipelineIssue.State)
+	}
+	if pipelineIssue.ResolvedBy != models.ResolutionSourceAutoResolve {
+		t.Errorf("Expected the pipeline failure to be resolved by %s, got '%s'", models.ResolutionSourceAutoResolve, pipelineIssue.ResolvedBy)
+	}
+	if len(pipelineIssue.Notes) != 1 || pipelineIssue.Notes[0].Content != "Resolved automatically, not reported for 1h0m0s" {
+		t.Errorf("Expected a note explaining the resolution, got %+v", pipelineIssue.Notes)
+	}
+
+	// Issue types without a TTL stay active
+	buildIssue, err := repo.FindByID(ctx, issues[models.IssueTypeBuild].ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buildIssue.State != models.IssueStateActive {
+		t.Errorf("Expected the build failure to stay active, got state %s", buildIssue.State)
+	}
+}
+
+func TestAutoResolver_Deescalate(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	repo := repository.NewIssueRepository(db, logger, repository.WithClock(clock))
+	ctx := context.Background()
+
+	report := func(name string, severity models.Severity) *models.Issue {
+		t.Helper()
+		issue, _, err := repo.CreateOrUpdate(ctx, dto.CreateIssueRequest{
+			Title:       "Build failed for " + name,
+			Description: "The build keeps failing",
+			Severity:    severity,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "team-quiet",
+			Scope: dto.ScopeReqBody{
+				ResourceType: "component",
+				ResourceName: name,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return issue
+	}
+
+	// Repeated failures escalate the issue from minor to critical
+	report("escalated", models.SeverityMinor)
+	escalated := report("escalated", models.SeverityCritical)
+	if escalated.Severity != models.SeverityCritical || escalated.BaseSeverity != models.SeverityMinor {
+		t.Fatalf("Expected a critical issue first reported as minor, got %s from %s", escalated.Severity, escalated.BaseSeverity)
+	}
+
+	// Resolved issues are left untouched
+	resolved := report("resolved", models.SeverityMinor)
+	report("resolved", models.SeverityMajor)
+	if _, err := repo.Update(ctx, resolved.ID, dto.UpdateIssueRequest{State: models.IssueStateResolved}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resolver := NewAutoResolver(repo, logger, nil, time.Minute, WithDeescalation(24*time.Hour), WithAutoResolverClock(clock))
+	if !resolver.Enabled() {
+		t.Fatal("Expected the resolver to be enabled by de-escalation")
+	}
+
+	deescalate := func(expected int64, severity models.Severity) {
+		t.Helper()
+		deescalated, err := resolver.Deescalate(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if deescalated != expected {
+			t.Errorf("Expected %d issues de-escalated, got %d", expected, deescalated)
+		}
+		issue, err := repo.FindByID(ctx, escalated.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if issue.Severity != severity {
+			t.Errorf("Expected severity %s, got %s", severity, issue.Severity)
+		}
+	}
+
+	// Within the window, the issue may still fail again
+	clock.Advance(23 * time.Hour)
+	deescalate(0, models.SeverityCritical)
+
+	// Past the window, severity steps down one level per quiet window
+	clock.Advance(2 * time.Hour)
+	deescalate(1, models.SeverityMajor)
+	deescalate(0, models.SeverityMajor)
+	clock.Advance(25 * time.Hour)
+	deescalate(1, models.SeverityMinor)
+
+	// Not below the severity it was first reported with
+	clock.Advance(25 * time.Hour)
+	deescalate(0, models.SeverityMinor)
+
+	issue, err := repo.FindByID(ctx, escalated.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(issue.Notes) != 2 || issue.Notes[0].Content != "De-escalated automatically, not reported for 24h0m0s, severity lowered from critical to major" {
+		t.Errorf("Expected a note recording each de-escalation, got %+v", issue.Notes)
+	}
+
+	untouched, err := repo.FindByID(ctx, resolved.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if untouched.Severity != models.SeverityMajor {
+		t.Errorf("Expected the resolved issue to stay major, got %s", untouched.Severity)
+	}
+}
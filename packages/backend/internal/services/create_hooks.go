@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateHook runs custom logic, e.g. enrichment or external ticketing, on the issues created.
+// It's called once the issue is stored, and isn't called for the duplicates updating an issue.
+type CreateHook interface {
+	OnIssueCreated(ctx context.Context, issue *models.Issue) error
+}
+
+// CreateHookFunc adapts a function to a CreateHook
+type CreateHookFunc func(ctx context.Context, issue *models.Issue) error
+
+// OnIssueCreated calls the function
+func (f CreateHookFunc) OnIssueCreated(ctx context.Context, issue *models.Issue) error {
+	return f(ctx, issue)
+}
+
+// ErrCreateHookFailed is returned when a create hook fails and its errors fail the creation.
+// The issue is stored nonetheless.
+var ErrCreateHookFailed = errors.New("issue create hook failed")
+
+// WithCreateHooks registers hooks called, in order, with the issues created.
+// Hooks are called in the request creating the issue, so they should be quick.
+func WithCreateHooks(hooks ...CreateHook) Option {
+	return func(s *IssueService) {
+		s.createHooks = append(s.createHooks, hooks...)
+	}
+}
+
+// WithCreateHookErrors sets whether the errors of create hooks fail the creation of issues.
+// They're only logged by default.
+func WithCreateHookErrors(fail bool) Option {
+	return func(s *IssueService) {
+		s.failOnCreateHookError = fail
+	}
+}
+
+// runCreateHooks calls the create hooks with a new issue. Every hook is called even if
+// some fail, their errors are only returned when they fail the creation.
+func (s *IssueService) runCreateHooks(ctx context.Context, issue *models.Issue) error {
+	var errs []error
+	for _, hook := range s.createHooks {
+		if err := hook.OnIssueCreated(ctx, issue); err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"issue_id": issue.ID,
+				"hook":     fmt.Sprintf("%T", hook),
+			}).Error("Issue create hook failed")
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 || !s.failOnCreateHookError {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrCreateHookFailed, errors.Join(errs...))
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+// stubCreateHook records the issues it's called with, and fails with err
+type stubCreateHook struct {
+	mu     sync.Mutex
+	issues []*models.Issue
+	err    error
+}
+
+func (h *stubCreateHook) OnIssueCreated(ctx context.Context, issue *models.Issue) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.issues = append(h.issues, issue)
+	return h.err
+}
+
+func newHookedIssueRequest(name string) dto.CreateIssueRequest {
+	return dto.CreateIssueRequest{
+		Title:       "Build failed: " + name,
+		Description: "The build failed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-hooked",
+		Scope: dto.ScopeReqBody{
+			ResourceType: "component",
+			ResourceName: name,
+		},
+	}
+}
+
+func TestIssueService_CreateHooks(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	logger.SetLevel(logrus.PanicLevel)
+	first := &stubCreateHook{}
+	second := &stubCreateHook{}
+	service := NewIssueService(repo, logger, WithCreateHooks(first), WithCreateHooks(CreateHookFunc(second.OnIssueCreated)))
+
+	issue, isNew, err := service.CreateOrUpdateIssue(ctx, newHookedIssueRequest("frontend"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !isNew {
+		t.Error("Expected the issue to be reported as created")
+	}
+	created, err := service.CreateIssue(ctx, newHookedIssueRequest("backend"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Duplicates update the issue without calling the hooks again
+	_, isNew, err = service.CreateOrUpdateIssue(ctx, newHookedIssueRequest("frontend"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if isNew {
+		t.Error("Expected the duplicate to be reported as updated")
+	}
+
+	for _, hook := range []*stubCreateHook{first, second} {
+		if len(hook.issues) != 2 {
+			t.Fatalf("Expected the hook to be called with 2 issues, got %d", len(hook.issues))
+		}
+		if hook.issues[0].ID != issue.ID || hook.issues[1].ID != created.ID {
+			t.Errorf("Expected the hook to be called with issues %s and %s, got %s and %s",
+				issue.ID, created.ID, hook.issues[0].ID, hook.issues[1].ID)
+		}
+		if hook.issues[0].Title != "Build failed: frontend" {
+			t.Errorf("Expected the hook to be called with the created issue, got '%s'", hook.issues[0].Title)
+		}
+	}
+}
+
+func TestIssueService_CreateHooks_ConcurrentDuplicates(t *testing.T) {
+	const numRequests = 10
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	repo := repository.NewIssueRepository(testhelpers.SetupConcurrentTestDB(t), logger)
+	hook := &stubCreateHook{}
+	service := NewIssueService(repo, logger, WithCreateHooks(hook))
+
+	// Only the report that created the issue calls the hooks
+	var wg sync.WaitGroup
+	errs := make([]error, numRequests)
+	for i := range numRequests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, errs[i] = service.CreateOrUpdateIssue(context.Background(), newHookedIssueRequest("frontend"))
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(hook.issues) != 1 {
+		t.Errorf("Expected the hook to be called once, got %d calls", len(hook.issues))
+	}
+}
+
+func TestIssueService_CreateHooks_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		failOnError bool
+		expectedErr error
+	}{
+		{name: "logged by default"},
+		{name: "fail the creation", failOnError: true, expectedErr: ErrCreateHookFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, logger, repo, _ := setupServiceDependents(t)
+			logger.SetLevel(logrus.PanicLevel)
+			failing := &stubCreateHook{err: errors.New("ticketing unavailable")}
+			next := &stubCreateHook{}
+			service := NewIssueService(repo, logger, WithCreateHooks(failing, next), WithCreateHookErrors(tt.failOnError))
+
+			issue, _, err := service.CreateOrUpdateIssue(ctx, newHookedIssueRequest("frontend"))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr == nil && issue == nil {
+				t.Error("Expected the created issue, got nil")
+			}
+			if len(next.issues) != 1 {
+				t.Errorf("Expected the hooks after the failing one to be called, got %d calls", len(next.issues))
+			}
+
+			// The issue is stored either way
+			stored, err := repo.FindDuplicate(ctx, newHookedIssueRequest("frontend"))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if stored == nil {
+				t.Error("Expected the issue to be stored")
+			}
+		})
+	}
+}
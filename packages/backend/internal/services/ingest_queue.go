@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrIngestQueueFull is returned when the ingest queue has no room left for a request
+var ErrIngestQueueFull = errors.New("ingest queue is full")
+
+// ErrIngestQueueClosed is returned when the ingest queue is draining, on shutdown
+var ErrIngestQueueClosed = errors.New("ingest queue is closed")
+
+// ingestJob is a queued issue, along with the namespaces whose duplicates it can update
+type ingestJob struct {
+	req       dto.CreateIssueRequest
+	authorize repository.NamespaceAuthorizer // Nil to only update duplicates of its own namespace
+}
+
+// IngestQueue buffers the issues reported by webhooks, so they're accepted right
+// away and persisted in the background by a pool of workers.
+//
+// The queue is bounded: requests are rejected once it's full rather than piling up
+// in memory. Requests still queued are lost if the process stops without draining it.
+type IngestQueue struct {
+	logger  *logrus.Logger
+	jobs    chan ingestJob
+	workers int              // Number of requests persisted at once
+	metrics *metrics.Metrics // Records the depth and drops of the queue, nil to disable
+
+	mu     sync.RWMutex // Guards closed, so requests aren't sent on a closed queue
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// IngestQueueOption configures optional behavior of the ingest queue
+type IngestQueueOption func(*IngestQueue)
+
+// WithIngestQueueMetrics records the depth of the queue and the requests it rejects
+func WithIngestQueueMetrics(m *metrics.Metrics) IngestQueueOption {
+	return func(q *IngestQueue) {
+		q.metrics = m
+	}
+}
+
+// NewIngestQueue creates a queue holding up to size requests, persisted by the given
+// number of workers once it's started.
+func NewIngestQueue(logger *logrus.Logger, size, workers int, opts ...IngestQueueOption) *IngestQueue {
+	queue := &IngestQueue{
+		logger:  logger,
+		jobs:    make(chan ingestJob, size),
+		workers: workers,
+	}
+	for _, opt := range opts {
+		opt(queue)
+	}
+	return queue
+}
+
+// Start starts the workers creating or updating the queued issues with the issue service
+func (q *IngestQueue) Start(issueService IssueServiceInterface) {
+	for range q.workers {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for job := range q.jobs {
+				if q.metrics != nil {
+					q.metrics.WebhookQueueDepth.Dec()
+				}
+				q.process(issueService, job)
+			}
+		}()
+	}
+}
+
+// process creates or updates a queued issue. The request it came from is long gone,
+// so failures can only be logged.
+func (q *IngestQueue) process(issueService IssueServiceInterface, job ingestJob) {
+	req := job.req
+	fields := logrus.Fields{
+		"namespace":     req.Namespace,
+		"resource_type": req.Scope.ResourceType,
+		"resource_name": req.Scope.ResourceName,
+	}
+
+	ctx := context.Background()
+	if job.authorize != nil {
+		ctx = repository.WithNamespaceAuthorizer(ctx, job.authorize)
+	}
+	issue, _, err := issueService.CreateOrUpdateIssue(ctx, req)
+	if errors.Is(err, repository.ErrNamespaceIssueLimitExceeded) {
+		q.logger.WithFields(fields).Warn("Dropped queued issue, its namespace reached its maximum number of active issues")
+		return
+	}
+	if err != nil {
+		q.logger.WithError(err).WithFields(fields).Error("Failed to create or update queued issue")
+		return
+	}
+	q.logger.WithField("issue_id", issue.ID).Debug("Processed queued issue")
+}
+
+// Enqueue queues an issue to create or update. It never blocks: ErrIngestQueueFull is
+// returned when the queue is full, and ErrIngestQueueClosed once it's draining.
+//
+// The issue updates the duplicates of the namespaces authorize allows, like a report
+// persisted right away with repository.WithNamespaceAuthorizer, only those of its own
+// namespace if nil. authorize is called once the request is over, so it can't rely on it.
+func (q *IngestQueue) Enqueue(req dto.CreateIssueRequest, authorize repository.NamespaceAuthorizer) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		q.recordDrop(metrics.QueueDropClosed)
+		return ErrIngestQueueClosed
+	}
+
+	// Counted before it's queued, so a worker never takes it off the depth first
+	if q.metrics != nil {
+		q.metrics.WebhookQueueDepth.Inc()
+	}
+	select {
+	case q.jobs <- ingestJob{req: req, authorize: authorize}:
+		return nil
+	default:
+		if q.metrics != nil {
+			q.metrics.WebhookQueueDepth.Dec()
+		}
+		q.recordDrop(metrics.QueueDropFull)
+		return ErrIngestQueueFull
+	}
+}
+
+// recordDrop counts a request rejected by the queue
+func (q *IngestQueue) recordDrop(reason string) {
+	if q.metrics != nil {
+		q.metrics.WebhookQueueDropped.WithLabelValues(reason).Inc()
+	}
+}
+
+// Len returns the number of requests waiting in the queue
+func (q *IngestQueue) Len() int {
+	return len(q.jobs)
+}
+
+// Stats returns the number of requests waiting in the queue, along with its capacity
+// and number of workers
+func (q *IngestQueue) Stats() dto.IngestQueueStats {
+	return dto.IngestQueueStats{
+		Depth:    len(q.jobs),
+		Capacity: cap(q.jobs),
+		Workers:  q.workers,
+	}
+}
+
+// Drain stops accepting requests and waits for the queued ones to be persisted,
+// or for the context to be done.
+func (q *IngestQueue) Drain(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		q.logger.WithField("queued", q.Len()).Error("Stopped draining the ingest queue, queued issues are lost")
+		return ctx.Err()
+	}
+}
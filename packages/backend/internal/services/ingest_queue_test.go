@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newQueuedIssueRequest(name string) dto.CreateIssueRequest {
+	return dto.CreateIssueRequest{
+		Title:       fmt.Sprintf("Build failed: %s", name),
+		Description: "Queued build failure",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-queue",
+		Scope: dto.ScopeReqBody{
+			ResourceType:      "component",
+			ResourceName:      name,
+			ResourceNamespace: "team-queue",
+		},
+	}
+}
+
+func TestIngestQueue_Drain(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	queue := NewIngestQueue(logger, 10, 1)
+	queue.Start(NewIssueService(repo, logger))
+
+	for _, name := range []string{"component-a", "component-b", "component-a"} {
+		if err := queue.Enqueue(newQueuedIssueRequest(name), nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if err := queue.Drain(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("Expected the queue to be empty, got %d", queue.Len())
+	}
+
+	// The duplicate report updated the first issue
+	_, total, err := repo.FindAll(ctx, repository.IssueQueryFilters{Namespace: "team-queue"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 issues, got %d", total)
+	}
+
+	if err := queue.Enqueue(newQueuedIssueRequest("component-c"), nil); !errors.Is(err, ErrIngestQueueClosed) {
+		t.Errorf("Expected ErrIngestQueueClosed, got %v", err)
+	}
+}
+
+func TestIngestQueue_AuthorizedNamespaces(t *testing.T) {
+	ctx, logger, _, db := setupServiceDependents(t)
+	repo := repository.NewIssueRepository(db, logger,
+		repository.WithDedupOptions(repository.DedupOptions{AcrossNamespaces: true}))
+	queue := NewIngestQueue(logger, 10, 1)
+	queue.Start(NewIssueService(repo, logger))
+
+	// Both components are already tracked by another team
+	for _, name := range []string{"component-a", "component-b"} {
+		req := newQueuedIssueRequest(name)
+		req.Namespace = "team-shared"
+		if _, _, err := repo.Create(ctx, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Only the report allowed to update the other team's issues is deduplicated with them
+	if err := queue.Enqueue(newQueuedIssueRequest("component-a"), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	allowShared := func(namespace string) bool { return namespace == "team-shared" }
+	if err := queue.Enqueue(newQueuedIssueRequest("component-b"), allowShared); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := queue.Drain(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	issues, _, err := repo.FindAll(ctx, repository.IssueQueryFilters{Namespace: "team-queue"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(issues) != 1 || issues[0].Scope.ResourceName != "component-a" {
+		t.Errorf("Expected only component-a to be tracked in team-queue, got %+v", issues)
+	}
+	shared, _, err := repo.FindAll(ctx, repository.IssueQueryFilters{Namespace: "team-shared", ResourceName: "component-b"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(shared) != 1 || shared[0].OccurrenceCount != 2 {
+		t.Errorf("Expected the queued report to update the team-shared issue, got %+v", shared)
+	}
+}
+
+func TestIngestQueue_Full(t *testing.T) {
+	_, logger, _, _ := setupServiceDependents(t)
+	// Not started, so nothing is taken off the queue
+	queue := NewIngestQueue(logger, 2, 1)
+
+	for _, name := range []string{"component-a", "component-b"} {
+		if err := queue.Enqueue(newQueuedIssueRequest(name), nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := queue.Enqueue(newQueuedIssueRequest("component-c"), nil); !errors.Is(err, ErrIngestQueueFull) {
+		t.Errorf("Expected ErrIngestQueueFull, got %v", err)
+	}
+	if queue.Len() != 2 {
+		t.Errorf("Expected 2 queued issues, got %d", queue.Len())
+	}
+}
+
+func TestIngestQueue_DrainTimeout(t *testing.T) {
+	_, logger, _, _ := setupServiceDependents(t)
+	queue := NewIngestQueue(logger, 2, 1)
+	// A worker blocked on an issue service that never returns
+	blocked := make(chan struct{})
+	defer close(blocked)
+	queue.Start(blockingIssueService{blocked: blocked})
+
+	if err := queue.Enqueue(newQueuedIssueRequest("component-a"), nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := queue.Drain(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestIngestQueue_Metrics(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	m := metrics.New()
+	queue := NewIngestQueue(logger, 3, 2, WithIngestQueueMetrics(m))
+
+	// Not started yet, so the issues stay queued
+	for _, name := range []string{"component-a", "component-b", "component-c"} {
+		if err := queue.Enqueue(newQueuedIssueRequest(name), nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := queue.Enqueue(newQueuedIssueRequest("component-d"), nil); !errors.Is(err, ErrIngestQueueFull) {
+		t.Fatalf("Expected ErrIngestQueueFull, got %v", err)
+	}
+
+	if depth := testutil.ToFloat64(m.WebhookQueueDepth); depth != 3 {
+		t.Errorf("Expected a depth of 3, got %v", depth)
+	}
+	if dropped := testutil.ToFloat64(m.WebhookQueueDropped.WithLabelValues(metrics.QueueDropFull)); dropped != 1 {
+		t.Errorf("Expected 1 issue dropped by the full queue, got %v", dropped)
+	}
+	expected := dto.IngestQueueStats{Depth: 3, Capacity: 3, Workers: 2}
+	if stats := queue.Stats(); stats != expected {
+		t.Errorf("Expected stats %+v, got %+v", expected, stats)
+	}
+
+	// Drained, the queue is empty
+	queue.Start(NewIssueService(repo, logger))
+	if err := queue.Drain(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if depth := testutil.ToFloat64(m.WebhookQueueDepth); depth != 0 {
+		t.Errorf("Expected a depth of 0, got %v", depth)
+	}
+	if stats := queue.Stats(); stats.Depth != 0 {
+		t.Errorf("Expected no queued issue, got %d", stats.Depth)
+	}
+
+	if err := queue.Enqueue(newQueuedIssueRequest("component-e"), nil); !errors.Is(err, ErrIngestQueueClosed) {
+		t.Fatalf("Expected ErrIngestQueueClosed, got %v", err)
+	}
+	if dropped := testutil.ToFloat64(m.WebhookQueueDropped.WithLabelValues(metrics.QueueDropClosed)); dropped != 1 {
+		t.Errorf("Expected 1 issue dropped by the closed queue, got %v", dropped)
+	}
+}
+
+// blockingIssueService creates issues once blocked is closed
+type blockingIssueService struct {
+	IssueServiceInterface
+	blocked chan struct{}
+}
+
+func (s blockingIssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	<-s.blocked
+	return nil, false, errors.New("service stopped")
+}
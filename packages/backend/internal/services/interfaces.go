@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
@@ -12,16 +13,50 @@ import (
 // This allows us to mock it for testing
 type IssueServiceInterface interface {
 	FindIssues(ctx context.Context, filters repository.IssueQueryFilters) (*dto.IssueResponse, error)
+	SearchIssues(ctx context.Context, filters repository.IssueQueryFilters) ([]dto.SearchResult, error)
+	FindMTTR(ctx context.Context, filters repository.IssueQueryFilters, since time.Time, groupBy string) ([]dto.MTTRGroup, error)
+	GroupIssuesByResource(ctx context.Context, filters repository.IssueQueryFilters, maxGroups int) ([]dto.IssueGroup, error)
 	FindIssueByID(ctx context.Context, id string) (*models.Issue, error)
+	FindIssuesByIDs(ctx context.Context, ids []string) ([]models.Issue, error)
+	FindIssueStatus(ctx context.Context, id string) (*dto.IssueStatus, error)
 	CreateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
 	UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error)
+	PatchIssue(ctx context.Context, id string, ops []dto.PatchOperation) (*models.Issue, error)
+	SnoozeIssue(ctx context.Context, id string, snoozed bool) (*models.Issue, error)
 	DeleteIssue(ctx context.Context, id string) error
+	ResolveIssueWithCascade(ctx context.Context, id string) ([]string, error)
+	BulkDeleteIssues(ctx context.Context, namespace string, ids []string) ([]dto.BulkIssueResult, error)
+	MergeDuplicateIssues(ctx context.Context, namespace string, dryRun bool) ([]dto.DuplicateMerge, error)
+	ResolveNamespaceIssues(ctx context.Context, namespace string) (int64, error)
 	FindDuplicateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
 	ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error)
+	ResolveIssuesByScopes(ctx context.Context, resourceTypes []string, resourceName, namespace string) (int64, error)
+	ResolveIssuesByScopePrefix(ctx context.Context, resourceType, namePrefix, namespace string) (int64, error)
+	AcknowledgeIssuesByScope(ctx context.Context, resourceType, resourceName, namespace, acknowledgedBy, note string) (int64, error)
 	AddRelatedIssue(ctx context.Context, sourceID, targetID string) error
 	RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error
-	CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error)
+	RemoveAllRelatedIssues(ctx context.Context, id string) (int64, error)
+	FindIssueGraph(ctx context.Context, id string, maxSize int) (*dto.IssueGraph, error)
+	BatchAddRelatedIssues(ctx context.Context, edges []dto.RelationshipEdgeRequest) ([]dto.RelationshipEdgeResult, error)
+	CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error)
+	ImportIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error)
+	FindNamespaces(ctx context.Context) ([]dto.NamespaceSummary, error)
+	RankNamespacesByIssues(ctx context.Context, states []models.IssueState, limit int) ([]dto.NamespaceIssueCount, error)
+	FindIssueOccurrences(ctx context.Context, issueID string) ([]models.Occurrence, error)
+	FindOccurrenceHistogram(ctx context.Context, issueID, unit string, since time.Time) ([]dto.OccurrenceBucket, error)
+	AddIssueAttachment(ctx context.Context, issueID string, req dto.CreateAttachmentRequest) (*models.Attachment, error)
+	FindIssueAttachments(ctx context.Context, issueID string) ([]models.Attachment, error)
+	AddIssueLink(ctx context.Context, issueID string, req dto.CreateLinkRequest) (*models.Link, error)
+	UpdateIssueLink(ctx context.Context, issueID, linkID string, req dto.CreateLinkRequest) (*models.Link, error)
+	DeleteIssueLink(ctx context.Context, issueID, linkID string) error
+}
+
+// WebhookDeliveryServiceInterface defines what a webhook delivery service should do
+type WebhookDeliveryServiceInterface interface {
+	RecordDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
 }
 
 // Compile-time interface check to verify that IssueService implements the interface
 var _ IssueServiceInterface = (*IssueService)(nil)
+var _ WebhookDeliveryServiceInterface = (*WebhookDeliveryService)(nil)
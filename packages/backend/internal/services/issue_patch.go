@@ -0,0 +1,155 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+)
+
+// ErrInvalidPatch is returned when a JSON Patch operation can't be applied to an issue
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// ErrPatchTestFailed is returned when a "test" operation of a JSON Patch doesn't match the issue
+var ErrPatchTestFailed = errors.New("patch test failed")
+
+// patchableFields maps the JSON Patch paths that can be changed to whether they can be removed.
+// Removing a field clears it, so only optional fields can be removed.
+var patchableFields = map[string]bool{
+	"/title":       false,
+	"/description": true,
+	"/severity":    false,
+	"/state":       false,
+}
+
+// PatchIssue applies a JSON Patch (RFC 6902) to an issue.
+//
+// Only the title, description, severity and state of an issue can be patched.
+// Operations are applied in order and the patch is only persisted if all of them succeed.
+func (s *IssueService) PatchIssue(ctx context.Context, id string, ops []dto.PatchOperation) (*models.Issue, error) {
+	issue, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, repository.ErrIssueNotFound
+	}
+
+	patch, err := applyPatchOperations(issue, ops)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Title != nil {
+		redacted := s.redactor.Redact(*patch.Title)
+		patch.Title = &redacted
+	}
+	if patch.Description != nil {
+		redacted := s.redactor.Redact(*patch.Description)
+		patch.Description = &redacted
+	}
+
+	return s.repo.Patch(ctx, id, patch)
+}
+
+// applyPatchOperations applies JSON Patch operations to the fields of an issue,
+// returning the fields changed.
+func applyPatchOperations(issue *models.Issue, ops []dto.PatchOperation) (repository.IssuePatch, error) {
+	fields := map[string]string{
+		"/title":       issue.Title,
+		"/description": issue.Description,
+		"/severity":    string(issue.Severity),
+		"/state":       string(issue.State),
+	}
+	changed := make(map[string]bool)
+
+	for idx, op := range ops {
+		removable, ok := patchableFields[op.Path]
+		if !ok {
+			return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: path %q can't be patched", ErrInvalidPatch, idx, op.Path)
+		}
+
+		switch op.Op {
+		case "add", "replace":
+			value, err := patchStringValue(op)
+			if err != nil {
+				return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, idx, err)
+			}
+			if err := validatePatchedField(op.Path, value); err != nil {
+				return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, idx, err)
+			}
+			fields[op.Path] = value
+			changed[op.Path] = true
+		case "remove":
+			if !removable {
+				return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: path %q can't be removed", ErrInvalidPatch, idx, op.Path)
+			}
+			fields[op.Path] = ""
+			changed[op.Path] = true
+		case "test":
+			value, err := patchStringValue(op)
+			if err != nil {
+				return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, idx, err)
+			}
+			if fields[op.Path] != value {
+				return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: %s is %q", ErrPatchTestFailed, idx, op.Path, fields[op.Path])
+			}
+		default:
+			return repository.IssuePatch{}, fmt.Errorf("%w: operation %d: unsupported op %q", ErrInvalidPatch, idx, op.Op)
+		}
+	}
+
+	var patch repository.IssuePatch
+	if changed["/title"] {
+		title := fields["/title"]
+		patch.Title = &title
+	}
+	if changed["/description"] {
+		description := fields["/description"]
+		patch.Description = &description
+	}
+	if changed["/severity"] {
+		severity := models.Severity(fields["/severity"])
+		patch.Severity = &severity
+	}
+	if changed["/state"] {
+		state := models.IssueState(fields["/state"])
+		patch.State = &state
+	}
+	return patch, nil
+}
+
+// patchStringValue decodes the value of an operation, all patchable fields are strings
+func patchStringValue(op dto.PatchOperation) (string, error) {
+	if len(op.Value) == 0 || bytes.Equal(op.Value, []byte("null")) {
+		return "", fmt.Errorf("%s on %q requires a value", op.Op, op.Path)
+	}
+	var value string
+	if err := json.Unmarshal(op.Value, &value); err != nil {
+		return "", fmt.Errorf("value of %q must be a string", op.Path)
+	}
+	return value, nil
+}
+
+// validatePatchedField checks the new value of a field
+func validatePatchedField(path, value string) error {
+	switch path {
+	case "/title":
+		if value == "" {
+			return errors.New("title can't be empty")
+		}
+	case "/severity":
+		if models.Severity(value).Rank() == 0 {
+			return fmt.Errorf("invalid severity %q", value)
+		}
+	case "/state":
+		if !models.IssueState(value).IsValid() {
+			return fmt.Errorf("invalid state %q", value)
+		}
+	}
+	return nil
+}
@@ -2,16 +2,105 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/cache"
+	"github.com/konflux-ci/kite/internal/pkg/commitlink"
+	"github.com/konflux-ci/kite/internal/pkg/linkdomain"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/pkg/redact"
 	"github.com/konflux-ci/kite/internal/repository"
 	"github.com/sirupsen/logrus"
 )
 
 type IssueService struct {
-	repo   repository.IssueRepository // Repository instance
-	logger *logrus.Logger             // Logging instance
+	repo     repository.IssueRepository // Repository instance
+	logger   *logrus.Logger             // Logging instance
+	redactor *redact.Redactor           // Redacts sensitive data before persistence
+	metrics  *metrics.Metrics           // Records rejected issues, nil to disable
+	notifier *Notifier                  // Notifies new issues, nil to disable
+	linker   *commitlink.Linker         // Links issues to their commit, nil to disable
+
+	// Domains the commit links can point to, all are allowed if nil
+	linkDomains *linkdomain.Allowlist
+
+	// Minimum severity of the issues created in each namespace
+	severityFloors map[string]models.Severity
+
+	// Called with the issues created, their errors fail the creation if failOnCreateHookError
+	createHooks           []CreateHook
+	failOnCreateHookError bool
+
+	// Caches the results of aggregate queries for aggregateTTL, nil to disable
+	aggregateCache *cache.Cache
+	aggregateTTL   time.Duration
+}
+
+// Option configures optional behavior of the issue service
+type Option func(*IssueService)
+
+// WithRedactor sets the redactor applied to issue titles and descriptions before they're stored
+func WithRedactor(redactor *redact.Redactor) Option {
+	return func(s *IssueService) {
+		s.redactor = redactor
+	}
+}
+
+// WithMetrics records the issues rejected because their namespace reached its limit
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *IssueService) {
+		s.metrics = m
+	}
+}
+
+// WithNotifier notifies the new issues, the issues updated by duplicates aren't notified
+func WithNotifier(notifier *Notifier) Option {
+	return func(s *IssueService) {
+		s.notifier = notifier
+	}
+}
+
+// maxAggregateCacheEntries bounds the results cached by WithAggregateCache, as every
+// combination of filters is cached separately
+const maxAggregateCacheEntries = 1000
+
+// WithAggregateCache caches the results of MTTR queries for the TTL, the only aggregate
+// cached for now. Identical queries within the TTL get the cached results, so dashboards
+// refreshing often don't recompute them. Results aren't invalidated when issues change.
+func WithAggregateCache(ttl time.Duration) Option {
+	return func(s *IssueService) {
+		s.aggregateCache = cache.NewBounded(maxAggregateCacheEntries)
+		s.aggregateTTL = ttl
+	}
+}
+
+// WithCommitLinker links the issues reported with a commit to it
+func WithCommitLinker(linker *commitlink.Linker) Option {
+	return func(s *IssueService) {
+		s.linker = linker
+	}
+}
+
+// WithAllowedLinkDomains only links issues to the commits hosted on the allowed domains
+func WithAllowedLinkDomains(allowlist *linkdomain.Allowlist) Option {
+	return func(s *IssueService) {
+		s.linkDomains = allowlist
+	}
+}
+
+// WithSeverityFloors escalates the issues of namespaces with a floor that are less severe
+// than it, e.g. info issues of a namespace with a major floor are stored as major.
+// Issues are escalated rather than dropped, so nothing reported is lost.
+func WithSeverityFloors(floors map[string]models.Severity) Option {
+	return func(s *IssueService) {
+		s.severityFloors = floors
+	}
 }
 
 type IssueQueryFilters struct {
@@ -31,11 +120,15 @@ type DuplicateCheckResult struct {
 	ExistingIssue *models.Issue
 }
 
-func NewIssueService(repo repository.IssueRepository, logger *logrus.Logger) *IssueService {
-	return &IssueService{
+func NewIssueService(repo repository.IssueRepository, logger *logrus.Logger, opts ...Option) *IssueService {
+	service := &IssueService{
 		repo:   repo,
 		logger: logger,
 	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
 }
 
 // CheckForDuplicateIssue checks if a similar issue already exists
@@ -48,29 +141,199 @@ func (s *IssueService) FindDuplicateIssue(ctx context.Context, req dto.CreateIss
 }
 
 // CreateOrUpdateIssue creates an issue if a duplicate is not found and updates the record if it is.
+// It reports whether the issue was created.
 //
 // NOTE: This method is mainly used for webhook endpoints.
-func (s *IssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
-	issue, err := s.repo.CreateOrUpdate(ctx, req)
+func (s *IssueService) CreateOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	issue, created, err := s.createOrUpdateIssue(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		issue, err = s.issueCreated(ctx, issue)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return issue, created, nil
+}
+
+// createOrUpdateIssue prepares and stores the issue of a request, updating its duplicate if any.
+// It reports whether the issue was created.
+func (s *IssueService) createOrUpdateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	req = s.prepareIssue(req)
+
+	issue, created, err := s.repo.CreateOrUpdate(ctx, req)
 	if err != nil {
+		s.recordRejection(err, req.Namespace)
+		return nil, false, err
+	}
+	return issue, created, nil
+}
+
+// commitLinkTitle is the title of the link generated to the commit of an issue
+const commitLinkTitle = "View commit"
+
+// prepareIssue redacts the issue of a request, escalates it to the severity floor of its
+// namespace, and links it to its commit if it has one
+func (s *IssueService) prepareIssue(req dto.CreateIssueRequest) dto.CreateIssueRequest {
+	if floor, ok := s.severityFloors[req.Namespace]; ok && req.Severity.Rank() < floor.Rank() {
+		req.Severity = floor
+	}
+	req.Title = s.redactor.Redact(req.Title)
+	req.Description = s.redactor.Redact(req.Description)
+
+	commitURL, err := s.linker.URL(req.RepoURL, req.CommitSHA, req.Branch)
+	if err != nil {
+		// The issue matters more than the link to its commit
+		s.logger.WithError(err).WithField("repo_url", req.RepoURL).Warn("Failed to generate commit link")
+		return req
+	}
+	if commitURL == "" || slices.ContainsFunc(req.Links, func(link dto.CreateLinkRequest) bool { return link.URL == commitURL }) {
+		return req
+	}
+	if err := s.linkDomains.Check(commitURL); err != nil {
+		s.logger.WithError(err).WithField("repo_url", req.RepoURL).Warn("Skipped commit link to a domain not allowed")
+		return req
+	}
+	// Copy the links, so the caller's aren't modified
+	req.Links = append(slices.Clip(req.Links), dto.CreateLinkRequest{
+		Title: commitLinkTitle,
+		URL:   commitURL,
+		Order: len(req.Links),
+	})
+	return req
+}
+
+// issueCreated notifies a new issue and calls the create hooks with it
+func (s *IssueService) issueCreated(ctx context.Context, issue *models.Issue) (*models.Issue, error) {
+	s.notify(issue)
+	if err := s.runCreateHooks(ctx, issue); err != nil {
 		return nil, err
 	}
 	return issue, nil
 }
 
-// FindIssues retrieves issues with optional filters
+// notify notifies a new issue in the background, so requests don't wait for the notification
+func (s *IssueService) notify(issue *models.Issue) {
+	if s.notifier == nil {
+		return
+	}
+	go func() {
+		if err := s.notifier.Notify(context.Background(), issue); err != nil {
+			s.logger.WithError(err).WithField("issue_id", issue.ID).Error("Failed to notify new issue")
+		}
+	}()
+}
+
+// recordRejection counts the issues rejected because their namespace reached its limit
+func (s *IssueService) recordRejection(err error, namespace string) {
+	if s.metrics != nil && errors.Is(err, repository.ErrNamespaceIssueLimitExceeded) {
+		s.metrics.IssueLimitRejections.WithLabelValues(namespace).Inc()
+	}
+}
+
+// ImportIssue creates an issue from an import, or updates its duplicate.
+// It reports whether the issue was created.
+func (s *IssueService) ImportIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, bool, error) {
+	// Imports aren't notified nor hooked, they would flood the notifications
+	return s.createOrUpdateIssue(ctx, req)
+}
+
+// SearchIssues finds the issues matching a text query, most relevant first
+func (s *IssueService) SearchIssues(ctx context.Context, filters repository.IssueQueryFilters) ([]dto.SearchResult, error) {
+	results, err := s.repo.Search(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindIssues retrieves issues with optional filters.
+// When changes since a time are requested, the issues deleted since then are included too.
 func (s *IssueService) FindIssues(ctx context.Context, filters repository.IssueQueryFilters) (*dto.IssueResponse, error) {
 	issues, total, err := s.repo.FindAll(ctx, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.IssueResponse{
+	response := &dto.IssueResponse{
 		Data:   issues,
 		Total:  total,
 		Limit:  filters.Limit,
 		Offset: filters.Offset,
-	}, nil
+	}
+
+	if filters.ChangedSince != nil {
+		response.Deleted, err = s.repo.FindDeletedSince(ctx, filters, *filters.ChangedSince)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// maxGroupedIssues is the number of issues listed per group of GroupIssuesByResource,
+// the most recently detected
+const maxGroupedIssues = 100
+
+// GroupIssuesByResource retrieves the issues matching the filters, grouped by the resource
+// they're scoped to. Groups are ordered by their most severe issue, then by their number of
+// active issues, and at most maxGroups groups are returned when it's positive.
+//
+// Groups are counted by the database, and only the latest issues of the groups returned
+// are loaded, so large namespaces don't load all of their issues.
+func (s *IssueService) GroupIssuesByResource(ctx context.Context, filters repository.IssueQueryFilters, maxGroups int) ([]dto.IssueGroup, error) {
+	return s.repo.GroupByResource(ctx, filters, maxGroups, maxGroupedIssues)
+}
+
+// FindMTTR computes the mean and median time to resolve issues, grouped by a dimension
+func (s *IssueService) FindMTTR(ctx context.Context, filters repository.IssueQueryFilters, since time.Time, groupBy string) ([]dto.MTTRGroup, error) {
+	// Dashboards compute since from the current time, queries within the TTL share results
+	key := aggregateCacheKey("mttr", filters, since.UTC().Truncate(s.aggregateTTL), groupBy)
+	if groups, ok := s.cachedAggregate(key).([]dto.MTTRGroup); ok {
+		return groups, nil
+	}
+
+	groups, err := s.repo.MTTR(ctx, filters, since, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	s.cacheAggregate(key, groups)
+	return groups, nil
+}
+
+// aggregateCacheKey returns the key of an aggregate query in the cache, made of its
+// name and parameters. The namespaces and states of the filters are sorted, so the
+// order they're requested in doesn't matter. It's empty, and the query isn't cached,
+// if the parameters can't be encoded.
+func aggregateCacheKey(name string, filters repository.IssueQueryFilters, params ...any) string {
+	filters.Namespaces = slices.Sorted(slices.Values(filters.Namespaces))
+	filters.ResourceNamespaces = slices.Sorted(slices.Values(filters.ResourceNamespaces))
+	filters.States = slices.Sorted(slices.Values(filters.States))
+
+	key, err := json.Marshal(append([]any{name, filters}, params...))
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
+
+// cachedAggregate returns the cached results of an aggregate query, nil if they
+// aren't cached or caching is disabled
+func (s *IssueService) cachedAggregate(key string) any {
+	if s.aggregateCache == nil || key == "" {
+		return nil
+	}
+	return s.aggregateCache.Get(key)
+}
+
+// cacheAggregate caches the results of an aggregate query, if caching is enabled
+func (s *IssueService) cacheAggregate(key string, results any) {
+	if s.aggregateCache != nil && key != "" {
+		s.aggregateCache.Set(key, results, s.aggregateTTL)
+	}
 }
 
 // FindIssueByID retrieves a single issue by ID
@@ -82,17 +345,40 @@ func (s *IssueService) FindIssueByID(ctx context.Context, id string) (*models.Is
 	return issue, nil
 }
 
+// FindIssuesByIDs retrieves the issues with the given IDs, skipping the ones that don't exist
+func (s *IssueService) FindIssuesByIDs(ctx context.Context, ids []string) ([]models.Issue, error) {
+	issues, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// FindIssueStatus retrieves the state and severity of an issue, nil if it doesn't exist
+func (s *IssueService) FindIssueStatus(ctx context.Context, id string) (*dto.IssueStatus, error) {
+	return s.repo.FindStatusByID(ctx, id)
+}
+
 // CreateIssue creates a new issue if a duplicate is not found and updates the record if it is.
 func (s *IssueService) CreateIssue(ctx context.Context, req dto.CreateIssueRequest) (*models.Issue, error) {
-	issue, err := s.repo.Create(ctx, req)
+	req = s.prepareIssue(req)
+
+	issue, created, err := s.repo.Create(ctx, req)
 	if err != nil {
+		s.recordRejection(err, req.Namespace)
 		return nil, err
 	}
+	if created {
+		return s.issueCreated(ctx, issue)
+	}
 	return issue, nil
 }
 
 // UpdateIssue updates and existing issue
 func (s *IssueService) UpdateIssue(ctx context.Context, id string, req dto.UpdateIssueRequest) (*models.Issue, error) {
+	req.Title = s.redactor.Redact(req.Title)
+	req.Description = s.redactor.Redact(req.Description)
+
 	issue, err := s.repo.Update(ctx, id, req)
 	if err != nil {
 		return nil, err
@@ -100,6 +386,12 @@ func (s *IssueService) UpdateIssue(ctx context.Context, id string, req dto.Updat
 	return issue, nil
 }
 
+// SnoozeIssue hides an issue from the default issue list until it's reported again,
+// or shows it again when snoozed is false
+func (s *IssueService) SnoozeIssue(ctx context.Context, id string, snoozed bool) (*models.Issue, error) {
+	return s.repo.Patch(ctx, id, repository.IssuePatch{SnoozedUntilRecurrence: &snoozed})
+}
+
 // DeleteIssue deletes an issue and related entities
 func (s *IssueService) DeleteIssue(ctx context.Context, id string) error {
 	err := s.repo.Delete(ctx, id)
@@ -109,6 +401,39 @@ func (s *IssueService) DeleteIssue(ctx context.Context, id string) error {
 	return nil
 }
 
+// ResolveIssueWithCascade resolves an issue along with the issues it caused
+func (s *IssueService) ResolveIssueWithCascade(ctx context.Context, id string) ([]string, error) {
+	cascaded, err := s.repo.ResolveWithCascade(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cascaded, nil
+}
+
+// BulkDeleteIssues deletes many issues, reporting the outcome for each of them
+func (s *IssueService) BulkDeleteIssues(ctx context.Context, namespace string, ids []string) ([]dto.BulkIssueResult, error) {
+	results, err := s.repo.BulkDelete(ctx, namespace, ids)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// MergeDuplicateIssues merges the open issues of a namespace that duplicate detection
+// would match with each other into the oldest of them, or only reports them on a dry run
+func (s *IssueService) MergeDuplicateIssues(ctx context.Context, namespace string, dryRun bool) ([]dto.DuplicateMerge, error) {
+	return s.repo.MergeDuplicates(ctx, namespace, dryRun)
+}
+
+// DecommissionedNamespaceNote is the note added to the issues resolved along with their namespace
+const DecommissionedNamespaceNote = "namespace decommissioned"
+
+// ResolveNamespaceIssues resolves all the open issues of a namespace that's decommissioned,
+// e.g. when a tenant is offboarded, so they aren't left orphaned
+func (s *IssueService) ResolveNamespaceIssues(ctx context.Context, namespace string) (int64, error) {
+	return s.repo.ResolveNamespace(ctx, namespace, DecommissionedNamespaceNote)
+}
+
 // AddRelatedIsue creates a relationship between two issues
 func (s *IssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	if err := s.repo.AddRelatedIssue(ctx, sourceID, targetID); err != nil {
@@ -117,6 +442,15 @@ func (s *IssueService) AddRelatedIssue(ctx context.Context, sourceID, targetID s
 	return nil
 }
 
+// BatchAddRelatedIssues creates many relationships between issues at once
+func (s *IssueService) BatchAddRelatedIssues(ctx context.Context, edges []dto.RelationshipEdgeRequest) ([]dto.RelationshipEdgeResult, error) {
+	results, err := s.repo.BatchAddRelatedIssues(ctx, edges)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // RemoveRelatedIssue removes a relationship between issues
 func (s *IssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetID string) error {
 	if err := s.repo.RemoveRelatedIssue(ctx, sourceID, targetID); err != nil {
@@ -125,6 +459,87 @@ func (s *IssueService) RemoveRelatedIssue(ctx context.Context, sourceID, targetI
 	return nil
 }
 
+// RemoveAllRelatedIssues removes every relationship of an issue, returning how many were removed
+func (s *IssueService) RemoveAllRelatedIssues(ctx context.Context, id string) (int64, error) {
+	removed, err := s.repo.RemoveAllRelated(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// FindIssueGraph retrieves the issues connected to an issue through relationships,
+// returning at most maxSize issues and relationships
+func (s *IssueService) FindIssueGraph(ctx context.Context, id string, maxSize int) (*dto.IssueGraph, error) {
+	graph, err := s.repo.FindRelatedGraph(ctx, id, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// FindIssueOccurrences retrieves the recent occurrences of an issue
+func (s *IssueService) FindIssueOccurrences(ctx context.Context, issueID string) ([]models.Occurrence, error) {
+	occurrences, err := s.repo.FindOccurrences(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// FindOccurrenceHistogram counts the occurrences of an issue in buckets of a unit of time
+func (s *IssueService) FindOccurrenceHistogram(ctx context.Context, issueID, unit string, since time.Time) ([]dto.OccurrenceBucket, error) {
+	buckets, err := s.repo.OccurrenceHistogram(ctx, issueID, unit, since)
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// AddIssueAttachment registers a file attached to an issue
+func (s *IssueService) AddIssueAttachment(ctx context.Context, issueID string, req dto.CreateAttachmentRequest) (*models.Attachment, error) {
+	attachment, err := s.repo.AddAttachment(ctx, issueID, req)
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// FindIssueAttachments retrieves the attachments of an issue
+func (s *IssueService) FindIssueAttachments(ctx context.Context, issueID string) ([]models.Attachment, error) {
+	attachments, err := s.repo.FindAttachments(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// AddIssueLink appends a link to an issue
+func (s *IssueService) AddIssueLink(ctx context.Context, issueID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	link, err := s.repo.AddLink(ctx, issueID, req)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// UpdateIssueLink edits a single link of an issue
+func (s *IssueService) UpdateIssueLink(ctx context.Context, issueID, linkID string, req dto.CreateLinkRequest) (*models.Link, error) {
+	link, err := s.repo.UpdateLink(ctx, issueID, linkID, req)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// DeleteIssueLink removes a single link of an issue
+func (s *IssueService) DeleteIssueLink(ctx context.Context, issueID, linkID string) error {
+	if err := s.repo.DeleteLink(ctx, issueID, linkID); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ResolveIssuesByScope resolves all active issues for a given scope
 func (s *IssueService) ResolveIssuesByScope(ctx context.Context, resourceType, resourceName, namespace string) (int64, error) {
 	count, err := s.repo.ResolveByScope(ctx, resourceType, resourceName, namespace)
@@ -133,3 +548,54 @@ func (s *IssueService) ResolveIssuesByScope(ctx context.Context, resourceType, r
 	}
 	return count, nil
 }
+
+// ResolveIssuesByScopes resolves all active issues for a resource tracked under any of the given resource types
+func (s *IssueService) ResolveIssuesByScopes(ctx context.Context, resourceTypes []string, resourceName, namespace string) (int64, error) {
+	count, err := s.repo.ResolveByScopes(ctx, resourceTypes, resourceName, namespace)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ResolveIssuesByScopePrefix resolves all active issues for a resource, including the ones
+// scoped to its runs named after it with a generated suffix
+func (s *IssueService) ResolveIssuesByScopePrefix(ctx context.Context, resourceType, namePrefix, namespace string) (int64, error) {
+	count, err := s.repo.ResolveByScopePrefix(ctx, resourceType, namePrefix, namespace)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AcknowledgeIssuesByScope acknowledges all active issues for a given scope. Every issue
+// acknowledged gets a note recording who acknowledged it, followed by the optional note.
+func (s *IssueService) AcknowledgeIssuesByScope(ctx context.Context, resourceType, resourceName, namespace, acknowledgedBy, note string) (int64, error) {
+	content := "Acknowledged"
+	if acknowledgedBy != "" {
+		content += " by " + acknowledgedBy
+	}
+	if note = strings.TrimSpace(s.redactor.Redact(note)); note != "" {
+		content += ": " + note
+	}
+	return s.repo.AcknowledgeByScope(ctx, resourceType, resourceName, namespace, content)
+}
+
+// RankNamespacesByIssues ranks the namespaces by their number of issues in the states passed,
+// the namespaces with the most issues first, up to limit namespaces
+func (s *IssueService) RankNamespacesByIssues(ctx context.Context, states []models.IssueState, limit int) ([]dto.NamespaceIssueCount, error) {
+	ranking, err := s.repo.RankNamespaces(ctx, states, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ranking, nil
+}
+
+// FindNamespaces retrieves all namespaces containing issues with their active issue counts
+func (s *IssueService) FindNamespaces(ctx context.Context) ([]dto.NamespaceSummary, error) {
+	namespaces, err := s.repo.FindNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// MetricsRefresher periodically recomputes the issue metrics from the database,
+// so they stay accurate across restarts and whatever path changed the issues.
+type MetricsRefresher struct {
+	repo     repository.IssueRepository
+	metrics  *metrics.Metrics
+	logger   *logrus.Logger
+	interval time.Duration // How often the metrics are refreshed
+	clock    clock.Clock
+	// Resolutions up to this time are already observed
+	observedUntil time.Time
+}
+
+// MetricsRefresherOption configures optional behavior of the metrics refresher
+type MetricsRefresherOption func(*MetricsRefresher)
+
+// WithMetricsRefresherClock sets the clock overdue issues and resolutions are timed with,
+// e.g. a fake clock in tests
+func WithMetricsRefresherClock(c clock.Clock) MetricsRefresherOption {
+	return func(r *MetricsRefresher) {
+		r.clock = c
+	}
+}
+
+// NewMetricsRefresher creates a refresher for the metrics. Only the issues resolved
+// after it's created are observed in the resolution time histogram.
+func NewMetricsRefresher(repo repository.IssueRepository, m *metrics.Metrics, logger *logrus.Logger, interval time.Duration, opts ...MetricsRefresherOption) *MetricsRefresher {
+	r := &MetricsRefresher{
+		repo:     repo,
+		metrics:  m,
+		logger:   logger,
+		interval: interval,
+		clock:    clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.observedUntil = r.clock.Now()
+	return r
+}
+
+// Run refreshes the metrics right away, then every interval until the context is done
+func (r *MetricsRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+			r.logger.WithError(err).Error("Failed to refresh metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh sets the open and overdue issue counts, replacing the previous ones, and observes
+// the resolution time of the issues resolved since the last refresh.
+func (r *MetricsRefresher) Refresh(ctx context.Context) error {
+	counts, err := r.repo.CountOpen(ctx)
+	if err != nil {
+		return err
+	}
+	// Reset first, so combinations without active issues left aren't reported anymore
+	r.metrics.OpenIssues.Reset()
+	for _, count := range counts {
+		r.metrics.OpenIssues.
+			WithLabelValues(count.Namespace, string(count.IssueType), string(count.Severity)).
+			Set(float64(count.Count))
+	}
+
+	until := r.clock.Now()
+	overdue, err := r.repo.CountOverdue(ctx, until)
+	if err != nil {
+		return err
+	}
+	r.metrics.OverdueIssues.Reset()
+	for _, count := range overdue {
+		r.metrics.OverdueIssues.
+			WithLabelValues(count.Namespace, string(count.Severity)).
+			Set(float64(count.Count))
+	}
+
+	resolutions, err := r.repo.FindResolutions(ctx, r.observedUntil, until)
+	if err != nil {
+		return err
+	}
+	for _, resolution := range resolutions {
+		r.metrics.ResolutionSeconds.
+			WithLabelValues(string(resolution.IssueType)).
+			Observe(resolution.ResolvedAt.Sub(resolution.DetectedAt).Seconds())
+	}
+	r.observedUntil = until
+	return nil
+}
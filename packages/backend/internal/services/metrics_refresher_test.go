@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	prommodel "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+)
+
+// resolutionCount is the number of resolutions observed for an issue type
+func resolutionCount(t *testing.T, m *metrics.Metrics, issueType models.IssueType) uint64 {
+	t.Helper()
+	var metric prommodel.Metric
+	if err := m.ResolutionSeconds.WithLabelValues(string(issueType)).(prometheus.Histogram).Write(&metric); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsRefresher_Refresh(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	logger.SetLevel(logrus.ErrorLevel)
+
+	seed := []struct {
+		namespace string
+		issueType models.IssueType
+		severity  models.Severity
+		count     int
+	}{
+		{"team-alpha", models.IssueTypeBuild, models.SeverityCritical, 3},
+		{"team-alpha", models.IssueTypeTest, models.SeverityMinor, 1},
+		{"team-beta", models.IssueTypeBuild, models.SeverityCritical, 2},
+	}
+	var alphaBuilds []*models.Issue
+	for _, s := range seed {
+		for idx := range s.count {
+			issue, _, err := repo.Create(ctx, dto.CreateIssueRequest{
+				Title:       fmt.Sprintf("%s failure %d", s.issueType, idx),
+				Description: "Seeded for metrics",
+				Severity:    s.severity,
+				IssueType:   s.issueType,
+				Namespace:   s.namespace,
+				Scope: dto.ScopeReqBody{
+					ResourceType:      "component",
+					ResourceName:      fmt.Sprintf("%s-%s-%d", s.namespace, s.issueType, idx),
+					ResourceNamespace: s.namespace,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if s.namespace == "team-alpha" && s.issueType == models.IssueTypeBuild {
+				alphaBuilds = append(alphaBuilds, issue)
+			}
+		}
+	}
+
+	m := metrics.New()
+	refresher := NewMetricsRefresher(repo, m, logger, time.Minute)
+
+	if _, err := repo.ResolveWithCascade(ctx, alphaBuilds[0].ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := refresher.Refresh(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[[3]string]float64{
+		{"team-alpha", "build", "critical"}: 2,
+		{"team-alpha", "test", "minor"}:     1,
+		{"team-beta", "build", "critical"}:  2,
+	}
+	if count := testutil.CollectAndCount(m.OpenIssues); count != len(expected) {
+		t.Errorf("Expected %d open issue series, got %d", len(expected), count)
+	}
+	for labels, value := range expected {
+		if got := testutil.ToFloat64(m.OpenIssues.WithLabelValues(labels[:]...)); got != value {
+			t.Errorf("Expected %v open issues for %v, got %v", value, labels, got)
+		}
+	}
+	if count := resolutionCount(t, m, models.IssueTypeBuild); count != 1 {
+		t.Errorf("Expected 1 build resolution observed, got %d", count)
+	}
+
+	// Once every issue of a series is resolved, the series is dropped,
+	// and resolutions already observed aren't observed again
+	for _, issue := range alphaBuilds[1:] {
+		if _, err := repo.ResolveWithCascade(ctx, issue.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := refresher.Refresh(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if count := testutil.CollectAndCount(m.OpenIssues); count != 2 {
+		t.Errorf("Expected 2 open issue series, got %d", count)
+	}
+	if count := resolutionCount(t, m, models.IssueTypeBuild); count != 3 {
+		t.Errorf("Expected 3 build resolutions observed, got %d", count)
+	}
+}
+
+func TestMetricsRefresher_OverdueIssues(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	logger.SetLevel(logrus.ErrorLevel)
+
+	now := time.Now()
+	for idx, dueAt := range []time.Time{now.Add(-time.Hour), now.Add(-time.Minute), now.Add(time.Hour)} {
+		_, _, err := repo.Create(ctx, dto.CreateIssueRequest{
+			Title:       fmt.Sprintf("Build failure %d", idx),
+			Description: "Seeded for metrics",
+			Severity:    models.SeverityCritical,
+			IssueType:   models.IssueTypeBuild,
+			Namespace:   "team-alpha",
+			Scope: dto.ScopeReqBody{
+				ResourceType: "component",
+				ResourceName: fmt.Sprintf("component-%d", idx),
+			},
+			DueAt: &dueAt,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	m := metrics.New()
+	clock := testhelpers.NewFakeClock(now)
+	refresher := NewMetricsRefresher(repo, m, logger, time.Minute, WithMetricsRefresherClock(clock))
+	if err := refresher.Refresh(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := testutil.ToFloat64(m.OverdueIssues.WithLabelValues("team-alpha", "critical")); got != 2 {
+		t.Errorf("Expected 2 overdue issues, got %v", got)
+	}
+
+	// Once past their deadline too, every issue is overdue
+	clock.Advance(2 * time.Hour)
+	if err := refresher.Refresh(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := testutil.ToFloat64(m.OverdueIssues.WithLabelValues("team-alpha", "critical")); got != 3 {
+		t.Errorf("Expected 3 overdue issues, got %v", got)
+	}
+}
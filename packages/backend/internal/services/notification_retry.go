@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// Default delays before retrying a failed notification
+const (
+	DefaultNotificationRetryBackoff    = 5 * time.Second
+	DefaultNotificationRetryMaxBackoff = 5 * time.Minute
+)
+
+// pendingNotification is a notification waiting to be retried
+type pendingNotification struct {
+	notification Notification
+	attempts     int       // Number of failed attempts so far
+	nextAttempt  time.Time // When it's retried
+	lastErr      error
+}
+
+// NotificationRetryQueue is a sender retrying the notifications that failed, e.g. while
+// the chat service is down, with an exponential backoff. Notifications failing on every
+// attempt are logged as dead letters, so they can be found and sent by hand.
+//
+// The queue is bounded and held in memory: notifications are dead-lettered once it's
+// full, and the ones still queued are lost if the process stops.
+type NotificationRetryQueue struct {
+	sender      NotificationSender
+	logger      *logrus.Logger
+	maxAttempts int // Attempts before giving up on a notification, including the first
+	size        int // Number of notifications waiting to be retried at most
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	metrics     *metrics.Metrics // Records the deliveries and dead letters, nil to disable
+	clock       clock.Clock      // Tells when notifications are due
+
+	mu      sync.Mutex
+	pending []*pendingNotification
+	wake    chan struct{} // Signaled when a notification is queued
+}
+
+// NotificationRetryOption configures optional behavior of the notification retry queue
+type NotificationRetryOption func(*NotificationRetryQueue)
+
+// WithRetryBackoff sets the delay before the first retry, doubled for every next one
+// up to maxBackoff
+func WithRetryBackoff(backoff, maxBackoff time.Duration) NotificationRetryOption {
+	return func(q *NotificationRetryQueue) {
+		q.backoff = backoff
+		q.maxBackoff = maxBackoff
+	}
+}
+
+// WithNotificationRetryMetrics records the delivery attempts and the notifications given up on
+func WithNotificationRetryMetrics(m *metrics.Metrics) NotificationRetryOption {
+	return func(q *NotificationRetryQueue) {
+		q.metrics = m
+	}
+}
+
+// WithRetryClock sets the clock the retries are scheduled with, e.g. a fake clock in tests
+func WithRetryClock(c clock.Clock) NotificationRetryOption {
+	return func(q *NotificationRetryQueue) {
+		q.clock = c
+	}
+}
+
+// NewNotificationRetryQueue creates a queue delivering notifications with the sender,
+// in up to maxAttempts attempts, and holding up to size notifications to retry.
+// Notifications are only retried once the queue runs.
+func NewNotificationRetryQueue(sender NotificationSender, logger *logrus.Logger, maxAttempts, size int, opts ...NotificationRetryOption) *NotificationRetryQueue {
+	queue := &NotificationRetryQueue{
+		sender:      sender,
+		logger:      logger,
+		maxAttempts: maxAttempts,
+		size:        size,
+		backoff:     DefaultNotificationRetryBackoff,
+		maxBackoff:  DefaultNotificationRetryMaxBackoff,
+		clock:       clock.Real{},
+		wake:        make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(queue)
+	}
+	return queue
+}
+
+// Send delivers the notification, or queues it to be retried if it fails. An error is
+// only returned when the notification is given up on.
+func (q *NotificationRetryQueue) Send(ctx context.Context, notification Notification) error {
+	err := q.deliver(ctx, notification)
+	if err == nil {
+		return nil
+	}
+
+	pending := &pendingNotification{notification: notification, attempts: 1, lastErr: err}
+	if q.maxAttempts <= 1 {
+		q.deadLetter(pending, metrics.DeadLetterMaxAttempts)
+		return err
+	}
+	if !q.enqueue(pending) {
+		q.deadLetter(pending, metrics.DeadLetterQueueFull)
+		return err
+	}
+	q.logger.WithError(err).WithField("kind", notification.Kind).Warn("Failed to send notification, retrying")
+	return nil
+}
+
+// deliver attempts to send a notification once
+func (q *NotificationRetryQueue) deliver(ctx context.Context, notification Notification) error {
+	err := q.sender.Send(ctx, notification)
+	if q.metrics != nil {
+		result := metrics.NotificationDelivered
+		if err != nil {
+			result = metrics.NotificationFailed
+		}
+		q.metrics.NotificationDeliveries.WithLabelValues(result).Inc()
+	}
+	return err
+}
+
+// enqueue schedules the next attempt of a failed notification, returning false if the
+// queue is full
+func (q *NotificationRetryQueue) enqueue(pending *pendingNotification) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) >= q.size {
+		return false
+	}
+	pending.nextAttempt = q.clock.Now().Add(q.backoffAfter(pending.attempts))
+	q.pending = append(q.pending, pending)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// backoffAfter returns the delay before retrying a notification failed the given number of times
+func (q *NotificationRetryQueue) backoffAfter(attempts int) time.Duration {
+	delay := q.backoff
+	for range attempts - 1 {
+		if delay >= q.maxBackoff/2 {
+			return q.maxBackoff
+		}
+		delay *= 2
+	}
+	return min(delay, q.maxBackoff)
+}
+
+// deadLetter logs a notification given up on, with what's needed to send it by hand
+func (q *NotificationRetryQueue) deadLetter(pending *pendingNotification, reason string) {
+	issueIDs := make([]string, 0, len(pending.notification.Issues))
+	for _, issue := range pending.notification.Issues {
+		issueIDs = append(issueIDs, issue.ID)
+	}
+	q.logger.WithError(pending.lastErr).WithFields(logrus.Fields{
+		"kind":      pending.notification.Kind,
+		"issue_ids": issueIDs,
+		"attempts":  pending.attempts,
+		"reason":    reason,
+	}).Error("Gave up on notification")
+	if q.metrics != nil {
+		q.metrics.NotificationDeadLetters.WithLabelValues(reason).Inc()
+	}
+}
+
+// Len returns the number of notifications waiting to be retried
+func (q *NotificationRetryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Run retries the failed notifications when they're due, until the context is done
+func (q *NotificationRetryQueue) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if pending := q.Len(); pending > 0 {
+				q.logger.WithField("queued", pending).Warn("Stopped retrying notifications, queued notifications are lost")
+			}
+			return
+		case <-q.wake:
+		case <-timer.C:
+			q.retryDue(ctx)
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if delay, ok := q.nextDelay(); ok {
+			timer.Reset(delay)
+		}
+	}
+}
+
+// nextDelay returns how long until the next notification is due, false if none is queued
+func (q *NotificationRetryQueue) nextDelay() (time.Duration, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		return 0, false
+	}
+	next := q.pending[0].nextAttempt
+	for _, pending := range q.pending[1:] {
+		if pending.nextAttempt.Before(next) {
+			next = pending.nextAttempt
+		}
+	}
+	return max(next.Sub(q.clock.Now()), 0), true
+}
+
+// retryDue retries the notifications due, queueing them again if they fail
+// until they run out of attempts
+func (q *NotificationRetryQueue) retryDue(ctx context.Context) {
+	now := q.clock.Now()
+	q.mu.Lock()
+	var due []*pendingNotification
+	remaining := q.pending[:0]
+	for _, pending := range q.pending {
+		if pending.nextAttempt.After(now) {
+			remaining = append(remaining, pending)
+		} else {
+			due = append(due, pending)
+		}
+	}
+	q.pending = remaining
+	q.mu.Unlock()
+
+	for _, pending := range due {
+		if ctx.Err() != nil {
+			// Kept for the log of the notifications lost
+			q.mu.Lock()
+			q.pending = append(q.pending, pending)
+			q.mu.Unlock()
+			continue
+		}
+		err := q.deliver(ctx, pending.notification)
+		if err == nil {
+			q.logger.WithFields(logrus.Fields{
+				"kind":     pending.notification.Kind,
+				"attempts": pending.attempts + 1,
+			}).Info("Sent notification after retrying")
+			continue
+		}
+
+		pending.attempts++
+		pending.lastErr = err
+		if pending.attempts >= q.maxAttempts {
+			q.deadLetter(pending, metrics.DeadLetterMaxAttempts)
+			continue
+		}
+		// Room was made when it was taken off the queue, unless new failures took it
+		if !q.enqueue(pending) {
+			q.deadLetter(pending, metrics.DeadLetterQueueFull)
+		}
+	}
+}
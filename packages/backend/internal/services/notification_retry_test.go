@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/pkg/metrics"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+)
+
+// flakySender fails a number of times before delivering notifications
+type flakySender struct {
+	mu        sync.Mutex
+	failures  int // Number of attempts failing before the first success, -1 to always fail
+	attempts  int
+	delivered chan Notification
+}
+
+func newFlakySender(failures int) *flakySender {
+	return &flakySender{failures: failures, delivered: make(chan Notification, 10)}
+}
+
+func (f *flakySender) Send(ctx context.Context, notification Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.failures < 0 || f.attempts <= f.failures {
+		return errors.New("service unavailable")
+	}
+	f.delivered <- notification
+	return nil
+}
+
+func (f *flakySender) attemptCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+func newRetryTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+	return logger
+}
+
+func TestNotificationRetryQueue_EventualDelivery(t *testing.T) {
+	sender := newFlakySender(2)
+	m := metrics.New()
+	queue := NewNotificationRetryQueue(sender, newRetryTestLogger(), 5, 10,
+		WithRetryBackoff(time.Millisecond, 10*time.Millisecond), WithNotificationRetryMetrics(m))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	notification := Notification{Kind: NotificationIssueCreated, Issues: []NotifiedIssue{{ID: "issue-1"}}}
+	if err := queue.Send(ctx, notification); err != nil {
+		t.Fatalf("Expected the notification to be queued for retry, got %v", err)
+	}
+
+	select {
+	case delivered := <-sender.delivered:
+		if delivered.Issues[0].ID != "issue-1" {
+			t.Errorf("Expected issue-1 to be notified, got %s", delivered.Issues[0].ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the notification to be delivered")
+	}
+
+	if attempts := sender.attemptCount(); attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if delivered := testutil.ToFloat64(m.NotificationDeliveries.WithLabelValues(metrics.NotificationDelivered)); delivered != 1 {
+		t.Errorf("Expected 1 delivered attempt, got %v", delivered)
+	}
+	if failed := testutil.ToFloat64(m.NotificationDeliveries.WithLabelValues(metrics.NotificationFailed)); failed != 2 {
+		t.Errorf("Expected 2 failed attempts, got %v", failed)
+	}
+	if deadLetters := testutil.CollectAndCount(m.NotificationDeadLetters); deadLetters != 0 {
+		t.Errorf("Expected no dead letters, got %d", deadLetters)
+	}
+}
+
+func TestNotificationRetryQueue_MaxAttempts(t *testing.T) {
+	sender := newFlakySender(-1)
+	m := metrics.New()
+	queue := NewNotificationRetryQueue(sender, newRetryTestLogger(), 3, 10,
+		WithRetryBackoff(time.Millisecond, time.Millisecond), WithNotificationRetryMetrics(m))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	if err := queue.Send(ctx, Notification{Kind: NotificationIssueCreated}); err != nil {
+		t.Fatalf("Expected the notification to be queued for retry, got %v", err)
+	}
+
+	deadLetters := m.NotificationDeadLetters.WithLabelValues(metrics.DeadLetterMaxAttempts)
+	deadline := time.Now().Add(5 * time.Second)
+	for testutil.ToFloat64(deadLetters) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if count := testutil.ToFloat64(deadLetters); count != 1 {
+		t.Fatalf("Expected 1 dead letter, got %v", count)
+	}
+	if attempts := sender.attemptCount(); attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("Expected the queue to be empty, got %d", queue.Len())
+	}
+}
+
+func TestNotificationRetryQueue_Full(t *testing.T) {
+	sender := newFlakySender(-1)
+	m := metrics.New()
+	// Not running, so nothing is taken off the queue
+	queue := NewNotificationRetryQueue(sender, newRetryTestLogger(), 5, 1, WithNotificationRetryMetrics(m))
+
+	if err := queue.Send(context.Background(), Notification{Kind: NotificationIssueCreated}); err != nil {
+		t.Fatalf("Expected the notification to be queued for retry, got %v", err)
+	}
+	if err := queue.Send(context.Background(), Notification{Kind: NotificationIssueCreated}); err == nil {
+		t.Error("Expected an error once the queue is full")
+	}
+
+	if queue.Len() != 1 {
+		t.Errorf("Expected 1 queued notification, got %d", queue.Len())
+	}
+	if count := testutil.ToFloat64(m.NotificationDeadLetters.WithLabelValues(metrics.DeadLetterQueueFull)); count != 1 {
+		t.Errorf("Expected 1 dead letter, got %v", count)
+	}
+}
+
+func TestNotificationRetryQueue_Clock(t *testing.T) {
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC))
+	sender := newFlakySender(1)
+	queue := NewNotificationRetryQueue(sender, newRetryTestLogger(), 5, 10,
+		WithRetryBackoff(time.Minute, time.Hour), WithRetryClock(clock))
+	ctx := context.Background()
+
+	if err := queue.Send(ctx, Notification{Kind: NotificationIssueCreated}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delay, ok := queue.nextDelay(); !ok || delay != time.Minute {
+		t.Errorf("Expected the retry in 1m0s, got %s (queued: %v)", delay, ok)
+	}
+
+	// Not due until the clock reaches the backoff
+	clock.Advance(59 * time.Second)
+	queue.retryDue(ctx)
+	if attempts := sender.attemptCount(); attempts != 1 {
+		t.Errorf("Expected no retry before the backoff, got %d attempts", attempts)
+	}
+	if delay, _ := queue.nextDelay(); delay != time.Second {
+		t.Errorf("Expected the retry in 1s, got %s", delay)
+	}
+
+	clock.Advance(time.Second)
+	queue.retryDue(ctx)
+	if attempts := sender.attemptCount(); attempts != 2 {
+		t.Errorf("Expected the notification to be retried, got %d attempts", attempts)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("Expected the queue to be empty, got %d", queue.Len())
+	}
+}
+
+func TestNotificationRetryQueue_Backoff(t *testing.T) {
+	queue := NewNotificationRetryQueue(newFlakySender(0), newRetryTestLogger(), 10, 10,
+		WithRetryBackoff(time.Second, 10*time.Second))
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for idx, delay := range expected {
+		if got := queue.backoffAfter(idx + 1); got != delay {
+			t.Errorf("Expected a delay of %s after %d attempts, got %s", delay, idx+1, got)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/clock"
+	"github.com/konflux-ci/kite/internal/pkg/quiethours"
+	"github.com/sirupsen/logrus"
+)
+
+// Kinds of notifications
+const (
+	// A new issue was reported
+	NotificationIssueCreated = "issue_created"
+	// Issues reported during quiet hours, sent once they end
+	NotificationQuietHoursSummary = "quiet_hours_summary"
+)
+
+// summaryCheckInterval is how often the end of quiet hours is checked for
+const summaryCheckInterval = time.Minute
+
+// Notification is sent when new issues are reported
+type Notification struct {
+	Kind   string          `json:"kind"`
+	Issues []NotifiedIssue `json:"issues"`
+}
+
+// NotifiedIssue is the summary of an issue included in notifications
+type NotifiedIssue struct {
+	ID         string           `json:"id"`
+	Title      string           `json:"title"`
+	Severity   models.Severity  `json:"severity"`
+	IssueType  models.IssueType `json:"issueType"`
+	Namespace  string           `json:"namespace"`
+	DetectedAt time.Time        `json:"detectedAt"`
+}
+
+// NotificationSender delivers notifications, e.g. to a chat webhook
+type NotificationSender interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// WebhookSender posts notifications as JSON to a URL
+type WebhookSender struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSender creates a sender posting notifications to the URL
+func NewWebhookSender(url string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Send posts the notification, failing unless it's accepted with a 2xx status
+func (s *WebhookSender) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Notifier notifies new issues. During quiet hours, issues below a severity are
+// held back and sent as a single summary once quiet hours end.
+type Notifier struct {
+	sender NotificationSender
+	logger *logrus.Logger
+	// Quiet hours, issues are never held back if nil
+	quietHours *quiethours.Window
+	// Issues at least this severe are notified during quiet hours too
+	minQuietSeverity models.Severity
+	clock            clock.Clock
+
+	mu         sync.Mutex
+	suppressed []NotifiedIssue // Issues held back until quiet hours end
+}
+
+// NotifierOption configures optional behavior of the notifier
+type NotifierOption func(*Notifier)
+
+// WithQuietHours holds back the issues less severe than minSeverity during quiet hours.
+// Critical issues are always notified.
+func WithQuietHours(window *quiethours.Window, minSeverity models.Severity) NotifierOption {
+	return func(n *Notifier) {
+		n.quietHours = window
+		n.minQuietSeverity = minSeverity
+	}
+}
+
+// WithNotifierClock sets the clock quiet hours are checked with, e.g. a fake clock in tests
+func WithNotifierClock(c clock.Clock) NotifierOption {
+	return func(n *Notifier) {
+		n.clock = c
+	}
+}
+
+// NewNotifier creates a notifier delivering notifications with the sender
+func NewNotifier(sender NotificationSender, logger *logrus.Logger, opts ...NotifierOption) *Notifier {
+	n := &Notifier{
+		sender:           sender,
+		logger:           logger,
+		minQuietSeverity: models.SeverityCritical,
+		clock:            clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Notify notifies a new issue, or holds it back until quiet hours end
+func (n *Notifier) Notify(ctx context.Context, issue *models.Issue) error {
+	notified := NotifiedIssue{
+		ID:         issue.ID,
+		Title:      issue.Title,
+		Severity:   issue.Severity,
+		IssueType:  issue.IssueType,
+		Namespace:  issue.Namespace,
+		DetectedAt: issue.DetectedAt,
+	}
+
+	n.mu.Lock()
+	if n.suppresses(issue.Severity) {
+		n.suppressed = append(n.suppressed, notified)
+		n.mu.Unlock()
+		n.logger.WithField("issue_id", issue.ID).Debug("Holding back notification during quiet hours")
+		return nil
+	}
+	n.mu.Unlock()
+
+	return n.sender.Send(ctx, Notification{Kind: NotificationIssueCreated, Issues: []NotifiedIssue{notified}})
+}
+
+// suppresses reports whether issues of the severity are held back right now
+func (n *Notifier) suppresses(severity models.Severity) bool {
+	if n.quietHours == nil || severity == models.SeverityCritical {
+		return false
+	}
+	return severity.Rank() < n.minQuietSeverity.Rank() && n.quietHours.Contains(n.clock.Now())
+}
+
+// SendSummary sends the issues held back once quiet hours ended.
+// Nothing is sent during quiet hours, or when no issue was held back.
+func (n *Notifier) SendSummary(ctx context.Context) error {
+	n.mu.Lock()
+	if len(n.suppressed) == 0 || (n.quietHours != nil && n.quietHours.Contains(n.clock.Now())) {
+		n.mu.Unlock()
+		return nil
+	}
+	issues := n.suppressed
+	n.suppressed = nil
+	n.mu.Unlock()
+
+	if err := n.sender.Send(ctx, Notification{Kind: NotificationQuietHoursSummary, Issues: issues}); err != nil {
+		// Keep the issues for the next attempt
+		n.mu.Lock()
+		n.suppressed = append(issues, n.suppressed...)
+		n.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run sends the summary of quiet hours once they end, until the context is done
+func (n *Notifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(summaryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.SendSummary(ctx); err != nil && ctx.Err() == nil {
+				n.logger.WithError(err).Error("Failed to send quiet hours summary")
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konflux-ci/kite/internal/handlers/dto"
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/pkg/quiethours"
+	"github.com/konflux-ci/kite/internal/testhelpers"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeSender records the notifications sent
+type fakeSender struct {
+	mu            sync.Mutex
+	notifications []Notification
+	err           error
+	sent          chan Notification // Receives the notifications sent, if set
+}
+
+func (f *fakeSender) Send(ctx context.Context, notification Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.notifications = append(f.notifications, notification)
+	if f.sent != nil {
+		f.sent <- notification
+	}
+	return nil
+}
+
+func (f *fakeSender) sentNotifications() []Notification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Notification(nil), f.notifications...)
+}
+
+func TestNotifier_QuietHours(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	window, err := quiethours.Parse("22:00-06:00", "Europe/Paris")
+	if err != nil {
+		t.Fatalf("Failed to parse quiet hours: %v", err)
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	sender := &fakeSender{}
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 16, 21, 59, 0, 0, paris))
+	notifier := NewNotifier(sender, logger, WithQuietHours(window, models.SeverityMajor), WithNotifierClock(clock))
+	ctx := context.Background()
+
+	notify := func(id string, severity models.Severity) {
+		t.Helper()
+		if err := notifier.Notify(ctx, &models.Issue{ID: id, Title: "Issue " + id, Severity: severity}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Before quiet hours, every issue is notified
+	notify("before", models.SeverityInfo)
+	if sent := sender.sentNotifications(); len(sent) != 1 || sent[0].Kind != NotificationIssueCreated {
+		t.Fatalf("Expected the issue to be notified before quiet hours, got %+v", sent)
+	}
+
+	// During quiet hours, only issues at least as severe as the threshold are notified
+	clock.Set(time.Date(2026, 10, 16, 22, 0, 0, 0, paris))
+	notify("minor", models.SeverityMinor)
+	notify("major", models.SeverityMajor)
+	clock.Set(time.Date(2026, 10, 17, 3, 0, 0, 0, paris))
+	notify("critical", models.SeverityCritical)
+	notify("info", models.SeverityInfo)
+
+	sent := sender.sentNotifications()
+	if len(sent) != 3 {
+		t.Fatalf("Expected 3 notifications, got %d", len(sent))
+	}
+	if sent[1].Issues[0].ID != "major" || sent[2].Issues[0].ID != "critical" {
+		t.Errorf("Expected the major and critical issues to be notified, got %+v and %+v", sent[1].Issues, sent[2].Issues)
+	}
+
+	// The summary isn't sent until quiet hours end
+	clock.Set(time.Date(2026, 10, 17, 5, 59, 59, 0, paris))
+	if err := notifier.SendSummary(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent := sender.sentNotifications(); len(sent) != 3 {
+		t.Fatalf("Expected no summary during quiet hours, got %d notifications", len(sent))
+	}
+
+	clock.Set(time.Date(2026, 10, 17, 6, 0, 0, 0, paris))
+	if err := notifier.SendSummary(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sent = sender.sentNotifications()
+	if len(sent) != 4 {
+		t.Fatalf("Expected the summary to be sent once quiet hours end, got %d notifications", len(sent))
+	}
+	summary := sent[3]
+	if summary.Kind != NotificationQuietHoursSummary {
+		t.Errorf("Expected a %s notification, got %s", NotificationQuietHoursSummary, summary.Kind)
+	}
+	if len(summary.Issues) != 2 || summary.Issues[0].ID != "minor" || summary.Issues[1].ID != "info" {
+		t.Errorf("Expected the minor and info issues in the summary, got %+v", summary.Issues)
+	}
+
+	// The summary is only sent once
+	if err := notifier.SendSummary(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent := sender.sentNotifications(); len(sent) != 4 {
+		t.Errorf("Expected the summary to be sent once, got %d notifications", len(sent))
+	}
+}
+
+func TestNotifier_SummaryRetried(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	window, err := quiethours.Parse("22:00-06:00", "UTC")
+	if err != nil {
+		t.Fatalf("Failed to parse quiet hours: %v", err)
+	}
+
+	sender := &fakeSender{}
+	clock := testhelpers.NewFakeClock(time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC))
+	notifier := NewNotifier(sender, logger, WithQuietHours(window, models.SeverityCritical), WithNotifierClock(clock))
+	ctx := context.Background()
+
+	if err := notifier.Notify(ctx, &models.Issue{ID: "major", Severity: models.SeverityMajor}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A summary that fails to be sent is sent on the next attempt
+	clock.Set(time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC))
+	sender.err = errors.New("webhook unavailable")
+	if err := notifier.SendSummary(ctx); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	sender.err = nil
+	if err := notifier.SendSummary(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sent := sender.sentNotifications()
+	if len(sent) != 1 || len(sent[0].Issues) != 1 || sent[0].Issues[0].ID != "major" {
+		t.Errorf("Expected the summary to be sent on retry, got %+v", sent)
+	}
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.Kind == NotificationQuietHoursSummary {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, time.Second)
+	notification := Notification{Kind: NotificationIssueCreated, Issues: []NotifiedIssue{{ID: "issue-1", Title: "Build failed"}}}
+	if err := sender.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if received.Kind != NotificationIssueCreated || len(received.Issues) != 1 || received.Issues[0].ID != "issue-1" {
+		t.Errorf("Expected the notification to be posted, got %+v", received)
+	}
+
+	if err := sender.Send(context.Background(), Notification{Kind: NotificationQuietHoursSummary}); err == nil {
+		t.Error("Expected an error when the notification is rejected, got nil")
+	}
+}
+
+func TestIssueService_NotifiesNewIssues(t *testing.T) {
+	ctx, logger, repo, _ := setupServiceDependents(t)
+	logger.SetLevel(logrus.ErrorLevel)
+	sender := &fakeSender{sent: make(chan Notification, 1)}
+	service := NewIssueService(repo, logger, WithNotifier(NewNotifier(sender, logger)))
+
+	req := dto.CreateIssueRequest{
+		Title:       "Build failed",
+		Description: "The build of the frontend failed",
+		Severity:    models.SeverityMajor,
+		IssueType:   models.IssueTypeBuild,
+		Namespace:   "team-notified",
+		Scope: dto.ScopeReqBody{
+			ResourceType: "component",
+			ResourceName: "frontend",
+		},
+	}
+	issue, _, err := service.CreateOrUpdateIssue(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	select {
+	case notification := <-sender.sent:
+		if notification.Issues[0].ID != issue.ID {
+			t.Errorf("Expected issue %s to be notified, got %+v", issue.ID, notification.Issues)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the new issue to be notified")
+	}
+
+	// Duplicates update the issue without notifying it again
+	if _, _, err := service.CreateOrUpdateIssue(ctx, req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	select {
+	case notification := <-sender.sent:
+		t.Errorf("Expected duplicates not to be notified, got %+v", notification)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
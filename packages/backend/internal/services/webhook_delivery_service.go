@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/konflux-ci/kite/internal/models"
+	"github.com/konflux-ci/kite/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookDeliveryService keeps track of the webhooks received and their outcome
+type WebhookDeliveryService struct {
+	repo   repository.WebhookDeliveryRepository
+	logger *logrus.Logger
+}
+
+// NewWebhookDeliveryService creates a new webhook delivery service
+func NewWebhookDeliveryService(repo repository.WebhookDeliveryRepository, logger *logrus.Logger) *WebhookDeliveryService {
+	return &WebhookDeliveryService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// RecordDelivery records a webhook delivery and its outcome
+func (s *WebhookDeliveryService) RecordDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return s.repo.Create(ctx, delivery)
+}
+
+// FindDelivery retrieves a webhook delivery, nil if it doesn't exist
+func (s *WebhookDeliveryService) FindDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	delivery, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
@@ -2,7 +2,9 @@ package testhelpers
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/konflux-ci/kite/internal/handlers/dto"
 	"github.com/konflux-ci/kite/internal/models"
@@ -24,12 +26,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Run migrations
-	err = db.AutoMigrate(
-		&models.IssueScope{},
-		&models.Issue{},
-		&models.Link{},
-		&models.RelatedIssue{},
-	)
+	err = db.AutoMigrate(models.All()...)
 
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
@@ -97,12 +94,7 @@ func SetupConcurrentTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Run DB migration
-	err = db.AutoMigrate(
-		&models.IssueScope{},
-		&models.Issue{},
-		&models.Link{},
-		&models.RelatedIssue{},
-	)
+	err = db.AutoMigrate(models.All()...)
 
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
@@ -181,3 +173,36 @@ func CompareIssueToDTO(a models.Issue, b dto.CreateIssueRequest) error {
 
 	return nil
 }
+
+// FakeClock is a clock.Clock whose time only moves when told to, so tests can
+// control timestamps and durations precisely.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a fake clock stopped at the given time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the duration
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to the given time
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
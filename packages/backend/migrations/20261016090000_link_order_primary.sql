@@ -0,0 +1,2 @@
+-- Modify "links" table
+ALTER TABLE "public"."links" ADD COLUMN "position" bigint NOT NULL DEFAULT 0, ADD COLUMN "is_primary" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+-- Modify "related_issues" table
+ALTER TABLE "public"."related_issues" ADD COLUMN "kind" character varying(32) NOT NULL DEFAULT 'RELATES_TO';
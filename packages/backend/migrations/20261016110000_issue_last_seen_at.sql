@@ -0,0 +1,6 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "last_seen_at" timestamptz NULL;
+-- Backfill existing issues with their last update
+UPDATE "public"."issues" SET "last_seen_at" = COALESCE("updated_at", "detected_at");
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ALTER COLUMN "last_seen_at" SET NOT NULL;
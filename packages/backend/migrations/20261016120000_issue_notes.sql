@@ -0,0 +1,11 @@
+-- Create "issue_notes" table
+CREATE TABLE "public"."issue_notes" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "issue_id" uuid NOT NULL,
+ "content" text NOT NULL,
+ "created_at" timestamptz NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_issues_notes" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_issue_notes_issue_id" to table: "issue_notes"
+CREATE INDEX "idx_issue_notes_issue_id" ON "public"."issue_notes" ("issue_id");
@@ -0,0 +1,11 @@
+-- Create "occurrences" table
+CREATE TABLE "public"."occurrences" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "issue_id" uuid NOT NULL,
+ "occurred_at" timestamptz NOT NULL,
+ "detail" text NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_issues_occurrences" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_occurrences_issue_id" to table: "occurrences"
+CREATE INDEX "idx_occurrences_issue_id" ON "public"."occurrences" ("issue_id");
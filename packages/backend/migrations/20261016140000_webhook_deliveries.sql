@@ -0,0 +1,10 @@
+-- Create "webhook_deliveries" table
+CREATE TABLE "public"."webhook_deliveries" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "endpoint" text NOT NULL,
+ "received_at" timestamptz NOT NULL,
+ "namespace" text NULL,
+ "issue_id" uuid NULL,
+ "status" character varying(20) NOT NULL,
+ PRIMARY KEY ("id")
+);
@@ -0,0 +1,11 @@
+-- Create index "idx_issues_updated_at" to table: "issues"
+CREATE INDEX "idx_issues_updated_at" ON "public"."issues" ("updated_at");
+-- Create "deleted_issues" table
+CREATE TABLE "public"."deleted_issues" (
+ "issue_id" uuid NOT NULL,
+ "namespace" text NOT NULL,
+ "deleted_at" timestamptz NOT NULL,
+ PRIMARY KEY ("issue_id")
+);
+-- Create index "idx_deleted_issues_deleted_at" to table: "deleted_issues"
+CREATE INDEX "idx_deleted_issues_deleted_at" ON "public"."deleted_issues" ("deleted_at");
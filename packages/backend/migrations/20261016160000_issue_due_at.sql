@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "due_at" timestamptz NULL;
+-- Create index "idx_issues_due_at" to table: "issues"
+CREATE INDEX "idx_issues_due_at" ON "public"."issues" ("due_at");
@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "commit_sha" text NULL, ADD COLUMN "repo_url" text NULL, ADD COLUMN "branch" text NULL;
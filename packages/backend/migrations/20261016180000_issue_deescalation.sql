@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "base_severity" character varying(20) NULL, ADD COLUMN "deescalated_at" timestamptz NULL;
+-- Existing issues are floored at their current severity
+UPDATE "public"."issues" SET "base_severity" = "severity";
@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "fingerprint" text NULL;
+-- Create index "idx_issues_fingerprint" to table: "issues"
+CREATE INDEX "idx_issues_fingerprint" ON "public"."issues" ("fingerprint");
@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "occurrence_count" bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "reason_hash" text NULL;
+-- Create index "idx_issues_reason_hash" to table: "issues"
+CREATE INDEX "idx_issues_reason_hash" ON "public"."issues" ("reason_hash");
@@ -0,0 +1,14 @@
+-- Create "attachments" table
+CREATE TABLE "public"."attachments" (
+ "id" uuid NOT NULL DEFAULT gen_random_uuid(),
+ "issue_id" uuid NOT NULL,
+ "name" text NOT NULL,
+ "content_type" text NOT NULL,
+ "size" bigint NOT NULL,
+ "storage_url" text NOT NULL,
+ "created_at" timestamptz NULL,
+ PRIMARY KEY ("id"),
+ CONSTRAINT "fk_issues_attachments" FOREIGN KEY ("issue_id") REFERENCES "public"."issues" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "idx_attachments_issue_id" to table: "attachments"
+CREATE INDEX "idx_attachments_issue_id" ON "public"."attachments" ("issue_id");
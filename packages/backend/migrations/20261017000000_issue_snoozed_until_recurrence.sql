@@ -0,0 +1,2 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "snoozed_until_recurrence" boolean NOT NULL DEFAULT false;
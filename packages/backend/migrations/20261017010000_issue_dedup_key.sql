@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "dedup_key" text NULL;
+-- Create index "idx_issues_open_dedup_key" to table: "issues"
+CREATE UNIQUE INDEX "idx_issues_open_dedup_key" ON "public"."issues" ("dedup_key") WHERE ((state)::text <> 'RESOLVED'::text);
@@ -0,0 +1,4 @@
+-- Modify "issues" table
+ALTER TABLE "public"."issues" ADD COLUMN "environment" text NULL;
+-- Create index "idx_issues_environment" to table: "issues"
+CREATE INDEX "idx_issues_environment" ON "public"."issues" ("environment");
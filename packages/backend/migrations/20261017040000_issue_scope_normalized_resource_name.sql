@@ -0,0 +1,6 @@
+-- Modify "issue_scopes" table
+ALTER TABLE "public"."issue_scopes" ADD COLUMN "normalized_resource_name" text NULL;
+-- Existing scopes are matched on their resource name as is
+UPDATE "public"."issue_scopes" SET "normalized_resource_name" = "resource_name";
+-- Create index "idx_issue_scopes_normalized_resource_name" to table: "issue_scopes"
+CREATE INDEX "idx_issue_scopes_normalized_resource_name" ON "public"."issue_scopes" ("normalized_resource_name");
@@ -0,0 +1,7 @@
+-- Create "settings" table
+CREATE TABLE "public"."settings" (
+ "name" text NOT NULL,
+ "value" text NOT NULL,
+ "updated_at" timestamptz NULL,
+ PRIMARY KEY ("name")
+);